@@ -63,6 +63,7 @@ func startE2ESetup(configPaths []string, debug bool) {
 		conf.InternalAuthZ,
 		nil,
 		dummyAuthZRepo{},
+		command.IPThrottleConfig{},
 	)
 	logging.Log("MAIN-54MLq").OnError(err).Fatal("cannot start command side")
 
@@ -7,6 +7,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"text/tabwriter"
 	"time"
 
 	"github.com/caos/logging"
@@ -28,6 +29,7 @@ import (
 	"github.com/caos/zitadel/internal/config"
 	sd "github.com/caos/zitadel/internal/config/systemdefaults"
 	"github.com/caos/zitadel/internal/config/types"
+	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/id"
 	"github.com/caos/zitadel/internal/notification"
@@ -133,7 +135,11 @@ func main() {
 	case cmdStart:
 		startZitadel(configPaths.Values())
 	case cmdSetup:
-		startSetup(setupPaths.Values())
+		setupFlags := flag.NewFlagSet(cmdSetup, flag.ExitOnError)
+		status := setupFlags.Bool("status", false, "print the state of the setup steps without executing them")
+		dryRun := setupFlags.Bool("dry-run", false, "print the setup steps which would be executed without executing them")
+		setupFlags.Parse(flag.Args()[1:])
+		startSetup(setupPaths.Values(), *status, *dryRun)
 	default:
 		logging.Log("MAIN-afEQ2").Fatal("please provide an valid argument [start, setup]")
 	}
@@ -162,13 +168,13 @@ func startZitadel(configPaths []string) {
 	authZRepo, err := authz.Start(conf.AuthZ, conf.SystemDefaults, queries)
 	logging.Log("MAIN-s9KOw").OnError(err).Fatal("error starting authz repo")
 
-	esCommands, err := eventstore.StartWithUser(conf.EventstoreBase, conf.Commands.Eventstore)
+	esCommands, err := eventstore.StartWithSnapshots(conf.EventstoreBase, conf.Commands.Eventstore, conf.Commands.Snapshots)
 	logging.Log("ZITAD-iRCMm").OnError(err).Fatal("cannot start eventstore for commands")
 
 	store, err := conf.AssetStorage.Config.NewStorage()
 	logging.Log("ZITAD-Bfhe2").OnError(err).Fatal("Unable to start asset storage")
 
-	commands, err := command.StartCommands(esCommands, conf.SystemDefaults, conf.InternalAuthZ, store, authZRepo)
+	commands, err := command.StartCommands(esCommands, conf.SystemDefaults, conf.InternalAuthZ, store, authZRepo, conf.Commands.IPThrottle)
 	if err != nil {
 		logging.Log("ZITAD-bmNiJ").OnError(err).Fatal("cannot start commands")
 	}
@@ -192,7 +198,7 @@ func startZitadel(configPaths []string) {
 	startUI(ctx, conf, authRepo, commands, queries, store)
 
 	if *notificationEnabled {
-		notification.Start(ctx, conf.Notification, conf.SystemDefaults, commands, queries, store != nil)
+		notification.Start(ctx, conf.Notification, conf.Projections.Customizations["user_notifier"], conf.SystemDefaults, commands, queries, store)
 	}
 
 	<-ctx.Done()
@@ -223,7 +229,7 @@ func startAPI(ctx context.Context, conf *Config, verifier *internal_authz.TokenV
 		apis.RegisterServer(ctx, admin.CreateServer(command, query, repo, conf.SystemDefaults.Domain, conf.API.Domain+"/assets/v1/"))
 	}
 	if *managementEnabled {
-		apis.RegisterServer(ctx, management.CreateServer(command, query, conf.SystemDefaults, conf.API.Domain+"/assets/v1/"))
+		apis.RegisterServer(ctx, management.CreateServer(command, query, conf.SystemDefaults, conf.API.Domain+"/assets/v1/", static))
 	}
 	if *authEnabled {
 		apis.RegisterServer(ctx, auth.CreateServer(command, query, authRepo, conf.SystemDefaults, conf.API.Domain+"/assets/v1/"))
@@ -244,7 +250,7 @@ func startAPI(ctx context.Context, conf *Config, verifier *internal_authz.TokenV
 	apis.Start(ctx)
 }
 
-func startSetup(configPaths []string) {
+func startSetup(configPaths []string, status, dryRun bool) {
 	conf := new(setupConfig)
 	err := config.Read(conf, configPaths...)
 	logging.Log("MAIN-FaF2r").OnError(err).Fatal("cannot read config")
@@ -254,9 +260,33 @@ func startSetup(configPaths []string) {
 	es, err := eventstore.Start(conf.Eventstore)
 	logging.Log("MAIN-Ddt3").OnError(err).Fatal("cannot start eventstore")
 
-	commands, err := command.StartCommands(es, conf.SystemDefaults, conf.InternalAuthZ, nil, nil)
+	commands, err := command.StartCommands(es, conf.SystemDefaults, conf.InternalAuthZ, nil, nil, command.IPThrottleConfig{})
 	logging.Log("MAIN-dsjrr").OnError(err).Fatal("cannot start command side")
 
-	err = setup.Execute(ctx, conf.SetUp, conf.SystemDefaults.IamID, commands)
-	logging.Log("MAIN-djs3R").OnError(err).Panic("failed to execute setup steps")
+	switch {
+	case status:
+		steps, err := setup.Status(ctx, commands)
+		logging.Log("MAIN-Sd2hT").OnError(err).Fatal("cannot read state of setup steps")
+		printSetupSteps(steps)
+	case dryRun:
+		steps, err := setup.DryRun(ctx, conf.SetUp, commands)
+		logging.Log("MAIN-Dr4uN").OnError(err).Fatal("cannot plan setup steps")
+		printSetupSteps(steps)
+	default:
+		err = setup.Execute(ctx, conf.SetUp, commands)
+		logging.Log("MAIN-djs3R").OnError(err).Panic("failed to execute setup steps")
+	}
+}
+
+func printSetupSteps(steps []*domain.StepStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tNAME\tSTATE\tCHANGED")
+	for _, step := range steps {
+		changed := "-"
+		if !step.ChangeDate.IsZero() {
+			changed = step.ChangeDate.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", step.Step, step.Name, step.State, changed)
+	}
+	w.Flush()
 }
@@ -0,0 +1,60 @@
+package cmds
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	http_util "github.com/caos/zitadel/internal/api/http"
+	"github.com/caos/zitadel/pkg/grpc/management"
+)
+
+const apiTokenEnv = "ZITADEL_TOKEN"
+
+//apiFlags are used by the commands calling the ZITADEL API instead of the kubernetes cluster
+type apiFlags struct {
+	address  string
+	token    string
+	orgID    string
+	insecure bool
+}
+
+func (f *apiFlags) register(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&f.address, "api", "api.zitadel.ch:443", "Address of the ZITADEL API")
+	flags.StringVar(&f.token, "token", "", "Access token used to call the API, defaults to the value of $"+apiTokenEnv)
+	flags.StringVar(&f.orgID, "org", "", "ID of the organisation, defaults to the organisation of the user of the token")
+	flags.BoolVar(&f.insecure, "insecure", false, "Connect to the API without TLS")
+}
+
+//managementClient connects to the management API
+// the returned context contains the token and organisation and must be used for all calls
+func (f *apiFlags) managementClient(ctx context.Context) (context.Context, management.ManagementServiceClient, *grpc.ClientConn, error) {
+	token := f.token
+	if token == "" {
+		token = os.Getenv(apiTokenEnv)
+	}
+	if token == "" {
+		return nil, nil, nil, errors.New("no access token provided, use --token or $" + apiTokenEnv)
+	}
+	transport := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	if f.insecure {
+		transport = grpc.WithInsecure()
+	}
+	conn, err := grpc.DialContext(ctx, f.address, transport)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, http_util.Authorization, authz.BearerPrefix+token)
+	if f.orgID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, http_util.ZitadelOrgID, f.orgID)
+	}
+	return ctx, management.NewManagementServiceClient(conn), conn, nil
+}
@@ -0,0 +1,282 @@
+package cmds
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caos/orbos/mntr"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+
+	"github.com/caos/zitadel/internal/backup"
+	"github.com/caos/zitadel/internal/config/types"
+)
+
+//databaseFlags are used by the commands connecting to the database directly instead of the kubernetes cluster
+// the defaults are read from the environment variables used in the configuration of ZITADEL
+type databaseFlags struct {
+	databaseType string
+	host         string
+	port         string
+	user         string
+	password     string
+	database     string
+	sslMode      string
+	sslRootCert  string
+	sslCert      string
+	sslKey       string
+}
+
+func (f *databaseFlags) register(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&f.databaseType, "db-type", envOrDefault("ZITADEL_DATABASE_TYPE", types.DatabaseCockroach), "Type of the database (cockroach or postgres), defaults to $ZITADEL_DATABASE_TYPE")
+	flags.StringVar(&f.host, "db-host", envOrDefault("CR_HOST", "localhost"), "Host of the database, defaults to $CR_HOST")
+	flags.StringVar(&f.port, "db-port", envOrDefault("CR_PORT", "26257"), "Port of the database, defaults to $CR_PORT")
+	flags.StringVar(&f.user, "db-user", envOrDefault("CR_USER", "root"), "User connecting to the database, defaults to $CR_USER")
+	flags.StringVar(&f.database, "db-name", "zitadel", "Database to connect to")
+	flags.StringVar(&f.sslMode, "db-ssl-mode", envOrDefault("CR_SSL_MODE", "disable"), "SSL mode of the connection, defaults to $CR_SSL_MODE")
+	flags.StringVar(&f.sslRootCert, "db-ssl-root-cert", os.Getenv("CR_ROOT_CERT"), "Path to the CA certificate, defaults to $CR_ROOT_CERT")
+	flags.StringVar(&f.sslCert, "db-ssl-cert", os.Getenv("CR_USER_CERT"), "Path to the client certificate, defaults to $CR_USER_CERT")
+	flags.StringVar(&f.sslKey, "db-ssl-key", os.Getenv("CR_USER_KEY"), "Path to the client key, defaults to $CR_USER_KEY")
+	f.password = os.Getenv("CR_PASSWORD")
+}
+
+func (f *databaseFlags) connect() (*sql.DB, error) {
+	config := &types.SQL{
+		Type:         f.databaseType,
+		Host:         f.host,
+		Port:         f.port,
+		User:         f.user,
+		Password:     f.password,
+		Database:     f.database,
+		MaxOpenConns: 1,
+		SSL: &types.SSL{
+			SSLBase: types.SSLBase{Mode: f.sslMode, RootCert: f.sslRootCert},
+			SSLUser: types.SSLUser{Cert: f.sslCert, Key: f.sslKey},
+		},
+	}
+	db, err := config.Start()
+	if err != nil {
+		return nil, err
+	}
+	return db, db.Ping()
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func DatabaseCommand(getRv GetRootValues) *cobra.Command {
+	var (
+		db  = new(databaseFlags)
+		cmd = &cobra.Command{
+			Use:     "db",
+			Aliases: []string{"database"},
+			Short:   "Backup and restore the database without kubernetes",
+			Long:    "Backup and restore the eventstore, projections and views of ZITADEL to and from a local archive, e.g. for deployments on VMs or with docker-compose.\nThe password of the database user is read from $CR_PASSWORD",
+		}
+	)
+	db.register(cmd)
+	cmd.AddCommand(
+		backupDatabaseCommand(getRv, db),
+		restoreDatabaseCommand(getRv, db),
+	)
+	return cmd
+}
+
+func backupDatabaseCommand(getRv GetRootValues, db *databaseFlags) *cobra.Command {
+	var (
+		file     string
+		keyFile  string
+		checksum bool
+		cmd      = &cobra.Command{
+			Use:   "backup",
+			Short: "Write the tables of ZITADEL to a local archive",
+			Long:  "Write the tables of ZITADEL to a local archive.\nWith --key-file the archive is encrypted, with --checksum the sha256 checksum of the archive is written to <file>.sha256",
+			Args:  cobra.NoArgs,
+			Example: `zitadelctl db backup -f zitadel-backup.tar.gz
+zitadelctl db backup --db-type postgres --db-port 5432 -f zitadel-backup.tar.gz.enc --key-file backup.key --checksum`,
+		}
+	)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path of the archive")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Path to a file containing the key (at least 16 random bytes) used to encrypt the archive, the content is used as is")
+	cmd.Flags().BoolVar(&checksum, "checksum", false, "Write the sha256 checksum of the archive to <file>.sha256")
+	_ = cmd.MarkFlagRequired("file")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("db backup", map[string]interface{}{"file": file, "encrypted": keyFile != ""}, "")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		key, err := readBackupKey(keyFile)
+		if err != nil {
+			return err
+		}
+		client, err := db.connect()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		archive, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return mntr.ToUserError(err)
+		}
+		defer archive.Close()
+		hash := sha256.New()
+		manifest, err := backup.Backup(rv.Ctx, client, db.databaseType, io.MultiWriter(archive, hash), key)
+		if err != nil {
+			archive.Close()
+			os.Remove(file)
+			return err
+		}
+		if err = archive.Close(); err != nil {
+			return err
+		}
+		if checksum {
+			line := hex.EncodeToString(hash.Sum(nil)) + "  " + filepath.Base(file) + "\n"
+			if err = ioutil.WriteFile(file+".sha256", []byte(line), 0600); err != nil {
+				return err
+			}
+		}
+		rv.Monitor.WithFields(map[string]interface{}{
+			"tables":        len(manifest.Tables),
+			"eventSequence": manifest.EventSequence,
+		}).Info("backup written")
+		return nil
+	}
+	return cmd
+}
+
+func restoreDatabaseCommand(getRv GetRootValues, db *databaseFlags) *cobra.Command {
+	var (
+		file    string
+		keyFile string
+		approve bool
+		cmd     = &cobra.Command{
+			Use:   "restore",
+			Short: "Restore the tables of ZITADEL from a local archive into a new database",
+			Long: "Restore the tables of ZITADEL from a local archive into a new database.\n" +
+				"The tables must exist and be empty, apply the migrations of the backed up version to a new database first.\n" +
+				"If <file>.sha256 exists, the checksum of the archive is verified first.\n" +
+				"The whole archive (checksums of the tables and the encryption) is verified before any row is inserted.\n" +
+				"The rows are inserted in batches, if the restore fails drop the database and restore again.\n" +
+				"Don't start ZITADEL on the database until the restore succeeded",
+			Args:    cobra.NoArgs,
+			Example: `zitadelctl db restore -f zitadel-backup.tar.gz`,
+		}
+	)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path of the archive")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Path to a file containing the key used to encrypt the archive")
+	cmd.Flags().BoolVarP(&approve, "yes", "y", false, "Restore without asking for confirmation")
+	_ = cmd.MarkFlagRequired("file")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("db restore", map[string]interface{}{"file": file, "encrypted": keyFile != ""}, "")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		key, err := readBackupKey(keyFile)
+		if err != nil {
+			return err
+		}
+		if err = verifyChecksum(file); err != nil {
+			return err
+		}
+		if err = verifyArchive(file, key); err != nil {
+			return err
+		}
+		if !approve {
+			prompt := promptui.Prompt{
+				Label:     "The backup is restored into the database, continue",
+				IsConfirm: true,
+			}
+			if _, err := prompt.Run(); err != nil {
+				rv.Monitor.Info("restore cancelled")
+				return nil
+			}
+		}
+		client, err := db.connect()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		archive, err := os.Open(file)
+		if err != nil {
+			return mntr.ToUserError(err)
+		}
+		defer archive.Close()
+		manifest, err := backup.Restore(rv.Ctx, client, db.databaseType, bufio.NewReader(archive), key)
+		if err != nil {
+			return err
+		}
+		rv.Monitor.WithFields(map[string]interface{}{
+			"tables":        len(manifest.Tables),
+			"eventSequence": manifest.EventSequence,
+			"created":       manifest.CreationDate,
+		}).Info("backup restored")
+		return nil
+	}
+	return cmd
+}
+
+func readBackupKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	key, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, mntr.ToUserError(err)
+	}
+	return key, nil
+}
+
+//verifyArchive reads the whole archive and verifies its content before the database is changed
+func verifyArchive(file string, key []byte) error {
+	archive, err := os.Open(file)
+	if err != nil {
+		return mntr.ToUserError(err)
+	}
+	defer archive.Close()
+	_, err = backup.Verify(bufio.NewReader(archive), key)
+	return err
+}
+
+//verifyChecksum compares the checksum of the archive with the checksum of <file>.sha256 if it exists
+func verifyChecksum(file string) error {
+	expected, err := ioutil.ReadFile(file + ".sha256")
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	archive, err := os.Open(file)
+	if err != nil {
+		return mntr.ToUserError(err)
+	}
+	defer archive.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, archive); err != nil {
+		return err
+	}
+	fields := strings.Fields(string(expected))
+	if len(fields) == 0 || fields[0] != hex.EncodeToString(hash.Sum(nil)) {
+		return mntr.ToUserError(fmt.Errorf("checksum of %s doesn't match %s.sha256", file, file))
+	}
+	return nil
+}
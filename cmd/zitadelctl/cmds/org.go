@@ -0,0 +1,126 @@
+package cmds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/caos/zitadel/pkg/grpc/management"
+	org_pb "github.com/caos/zitadel/pkg/grpc/org"
+)
+
+func OrgCommand(getRv GetRootValues) *cobra.Command {
+	var (
+		api = new(apiFlags)
+		cmd = &cobra.Command{
+			Use:   "org",
+			Short: "Export, import and apply the configuration of an organisation",
+			Long:  "Export, import and apply the projects, apps, roles, policies, identity providers, custom texts, actions and flows of an organisation using the management API",
+		}
+	)
+	api.register(cmd)
+	cmd.AddCommand(
+		exportOrgCommand(getRv, api),
+		importOrgCommand(getRv, api),
+		applyOrgCommand(getRv, api),
+	)
+	return cmd
+}
+
+func exportOrgCommand(getRv GetRootValues, api *apiFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Print the configuration of the organisation as json document to stdout",
+		Long:    "Print the configuration of the organisation as json document to stdout.\nSecrets of identity providers are not exported and must be added to the document before it's imported",
+		Args:    cobra.NoArgs,
+		Example: `zitadelctl org export --token $TOKEN --org 69234237810729019 > org.json`,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("org export", map[string]interface{}{"org": api.orgID}, "org")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		ctx, client, conn, err := api.managementClient(rv.Ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		resp, err := client.ExportOrg(ctx, &management.ExportOrgRequest{})
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(resp.Document)
+		return err
+	}
+	return cmd
+}
+
+func importOrgCommand(getRv GetRootValues, api *apiFlags) *cobra.Command {
+	var (
+		dryRun bool
+		cmd    = &cobra.Command{
+			Use:   "import [file]",
+			Short: "Create and update the objects of an exported document in the organisation",
+			Long:  "Create and update the objects of an exported document in the organisation.\nObjects are matched by their names, the changes are printed to stdout",
+			Args:  cobra.ExactArgs(1),
+			Example: `zitadelctl org import --token $TOKEN --org 69234237810729019 --dry-run org.json
+zitadelctl org import --token $TOKEN --org 69234237810729019 org.json`,
+		}
+	)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the changes without applying them")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("org import", map[string]interface{}{"org": api.orgID, "dryRun": dryRun}, "org")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		document, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		ctx, client, conn, err := api.managementClient(rv.Ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		resp, err := client.ImportOrg(ctx, &management.ImportOrgRequest{
+			Document: document,
+			DryRun:   dryRun,
+		})
+		if err != nil {
+			return err
+		}
+		for _, change := range resp.Changes {
+			if change.Type == org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_UNCHANGED {
+				continue
+			}
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\n", importChangeType(change.Type), change.ObjectType, change.Key, change.TargetId)
+		}
+		printClientSecrets(os.Stdout, resp.Changes)
+		rv.Monitor.WithFields(map[string]interface{}{
+			"created": resp.CreatedCount,
+			"updated": resp.UpdatedCount,
+			"dryRun":  dryRun,
+		}).Info("organisation imported")
+		return nil
+	}
+	return cmd
+}
+
+func importChangeType(changeType org_pb.ImportChangeType) string {
+	switch changeType {
+	case org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_CREATE:
+		return "create"
+	case org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_UPDATE:
+		return "update"
+	default:
+		return "unchanged"
+	}
+}
@@ -0,0 +1,232 @@
+package cmds
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	action_grpc "github.com/caos/zitadel/internal/api/grpc/action"
+	text_grpc "github.com/caos/zitadel/internal/api/grpc/text"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/org/transfer"
+	"github.com/caos/zitadel/pkg/grpc/management"
+	org_pb "github.com/caos/zitadel/pkg/grpc/org"
+)
+
+func applyOrgCommand(getRv GetRootValues, api *apiFlags) *cobra.Command {
+	var (
+		file    string
+		prune   bool
+		approve bool
+		cmd     = &cobra.Command{
+			Use:   "apply",
+			Short: "Reconcile the organisation with the desired state of a yaml file",
+			Long: "Reconcile the organisation with the desired state of a yaml file.\n" +
+				"The file has the same structure as an exported document, objects are matched by their names.\n" +
+				"The plan is printed before it's applied, with --prune objects which are not part of the file are removed",
+			Args: cobra.NoArgs,
+			Example: `zitadelctl org apply --token $TOKEN --org 69234237810729019 -f org.yaml
+zitadelctl org apply --token $TOKEN --org 69234237810729019 -f org.yaml --prune --yes`,
+		}
+	)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to the yaml file describing the desired state of the organisation")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Remove objects of the organisation which are not part of the file")
+	cmd.Flags().BoolVarP(&approve, "yes", "y", false, "Apply the plan without asking for confirmation")
+	_ = cmd.MarkFlagRequired("file")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("org apply", map[string]interface{}{"org": api.orgID, "file": file, "prune": prune}, "org")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		desired, err := transfer.ReadYAML(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		document := new(bytes.Buffer)
+		if err := transfer.Write(document, desired); err != nil {
+			return err
+		}
+
+		ctx, client, conn, err := api.managementClient(rv.Ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		plan, err := client.ImportOrg(ctx, &management.ImportOrgRequest{
+			Document: document.Bytes(),
+			DryRun:   true,
+		})
+		if err != nil {
+			return err
+		}
+		var removals []*transfer.Removal
+		if prune {
+			exported, err := client.ExportOrg(ctx, &management.ExportOrgRequest{})
+			if err != nil {
+				return err
+			}
+			current, err := transfer.Read(bytes.NewReader(exported.Document))
+			if err != nil {
+				return err
+			}
+			iam, err := client.GetIAM(ctx, &management.GetIAMRequest{})
+			if err != nil {
+				return err
+			}
+			removals = transfer.Prune(current, desired, iam.IamProjectId)
+		}
+
+		if plan.CreatedCount+plan.UpdatedCount == 0 && len(removals) == 0 {
+			rv.Monitor.Info("organisation is up to date")
+			return nil
+		}
+		printApplyPlan(os.Stdout, plan.Changes, removals)
+		if !approve && !confirm(os.Stdin, os.Stdout, "Apply the plan?") {
+			rv.Monitor.Info("plan not applied")
+			return nil
+		}
+
+		resp, err := client.ImportOrg(ctx, &management.ImportOrgRequest{
+			Document: document.Bytes(),
+		})
+		if err != nil {
+			return err
+		}
+		printClientSecrets(os.Stdout, resp.Changes)
+		for _, removal := range removals {
+			if err := removeObject(ctx, client, removal); err != nil {
+				return fmt.Errorf("removing %s %s failed: %w", removal.ObjectType, removal.Key, err)
+			}
+		}
+		rv.Monitor.WithFields(map[string]interface{}{
+			"created": resp.CreatedCount,
+			"updated": resp.UpdatedCount,
+			"removed": len(removals),
+		}).Info("organisation applied")
+		return nil
+	}
+	return cmd
+}
+
+func printApplyPlan(w io.Writer, changes []*org_pb.ImportChange, removals []*transfer.Removal) {
+	for _, change := range changes {
+		switch change.Type {
+		case org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_CREATE:
+			fmt.Fprintf(w, "+ %s\t%s\n", change.ObjectType, change.Key)
+		case org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_UPDATE:
+			fmt.Fprintf(w, "~ %s\t%s\n", change.ObjectType, change.Key)
+		}
+	}
+	for _, removal := range removals {
+		fmt.Fprintf(w, "- %s\t%s\n", removal.ObjectType, removal.Key)
+	}
+}
+
+//printClientSecrets prints the credentials of the created apps, as the secrets can't be read afterwards
+func printClientSecrets(w io.Writer, changes []*org_pb.ImportChange) {
+	for _, change := range changes {
+		if change.ClientSecret == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s\tclient id: %s\tclient secret: %s\n", change.Key, change.ClientId, change.ClientSecret)
+	}
+}
+
+//confirm asks the question on out and returns true if the answer read from in is yes
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+//removeObject removes the object from the organisation
+// policies and custom texts are reset to the defaults of the instance
+func removeObject(ctx context.Context, client management.ManagementServiceClient, removal *transfer.Removal) (err error) {
+	switch removal.ObjectType {
+	case transfer.ObjectTypeIDP:
+		_, err = client.RemoveOrgIDP(ctx, &management.RemoveOrgIDPRequest{IdpId: removal.ID})
+	case transfer.ObjectTypeLoginPolicyIDP:
+		_, err = client.RemoveIDPFromLoginPolicy(ctx, &management.RemoveIDPFromLoginPolicyRequest{IdpId: removal.ID})
+	case transfer.ObjectTypeAction:
+		_, err = client.DeleteAction(ctx, &management.DeleteActionRequest{Id: removal.ID})
+	case transfer.ObjectTypeProject:
+		_, err = client.RemoveProject(ctx, &management.RemoveProjectRequest{Id: removal.ID})
+	case transfer.ObjectTypeProjectRole:
+		_, err = client.RemoveProjectRole(ctx, &management.RemoveProjectRoleRequest{ProjectId: removal.ProjectID, RoleKey: removal.ID})
+	case transfer.ObjectTypeOIDCApp, transfer.ObjectTypeAPIApp:
+		_, err = client.RemoveApp(ctx, &management.RemoveAppRequest{ProjectId: removal.ProjectID, AppId: removal.ID})
+	case transfer.ObjectTypeLoginPolicy:
+		_, err = client.ResetLoginPolicyToDefault(ctx, &management.ResetLoginPolicyToDefaultRequest{})
+	case transfer.ObjectTypePasswordComplexityPolicy:
+		_, err = client.ResetPasswordComplexityPolicyToDefault(ctx, &management.ResetPasswordComplexityPolicyToDefaultRequest{})
+	case transfer.ObjectTypePasswordAgePolicy:
+		_, err = client.ResetPasswordAgePolicyToDefault(ctx, &management.ResetPasswordAgePolicyToDefaultRequest{})
+	case transfer.ObjectTypeLockoutPolicy:
+		_, err = client.ResetLockoutPolicyToDefault(ctx, &management.ResetLockoutPolicyToDefaultRequest{})
+	case transfer.ObjectTypePrivacyPolicy:
+		_, err = client.ResetPrivacyPolicyToDefault(ctx, &management.ResetPrivacyPolicyToDefaultRequest{})
+	case transfer.ObjectTypeLabelPolicy:
+		_, err = client.ResetLabelPolicyToDefault(ctx, &management.ResetLabelPolicyToDefaultRequest{})
+	case transfer.ObjectTypeFlow:
+		if removal.TriggerType == domain.TriggerTypeUnspecified {
+			_, err = client.ClearFlow(ctx, &management.ClearFlowRequest{Type: action_grpc.FlowTypeToPb(removal.FlowType)})
+			return err
+		}
+		_, err = client.SetTriggerActions(ctx, &management.SetTriggerActionsRequest{
+			FlowType:    action_grpc.FlowTypeToPb(removal.FlowType),
+			TriggerType: action_grpc.TriggerTypeToPb(removal.TriggerType),
+		})
+	case transfer.ObjectTypeCustomTexts:
+		return resetCustomTexts(ctx, client, removal.Template, removal.Language)
+	default:
+		return fmt.Errorf("unknown object type %s", removal.ObjectType)
+	}
+	return err
+}
+
+func resetCustomTexts(ctx context.Context, client management.ManagementServiceClient, template, language string) (err error) {
+	switch template {
+	case domain.LoginCustomText:
+		_, err = client.ResetCustomLoginTextToDefault(ctx, &management.ResetCustomLoginTextsToDefaultRequest{Language: language})
+	case domain.InitCodeMessageType:
+		_, err = client.ResetCustomInitMessageTextToDefault(ctx, &management.ResetCustomInitMessageTextToDefaultRequest{Language: language})
+	case domain.PasswordResetMessageType:
+		_, err = client.ResetCustomPasswordResetMessageTextToDefault(ctx, &management.ResetCustomPasswordResetMessageTextToDefaultRequest{Language: language})
+	case domain.VerifyEmailMessageType:
+		_, err = client.ResetCustomVerifyEmailMessageTextToDefault(ctx, &management.ResetCustomVerifyEmailMessageTextToDefaultRequest{Language: language})
+	case domain.VerifyPhoneMessageType:
+		_, err = client.ResetCustomVerifyPhoneMessageTextToDefault(ctx, &management.ResetCustomVerifyPhoneMessageTextToDefaultRequest{Language: language})
+	case domain.DomainClaimedMessageType:
+		_, err = client.ResetCustomDomainClaimedMessageTextToDefault(ctx, &management.ResetCustomDomainClaimedMessageTextToDefaultRequest{Language: language})
+	case domain.PasswordlessRegistrationMessageType:
+		_, err = client.ResetCustomPasswordlessRegistrationMessageTextToDefault(ctx, &management.ResetCustomPasswordlessRegistrationMessageTextToDefaultRequest{Language: language})
+	case domain.PasswordChangedMessageType,
+		domain.MFAAddedMessageType,
+		domain.MFARemovedMessageType,
+		domain.NewUserAgentMessageType,
+		domain.UserLockedMessageType,
+		domain.EmailChangedMessageType:
+		_, err = client.ResetCustomSecurityAlertMessageTextToDefault(ctx, &management.ResetCustomSecurityAlertMessageTextToDefaultRequest{AlertType: text_grpc.SecurityAlertTypeToPb(template), Language: language})
+	default:
+		return fmt.Errorf("unknown text template %s", template)
+	}
+	return err
+}
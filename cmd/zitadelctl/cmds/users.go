@@ -0,0 +1,145 @@
+package cmds
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caos/orbos/mntr"
+	"github.com/spf13/cobra"
+
+	"github.com/caos/zitadel/pkg/grpc/management"
+	user_pb "github.com/caos/zitadel/pkg/grpc/user"
+)
+
+func UsersCommand(getRv GetRootValues) *cobra.Command {
+	var (
+		api = new(apiFlags)
+		cmd = &cobra.Command{
+			Use:   "users",
+			Short: "Import and export the human users of an organisation",
+			Long:  "Import and export the human users of an organisation including their idp links, metadata and user grants using the management API",
+		}
+	)
+	api.register(cmd)
+	cmd.AddCommand(
+		importUsersCommand(getRv, api),
+		exportUsersCommand(getRv, api),
+	)
+	return cmd
+}
+
+func importUsersCommand(getRv GetRootValues, api *apiFlags) *cobra.Command {
+	var (
+		format string
+		cmd    = &cobra.Command{
+			Use:   "import [file]",
+			Short: "Create the users of a json or csv file",
+			Long:  "Create the users of a json or csv file.\nUsers which can't be created are printed with the reason, all other users are created",
+			Args:  cobra.ExactArgs(1),
+			Example: `zitadelctl users import --token $TOKEN --org 69234237810729019 users.csv
+zitadelctl users import --format json users.export`,
+		}
+	)
+	cmd.Flags().StringVar(&format, "format", "", "Format of the file (json or csv), defaults to the file extension")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("users import", map[string]interface{}{"format": format, "org": api.orgID}, "users")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		fileFormat, err := userFileFormat(format, args[0])
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		ctx, client, conn, err := api.managementClient(rv.Ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		resp, err := client.BulkImportHumanUsers(ctx, &management.BulkImportHumanUsersRequest{
+			Format: fileFormat,
+			Data:   data,
+		})
+		if err != nil {
+			return err
+		}
+		for _, result := range resp.Results {
+			if result.Error != "" {
+				fmt.Fprintf(os.Stdout, "user %d: %s\n", result.Index, result.Error)
+			}
+		}
+		rv.Monitor.WithFields(map[string]interface{}{
+			"imported": resp.ImportedCount,
+			"failed":   resp.FailedCount,
+		}).Info("users imported")
+		if resp.FailedCount > 0 {
+			return mntr.ToUserError(fmt.Errorf("%d of %d users could not be imported", resp.FailedCount, len(resp.Results)))
+		}
+		return nil
+	}
+	return cmd
+}
+
+func exportUsersCommand(getRv GetRootValues, api *apiFlags) *cobra.Command {
+	var (
+		format string
+		cmd    = &cobra.Command{
+			Use:     "export",
+			Short:   "Print the users of the organisation as json or csv file to stdout",
+			Long:    "Print the users of the organisation as json or csv file to stdout.\nThe file can be imported into another organisation, passwords are not exported",
+			Args:    cobra.NoArgs,
+			Example: `zitadelctl users export --token $TOKEN --org 69234237810729019 --format csv > users.csv`,
+		}
+	)
+	cmd.Flags().StringVar(&format, "format", "json", "Format of the file (json or csv)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+		rv := getRv("users export", map[string]interface{}{"format": format, "org": api.orgID}, "users")
+		defer func() {
+			err = rv.ErrFunc(err)
+		}()
+
+		fileFormat, err := userFileFormat(format, "")
+		if err != nil {
+			return err
+		}
+		ctx, client, conn, err := api.managementClient(rv.Ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		resp, err := client.ExportHumanUsers(ctx, &management.ExportHumanUsersRequest{
+			Format: fileFormat,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(resp.Data)
+		return err
+	}
+	return cmd
+}
+
+func userFileFormat(format, path string) (user_pb.UserFileFormat, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	switch strings.ToLower(format) {
+	case "json":
+		return user_pb.UserFileFormat_USER_FILE_FORMAT_JSON, nil
+	case "csv":
+		return user_pb.UserFileFormat_USER_FILE_FORMAT_CSV, nil
+	}
+	return user_pb.UserFileFormat_USER_FILE_FORMAT_UNSPECIFIED, mntr.ToUserError(errors.New("unsupported format, use json or csv"))
+}
@@ -39,6 +39,9 @@ func main() {
 		cmds.StartDatabase(rootValues),
 		cmds.ConfigCommand(rootValues, githubClientID, githubClientSecret),
 		cmds.TeardownCommand(rootValues),
+		cmds.UsersCommand(rootValues),
+		cmds.OrgCommand(rootValues),
+		cmds.DatabaseCommand(rootValues),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -78,13 +78,13 @@ module.exports = {
       type: "category",
       label: "Authorization",
       collapsed: false,
-      items: ["guides/authorization/oauth-recommended-flows"],
+      items: ["guides/authorization/oauth-recommended-flows", "guides/authorization/custom-roles", "guides/authorization/authorization-checks"],
     },
     {
       type: "category",
       label: "API",
       collapsed: false,
-      items: ["guides/api/access-zitadel-apis"],
+      items: ["guides/api/access-zitadel-apis", "guides/api/import-export-users", "guides/api/export-import-org", "guides/api/declarative-org-config", "guides/api/point-in-time"],
     },
     {
       type: "category",
@@ -125,6 +125,8 @@ module.exports = {
             "guides/installation/crd",
             "guides/installation/gitops",
             "guides/installation/orbos",
+            "guides/installation/backup",
+            "guides/installation/setup-steps",
           ],
         },
       ],
@@ -173,6 +175,7 @@ module.exports = {
             "apis/proto/idp",
             "apis/proto/member",
             "apis/proto/metadata",
+            "apis/proto/notification",
             "apis/proto/message",
             "apis/proto/text",
             "apis/proto/object",
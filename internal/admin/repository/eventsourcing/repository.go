@@ -33,7 +33,7 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 	if err != nil {
 		return nil, err
 	}
-	sqlClient, err := conf.View.Start()
+	sqlClient, err := conf.View.StartCockroach()
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/caos/logging"
 	sentryhttp "github.com/getsentry/sentry-go/http"
@@ -22,6 +23,7 @@ import (
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/errors"
 	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/setup"
 	"github.com/caos/zitadel/internal/telemetry/metrics"
 	"github.com/caos/zitadel/internal/telemetry/metrics/otel"
 	"github.com/caos/zitadel/internal/telemetry/tracing"
@@ -42,6 +44,7 @@ type API struct {
 	health         health
 	auth           auth
 	admin          admin
+	projections    projections
 }
 
 type health interface {
@@ -59,6 +62,10 @@ type admin interface {
 	GetSpoolerDiv(database, viewName string) int64
 }
 
+type projections interface {
+	SearchProjectionStates(ctx context.Context, queries *query.ProjectionStateSearchQueries) (*query.ProjectionStates, error)
+}
+
 func Create(config Config, authZ authz.Config, q *query.Queries, authZRepo authz_repo.Repository, authRepo *auth_es.EsRepository, adminRepo *admin_es.EsRepository, sd systemdefaults.SystemDefaults) *API {
 	api := &API{
 		serverPort: config.GRPC.ServerPort,
@@ -76,6 +83,7 @@ func Create(config Config, authZ authz.Config, q *query.Queries, authZRepo authz
 	api.health = &repo
 	api.auth = authRepo
 	api.admin = adminRepo
+	api.projections = q
 	api.grpcServer = server.CreateServer(api.verifier, authZ, sd.DefaultLanguage)
 	api.gatewayHandler = server.CreateGatewayHandler(config.GRPC)
 	api.RegisterHandler("", api.healthHandler())
@@ -112,10 +120,10 @@ func (a *API) healthHandler() http.Handler {
 			if err != nil && !errors.IsNotFound(err) {
 				return errors.ThrowPreconditionFailed(err, "API-dsgT2", "IAM SETUP CHECK FAILED")
 			}
-			if iam == nil || iam.SetupStarted < domain.StepCount-1 {
+			if iam == nil || iam.SetupStarted < setup.LastStep() {
 				return errors.ThrowPreconditionFailed(nil, "API-HBfs3", "IAM NOT SET UP")
 			}
-			if iam.SetupDone < domain.StepCount-1 {
+			if iam.SetupDone < setup.LastStep() {
 				return errors.ThrowPreconditionFailed(nil, "API-DASs2", "IAM SETUP RUNNING")
 			}
 			return nil
@@ -170,6 +178,7 @@ func (a *API) handleClientID(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleMetrics() http.Handler {
 	a.registerActiveSessionCounters()
 	a.registerSpoolerDivCounters()
+	a.registerProjectionCounters()
 	return metrics.GetExporter()
 }
 
@@ -209,6 +218,50 @@ func (a *API) registerSpoolerDivCounters() {
 	)
 }
 
+func (a *API) registerProjectionCounters() {
+	values := map[string]func(*query.ProjectionState) int64{
+		metrics.ProjectionEventsBehind: func(state *query.ProjectionState) int64 {
+			return int64(state.EventsBehind)
+		},
+		metrics.ProjectionMillisecondsBehind: func(state *query.ProjectionState) int64 {
+			return state.TimeBehind().Milliseconds()
+		},
+		metrics.ProjectionLastRunDiv: func(state *query.ProjectionState) int64 {
+			return time.Since(state.LastSuccessfulRun).Milliseconds()
+		},
+		metrics.ProjectionLocked: func(state *query.ProjectionState) int64 {
+			if state.IsLocked() {
+				return 1
+			}
+			return 0
+		},
+	}
+	//the states are read once per collection because the query aggregates over all events of each projection
+	metrics.RegisterBatchValueObserver(
+		map[string]string{
+			metrics.ProjectionEventsBehind:       metrics.ProjectionEventsBehindDescription,
+			metrics.ProjectionMillisecondsBehind: metrics.ProjectionMillisecondsBehindDescription,
+			metrics.ProjectionLastRunDiv:         metrics.ProjectionLastRunDivDescription,
+			metrics.ProjectionLocked:             metrics.ProjectionLockedDescription,
+		},
+		func(ctx context.Context, observe func(string, int64, map[string]attribute.Value)) {
+			states, err := a.projections.SearchProjectionStates(ctx, new(query.ProjectionStateSearchQueries))
+			if err != nil {
+				logging.Log("API-Ql2nB").WithError(err).Warn("could not read projection states for metrics")
+				return
+			}
+			for _, state := range states.ProjectionStates {
+				labels := map[string]attribute.Value{
+					metrics.ProjectionName: attribute.StringValue(state.ProjectionName),
+				}
+				for name, value := range values {
+					observe(name, value(state), labels)
+				}
+			}
+		},
+	)
+}
+
 type ValidationFunction func(ctx context.Context) error
 
 func validate(ctx context.Context, validations []ValidationFunction) []error {
@@ -10,3 +10,8 @@ func NewMockContextWithPermissions(orgID, userID string, permissions []string) c
 	ctx := context.WithValue(context.Background(), dataKey, CtxData{UserID: userID, OrgID: orgID})
 	return context.WithValue(ctx, requestPermissionsKey, permissions)
 }
+
+func NewMockContextWithAllPermissions(orgID, userID string, permissions []string) context.Context {
+	ctx := context.WithValue(context.Background(), dataKey, CtxData{UserID: userID, OrgID: orgID})
+	return context.WithValue(ctx, allPermissionsKey, permissions)
+}
@@ -35,10 +35,41 @@ func getUserMethodPermissions(ctx context.Context, t *TokenVerifier, requiredPer
 			return nil, nil, nil
 		}
 	}
+	authConfig, err = withCustomRoles(ctx, t, authConfig, memberships)
+	if err != nil {
+		return nil, nil, err
+	}
 	requestedPermissions, allPermissions = mapMembershipsToPermissions(requiredPerm, memberships, authConfig)
 	return requestedPermissions, allPermissions, nil
 }
 
+//withCustomRoles appends the roles defined at runtime to the roles of the config
+// they are only loaded if a membership has a role which isn't part of the config
+func withCustomRoles(ctx context.Context, t *TokenVerifier, authConfig Config, memberships []*Membership) (Config, error) {
+	if !hasUnknownRoles(memberships, authConfig) {
+		return authConfig, nil
+	}
+	customRoles, err := t.authZRepo.CustomRoleMappings(ctx)
+	if err != nil {
+		return authConfig, err
+	}
+	mappings := make([]RoleMapping, 0, len(authConfig.RolePermissionMappings)+len(customRoles))
+	mappings = append(mappings, authConfig.RolePermissionMappings...)
+	authConfig.RolePermissionMappings = append(mappings, customRoles...)
+	return authConfig, nil
+}
+
+func hasUnknownRoles(memberships []*Membership, authConfig Config) bool {
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			if authConfig.getPermissionsFromRole(role) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func mapMembershipsToPermissions(requiredPerm string, memberships []*Membership, authConfig Config) (requestPermissions, allPermissions []string) {
 	requestPermissions = make([]string, 0)
 	allPermissions = make([]string, 0)
@@ -13,6 +13,7 @@ func getTestCtx(userID, orgID string) context.Context {
 
 type testVerifier struct {
 	memberships []*Membership
+	customRoles []RoleMapping
 }
 
 func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
@@ -38,6 +39,10 @@ func (v *testVerifier) CheckOrgFeatures(context.Context, string, ...string) erro
 	return nil
 }
 
+func (v *testVerifier) CustomRoleMappings(context.Context) ([]RoleMapping, error) {
+	return v.customRoles, nil
+}
+
 func equalStringArray(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -140,6 +145,38 @@ func Test_GetUserMethodPermissions(t *testing.T) {
 			},
 			result: []string{"project.read"},
 		},
+		{
+			name: "Get Permissions of custom role",
+			args: args{
+				ctxData: CtxData{UserID: "userID", OrgID: "orgID"},
+				verifier: Start(&testVerifier{
+					memberships: []*Membership{
+						{
+							AggregateID: "IAM",
+							ObjectID:    "IAM",
+							MemberType:  MemberTypeIam,
+							Roles:       []string{"IAM_AUDITOR"},
+						},
+					},
+					customRoles: []RoleMapping{
+						{
+							Role:        "IAM_AUDITOR",
+							Permissions: []string{"iam.read", "project.read"},
+						},
+					},
+				}),
+				requiredPerm: "project.read",
+				authConfig: Config{
+					RolePermissionMappings: []RoleMapping{
+						{
+							Role:        "IAM_OWNER",
+							Permissions: []string{"project.read"},
+						},
+					},
+				},
+			},
+			result: []string{"iam.read", "project.read"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
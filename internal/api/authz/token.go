@@ -26,6 +26,7 @@ type authZRepo interface {
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	ExistsOrg(ctx context.Context, orgID string) error
 	CheckOrgFeatures(ctx context.Context, orgID string, requiredFeatures ...string) error
+	CustomRoleMappings(ctx context.Context) ([]RoleMapping, error)
 }
 
 func Start(authZRepo authZRepo) (v *TokenVerifier) {
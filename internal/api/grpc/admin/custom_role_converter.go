@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/query"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func ListCustomRolesRequestToQuery(req *admin_pb.ListCustomRolesRequest) (*query.CustomRoleSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, 0, 1)
+	if req.Scope != admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_UNSPECIFIED {
+		scopeQuery, err := query.NewCustomRoleScopeSearchQuery(CustomRoleScopeToDomain(req.Scope))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, scopeQuery)
+	}
+	return &query.CustomRoleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func AddCustomRoleToDomain(req *admin_pb.AddCustomRoleRequest) *domain.CustomRole {
+	return &domain.CustomRole{
+		Role:        req.Role,
+		Scope:       CustomRoleScopeToDomain(req.Scope),
+		Permissions: req.Permissions,
+	}
+}
+
+func CustomRolesToPb(roles []*query.CustomRole) []*admin_pb.CustomRole {
+	r := make([]*admin_pb.CustomRole, len(roles))
+	for i, role := range roles {
+		r[i] = CustomRoleToPb(role)
+	}
+	return r
+}
+
+func CustomRoleToPb(role *query.CustomRole) *admin_pb.CustomRole {
+	return &admin_pb.CustomRole{
+		Details:     object.ToViewDetailsPb(role.Sequence, role.CreationDate, role.ChangeDate, role.ResourceOwner),
+		Role:        role.Role,
+		Scope:       CustomRoleScopeToPb(role.Scope),
+		Permissions: role.Permissions,
+	}
+}
+
+func CustomRoleScopeToDomain(scope admin_pb.CustomRoleScope) domain.CustomRoleScope {
+	switch scope {
+	case admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_IAM:
+		return domain.CustomRoleScopeIAM
+	case admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_ORG:
+		return domain.CustomRoleScopeOrg
+	case admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_PROJECT:
+		return domain.CustomRoleScopeProject
+	default:
+		return domain.CustomRoleScopeUnspecified
+	}
+}
+
+func CustomRoleScopeToPb(scope domain.CustomRoleScope) admin_pb.CustomRoleScope {
+	switch scope {
+	case domain.CustomRoleScopeIAM:
+		return admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_IAM
+	case domain.CustomRoleScopeOrg:
+		return admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_ORG
+	case domain.CustomRoleScopeProject:
+		return admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_PROJECT
+	default:
+		return admin_pb.CustomRoleScope_CUSTOM_ROLE_SCOPE_UNSPECIFIED
+	}
+}
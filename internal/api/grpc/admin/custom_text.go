@@ -299,6 +299,54 @@ func (s *Server) ResetCustomPasswordlessRegistrationMessageTextToDefault(ctx con
 	}, nil
 }
 
+func (s *Server) GetDefaultSecurityAlertMessageText(ctx context.Context, req *admin_pb.GetDefaultSecurityAlertMessageTextRequest) (*admin_pb.GetDefaultSecurityAlertMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(text_grpc.SecurityAlertTypeToDomain(req.AlertType), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultSecurityAlertMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomSecurityAlertMessageText(ctx context.Context, req *admin_pb.GetCustomSecurityAlertMessageTextRequest) (*admin_pb.GetCustomSecurityAlertMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, domain.IAMID, text_grpc.SecurityAlertTypeToDomain(req.AlertType), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomSecurityAlertMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultSecurityAlertMessageText(ctx context.Context, req *admin_pb.SetDefaultSecurityAlertMessageTextRequest) (*admin_pb.SetDefaultSecurityAlertMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, SetSecurityAlertCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultSecurityAlertMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomSecurityAlertMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomSecurityAlertMessageTextToDefaultRequest) (*admin_pb.ResetCustomSecurityAlertMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveIAMMessageTexts(ctx, text_grpc.SecurityAlertTypeToDomain(req.AlertType), language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomSecurityAlertMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetDefaultLoginTexts(ctx context.Context, req *admin_pb.GetDefaultLoginTextsRequest) (*admin_pb.GetDefaultLoginTextsResponse, error) {
 	msg, err := s.query.GetDefaultLoginTexts(ctx, req.Language)
 	if err != nil {
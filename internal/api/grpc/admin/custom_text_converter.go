@@ -98,6 +98,21 @@ func SetPasswordlessRegistrationCustomTextToDomain(msg *admin_pb.SetDefaultPassw
 	}
 }
 
+func SetSecurityAlertCustomTextToDomain(msg *admin_pb.SetDefaultSecurityAlertMessageTextRequest) *domain.CustomMessageText {
+	langTag := language.Make(msg.Language)
+	return &domain.CustomMessageText{
+		MessageTextType: text.SecurityAlertTypeToDomain(msg.AlertType),
+		Language:        langTag,
+		Title:           msg.Title,
+		PreHeader:       msg.PreHeader,
+		Subject:         msg.Subject,
+		Greeting:        msg.Greeting,
+		Text:            msg.Text,
+		ButtonText:      msg.ButtonText,
+		FooterText:      msg.FooterText,
+	}
+}
+
 func SetLoginTextToDomain(req *admin_pb.SetCustomLoginTextsRequest) *domain.CustomLoginText {
 	langTag := language.Make(req.Language)
 	result := &domain.CustomLoginText{
@@ -3,6 +3,7 @@ package admin
 import (
 	"context"
 
+	"github.com/caos/zitadel/internal/errors"
 	"github.com/caos/zitadel/internal/query"
 	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
 )
@@ -35,3 +36,40 @@ func (s *Server) RemoveFailedEvent(ctx context.Context, req *admin_pb.RemoveFail
 	}
 	return &admin_pb.RemoveFailedEventResponse{}, nil
 }
+
+func (s *Server) GetFailedEvent(ctx context.Context, req *admin_pb.GetFailedEventRequest) (*admin_pb.GetFailedEventResponse, error) {
+	if req.Database != "zitadel" {
+		return nil, errors.ThrowUnimplemented(nil, "ADMIN-Wq3nb", "Errors.FailedEvents.OnlyProjections")
+	}
+	failedEvent, err := s.query.FailedEventByID(ctx, req.ViewName, req.FailedSequence)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetFailedEventResponse{
+		FailedEvent: FailedEventToPb(failedEvent),
+		Event:       FailedEventPayloadToPb(failedEvent.Event),
+		Errors:      FailedEventErrorsToPb(failedEvent.Errors),
+	}, nil
+}
+
+func (s *Server) RetryFailedEvent(ctx context.Context, req *admin_pb.RetryFailedEventRequest) (*admin_pb.RetryFailedEventResponse, error) {
+	if req.Database != "zitadel" {
+		return nil, errors.ThrowUnimplemented(nil, "ADMIN-0Xb1r", "Errors.FailedEvents.OnlyProjections")
+	}
+	err := s.query.RetryFailedEvent(ctx, req.ViewName, req.FailedSequence)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RetryFailedEventResponse{}, nil
+}
+
+func (s *Server) SkipFailedEvent(ctx context.Context, req *admin_pb.SkipFailedEventRequest) (*admin_pb.SkipFailedEventResponse, error) {
+	if req.Database != "zitadel" {
+		return nil, errors.ThrowUnimplemented(nil, "ADMIN-pT9sw", "Errors.FailedEvents.OnlyProjections")
+	}
+	err := s.query.SkipFailedEvent(ctx, req.ViewName, req.FailedSequence, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SkipFailedEventResponse{}, nil
+}
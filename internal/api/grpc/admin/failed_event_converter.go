@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/caos/zitadel/internal/query"
 	"github.com/caos/zitadel/internal/view/model"
 	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
@@ -39,7 +41,36 @@ func FailedEventToPb(failedEvent *query.FailedEvent) *admin_pb.FailedEvent {
 		FailedSequence: failedEvent.FailedSequence,
 		FailureCount:   failedEvent.FailureCount,
 		ErrorMessage:   failedEvent.Error,
+		LastFailed:     timestamppb.New(failedEvent.LastFailed),
+		Skipped:        failedEvent.Skipped,
+		SkipReason:     failedEvent.SkipReason,
+	}
+}
+
+func FailedEventPayloadToPb(event *query.FailedEventPayload) *admin_pb.FailedEventPayload {
+	if event == nil {
+		return nil
+	}
+	return &admin_pb.FailedEventPayload{
+		EventType:     event.Type,
+		AggregateType: event.AggregateType,
+		AggregateId:   event.AggregateID,
+		ResourceOwner: event.ResourceOwner,
+		CreationDate:  timestamppb.New(event.CreationDate),
+		Payload:       event.Data,
+	}
+}
+
+func FailedEventErrorsToPb(failures []*query.FailedEventError) []*admin_pb.FailedEventError {
+	errs := make([]*admin_pb.FailedEventError, len(failures))
+	for i, failure := range failures {
+		errs[i] = &admin_pb.FailedEventError{
+			FailureCount: failure.FailureCount,
+			ErrorMessage: failure.Error,
+			CreationDate: timestamppb.New(failure.CreationDate),
+		}
 	}
+	return errs
 }
 
 func RemoveFailedEventRequestToModel(req *admin_pb.RemoveFailedEventRequest) *model.FailedEvent {
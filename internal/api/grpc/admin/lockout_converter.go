@@ -8,5 +8,9 @@ import (
 func UpdateLockoutPolicyToDomain(p *admin.UpdateLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		LockoutDuration:     p.LockoutDuration.AsDuration(),
+		MaxIPAttempts:       uint64(p.MaxIpAttempts),
+		IPThrottleDuration:  p.IpThrottleDuration.AsDuration(),
 	}
 }
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/caos/zitadel/internal/api/grpc/policy"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/notification/types"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.GetDefaultMailMessageTemplateRequest) (*admin_pb.GetDefaultMailMessageTemplateResponse, error) {
+	template, err := s.query.DefaultMailMessageTemplate(ctx, policy_grpc.MailMessageTypeToDomain(req.MessageType))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMailMessageTemplateResponse{Template: policy_grpc.ModelMailMessageTemplateToPb(template)}, nil
+}
+
+func (s *Server) SetDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.SetDefaultMailMessageTemplateRequest) (*admin_pb.SetDefaultMailMessageTemplateResponse, error) {
+	result, err := s.command.SetDefaultMailMessageTemplate(ctx, SetMailMessageTemplateToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMailMessageTemplateResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.ResetDefaultMailMessageTemplateRequest) (*admin_pb.ResetDefaultMailMessageTemplateResponse, error) {
+	result, err := s.command.ResetDefaultMailMessageTemplate(ctx, policy_grpc.MailMessageTypeToDomain(req.MessageType))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetDefaultMailMessageTemplateResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) PreviewDefaultMailMessageTemplate(ctx context.Context, req *admin_pb.PreviewDefaultMailMessageTemplateRequest) (*admin_pb.PreviewDefaultMailMessageTemplateResponse, error) {
+	if !domain.IsValidMailTemplate(req.Template) {
+		return nil, errors.ThrowInvalidArgument(nil, "ADMIN-Mt8pV", "Errors.IAM.MailMessageTemplate.Invalid")
+	}
+	text, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, policy_grpc.MailMessageTypeToDomain(req.MessageType), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.query.DefaultActiveLabelPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	html, err := types.RenderMailTemplatePreview(string(req.Template), text, policy, s.assetsAPIDomain)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.PreviewDefaultMailMessageTemplateResponse{Html: html}, nil
+}
@@ -0,0 +1,14 @@
+package admin
+
+import (
+	policy_grpc "github.com/caos/zitadel/internal/api/grpc/policy"
+	"github.com/caos/zitadel/internal/domain"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func SetMailMessageTemplateToDomain(req *admin_pb.SetDefaultMailMessageTemplateRequest) *domain.MailMessageTemplate {
+	return &domain.MailMessageTemplate{
+		MessageType: policy_grpc.MailMessageTypeToDomain(req.MessageType),
+		Template:    req.Template,
+	}
+}
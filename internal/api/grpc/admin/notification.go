@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/api/grpc/notification"
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotifications(ctx context.Context, req *admin_pb.ListNotificationsRequest) (*admin_pb.ListNotificationsResponse, error) {
+	queries, err := ListNotificationsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchNotifications(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationsResponse{
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+		Result:  notification.NotificationsToPb(res.Notifications),
+	}, nil
+}
+
+func (s *Server) ResendNotification(ctx context.Context, req *admin_pb.ResendNotificationRequest) (*admin_pb.ResendNotificationResponse, error) {
+	n, err := s.query.NotificationByID(ctx, req.NotificationId)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ResendNotification(ctx, n.ResourceOwner, n.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResendNotificationResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
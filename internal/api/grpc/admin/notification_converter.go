@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/notification"
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/query"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func ListNotificationsRequestToQuery(req *admin_pb.ListNotificationsRequest) (*query.NotificationSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := notification.NotificationQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func UpdateNotificationPolicyToDomain(req *admin_pb.UpdateNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChanged: req.PasswordChanged,
+		MFAAdded:        req.MfaAdded,
+		MFARemoved:      req.MfaRemoved,
+		NewUserAgent:    req.NewUserAgent,
+		UserLocked:      req.UserLocked,
+		EmailChanged:    req.EmailChanged,
+	}
+}
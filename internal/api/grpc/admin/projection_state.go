@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/query"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListProjectionStates(ctx context.Context, _ *admin_pb.ListProjectionStatesRequest) (*admin_pb.ListProjectionStatesResponse, error) {
+	states, err := s.query.SearchProjectionStates(ctx, new(query.ProjectionStateSearchQueries))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListProjectionStatesResponse{
+		Details: object.ToListDetails(states.Count, 0, time.Now()),
+		Result:  ProjectionStatesToPb(states),
+	}, nil
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/caos/zitadel/internal/query"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func ProjectionStatesToPb(states *query.ProjectionStates) []*admin_pb.ProjectionState {
+	s := make([]*admin_pb.ProjectionState, len(states.ProjectionStates))
+	for i, state := range states.ProjectionStates {
+		s[i] = ProjectionStateToPb(state)
+	}
+	return s
+}
+
+func ProjectionStateToPb(state *query.ProjectionState) *admin_pb.ProjectionState {
+	return &admin_pb.ProjectionState{
+		ProjectionName:    state.ProjectionName,
+		CurrentSequence:   state.CurrentSequence,
+		LatestSequence:    state.LatestSequence,
+		EventsBehind:      state.EventsBehind,
+		TimeBehind:        durationpb.New(state.TimeBehind()),
+		LastSuccessfulRun: timestamppb.New(state.LastSuccessfulRun),
+		LockerId:          state.LockerID,
+		LockedUntil:       timestamppb.New(state.LockedUntil),
+	}
+}
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	text_grpc "github.com/caos/zitadel/internal/api/grpc/text"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListTranslationBundles(ctx context.Context, req *admin_pb.ListTranslationBundlesRequest) (*admin_pb.ListTranslationBundlesResponse, error) {
+	queries, err := ListTranslationBundlesRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchTranslationBundles(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListTranslationBundlesResponse{
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+		Result:  text_grpc.TranslationBundlesToPb(res.TranslationBundles),
+	}, nil
+}
+
+func (s *Server) GetTranslationBundle(ctx context.Context, req *admin_pb.GetTranslationBundleRequest) (*admin_pb.GetTranslationBundleResponse, error) {
+	lang, err := translationLanguageToDomain(req.Language)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := s.query.TranslationBundleByComponentAndLanguage(ctx, text_grpc.TranslationComponentToDomain(req.Component), lang)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetTranslationBundleResponse{
+		Bundle: text_grpc.TranslationBundleToPb(bundle),
+	}, nil
+}
+
+func (s *Server) SetTranslationBundle(ctx context.Context, req *admin_pb.SetTranslationBundleRequest) (*admin_pb.SetTranslationBundleResponse, error) {
+	bundle, err := translationBundleToDomain(req.Component, req.Language, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	reference, err := s.query.DefaultTranslationMessages(bundle.Component, language.English)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.SetTranslationBundle(ctx, bundle, reference)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetTranslationBundleResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ValidateTranslationBundle(ctx context.Context, req *admin_pb.ValidateTranslationBundleRequest) (*admin_pb.ValidateTranslationBundleResponse, error) {
+	bundle, err := translationBundleToDomain(req.Component, req.Language, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	reference, err := s.query.DefaultTranslationMessages(bundle.Component, language.English)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ValidateTranslationBundleResponse{
+		MissingKeys: bundle.MissingKeys(reference),
+	}, nil
+}
+
+func (s *Server) RemoveTranslationBundle(ctx context.Context, req *admin_pb.RemoveTranslationBundleRequest) (*admin_pb.RemoveTranslationBundleResponse, error) {
+	lang, err := translationLanguageToDomain(req.Language)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemoveTranslationBundle(ctx, text_grpc.TranslationComponentToDomain(req.Component), lang)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveTranslationBundleResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	text_grpc "github.com/caos/zitadel/internal/api/grpc/text"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/query"
+	admin_pb "github.com/caos/zitadel/pkg/grpc/admin"
+	text_pb "github.com/caos/zitadel/pkg/grpc/text"
+)
+
+func ListTranslationBundlesRequestToQuery(req *admin_pb.ListTranslationBundlesRequest) (*query.TranslationBundleSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, 0, 1)
+	if req.Component != text_pb.TranslationComponent_TRANSLATION_COMPONENT_UNSPECIFIED {
+		componentQuery, err := query.NewTranslationBundleComponentSearchQuery(text_grpc.TranslationComponentToDomain(req.Component))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, componentQuery)
+	}
+	return &query.TranslationBundleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func translationBundleToDomain(component text_pb.TranslationComponent, lang string, content []byte) (*domain.TranslationBundle, error) {
+	tag, err := translationLanguageToDomain(lang)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := i18n.ParseMessages(content)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TranslationBundle{
+		Component: text_grpc.TranslationComponentToDomain(component),
+		Language:  tag,
+		Messages:  messages,
+	}, nil
+}
+
+func translationLanguageToDomain(lang string) (language.Tag, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return language.Und, caos_errs.ThrowInvalidArgument(err, "ADMIN-Tb2lP", "Errors.TranslationBundle.LanguageInvalid")
+	}
+	return tag, nil
+}
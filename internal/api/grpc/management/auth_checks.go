@@ -22,6 +22,14 @@ func checkExplicitProjectPermission(ctx context.Context, grantID, projectID stri
 	return caos_errors.ThrowPermissionDenied(nil, "EVENT-Shu7e", "Errors.UserGrant.NoPermissionForProject")
 }
 
+//checkMembershipReadPermission checks if the user may read the memberships of the organisation
+func checkMembershipReadPermission(ctx context.Context) error {
+	if authz.HasGlobalExplicitPermission(authz.GetAllPermissionsFromCtx(ctx), "user.membership.read") {
+		return nil
+	}
+	return caos_errors.ThrowPermissionDenied(nil, "MANAG-Az2eP", "Errors.AuthorizationCheck.ExplainNotAllowed")
+}
+
 func listContainsID(ids []string, id string) bool {
 	for _, i := range ids {
 		if i == id {
@@ -0,0 +1,23 @@
+package management
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) CheckAuthorizations(ctx context.Context, req *mgmt_pb.CheckAuthorizationsRequest) (*mgmt_pb.CheckAuthorizationsResponse, error) {
+	if req.Explain {
+		if err := checkMembershipReadPermission(ctx); err != nil {
+			return nil, err
+		}
+	}
+	results, err := s.query.CheckAuthorizations(ctx, authz.GetCtxData(ctx).OrgID, AuthorizationChecksToQuery(req.Checks), req.Explain)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.CheckAuthorizationsResponse{
+		Results: AuthorizationCheckResultsToPb(results),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package management
+
+import (
+	"github.com/caos/zitadel/internal/query"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func AuthorizationChecksToQuery(checks []*mgmt_pb.AuthorizationCheck) []*query.AuthorizationCheck {
+	c := make([]*query.AuthorizationCheck, len(checks))
+	for i, check := range checks {
+		c[i] = &query.AuthorizationCheck{
+			UserID:     check.UserId,
+			Permission: check.Permission,
+			Resource: query.AuthorizationResource{
+				OrgID:          check.GetOrgId(),
+				ProjectID:      check.GetProjectId(),
+				ProjectGrantID: check.GetProjectGrantId(),
+			},
+		}
+	}
+	return c
+}
+
+func AuthorizationCheckResultsToPb(results []*query.AuthorizationCheckResult) []*mgmt_pb.AuthorizationCheckResult {
+	r := make([]*mgmt_pb.AuthorizationCheckResult, len(results))
+	for i, result := range results {
+		r[i] = &mgmt_pb.AuthorizationCheckResult{
+			Allowed: result.Allowed,
+			Matches: AuthorizationMatchesToPb(result.Matches),
+		}
+	}
+	return r
+}
+
+func AuthorizationMatchesToPb(matches []*query.AuthorizationMatch) []*mgmt_pb.AuthorizationMatch {
+	m := make([]*mgmt_pb.AuthorizationMatch, len(matches))
+	for i, match := range matches {
+		m[i] = &mgmt_pb.AuthorizationMatch{
+			Source: AuthorizationMatchSourceToPb(match.Source),
+			Id:     match.ID,
+			Role:   match.Role,
+		}
+	}
+	return m
+}
+
+func AuthorizationMatchSourceToPb(source query.AuthorizationMatchSource) mgmt_pb.AuthorizationMatchSource {
+	switch source {
+	case query.AuthorizationMatchSourceUserGrant:
+		return mgmt_pb.AuthorizationMatchSource_AUTHORIZATION_MATCH_SOURCE_USER_GRANT
+	case query.AuthorizationMatchSourceIAMMember:
+		return mgmt_pb.AuthorizationMatchSource_AUTHORIZATION_MATCH_SOURCE_IAM_MEMBER
+	case query.AuthorizationMatchSourceOrgMember:
+		return mgmt_pb.AuthorizationMatchSource_AUTHORIZATION_MATCH_SOURCE_ORG_MEMBER
+	case query.AuthorizationMatchSourceProjectMember:
+		return mgmt_pb.AuthorizationMatchSource_AUTHORIZATION_MATCH_SOURCE_PROJECT_MEMBER
+	case query.AuthorizationMatchSourceProjectGrantMember:
+		return mgmt_pb.AuthorizationMatchSource_AUTHORIZATION_MATCH_SOURCE_PROJECT_GRANT_MEMBER
+	default:
+		return mgmt_pb.AuthorizationMatchSource_AUTHORIZATION_MATCH_SOURCE_UNSPECIFIED
+	}
+}
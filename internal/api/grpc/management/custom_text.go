@@ -300,6 +300,54 @@ func (s *Server) ResetCustomPasswordlessRegistrationMessageTextToDefault(ctx con
 	}, nil
 }
 
+func (s *Server) GetCustomSecurityAlertMessageText(ctx context.Context, req *mgmt_pb.GetCustomSecurityAlertMessageTextRequest) (*mgmt_pb.GetCustomSecurityAlertMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.SecurityAlertTypeToDomain(req.AlertType), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomSecurityAlertMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultSecurityAlertMessageText(ctx context.Context, req *mgmt_pb.GetDefaultSecurityAlertMessageTextRequest) (*mgmt_pb.GetDefaultSecurityAlertMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, text_grpc.SecurityAlertTypeToDomain(req.AlertType), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultSecurityAlertMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomSecurityAlertMessageText(ctx context.Context, req *mgmt_pb.SetCustomSecurityAlertMessageTextRequest) (*mgmt_pb.SetCustomSecurityAlertMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetSecurityAlertCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomSecurityAlertMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomSecurityAlertMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomSecurityAlertMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomSecurityAlertMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, text_grpc.SecurityAlertTypeToDomain(req.AlertType), language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomSecurityAlertMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetCustomLoginTexts(ctx context.Context, req *mgmt_pb.GetCustomLoginTextsRequest) (*mgmt_pb.GetCustomLoginTextsResponse, error) {
 	msg, err := s.query.GetCustomLoginTexts(ctx, authz.GetCtxData(ctx).OrgID, req.Language)
 	if err != nil {
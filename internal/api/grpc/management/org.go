@@ -82,6 +82,24 @@ func (s *Server) UpdateOrg(ctx context.Context, req *mgmt_pb.UpdateOrgRequest) (
 	}, err
 }
 
+func (s *Server) GetMyOrgAt(ctx context.Context, req *mgmt_pb.GetMyOrgAtRequest) (*mgmt_pb.GetMyOrgAtResponse, error) {
+	org, err := s.command.OrgAt(ctx, authz.GetCtxData(ctx).OrgID, object.PointInTimeToDomain(req.Point))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetMyOrgAtResponse{Org: org_grpc.DomainOrgToPb(org)}, nil
+}
+
+func (s *Server) RevertMyOrg(ctx context.Context, req *mgmt_pb.RevertMyOrgRequest) (*mgmt_pb.RevertMyOrgResponse, error) {
+	details, err := s.command.RevertOrg(ctx, authz.GetCtxData(ctx).OrgID, object.PointInTimeToDomain(req.Point))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RevertMyOrgResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeactivateOrg(ctx context.Context, req *mgmt_pb.DeactivateOrgRequest) (*mgmt_pb.DeactivateOrgResponse, error) {
 	objectDetails, err := s.command.DeactivateOrg(ctx, authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -206,6 +224,26 @@ func (s *Server) SetPrimaryOrgDomain(ctx context.Context, req *mgmt_pb.SetPrimar
 	}, nil
 }
 
+func (s *Server) SetOrgLoginDomain(ctx context.Context, req *mgmt_pb.SetOrgLoginDomainRequest) (*mgmt_pb.SetOrgLoginDomainResponse, error) {
+	details, err := s.command.SetOrgLoginDomain(ctx, SetOrgLoginDomainRequestToDomain(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetOrgLoginDomainResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgLoginDomain(ctx context.Context, req *mgmt_pb.RemoveOrgLoginDomainRequest) (*mgmt_pb.RemoveOrgLoginDomainResponse, error) {
+	details, err := s.command.RemoveOrgLoginDomain(ctx, RemoveOrgLoginDomainRequestToDomain(ctx, req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgLoginDomainResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) ListOrgMemberRoles(ctx context.Context, req *mgmt_pb.ListOrgMemberRolesRequest) (*mgmt_pb.ListOrgMemberRolesResponse, error) {
 	iam, err := s.query.IAMByID(ctx, domain.IAMID)
 	if err != nil {
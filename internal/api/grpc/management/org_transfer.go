@@ -0,0 +1,37 @@
+package management
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/org/transfer"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ExportOrg(ctx context.Context, req *mgmt_pb.ExportOrgRequest) (*mgmt_pb.ExportOrgResponse, error) {
+	doc, err := transfer.Export(ctx, s.query, authz.GetCtxData(ctx).OrgID, authz.GetAllPermissionsFromCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err = transfer.Write(data, doc); err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ExportOrgResponse{
+		Document: data.Bytes(),
+	}, nil
+}
+
+func (s *Server) ImportOrg(ctx context.Context, req *mgmt_pb.ImportOrgRequest) (*mgmt_pb.ImportOrgResponse, error) {
+	doc, err := transfer.Read(bytes.NewReader(req.Document))
+	if err != nil {
+		return nil, err
+	}
+	ctxData := authz.GetCtxData(ctx)
+	changes, err := transfer.Import(ctx, s.query, s.command, ctxData.OrgID, ctxData.UserID, authz.GetAllPermissionsFromCtx(ctx), doc, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return ImportChangesToPb(changes), nil
+}
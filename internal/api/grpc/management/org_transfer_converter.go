@@ -0,0 +1,44 @@
+package management
+
+import (
+	"github.com/caos/zitadel/internal/org/transfer"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+	org_pb "github.com/caos/zitadel/pkg/grpc/org"
+)
+
+func ImportChangesToPb(changes []*transfer.Change) *mgmt_pb.ImportOrgResponse {
+	resp := &mgmt_pb.ImportOrgResponse{
+		Changes: make([]*org_pb.ImportChange, len(changes)),
+	}
+	for i, change := range changes {
+		resp.Changes[i] = &org_pb.ImportChange{
+			ObjectType:   string(change.ObjectType),
+			Key:          change.Key,
+			Type:         ImportChangeTypeToPb(change.Type),
+			SourceId:     change.SourceID,
+			TargetId:     change.TargetID,
+			ClientId:     change.ClientID,
+			ClientSecret: change.ClientSecret,
+		}
+		switch change.Type {
+		case transfer.ChangeTypeCreate:
+			resp.CreatedCount++
+		case transfer.ChangeTypeUpdate:
+			resp.UpdatedCount++
+		}
+	}
+	return resp
+}
+
+func ImportChangeTypeToPb(changeType transfer.ChangeType) org_pb.ImportChangeType {
+	switch changeType {
+	case transfer.ChangeTypeCreate:
+		return org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_CREATE
+	case transfer.ChangeTypeUpdate:
+		return org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_UPDATE
+	case transfer.ChangeTypeUnchanged:
+		return org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_UNCHANGED
+	default:
+		return org_pb.ImportChangeType_IMPORT_CHANGE_TYPE_UNSPECIFIED
+	}
+}
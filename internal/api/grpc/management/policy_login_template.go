@@ -0,0 +1,99 @@
+package management
+
+import (
+	"context"
+	"io/ioutil"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/caos/zitadel/internal/api/grpc/policy"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/ui/login/handler"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListLoginTemplates(ctx context.Context, req *mgmt_pb.ListLoginTemplatesRequest) (*mgmt_pb.ListLoginTemplatesResponse, error) {
+	templates, err := s.query.LoginTemplatesByOrg(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListLoginTemplatesResponse{
+		Result: policy_grpc.ModelLoginTemplatesToPb(templates.LoginTemplates),
+		Details: object.ToListDetails(
+			templates.Count,
+			templates.Sequence,
+			templates.Timestamp,
+		),
+	}, nil
+}
+
+func (s *Server) GetLoginTemplate(ctx context.Context, req *mgmt_pb.GetLoginTemplateRequest) (*mgmt_pb.GetLoginTemplateResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	template, err := s.query.LoginTemplateByOrg(ctx, orgID, req.Template)
+	if errors.IsNotFound(err) {
+		content, err := s.query.DefaultLoginTemplate(req.Template)
+		if err != nil {
+			return nil, err
+		}
+		return &mgmt_pb.GetLoginTemplateResponse{Template: policy_grpc.DefaultLoginTemplateToPb(req.Template, content)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	reader, _, err := s.static.GetObject(ctx, orgID, template.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "MGMT-Tp1rA", "Errors.Internal")
+	}
+	return &mgmt_pb.GetLoginTemplateResponse{Template: policy_grpc.ModelLoginTemplateToPb(template, content)}, nil
+}
+
+func (s *Server) SetCustomLoginTemplate(ctx context.Context, req *mgmt_pb.SetCustomLoginTemplateRequest) (*mgmt_pb.SetCustomLoginTemplateResponse, error) {
+	result, err := s.command.SetOrgLoginTemplate(ctx, authz.GetCtxData(ctx).OrgID, SetLoginTemplateToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomLoginTemplateResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetLoginTemplateToDefault(ctx context.Context, req *mgmt_pb.ResetLoginTemplateToDefaultRequest) (*mgmt_pb.ResetLoginTemplateToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgLoginTemplate(ctx, authz.GetCtxData(ctx).OrgID, req.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetLoginTemplateToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) PreviewLoginTemplate(ctx context.Context, req *mgmt_pb.PreviewLoginTemplateRequest) (*mgmt_pb.PreviewLoginTemplateResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	policy, err := s.query.ActiveLabelPolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	lang := s.query.DefaultLanguage
+	if req.Language != "" {
+		lang = language.Make(req.Language)
+	}
+	html, err := handler.RenderLoginTemplatePreview(req.Template, req.Content, orgID, policy, lang)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewLoginTemplateResponse{Html: html}, nil
+}
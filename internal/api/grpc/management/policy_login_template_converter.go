@@ -0,0 +1,13 @@
+package management
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func SetLoginTemplateToDomain(req *mgmt_pb.SetCustomLoginTemplateRequest) *domain.LoginTemplate {
+	return &domain.LoginTemplate{
+		Template: req.Template,
+		Content:  req.Content,
+	}
+}
@@ -0,0 +1,77 @@
+package management
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/caos/zitadel/internal/api/grpc/policy"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/notification/types"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetMailMessageTemplate(ctx context.Context, req *mgmt_pb.GetMailMessageTemplateRequest) (*mgmt_pb.GetMailMessageTemplateResponse, error) {
+	template, err := s.query.MailMessageTemplateByOrg(ctx, authz.GetCtxData(ctx).OrgID, policy_grpc.MailMessageTypeToDomain(req.MessageType))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetMailMessageTemplateResponse{Template: policy_grpc.ModelMailMessageTemplateToPb(template)}, nil
+}
+
+func (s *Server) GetDefaultMailMessageTemplate(ctx context.Context, req *mgmt_pb.GetDefaultMailMessageTemplateRequest) (*mgmt_pb.GetDefaultMailMessageTemplateResponse, error) {
+	template, err := s.query.DefaultMailMessageTemplate(ctx, policy_grpc.MailMessageTypeToDomain(req.MessageType))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultMailMessageTemplateResponse{Template: policy_grpc.ModelMailMessageTemplateToPb(template)}, nil
+}
+
+func (s *Server) SetCustomMailMessageTemplate(ctx context.Context, req *mgmt_pb.SetCustomMailMessageTemplateRequest) (*mgmt_pb.SetCustomMailMessageTemplateResponse, error) {
+	result, err := s.command.SetOrgMailMessageTemplate(ctx, authz.GetCtxData(ctx).OrgID, SetMailMessageTemplateToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMailMessageTemplateResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetMailMessageTemplateToDefault(ctx context.Context, req *mgmt_pb.ResetMailMessageTemplateToDefaultRequest) (*mgmt_pb.ResetMailMessageTemplateToDefaultResponse, error) {
+	result, err := s.command.ResetOrgMailMessageTemplate(ctx, authz.GetCtxData(ctx).OrgID, policy_grpc.MailMessageTypeToDomain(req.MessageType))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetMailMessageTemplateToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) PreviewMailMessageTemplate(ctx context.Context, req *mgmt_pb.PreviewMailMessageTemplateRequest) (*mgmt_pb.PreviewMailMessageTemplateResponse, error) {
+	if !domain.IsValidMailTemplate(req.Template) {
+		return nil, errors.ThrowInvalidArgument(nil, "MGMT-Mt8pV", "Errors.Org.MailMessageTemplate.Invalid")
+	}
+	orgID := authz.GetCtxData(ctx).OrgID
+	text, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, orgID, policy_grpc.MailMessageTypeToDomain(req.MessageType), req.Language)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.query.ActiveLabelPolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	html, err := types.RenderMailTemplatePreview(string(req.Template), text, policy, s.assetAPIPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewMailMessageTemplateResponse{Html: html}, nil
+}
@@ -0,0 +1,14 @@
+package management
+
+import (
+	policy_grpc "github.com/caos/zitadel/internal/api/grpc/policy"
+	"github.com/caos/zitadel/internal/domain"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func SetMailMessageTemplateToDomain(req *mgmt_pb.SetCustomMailMessageTemplateRequest) *domain.MailMessageTemplate {
+	return &domain.MailMessageTemplate{
+		MessageType: policy_grpc.MailMessageTypeToDomain(req.MessageType),
+		Template:    req.Template,
+	}
+}
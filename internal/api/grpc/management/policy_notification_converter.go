@@ -0,0 +1,28 @@
+package management
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+func AddNotificationPolicyToDomain(req *mgmt_pb.AddCustomNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChanged: req.PasswordChanged,
+		MFAAdded:        req.MfaAdded,
+		MFARemoved:      req.MfaRemoved,
+		NewUserAgent:    req.NewUserAgent,
+		UserLocked:      req.UserLocked,
+		EmailChanged:    req.EmailChanged,
+	}
+}
+
+func UpdateNotificationPolicyToDomain(req *mgmt_pb.UpdateCustomNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChanged: req.PasswordChanged,
+		MFAAdded:        req.MfaAdded,
+		MFARemoved:      req.MfaRemoved,
+		NewUserAgent:    req.NewUserAgent,
+		UserLocked:      req.UserLocked,
+		EmailChanged:    req.EmailChanged,
+	}
+}
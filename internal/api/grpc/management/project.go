@@ -154,6 +154,30 @@ func (s *Server) UpdateProject(ctx context.Context, req *mgmt_pb.UpdateProjectRe
 	}, nil
 }
 
+func (s *Server) GetProjectAt(ctx context.Context, req *mgmt_pb.GetProjectAtRequest) (*mgmt_pb.GetProjectAtResponse, error) {
+	project, err := s.command.ProjectAt(ctx, req.Id, authz.GetCtxData(ctx).OrgID, object_grpc.PointInTimeToDomain(req.Point))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProjectAtResponse{
+		Project: project_grpc.DomainProjectToPb(project),
+	}, nil
+}
+
+func (s *Server) RevertProject(ctx context.Context, req *mgmt_pb.RevertProjectRequest) (*mgmt_pb.RevertProjectResponse, error) {
+	project, err := s.command.RevertProject(ctx, req.Id, authz.GetCtxData(ctx).OrgID, object_grpc.PointInTimeToDomain(req.Point))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RevertProjectResponse{
+		Details: object_grpc.ChangeToDetailsPb(
+			project.Sequence,
+			project.ChangeDate,
+			project.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) DeactivateProject(ctx context.Context, req *mgmt_pb.DeactivateProjectRequest) (*mgmt_pb.DeactivateProjectResponse, error) {
 	details, err := s.command.DeactivateProject(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/caos/zitadel/internal/command"
 	"github.com/caos/zitadel/internal/config/systemdefaults"
 	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/static"
 	"github.com/caos/zitadel/pkg/grpc/management"
 )
 
@@ -23,14 +24,16 @@ type Server struct {
 	query          *query.Queries
 	systemDefaults systemdefaults.SystemDefaults
 	assetAPIPrefix string
+	static         static.Storage
 }
 
-func CreateServer(command *command.Commands, query *query.Queries, sd systemdefaults.SystemDefaults, assetAPIPrefix string) *Server {
+func CreateServer(command *command.Commands, query *query.Queries, sd systemdefaults.SystemDefaults, assetAPIPrefix string, static static.Storage) *Server {
 	return &Server{
 		command:        command,
 		query:          query,
 		systemDefaults: sd,
 		assetAPIPrefix: assetAPIPrefix,
+		static:         static,
 	}
 }
 
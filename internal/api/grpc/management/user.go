@@ -12,11 +12,13 @@ import (
 	change_grpc "github.com/caos/zitadel/internal/api/grpc/change"
 	idp_grpc "github.com/caos/zitadel/internal/api/grpc/idp"
 	"github.com/caos/zitadel/internal/api/grpc/metadata"
+	notification_grpc "github.com/caos/zitadel/internal/api/grpc/notification"
 	obj_grpc "github.com/caos/zitadel/internal/api/grpc/object"
 	"github.com/caos/zitadel/internal/api/grpc/user"
 	user_grpc "github.com/caos/zitadel/internal/api/grpc/user"
 	z_oidc "github.com/caos/zitadel/internal/api/oidc"
 	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
 	"github.com/caos/zitadel/internal/query"
 	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
 )
@@ -88,6 +90,43 @@ func (s *Server) ListUserChanges(ctx context.Context, req *mgmt_pb.ListUserChang
 	}, nil
 }
 
+func (s *Server) ListUserNotifications(ctx context.Context, req *mgmt_pb.ListUserNotificationsRequest) (*mgmt_pb.ListUserNotificationsResponse, error) {
+	queries, err := ListUserNotificationsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchNotifications(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserNotificationsResponse{
+		Result: notification_grpc.NotificationsToPb(res.Notifications),
+		Details: obj_grpc.ToListDetails(
+			res.Count,
+			res.Sequence,
+			res.Timestamp,
+		),
+	}, nil
+}
+
+func (s *Server) ResendUserNotification(ctx context.Context, req *mgmt_pb.ResendUserNotificationRequest) (*mgmt_pb.ResendUserNotificationResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	notification, err := s.query.NotificationByID(ctx, req.NotificationId)
+	if err != nil {
+		return nil, err
+	}
+	if notification.ResourceOwner != orgID || notification.UserID != req.UserId {
+		return nil, errors.ThrowNotFound(nil, "MANAG-Nf0ps", "Errors.Notification.NotFound")
+	}
+	details, err := s.command.ResendNotification(ctx, orgID, req.NotificationId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResendUserNotificationResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) IsUserUnique(ctx context.Context, req *mgmt_pb.IsUserUniqueRequest) (*mgmt_pb.IsUserUniqueResponse, error) {
 	orgID := authz.GetCtxData(ctx).OrgID
 	policy, err := s.query.OrgIAMPolicyByOrg(ctx, orgID)
@@ -367,6 +406,32 @@ func (s *Server) UpdateHumanProfile(ctx context.Context, req *mgmt_pb.UpdateHuma
 	}, nil
 }
 
+func (s *Server) GetHumanAt(ctx context.Context, req *mgmt_pb.GetHumanAtRequest) (*mgmt_pb.GetHumanAtResponse, error) {
+	human, err := s.command.HumanAt(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, obj_grpc.PointInTimeToDomain(req.Point))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetHumanAtResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			human.Sequence,
+			human.ChangeDate,
+			human.ResourceOwner,
+		),
+		UserName: human.Username,
+		Human:    user_grpc.DomainHumanToPb(human),
+	}, nil
+}
+
+func (s *Server) RevertHuman(ctx context.Context, req *mgmt_pb.RevertHumanRequest) (*mgmt_pb.RevertHumanResponse, error) {
+	details, err := s.command.RevertHuman(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, obj_grpc.PointInTimeToDomain(req.Point))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RevertHumanResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) GetHumanEmail(ctx context.Context, req *mgmt_pb.GetHumanEmailRequest) (*mgmt_pb.GetHumanEmailResponse, error) {
 	owner, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
 	if err != nil {
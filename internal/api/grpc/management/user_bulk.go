@@ -0,0 +1,94 @@
+package management
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/user/bulk"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+)
+
+const userGrantReadPermission = "user.grant.read"
+
+func (s *Server) BulkImportHumanUsers(ctx context.Context, req *mgmt_pb.BulkImportHumanUsersRequest) (*mgmt_pb.BulkImportHumanUsersResponse, error) {
+	users, err := bulk.Read(UserFileFormatToBulk(req.Format), bytes.NewReader(req.Data))
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.command.BulkImportHumans(ctx, authz.GetCtxData(ctx).OrgID, users)
+	if err != nil {
+		return nil, err
+	}
+	return BulkImportResultsToPb(results), nil
+}
+
+func (s *Server) ExportHumanUsers(ctx context.Context, req *mgmt_pb.ExportHumanUsersRequest) (*mgmt_pb.ExportHumanUsersResponse, error) {
+	users, err := s.exportHumans(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err = bulk.Write(UserFileFormatToBulk(req.Format), data, users); err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ExportHumanUsersResponse{
+		Data:      data.Bytes(),
+		UserCount: uint64(len(users)),
+	}, nil
+}
+
+func (s *Server) exportHumans(ctx context.Context, orgID string) ([]*domain.ImportUser, error) {
+	userOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	userTypeQuery, err := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.query.SearchUsers(ctx, &query.UserSearchQueries{Queries: []query.SearchQuery{userOwnerQuery, userTypeQuery}})
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.query.SearchOrgUserMetadata(ctx, orgID, &query.UserMetadataSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	linkOwnerQuery, err := query.NewIDPUserLinksResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.query.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{linkOwnerQuery}})
+	if err != nil {
+		return nil, err
+	}
+	grantOwnerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{grantOwnerQuery}})
+	if err != nil {
+		return nil, err
+	}
+	return HumansToExportUsers(users.Users, metadata.Metadata, links.Links, readableUserGrants(ctx, grants.UserGrants)), nil
+}
+
+//readableUserGrants returns the grants of the projects (grants) the user has the permission to read user grants of
+// the export itself only requires the permission to read users
+func readableUserGrants(ctx context.Context, grants []*query.UserGrant) []*query.UserGrant {
+	permissions := authz.GetAllPermissionsFromCtx(ctx)
+	if authz.HasGlobalExplicitPermission(permissions, userGrantReadPermission) {
+		return grants
+	}
+	ids := authz.GetExplicitPermissionCtxIDs(permissions, userGrantReadPermission)
+	readable := make([]*query.UserGrant, 0, len(grants))
+	for _, grant := range grants {
+		if listContainsID(ids, grant.ProjectID) || grant.GrantID != "" && listContainsID(ids, grant.GrantID) {
+			readable = append(readable, grant)
+		}
+	}
+	return readable
+}
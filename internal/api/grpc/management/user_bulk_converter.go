@@ -0,0 +1,119 @@
+package management
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/errors"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/user/bulk"
+	mgmt_pb "github.com/caos/zitadel/pkg/grpc/management"
+	user_pb "github.com/caos/zitadel/pkg/grpc/user"
+)
+
+func UserFileFormatToBulk(format user_pb.UserFileFormat) bulk.Format {
+	switch format {
+	case user_pb.UserFileFormat_USER_FILE_FORMAT_JSON:
+		return bulk.FormatJSON
+	case user_pb.UserFileFormat_USER_FILE_FORMAT_CSV:
+		return bulk.FormatCSV
+	default:
+		return ""
+	}
+}
+
+func BulkImportResultsToPb(results []*domain.ImportUserResult) *mgmt_pb.BulkImportHumanUsersResponse {
+	resp := &mgmt_pb.BulkImportHumanUsersResponse{
+		Results: make([]*mgmt_pb.BulkImportHumanUsersResponse_Result, len(results)),
+	}
+	for i, result := range results {
+		resp.Results[i] = &mgmt_pb.BulkImportHumanUsersResponse_Result{
+			Index:  uint32(result.Row),
+			UserId: result.UserID,
+		}
+		if result.Succeeded() {
+			resp.ImportedCount++
+			continue
+		}
+		resp.FailedCount++
+		resp.Results[i].Error = importErrorToPb(result.Err)
+	}
+	return resp
+}
+
+func importErrorToPb(err error) string {
+	_, key, id, ok := errors.ExtractCaosError(err)
+	if !ok {
+		return err.Error()
+	}
+	return key + " (" + id + ")"
+}
+
+//HumansToExportUsers combines the users with their metadata, idp links and user grants
+func HumansToExportUsers(users []*query.User, metadata []*query.UserMetadata, links []*query.IDPUserLink, grants []*query.UserGrant) []*domain.ImportUser {
+	exports := make([]*domain.ImportUser, 0, len(users))
+	byID := make(map[string]*domain.ImportUser, len(users))
+	for _, user := range users {
+		if user.Human == nil {
+			continue
+		}
+		export := &domain.ImportUser{Human: humanToDomain(user)}
+		exports = append(exports, export)
+		byID[user.ID] = export
+	}
+	for _, data := range metadata {
+		if export, ok := byID[data.UserID]; ok {
+			export.Metadata = append(export.Metadata, &domain.Metadata{
+				Key:   data.Key,
+				Value: data.Value,
+			})
+		}
+	}
+	for _, link := range links {
+		if export, ok := byID[link.UserID]; ok {
+			export.IDPLinks = append(export.IDPLinks, &domain.UserIDPLink{
+				IDPConfigID:    link.IDPID,
+				ExternalUserID: link.ProvidedUserID,
+				DisplayName:    link.ProvidedUsername,
+			})
+		}
+	}
+	for _, grant := range grants {
+		if export, ok := byID[grant.UserID]; ok {
+			export.Grants = append(export.Grants, &domain.UserGrant{
+				ProjectID:      grant.ProjectID,
+				ProjectGrantID: grant.GrantID,
+				RoleKeys:       grant.Roles,
+			})
+		}
+	}
+	return exports
+}
+
+func humanToDomain(user *query.User) *domain.Human {
+	human := &domain.Human{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   user.ID,
+			ResourceOwner: user.ResourceOwner,
+		},
+		Username: user.Username,
+		Profile: &domain.Profile{
+			FirstName:         user.Human.FirstName,
+			LastName:          user.Human.LastName,
+			NickName:          user.Human.NickName,
+			DisplayName:       user.Human.DisplayName,
+			PreferredLanguage: user.Human.PreferredLanguage,
+			Gender:            user.Human.Gender,
+		},
+		Email: &domain.Email{
+			EmailAddress:    user.Human.Email,
+			IsEmailVerified: user.Human.IsEmailVerified,
+		},
+	}
+	if user.Human.Phone != "" {
+		human.Phone = &domain.Phone{
+			PhoneNumber:     user.Human.Phone,
+			IsPhoneVerified: user.Human.IsPhoneVerified,
+		}
+	}
+	return human
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/caos/zitadel/internal/api/authz"
 	"github.com/caos/zitadel/internal/api/grpc/authn"
 	"github.com/caos/zitadel/internal/api/grpc/metadata"
+	notification_grpc "github.com/caos/zitadel/internal/api/grpc/notification"
 	"github.com/caos/zitadel/internal/api/grpc/object"
 	user_grpc "github.com/caos/zitadel/internal/api/grpc/user"
 	"github.com/caos/zitadel/internal/domain"
@@ -62,6 +63,29 @@ func ListUserMetadataToDomain(req *mgmt_pb.ListUserMetadataRequest) (*query.User
 	}, nil
 }
 
+func ListUserNotificationsRequestToQuery(ctx context.Context, req *mgmt_pb.ListUserNotificationsRequest) (*query.NotificationSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := notification_grpc.NotificationQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	notificationQueries := &query.NotificationSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}
+	if err = notificationQueries.AppendUserIDQuery(req.UserId); err != nil {
+		return nil, err
+	}
+	if err = notificationQueries.AppendMyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID); err != nil {
+		return nil, err
+	}
+	return notificationQueries, nil
+}
+
 func AddHumanUserRequestToDomain(req *mgmt_pb.AddHumanUserRequest) *domain.Human {
 	h := &domain.Human{
 		Username: req.UserName,
@@ -117,8 +141,8 @@ func ImportHumanUserRequestToDomain(req *mgmt_pb.ImportHumanUserRequest) (human
 			IsPhoneVerified: req.Phone.IsPhoneVerified,
 		}
 	}
-	if req.Password != "" {
-		human.Password = &domain.Password{SecretString: req.Password}
+	if req.Password != "" || req.HashedPassword != "" {
+		human.Password = &domain.Password{SecretString: req.Password, EncodedHash: req.HashedPassword}
 		human.Password.ChangeRequired = req.PasswordChangeRequired
 	}
 
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query"
+	notification_pb "github.com/caos/zitadel/pkg/grpc/notification"
+)
+
+func NotificationsToPb(notifications []*query.Notification) []*notification_pb.Notification {
+	n := make([]*notification_pb.Notification, len(notifications))
+	for i, notification := range notifications {
+		n[i] = NotificationToPb(notification)
+	}
+	return n
+}
+
+func NotificationToPb(notification *query.Notification) *notification_pb.Notification {
+	n := &notification_pb.Notification{
+		Id: notification.ID,
+		Details: object.ToViewDetailsPb(
+			notification.Sequence,
+			notification.CreationDate,
+			notification.ChangeDate,
+			notification.ResourceOwner,
+		),
+		UserId:      notification.UserID,
+		MessageType: notification.MessageType,
+		Channel:     NotificationChannelToPb(notification.Channel),
+		Recipient:   notification.Recipient,
+		State:       NotificationStateToPb(notification.State),
+		Attempts:    notification.Attempts,
+		LastError:   notification.LastError,
+	}
+	if notification.State.IsDue() {
+		n.NextAttempt = timestamppb.New(notification.NextAttempt)
+	}
+	return n
+}
+
+func NotificationChannelToPb(channel domain.NotificationType) notification_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeSms:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	}
+}
+
+func NotificationStateToPb(state domain.NotificationState) notification_pb.NotificationState {
+	switch state {
+	case domain.NotificationStateQueued:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_QUEUED
+	case domain.NotificationStateSent:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_SENT
+	case domain.NotificationStateRetrying:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_RETRYING
+	case domain.NotificationStateFailed:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_FAILED
+	default:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_UNSPECIFIED
+	}
+}
+
+func NotificationStateToDomain(state notification_pb.NotificationState) domain.NotificationState {
+	switch state {
+	case notification_pb.NotificationState_NOTIFICATION_STATE_QUEUED:
+		return domain.NotificationStateQueued
+	case notification_pb.NotificationState_NOTIFICATION_STATE_SENT:
+		return domain.NotificationStateSent
+	case notification_pb.NotificationState_NOTIFICATION_STATE_RETRYING:
+		return domain.NotificationStateRetrying
+	case notification_pb.NotificationState_NOTIFICATION_STATE_FAILED:
+		return domain.NotificationStateFailed
+	default:
+		return domain.NotificationStateUnspecified
+	}
+}
+
+func NotificationQueriesToQuery(queries []*notification_pb.NotificationQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = NotificationQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func NotificationQueryToQuery(notificationQuery *notification_pb.NotificationQuery) (query.SearchQuery, error) {
+	switch q := notificationQuery.Query.(type) {
+	case *notification_pb.NotificationQuery_StateQuery:
+		return query.NewNotificationStateSearchQuery(NotificationStateToDomain(q.StateQuery.State))
+	case *notification_pb.NotificationQuery_UserIdQuery:
+		return query.NewNotificationUserIDSearchQuery(q.UserIdQuery.UserId)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "NOTIF-Qk2mf", "List.Query.Invalid")
+	}
+}
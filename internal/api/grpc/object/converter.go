@@ -6,6 +6,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/query"
 	object_pb "github.com/caos/zitadel/pkg/grpc/object"
 )
@@ -128,3 +129,14 @@ func ListQueryToModel(query *object_pb.ListQuery) (offset, limit uint64, asc boo
 	}
 	return query.Offset, uint64(query.Limit), query.Asc
 }
+
+func PointInTimeToDomain(point *object_pb.PointInTime) eventstore.PointInTime {
+	switch p := point.GetPoint().(type) {
+	case *object_pb.PointInTime_Sequence:
+		return eventstore.PointInTime{Sequence: p.Sequence}
+	case *object_pb.PointInTime_Date:
+		return eventstore.PointInTime{Date: p.Date.AsTime()}
+	default:
+		return eventstore.PointInTime{}
+	}
+}
@@ -93,6 +93,16 @@ func OrgToPb(org *query.Org) *org_pb.Org {
 	}
 }
 
+func DomainOrgToPb(org *domain.Org) *org_pb.Org {
+	return &org_pb.Org{
+		Id:            org.AggregateID,
+		Name:          org.Name,
+		PrimaryDomain: org.PrimaryDomain,
+		Details:       object.ChangeToDetailsPb(org.Sequence, org.ChangeDate, org.ResourceOwner),
+		State:         OrgStateToPb(org.State),
+	}
+}
+
 func OrgStateToPb(state domain.OrgState) org_pb.OrgState {
 	switch state {
 	case domain.OrgStateActive:
@@ -138,6 +148,7 @@ func DomainToPb(d *query.Domain) *org_pb.Domain {
 		DomainName:     d.Domain,
 		IsVerified:     d.IsVerified,
 		IsPrimary:      d.IsPrimary,
+		IsLoginDomain:  d.IsLoginDomain,
 		ValidationType: DomainValidationTypeFromModel(d.ValidationType),
 		Details: object.ToViewDetailsPb(
 			d.Sequence,
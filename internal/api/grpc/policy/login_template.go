@@ -0,0 +1,36 @@
+package policy
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/query"
+	policy_pb "github.com/caos/zitadel/pkg/grpc/policy"
+)
+
+func ModelLoginTemplatesToPb(templates []*query.LoginTemplate) []*policy_pb.LoginTemplate {
+	result := make([]*policy_pb.LoginTemplate, len(templates))
+	for i, template := range templates {
+		result[i] = ModelLoginTemplateToPb(template, nil)
+	}
+	return result
+}
+
+func ModelLoginTemplateToPb(template *query.LoginTemplate, content []byte) *policy_pb.LoginTemplate {
+	return &policy_pb.LoginTemplate{
+		Template: template.Template,
+		Content:  content,
+		Details: object.ToViewDetailsPb(
+			template.Sequence,
+			template.CreationDate,
+			template.ChangeDate,
+			template.AggregateID,
+		),
+	}
+}
+
+func DefaultLoginTemplateToPb(template string, content []byte) *policy_pb.LoginTemplate {
+	return &policy_pb.LoginTemplate{
+		Template:  template,
+		Content:   content,
+		IsDefault: true,
+	}
+}
@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/query"
+	policy_pb "github.com/caos/zitadel/pkg/grpc/policy"
+)
+
+func ModelMailMessageTemplateToPb(template *query.MailMessageTemplate) *policy_pb.MailMessageTemplate {
+	return &policy_pb.MailMessageTemplate{
+		MessageType: MailMessageTypeToPb(template.MessageType),
+		Template:    template.Template,
+		IsDefault:   template.IsDefault,
+		Details: object.ToViewDetailsPb(
+			template.Sequence,
+			template.CreationDate,
+			template.ChangeDate,
+			template.AggregateID,
+		),
+	}
+}
+
+func MailMessageTypeToDomain(messageType policy_pb.MailMessageType) string {
+	switch messageType {
+	case policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_INIT:
+		return domain.InitCodeMessageType
+	case policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_PASSWORD_RESET:
+		return domain.PasswordResetMessageType
+	case policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_VERIFY_EMAIL:
+		return domain.VerifyEmailMessageType
+	case policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_DOMAIN_CLAIMED:
+		return domain.DomainClaimedMessageType
+	case policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_PASSWORDLESS_REGISTRATION:
+		return domain.PasswordlessRegistrationMessageType
+	default:
+		return ""
+	}
+}
+
+func MailMessageTypeToPb(messageType string) policy_pb.MailMessageType {
+	switch messageType {
+	case domain.InitCodeMessageType:
+		return policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_INIT
+	case domain.PasswordResetMessageType:
+		return policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_PASSWORD_RESET
+	case domain.VerifyEmailMessageType:
+		return policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_VERIFY_EMAIL
+	case domain.DomainClaimedMessageType:
+		return policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_DOMAIN_CLAIMED
+	case domain.PasswordlessRegistrationMessageType:
+		return policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_PASSWORDLESS_REGISTRATION
+	default:
+		return policy_pb.MailMessageType_MAIL_MESSAGE_TYPE_UNSPECIFIED
+	}
+}
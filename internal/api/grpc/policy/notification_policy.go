@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/query"
+	policy_pb "github.com/caos/zitadel/pkg/grpc/policy"
+)
+
+func ModelNotificationPolicyToPb(policy *query.NotificationPolicy) *policy_pb.NotificationPolicy {
+	return &policy_pb.NotificationPolicy{
+		IsDefault:       policy.IsDefault,
+		PasswordChanged: policy.PasswordChanged,
+		MfaAdded:        policy.MFAAdded,
+		MfaRemoved:      policy.MFARemoved,
+		NewUserAgent:    policy.NewUserAgent,
+		UserLocked:      policy.UserLocked,
+		EmailChanged:    policy.EmailChanged,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
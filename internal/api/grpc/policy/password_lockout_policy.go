@@ -4,12 +4,17 @@ import (
 	"github.com/caos/zitadel/internal/api/grpc/object"
 	"github.com/caos/zitadel/internal/query"
 	policy_pb "github.com/caos/zitadel/pkg/grpc/policy"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func ModelLockoutPolicyToPb(policy *query.LockoutPolicy) *policy_pb.LockoutPolicy {
 	return &policy_pb.LockoutPolicy{
 		IsDefault:           policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOtpAttempts:      policy.MaxOTPAttempts,
+		LockoutDuration:     durationpb.New(policy.LockoutDuration),
+		MaxIpAttempts:       policy.MaxIPAttempts,
+		IpThrottleDuration:  durationpb.New(policy.IPThrottleDuration),
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -35,6 +35,19 @@ func ProjectViewToPb(project *query.Project) *proj_pb.Project {
 	}
 }
 
+func DomainProjectToPb(project *domain.Project) *proj_pb.Project {
+	return &proj_pb.Project{
+		Id:                     project.AggregateID,
+		State:                  projectStateToPb(project.State),
+		Name:                   project.Name,
+		PrivateLabelingSetting: privateLabelingSettingToPb(project.PrivateLabelingSetting),
+		HasProjectCheck:        project.HasProjectCheck,
+		ProjectRoleAssertion:   project.ProjectRoleAssertion,
+		ProjectRoleCheck:       project.ProjectRoleCheck,
+		Details:                object.ChangeToDetailsPb(project.Sequence, project.ChangeDate, project.ResourceOwner),
+	}
+}
+
 func GrantedProjectViewsToPb(projects []*query.ProjectGrant) []*proj_pb.GrantedProject {
 	p := make([]*proj_pb.GrantedProject, len(projects))
 	for i, project := range projects {
@@ -19,13 +19,18 @@ var (
 	}
 )
 
-type verifierMock struct{}
+type verifierMock struct {
+	userID        string
+	resourceOwner string
+	memberships   []*authz.Membership
+	customRoles   []authz.RoleMapping
+}
 
 func (v *verifierMock) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
-	return "", "", "", "", "", nil
+	return v.userID, "", "", "", v.resourceOwner, nil
 }
 func (v *verifierMock) SearchMyMemberships(ctx context.Context) ([]*authz.Membership, error) {
-	return nil, nil
+	return v.memberships, nil
 }
 
 func (v *verifierMock) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (string, []string, error) {
@@ -40,6 +45,9 @@ func (v *verifierMock) VerifierClientID(ctx context.Context, appName string) (st
 func (v *verifierMock) CheckOrgFeatures(context.Context, string, ...string) error {
 	return nil
 }
+func (v *verifierMock) CustomRoleMappings(context.Context) ([]authz.RoleMapping, error) {
+	return v.customRoles, nil
+}
 
 func Test_authorize(t *testing.T) {
 	type args struct {
@@ -139,6 +147,72 @@ func Test_authorize(t *testing.T) {
 				false,
 			},
 		},
+		{
+			"custom role permission ok",
+			args{
+				ctx:     metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token")),
+				req:     &mockReq{},
+				info:    mockInfo("/need/permission"),
+				handler: emptyMockHandler,
+				verifier: func() *authz.TokenVerifier {
+					verifier := authz.Start(&verifierMock{
+						userID:        "user1",
+						resourceOwner: "org1",
+						memberships: []*authz.Membership{
+							{MemberType: authz.MemberTypeOrganisation, AggregateID: "org1", Roles: []string{"CUSTOM_AUDITOR"}},
+						},
+						customRoles: []authz.RoleMapping{
+							{Role: "CUSTOM_AUDITOR", Permissions: []string{"org.read"}},
+						},
+					})
+					verifier.RegisterServer("need", "need", authz.MethodMapping{"/need/permission": authz.Option{Permission: "org.read"}})
+					return verifier
+				}(),
+				authConfig: authz.Config{
+					RolePermissionMappings: []authz.RoleMapping{
+						{Role: "ORG_OWNER", Permissions: []string{"org.read", "org.write"}},
+					},
+				},
+				authMethods: mockMethods,
+			},
+			res{
+				&mockReq{},
+				false,
+			},
+		},
+		{
+			"custom role without permission error",
+			args{
+				ctx:     metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token")),
+				req:     &mockReq{},
+				info:    mockInfo("/need/permission"),
+				handler: emptyMockHandler,
+				verifier: func() *authz.TokenVerifier {
+					verifier := authz.Start(&verifierMock{
+						userID:        "user1",
+						resourceOwner: "org1",
+						memberships: []*authz.Membership{
+							{MemberType: authz.MemberTypeOrganisation, AggregateID: "org1", Roles: []string{"CUSTOM_AUDITOR"}},
+						},
+						customRoles: []authz.RoleMapping{
+							{Role: "CUSTOM_AUDITOR", Permissions: []string{"org.read"}},
+						},
+					})
+					verifier.RegisterServer("need", "need", authz.MethodMapping{"/need/permission": authz.Option{Permission: "org.write"}})
+					return verifier
+				}(),
+				authConfig: authz.Config{
+					RolePermissionMappings: []authz.RoleMapping{
+						{Role: "ORG_OWNER", Permissions: []string{"org.read", "org.write"}},
+					},
+				},
+				authMethods: mockMethods,
+			},
+			res{
+				nil,
+				true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -26,6 +26,44 @@ func ModelCustomMessageTextToPb(msg *query.MessageText) *text_pb.MessageCustomTe
 	}
 }
 
+func SecurityAlertTypeToDomain(alertType text_pb.SecurityAlertType) string {
+	switch alertType {
+	case text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_PASSWORD_CHANGED:
+		return domain.PasswordChangedMessageType
+	case text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_MFA_ADDED:
+		return domain.MFAAddedMessageType
+	case text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_MFA_REMOVED:
+		return domain.MFARemovedMessageType
+	case text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_NEW_USER_AGENT:
+		return domain.NewUserAgentMessageType
+	case text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_USER_LOCKED:
+		return domain.UserLockedMessageType
+	case text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_EMAIL_CHANGED:
+		return domain.EmailChangedMessageType
+	default:
+		return ""
+	}
+}
+
+func SecurityAlertTypeToPb(messageType string) text_pb.SecurityAlertType {
+	switch messageType {
+	case domain.PasswordChangedMessageType:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_PASSWORD_CHANGED
+	case domain.MFAAddedMessageType:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_MFA_ADDED
+	case domain.MFARemovedMessageType:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_MFA_REMOVED
+	case domain.NewUserAgentMessageType:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_NEW_USER_AGENT
+	case domain.UserLockedMessageType:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_USER_LOCKED
+	case domain.EmailChangedMessageType:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_EMAIL_CHANGED
+	default:
+		return text_pb.SecurityAlertType_SECURITY_ALERT_TYPE_UNSPECIFIED
+	}
+}
+
 func CustomLoginTextToPb(text *domain.CustomLoginText) *text_pb.LoginCustomText {
 	return &text_pb.LoginCustomText{
 		Details: object.ToViewDetailsPb(
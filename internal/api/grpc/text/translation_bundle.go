@@ -0,0 +1,52 @@
+package text
+
+import (
+	"github.com/caos/zitadel/internal/api/grpc/object"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/query"
+	text_pb "github.com/caos/zitadel/pkg/grpc/text"
+)
+
+func TranslationBundlesToPb(bundles []*query.TranslationBundle) []*text_pb.TranslationBundle {
+	result := make([]*text_pb.TranslationBundle, len(bundles))
+	for i, bundle := range bundles {
+		result[i] = TranslationBundleToPb(bundle)
+	}
+	return result
+}
+
+func TranslationBundleToPb(bundle *query.TranslationBundle) *text_pb.TranslationBundle {
+	return &text_pb.TranslationBundle{
+		Details: object.ToViewDetailsPb(
+			bundle.Sequence,
+			bundle.CreationDate,
+			bundle.ChangeDate,
+			domain.IAMID,
+		),
+		Component: TranslationComponentToPb(bundle.Component),
+		Language:  bundle.Language.String(),
+		Messages:  bundle.Messages,
+	}
+}
+
+func TranslationComponentToDomain(component text_pb.TranslationComponent) domain.TranslationComponent {
+	switch component {
+	case text_pb.TranslationComponent_TRANSLATION_COMPONENT_LOGIN:
+		return domain.TranslationComponentLogin
+	case text_pb.TranslationComponent_TRANSLATION_COMPONENT_NOTIFICATION:
+		return domain.TranslationComponentNotification
+	default:
+		return domain.TranslationComponentUnspecified
+	}
+}
+
+func TranslationComponentToPb(component domain.TranslationComponent) text_pb.TranslationComponent {
+	switch component {
+	case domain.TranslationComponentLogin:
+		return text_pb.TranslationComponent_TRANSLATION_COMPONENT_LOGIN
+	case domain.TranslationComponentNotification:
+		return text_pb.TranslationComponent_TRANSLATION_COMPONENT_NOTIFICATION
+	default:
+		return text_pb.TranslationComponent_TRANSLATION_COMPONENT_UNSPECIFIED
+	}
+}
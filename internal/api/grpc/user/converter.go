@@ -69,6 +69,32 @@ func HumanToPb(view *query.Human, assetPrefix, owner string) *user_pb.Human {
 	}
 }
 
+func DomainHumanToPb(human *domain.Human) *user_pb.Human {
+	h := &user_pb.Human{
+		Profile: &user_pb.Profile{
+			FirstName:         human.FirstName,
+			LastName:          human.LastName,
+			NickName:          human.NickName,
+			DisplayName:       human.DisplayName,
+			PreferredLanguage: human.PreferredLanguage.String(),
+			Gender:            GenderToPb(human.Gender),
+		},
+	}
+	if human.Email != nil {
+		h.Email = &user_pb.Email{
+			Email:           human.EmailAddress,
+			IsEmailVerified: human.IsEmailVerified,
+		}
+	}
+	if human.Phone != nil {
+		h.Phone = &user_pb.Phone{
+			Phone:           human.PhoneNumber,
+			IsPhoneVerified: human.IsPhoneVerified,
+		}
+	}
+	return h
+}
+
 func MachineToPb(view *query.Machine) *user_pb.Machine {
 	return &user_pb.Machine{
 		Name:        view.Name,
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+//HostFromRequest returns the hostname (without port) the request was sent to
+func HostFromRequest(r *http.Request) string {
+	host := r.Host
+	if host == "" && r.URL != nil {
+		host = r.URL.Host
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.ToLower(host)
+}
+
+//HostFromURL returns the hostname (without port) of the provided url
+func HostFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+//IsOnDomain checks if the host is the domain itself or a subdomain of it
+// an empty domain matches every host
+func IsOnDomain(host, domain string) bool {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+	if domain == "" {
+		return true
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+//ReplaceHost replaces the host (including port) of the provided url
+// path, query and fragment are kept
+func ReplaceHost(rawURL, host string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	parsed.Host = host
+	return parsed.String(), nil
+}
+
+//ReplaceHostname replaces the hostname of the provided url
+// the port, path, query and fragment are kept
+func ReplaceHostname(rawURL, hostname string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if port := parsed.Port(); port != "" {
+		hostname = net.JoinHostPort(hostname, port)
+	}
+	parsed.Host = hostname
+	return parsed.String(), nil
+}
@@ -27,10 +27,12 @@ type UserAgent struct {
 }
 
 type userAgentHandler struct {
-	cookieHandler *http_utils.CookieHandler
-	cookieName    string
-	idGenerator   id.Generator
-	nextHandler   http.Handler
+	cookieHandler     *http_utils.CookieHandler
+	hostCookieHandler *http_utils.CookieHandler
+	cookieDomain      string
+	cookieName        string
+	idGenerator       id.Generator
+	nextHandler       http.Handler
 }
 
 type UserAgentCookieConfig struct {
@@ -48,7 +50,6 @@ func NewUserAgentHandler(config *UserAgentCookieConfig, idGenerator id.Generator
 	cookieKey := []byte(key)
 	opts := []http_utils.CookieHandlerOpt{
 		http_utils.WithEncryption(cookieKey, cookieKey),
-		http_utils.WithDomain(config.Domain),
 		http_utils.WithMaxAge(int(config.MaxAge.Seconds())),
 	}
 	if localDevMode {
@@ -56,10 +57,12 @@ func NewUserAgentHandler(config *UserAgentCookieConfig, idGenerator id.Generator
 	}
 	return func(handler http.Handler) http.Handler {
 		return &userAgentHandler{
-			nextHandler:   handler,
-			cookieName:    config.Name,
-			cookieHandler: http_utils.NewCookieHandler(opts...),
-			idGenerator:   idGenerator,
+			nextHandler:       handler,
+			cookieName:        config.Name,
+			cookieDomain:      config.Domain,
+			cookieHandler:     http_utils.NewCookieHandler(append(opts, http_utils.WithDomain(config.Domain))...),
+			hostCookieHandler: http_utils.NewCookieHandler(opts...),
+			idGenerator:       idGenerator,
 		}
 	}, nil
 }
@@ -72,7 +75,7 @@ func (ua *userAgentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err == nil {
 		ctx := context.WithValue(r.Context(), userAgentKey, agent.ID)
 		r = r.WithContext(ctx)
-		ua.setUserAgent(w, agent)
+		ua.setUserAgent(w, r, agent)
 	}
 	ua.nextHandler.ServeHTTP(w, r)
 }
@@ -85,17 +88,26 @@ func (ua *userAgentHandler) newUserAgent() (*UserAgent, error) {
 	return &UserAgent{ID: agentID}, nil
 }
 
+//cookieHandlerForRequest returns a host only cookie handler
+// if the request is sent to a host outside of the configured cookie domain (e.g. a login domain of an organisation)
+func (ua *userAgentHandler) cookieHandlerForRequest(r *http.Request) *http_utils.CookieHandler {
+	if http_utils.IsOnDomain(http_utils.HostFromRequest(r), ua.cookieDomain) {
+		return ua.cookieHandler
+	}
+	return ua.hostCookieHandler
+}
+
 func (ua *userAgentHandler) getUserAgent(r *http.Request) (*UserAgent, error) {
 	userAgent := new(UserAgent)
-	err := ua.cookieHandler.GetEncryptedCookieValue(r, ua.cookieName, userAgent)
+	err := ua.cookieHandlerForRequest(r).GetEncryptedCookieValue(r, ua.cookieName, userAgent)
 	if err != nil {
 		return nil, errors.ThrowPermissionDenied(err, "HTTP-YULqH4", "cannot read user agent cookie")
 	}
 	return userAgent, nil
 }
 
-func (ua *userAgentHandler) setUserAgent(w http.ResponseWriter, agent *UserAgent) error {
-	err := ua.cookieHandler.SetEncryptedCookie(w, ua.cookieName, agent)
+func (ua *userAgentHandler) setUserAgent(w http.ResponseWriter, r *http.Request, agent *UserAgent) error {
+	err := ua.cookieHandlerForRequest(r).SetEncryptedCookie(w, ua.cookieName, agent)
 	if err != nil {
 		return errors.ThrowPermissionDenied(err, "HTTP-AqgqdA", "cannot set user agent cookie")
 	}
@@ -3,6 +3,7 @@ package oidc
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/caos/logging"
@@ -42,7 +43,19 @@ func (o *OPStorage) GetKeySet(ctx context.Context) (_ *jose.JSONWebKeySet, err e
 	return &jose.JSONWebKeySet{Keys: webKeys}, nil
 }
 
+//GetSigningKey checks and creates the signing keys and passes them to the provider
+// the providers of the login domains don't check the keys themselves, they receive the keys of the default provider
 func (o *OPStorage) GetSigningKey(ctx context.Context, keyCh chan<- jose.SigningKey) {
+	if o.sharedSigningKeys {
+		o.signingKeys.subscribe(ctx, keyCh)
+		return
+	}
+	keys := make(chan jose.SigningKey)
+	go o.signingKeys.publish(ctx, keys, keyCh)
+	o.checkSigningKeys(ctx, keys)
+}
+
+func (o *OPStorage) checkSigningKeys(ctx context.Context, keyCh chan<- jose.SigningKey) {
 	renewTimer := time.NewTimer(0)
 	go func() {
 		for {
@@ -181,3 +194,61 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (uint64, error) {
 func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
 	return keys[len(keys)-1]
 }
+
+//signingKeys distributes the signing keys of the default provider to the providers of the login domains
+type signingKeys struct {
+	mutex       sync.Mutex
+	current     *jose.SigningKey
+	subscribers map[chan<- jose.SigningKey]context.Context
+}
+
+func newSigningKeys() *signingKeys {
+	return &signingKeys{
+		subscribers: make(map[chan<- jose.SigningKey]context.Context),
+	}
+}
+
+//publish passes the keys to the default provider and all subscribed providers
+func (k *signingKeys) publish(ctx context.Context, keys <-chan jose.SigningKey, keyCh chan<- jose.SigningKey) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case key := <-keys:
+			if !sendSigningKey(ctx, keyCh, key) {
+				return
+			}
+			k.mutex.Lock()
+			k.current = &key
+			for subscriber, subscriberCtx := range k.subscribers {
+				sendSigningKey(subscriberCtx, subscriber, key)
+			}
+			k.mutex.Unlock()
+		}
+	}
+}
+
+//subscribe passes the current and all further keys to the provider until the context is done
+func (k *signingKeys) subscribe(ctx context.Context, keyCh chan<- jose.SigningKey) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if k.current != nil && !sendSigningKey(ctx, keyCh, *k.current) {
+		return
+	}
+	k.subscribers[keyCh] = ctx
+	go func() {
+		<-ctx.Done()
+		k.mutex.Lock()
+		delete(k.subscribers, keyCh)
+		k.mutex.Unlock()
+	}()
+}
+
+func sendSigningKey(ctx context.Context, keyCh chan<- jose.SigningKey, key jose.SigningKey) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case keyCh <- key:
+		return true
+	}
+}
@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/caos/logging"
+	"github.com/caos/oidc/pkg/op"
+
+	http_utils "github.com/caos/zitadel/internal/api/http"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query"
+)
+
+type loginDomainOrgKey struct{}
+
+//maxLoginDomainProviders limits the amount of cached providers,
+// the least recently created provider is stopped if the limit is reached
+const maxLoginDomainProviders = 1000
+
+//loginDomainCacheDuration is the time the organisation of a host (or that the host is no login domain) is cached
+const loginDomainCacheDuration = time.Minute
+
+//loginDomainProvider serves the default provider on the configured issuer
+// and a separate provider (issuer, discovery and endpoints) for every verified login domain of an organisation
+type loginDomainProvider struct {
+	op.OpenIDProvider
+	ctx          context.Context
+	config       OPHandlerConfig
+	storage      *OPStorage
+	interceptors op.Option
+	issuer       *url.URL
+	defaultHosts map[string]bool
+
+	mutex     sync.Mutex
+	providers map[string]*loginDomainOP
+	hosts     []string
+
+	orgsMutex sync.Mutex
+	orgs      map[string]*loginDomainOrg
+}
+
+//loginDomainOrg is the cached organisation of a host, org is nil if the host is no login domain
+type loginDomainOrg struct {
+	org     *query.Org
+	expires time.Time
+}
+
+type loginDomainOP struct {
+	op.OpenIDProvider
+	cancel context.CancelFunc
+}
+
+func newLoginDomainProvider(ctx context.Context, provider op.OpenIDProvider, config OPHandlerConfig, storage *OPStorage, interceptors op.Option) op.OpenIDProvider {
+	issuer, err := url.Parse(config.OPConfig.Issuer)
+	logging.Log("OIDC-Lg6iP").OnError(err).Panic("cannot parse issuer")
+	return &loginDomainProvider{
+		OpenIDProvider: provider,
+		ctx:            ctx,
+		config:         config,
+		storage:        storage,
+		interceptors:   interceptors,
+		issuer:         issuer,
+		defaultHosts:   defaultHosts(issuer, config, storage.defaultLoginURL),
+		providers:      make(map[string]*loginDomainOP),
+		orgs:           make(map[string]*loginDomainOrg),
+	}
+}
+
+//defaultHosts returns the hosts of the issuer, the endpoints and the login, which are never login domains
+func defaultHosts(issuer *url.URL, config OPHandlerConfig, loginURL string) map[string]bool {
+	hosts := map[string]bool{
+		issuer.Hostname(): true,
+	}
+	urls := []string{loginURL}
+	if endpoints := config.Endpoints; endpoints != nil {
+		for _, endpoint := range []*Endpoint{endpoints.Auth, endpoints.Token, endpoints.Introspection, endpoints.Userinfo, endpoints.Revocation, endpoints.EndSession, endpoints.Keys} {
+			if endpoint != nil {
+				urls = append(urls, endpoint.URL)
+			}
+		}
+	}
+	for _, rawURL := range urls {
+		if host := http_utils.HostFromURL(rawURL); host != "" {
+			hosts[host] = true
+		}
+	}
+	return hosts
+}
+
+func (p *loginDomainProvider) HttpHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := http_utils.HostFromRequest(r)
+		if host == "" || p.defaultHosts[host] {
+			p.OpenIDProvider.HttpHandler().ServeHTTP(w, r)
+			return
+		}
+		org := p.orgByHost(r.Context(), host)
+		if org == nil {
+			p.OpenIDProvider.HttpHandler().ServeHTTP(w, r)
+			return
+		}
+		provider, err := p.providerForHost(host)
+		if err != nil {
+			logging.LogWithFields("OIDC-Lg6pC", "host", host).WithError(err).Warn("cannot create provider for login domain")
+			p.OpenIDProvider.HttpHandler().ServeHTTP(w, r)
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), loginDomainOrgKey{}, org))
+		provider.HttpHandler().ServeHTTP(w, r)
+	})
+}
+
+//orgByHost returns the organisation of the login domain or nil if the host is no login domain
+// the result is cached for the loginDomainCacheDuration
+func (p *loginDomainProvider) orgByHost(ctx context.Context, host string) *query.Org {
+	p.orgsMutex.Lock()
+	cached, ok := p.orgs[host]
+	p.orgsMutex.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.org
+	}
+	org, err := p.storage.query.OrgByLoginDomain(ctx, host)
+	if err != nil && !errors.IsNotFound(err) {
+		logging.LogWithFields("OIDC-Lg6oQ", "host", host).WithError(err).Warn("unable to get org of login domain")
+		return nil
+	}
+	if err != nil {
+		org = nil
+		p.removeProvider(host)
+	}
+	p.orgsMutex.Lock()
+	defer p.orgsMutex.Unlock()
+	if len(p.orgs) >= maxLoginDomainProviders {
+		p.orgs = make(map[string]*loginDomainOrg)
+	}
+	p.orgs[host] = &loginDomainOrg{org: org, expires: time.Now().Add(loginDomainCacheDuration)}
+	return org
+}
+
+//providerForHost returns the provider of the login domain, it will be created on the first request
+// issuer and endpoints are on the (normalized) host, the login is called on the host as well
+func (p *loginDomainProvider) providerForHost(host string) (op.OpenIDProvider, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if provider, ok := p.providers[host]; ok {
+		return provider, nil
+	}
+	opConfig := *p.config.OPConfig
+	issuer, err := http_utils.ReplaceHostname(opConfig.Issuer, host)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Lg6uI", "Errors.Internal")
+	}
+	opConfig.Issuer = issuer
+	loginURL, err := http_utils.ReplaceHostname(p.storage.defaultLoginURL, host)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Lg6uR", "Errors.Internal")
+	}
+	storage := *p.storage
+	storage.defaultLoginURL = loginURL
+	storage.currentKey = nil
+	storage.keyChan = nil
+	storage.sharedSigningKeys = true
+	endpoints := p.config.Endpoints
+	ctx, cancel := context.WithCancel(p.ctx)
+	provider, err := op.NewOpenIDProvider(
+		ctx,
+		&opConfig,
+		&storage,
+		p.interceptors,
+		op.WithCustomAuthEndpoint(op.NewEndpoint(endpoints.Auth.Path)),
+		op.WithCustomTokenEndpoint(op.NewEndpoint(endpoints.Token.Path)),
+		op.WithCustomIntrospectionEndpoint(op.NewEndpoint(endpoints.Introspection.Path)),
+		op.WithCustomUserinfoEndpoint(op.NewEndpoint(endpoints.Userinfo.Path)),
+		op.WithCustomRevocationEndpoint(op.NewEndpoint(endpoints.Revocation.Path)),
+		op.WithCustomEndSessionEndpoint(op.NewEndpoint(endpoints.EndSession.Path)),
+		op.WithCustomKeysEndpoint(op.NewEndpoint(endpoints.Keys.Path)),
+	)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if len(p.hosts) >= maxLoginDomainProviders {
+		p.removeProviderLocked(p.hosts[0])
+	}
+	p.providers[host] = &loginDomainOP{OpenIDProvider: provider, cancel: cancel}
+	p.hosts = append(p.hosts, host)
+	return provider, nil
+}
+
+//removeProvider stops and removes the provider of the host (e.g. if the login domain was removed)
+func (p *loginDomainProvider) removeProvider(host string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.removeProviderLocked(host)
+}
+
+func (p *loginDomainProvider) removeProviderLocked(host string) {
+	provider, ok := p.providers[host]
+	if !ok {
+		return
+	}
+	provider.cancel()
+	delete(p.providers, host)
+	for i, h := range p.hosts {
+		if h == host {
+			p.hosts = append(p.hosts[:i], p.hosts[i+1:]...)
+			break
+		}
+	}
+}
+
+func loginDomainOrgFromCtx(ctx context.Context) *query.Org {
+	org, _ := ctx.Value(loginDomainOrgKey{}).(*query.Org)
+	return org
+}
+
+//setLoginDomainOrg requests the organisation of the login domain the auth request was created on
+func setLoginDomainOrg(ctx context.Context, authRequest *domain.AuthRequest) {
+	org := loginDomainOrgFromCtx(ctx)
+	if org == nil {
+		return
+	}
+	authRequest.RequestedOrgID = org.ID
+	authRequest.RequestedOrgName = org.Name
+	authRequest.RequestedPrimaryDomain = org.Domain
+}
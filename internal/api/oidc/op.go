@@ -70,6 +70,8 @@ type OPStorage struct {
 	defaultRefreshTokenExpiration     time.Duration
 	encAlg                            crypto.EncryptionAlgorithm
 	keyChan                           <-chan interface{}
+	signingKeys                       *signingKeys
+	sharedSigningKeys                 bool
 	currentKey                        query.PrivateKey
 	signingKeyRotationCheck           time.Duration
 	signingKeyGracefulPeriod          time.Duration
@@ -97,27 +99,33 @@ func NewProvider(ctx context.Context, config OPHandlerConfig, command *command.C
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	storage, err := newStorage(config.StorageConfig, command, query, repo, keyConfig, es, projections, keyChan, assetAPIPrefix)
 	logging.Log("OIDC-Jdg2k").OnError(err).WithField("traceID", tracing.TraceIDFromCtx(ctx)).Panic("cannot create storage")
+	interceptors := op.WithHttpInterceptors(
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor,
+		cookieHandler,
+		http_utils.CopyHeadersToContext,
+	)
 	provider, err := op.NewOpenIDProvider(
 		ctx,
 		config.OPConfig,
 		storage,
-		op.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor,
-			cookieHandler,
-			http_utils.CopyHeadersToContext,
-		),
-		op.WithCustomAuthEndpoint(op.NewEndpointWithURL(config.Endpoints.Auth.Path, config.Endpoints.Auth.URL)),
-		op.WithCustomTokenEndpoint(op.NewEndpointWithURL(config.Endpoints.Token.Path, config.Endpoints.Token.URL)),
-		op.WithCustomIntrospectionEndpoint(op.NewEndpointWithURL(config.Endpoints.Introspection.Path, config.Endpoints.Introspection.URL)),
-		op.WithCustomUserinfoEndpoint(op.NewEndpointWithURL(config.Endpoints.Userinfo.Path, config.Endpoints.Userinfo.URL)),
-		op.WithCustomRevocationEndpoint(op.NewEndpointWithURL(config.Endpoints.Revocation.Path, config.Endpoints.Revocation.URL)),
-		op.WithCustomEndSessionEndpoint(op.NewEndpointWithURL(config.Endpoints.EndSession.Path, config.Endpoints.EndSession.URL)),
-		op.WithCustomKeysEndpoint(op.NewEndpointWithURL(config.Endpoints.Keys.Path, config.Endpoints.Keys.URL)),
+		append([]op.Option{interceptors}, customEndpoints(config.Endpoints)...)...,
 	)
 	logging.Log("OIDC-asf13").OnError(err).WithField("traceID", tracing.TraceIDFromCtx(ctx)).Panic("cannot create provider")
-	return provider
+	return newLoginDomainProvider(ctx, provider, config, storage, interceptors)
+}
+
+func customEndpoints(endpoints *EndpointConfig) []op.Option {
+	return []op.Option{
+		op.WithCustomAuthEndpoint(op.NewEndpointWithURL(endpoints.Auth.Path, endpoints.Auth.URL)),
+		op.WithCustomTokenEndpoint(op.NewEndpointWithURL(endpoints.Token.Path, endpoints.Token.URL)),
+		op.WithCustomIntrospectionEndpoint(op.NewEndpointWithURL(endpoints.Introspection.Path, endpoints.Introspection.URL)),
+		op.WithCustomUserinfoEndpoint(op.NewEndpointWithURL(endpoints.Userinfo.Path, endpoints.Userinfo.URL)),
+		op.WithCustomRevocationEndpoint(op.NewEndpointWithURL(endpoints.Revocation.Path, endpoints.Revocation.URL)),
+		op.WithCustomEndSessionEndpoint(op.NewEndpointWithURL(endpoints.EndSession.Path, endpoints.EndSession.URL)),
+		op.WithCustomKeysEndpoint(op.NewEndpointWithURL(endpoints.Keys.Path, endpoints.Keys.URL)),
+	}
 }
 
 func newStorage(config StorageConfig, command *command.Commands, query *query.Queries, repo repository.Repository, keyConfig systemdefaults.KeyConfig, es *eventstore.Eventstore, projections types.SQL, keyChan <-chan interface{}, assetAPIPrefix string) (*OPStorage, error) {
@@ -145,6 +153,7 @@ func newStorage(config StorageConfig, command *command.Commands, query *query.Qu
 		signingKeyRotationCheck:           keyConfig.SigningKeyRotationCheck.Duration,
 		locker:                            crdb.NewLocker(sqlClient, locksTable, signingKey),
 		keyChan:                           keyChan,
+		signingKeys:                       newSigningKeys(),
 		assetAPIPrefix:                    assetAPIPrefix,
 	}, nil
 }
@@ -132,6 +132,7 @@ type mockViewUser struct {
 	PasswordInitRequired     bool
 	PasswordSet              bool
 	PasswordChangeRequired   bool
+	PasswordChanged          time.Time
 	IsEmailVerified          bool
 	OTPState                 int32
 	MFAMaxSetUp              int32
@@ -166,6 +167,7 @@ func (m *mockViewUser) UserByID(string) (*user_view_model.UserView, error) {
 			PasswordInitRequired:     m.PasswordInitRequired,
 			PasswordSet:              m.PasswordSet,
 			PasswordChangeRequired:   m.PasswordChangeRequired,
+			PasswordChanged:          m.PasswordChanged,
 			IsEmailVerified:          m.IsEmailVerified,
 			OTPState:                 m.OTPState,
 			MFAMaxSetUp:              m.MFAMaxSetUp,
@@ -180,6 +182,18 @@ func (m *mockViewUser) PrefixAvatarURL() string {
 	return ""
 }
 
+type mockUserCommands struct {
+	unlocked bool
+}
+
+func (m *mockUserCommands) BulkAddedUserIDPLinks(context.Context, string, string, []*domain.UserIDPLink) error {
+	return nil
+}
+
+func (m *mockUserCommands) UnlockExpiredUserLock(context.Context, string, string) (bool, error) {
+	return m.unlocked, nil
+}
+
 type mockViewOrg struct {
 	State domain.OrgState
 }
@@ -263,6 +277,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 		applicationProvider        applicationProvider
 		loginPolicyProvider        loginPolicyViewProvider
 		lockoutPolicyProvider      lockoutPolicyViewProvider
+		userCommandProvider        userCommandProvider
 		PasswordCheckLifeTime      time.Duration
 		ExternalLoginCheckLifeTime time.Duration
 		MFAInitSkippedLifeTime     time.Duration
@@ -462,12 +477,8 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 						Type:          user_es_model.UserLocked,
 					},
 				},
-				orgViewProvider: &mockViewOrg{State: domain.OrgStateActive},
-				lockoutPolicyProvider: &mockLockoutPolicy{
-					policy: &query.LockoutPolicy{
-						ShowFailures: true,
-					},
-				},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userCommandProvider: &mockUserCommands{},
 			},
 			args{&domain.AuthRequest{UserID: "UserID"}, false},
 			nil,
@@ -928,6 +939,122 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.ChangePasswordStep{}},
 			nil,
 		},
+		{
+			"password expired, password change step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     time.Now().UTC().Add(-5 * time.Minute),
+					SecondFactorVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					PasswordChanged: time.Now().UTC().Add(-31 * 24 * time.Hour),
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(model.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				PasswordCheckLifeTime:     10 * 24 * time.Hour,
+				SecondFactorCheckLifeTime: 18 * time.Hour,
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					},
+					PasswordAgePolicy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     30,
+						ExpireWarnDays: 5,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ChangePasswordStep{Expired: true}},
+			nil,
+		},
+		{
+			"password expires soon, password change warning step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     time.Now().UTC().Add(-5 * time.Minute),
+					SecondFactorVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					PasswordChanged: time.Now().UTC().Add(-(27*24*time.Hour + time.Hour)),
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(model.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				PasswordCheckLifeTime:     10 * 24 * time.Hour,
+				SecondFactorCheckLifeTime: 18 * time.Hour,
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					},
+					PasswordAgePolicy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     30,
+						ExpireWarnDays: 5,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ChangePasswordStep{Warning: true, DaysLeft: 2}},
+			nil,
+		},
+		{
+			"password expiry warning skipped, redirect to callback step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     time.Now().UTC().Add(-5 * time.Minute),
+					SecondFactorVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					PasswordChanged: time.Now().UTC().Add(-(27*24*time.Hour + time.Hour)),
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(model.MFALevelSecondFactor),
+				},
+				userEventProvider:   &mockEventUser{},
+				orgViewProvider:     &mockViewOrg{State: domain.OrgStateActive},
+				userGrantProvider:   &mockUserGrants{},
+				projectProvider:     &mockProject{},
+				applicationProvider: &mockApp{app: &query.App{OIDCConfig: &query.OIDCApp{AppType: domain.OIDCApplicationTypeWeb}}},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				PasswordCheckLifeTime:     10 * 24 * time.Hour,
+				SecondFactorCheckLifeTime: 18 * time.Hour,
+			},
+			args{
+				&domain.AuthRequest{
+					UserID:  "UserID",
+					Request: &domain.AuthRequestOIDC{},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					},
+					PasswordAgePolicy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     30,
+						ExpireWarnDays: 5,
+					},
+					PasswordWarningSkipped: true,
+				}, false},
+			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
+			nil,
+		},
 		{
 			"email not verified and no password change required, mail verification step",
 			fields{
@@ -1329,6 +1456,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 				ApplicationProvider:        tt.fields.applicationProvider,
 				LoginPolicyViewProvider:    tt.fields.loginPolicyProvider,
 				LockoutPolicyViewProvider:  tt.fields.lockoutPolicyProvider,
+				UserCommandProvider:        tt.fields.userCommandProvider,
 				PasswordCheckLifeTime:      tt.fields.PasswordCheckLifeTime,
 				ExternalLoginCheckLifeTime: tt.fields.ExternalLoginCheckLifeTime,
 				MFAInitSkippedLifeTime:     tt.fields.MFAInitSkippedLifeTime,
@@ -44,7 +44,7 @@ func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Comma
 		return nil, err
 	}
 
-	sqlClient, err := conf.View.Start()
+	sqlClient, err := conf.View.StartCockroach()
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +101,7 @@ func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Comma
 			UserEventProvider:          &userRepo,
 			IDPProviderViewProvider:    view,
 			LockoutPolicyViewProvider:  queries,
+			PasswordAgePolicyProvider:  queries,
 			LoginPolicyViewProvider:    queries,
 			UserGrantProvider:          queryView,
 			ProjectProvider:            queryView,
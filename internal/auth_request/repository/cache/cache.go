@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/caos/zitadel/internal/auth_request/repository"
+	"github.com/caos/zitadel/internal/cache/redis"
 	"github.com/caos/zitadel/internal/config/types"
 	"github.com/caos/zitadel/internal/domain"
 	caos_errs "github.com/caos/zitadel/internal/errors"
@@ -15,13 +17,18 @@ import (
 
 type Config struct {
 	Connection types.SQL
+	//Redis if set, the auth requests are stored in redis instead of the database
+	Redis *redis.Config
 }
 
 type AuthRequestCache struct {
 	client *sql.DB
 }
 
-func Start(conf Config) (*AuthRequestCache, error) {
+func Start(conf Config) (repository.AuthRequestCache, error) {
+	if conf.Redis != nil {
+		return StartRedis(conf.Redis)
+	}
 	client, err := conf.Connection.Start()
 	if err != nil {
 		return nil, caos_errs.ThrowPreconditionFailed(err, "SQL-9qBtr", "unable to open database connection")
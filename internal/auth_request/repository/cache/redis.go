@@ -0,0 +1,259 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/caos/logging"
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/caos/zitadel/internal/cache/redis"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+const (
+	//defaultAuthRequestTTL is used if no ttl is configured, so abandoned auth requests don't stay forever
+	defaultAuthRequestTTL = 24 * time.Hour
+
+	authRequestKeyPrefix     = "auth_request:id:"
+	authRequestCodeKeyPrefix = "auth_request:code:"
+	authRequestUserKeyPrefix = "auth_request:user:"
+
+	//maxTransactionRetries is the number of attempts to update an auth request which is changed concurrently
+	maxTransactionRetries = 3
+)
+
+//RedisAuthRequestCache stores the auth requests in redis, so they can be shared between multiple instances
+// the auth requests of a user are removed if the user is locked, deactivated or removed
+type RedisAuthRequestCache struct {
+	client *goredis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+type redisAuthRequest struct {
+	Type    domain.AuthRequestType
+	Request json.RawMessage
+}
+
+func StartRedis(conf *redis.Config) (*RedisAuthRequestCache, error) {
+	if conf.Address == "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "CACHE-Rd2aD", "address of redis missing")
+	}
+	ttl := conf.TTL.Duration
+	if ttl <= 0 {
+		ttl = defaultAuthRequestTTL
+	}
+	c := &RedisAuthRequestCache{
+		client: redis.NewClient(conf),
+		prefix: conf.KeyPrefix,
+		ttl:    ttl,
+	}
+	c.subscribe()
+	return c, nil
+}
+
+func (c *RedisAuthRequestCache) Health(ctx context.Context) error {
+	return redis.MapError(c.client.Ping(ctx).Err())
+}
+
+func (c *RedisAuthRequestCache) GetAuthRequestByID(ctx context.Context, id string) (*domain.AuthRequest, error) {
+	return c.getAuthRequest(ctx, c.client, id)
+}
+
+func (c *RedisAuthRequestCache) GetAuthRequestByCode(ctx context.Context, code string) (*domain.AuthRequest, error) {
+	id, err := c.client.Get(ctx, c.codeKey(code)).Result()
+	if err == goredis.Nil {
+		return nil, caos_errs.ThrowNotFound(nil, "CACHE-Rd2nF", "Errors.AuthRequest.NotFound")
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd2gC", "Errors.Internal")
+	}
+	return c.getAuthRequest(ctx, c.client, id)
+}
+
+func (c *RedisAuthRequestCache) SaveAuthRequest(ctx context.Context, request *domain.AuthRequest) error {
+	value, err := marshalAuthRequest(request)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		c.saveAuthRequest(ctx, pipe, request, value)
+		return nil
+	})
+	if err != nil {
+		return caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd4sS", "Errors.Internal")
+	}
+	return nil
+}
+
+//UpdateAuthRequest stores the auth request and its code in one transaction
+// the key of a previous code is removed in the same transaction, so the auth request can't be found by an old code
+// the transaction is retried if the auth request was changed concurrently
+func (c *RedisAuthRequestCache) UpdateAuthRequest(ctx context.Context, request *domain.AuthRequest) error {
+	if request.ChangeDate.IsZero() {
+		request.ChangeDate = time.Now()
+	}
+	value, err := marshalAuthRequest(request)
+	if err != nil {
+		return err
+	}
+	update := func(tx *goredis.Tx) error {
+		previous, err := c.getAuthRequest(ctx, tx, request.ID)
+		if err != nil && !caos_errs.IsNotFound(err) {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			c.saveAuthRequest(ctx, pipe, request, value)
+			if request.Code != "" {
+				pipe.Set(ctx, c.codeKey(request.Code), request.ID, c.ttl)
+			}
+			if previous == nil {
+				return nil
+			}
+			if previous.Code != "" && previous.Code != request.Code {
+				pipe.Del(ctx, c.codeKey(previous.Code))
+			}
+			if previous.UserID != "" && previous.UserID != request.UserID {
+				pipe.SRem(ctx, c.userKey(previous.UserID), request.ID)
+			}
+			return nil
+		})
+		return err
+	}
+	for i := 0; i < maxTransactionRetries; i++ {
+		err = c.client.Watch(ctx, update, c.idKey(request.ID))
+		if err != goredis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		return caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd2uC", "Errors.Internal")
+	}
+	return nil
+}
+
+func (c *RedisAuthRequestCache) DeleteAuthRequest(ctx context.Context, id string) error {
+	request, err := c.getAuthRequest(ctx, c.client, id)
+	if caos_errs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, c.idKey(id))
+		if request.Code != "" {
+			pipe.Del(ctx, c.codeKey(request.Code))
+		}
+		if request.UserID != "" {
+			pipe.SRem(ctx, c.userKey(request.UserID), id)
+		}
+		return nil
+	})
+	if err != nil {
+		return caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd2dK", "unable to delete auth request")
+	}
+	return nil
+}
+
+//DeleteAuthRequestsOfUser removes all auth requests of the user
+// the code keys are left to expire as they only point to the removed auth requests
+func (c *RedisAuthRequestCache) DeleteAuthRequestsOfUser(ctx context.Context, userID string) error {
+	ids, err := c.client.SMembers(ctx, c.userKey(userID)).Result()
+	if err != nil {
+		return caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd2mU", "Errors.Internal")
+	}
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, c.idKey(id))
+	}
+	keys = append(keys, c.userKey(userID))
+	if err = c.client.Del(ctx, keys...).Err(); err != nil {
+		return caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd4dU", "unable to delete auth requests of user")
+	}
+	return nil
+}
+
+func (c *RedisAuthRequestCache) getAuthRequest(ctx context.Context, client goredis.Cmdable, id string) (*domain.AuthRequest, error) {
+	b, err := client.Get(ctx, c.idKey(id)).Bytes()
+	if err == goredis.Nil {
+		return nil, caos_errs.ThrowNotFound(nil, "CACHE-Rd3nF", "Errors.AuthRequest.NotFound")
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(redis.MapError(err), "CACHE-Rd3gI", "Errors.Internal")
+	}
+	stored := new(redisAuthRequest)
+	if err = json.Unmarshal(b, stored); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Rd4gI", "Errors.Internal")
+	}
+	request, err := domain.NewAuthRequestFromType(stored.Type)
+	if err == nil {
+		err = json.Unmarshal(stored.Request, request)
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Rd5gI", "Errors.Internal")
+	}
+	return request, nil
+}
+
+//saveAuthRequest queues the commands to store the auth request and add it to the set of the user
+func (c *RedisAuthRequestCache) saveAuthRequest(ctx context.Context, pipe goredis.Pipeliner, request *domain.AuthRequest, value []byte) {
+	pipe.Set(ctx, c.idKey(request.ID), value, c.ttl)
+	if request.UserID == "" {
+		return
+	}
+	pipe.SAdd(ctx, c.userKey(request.UserID), request.ID)
+	pipe.PExpire(ctx, c.userKey(request.UserID), c.ttl)
+}
+
+func marshalAuthRequest(request *domain.AuthRequest) ([]byte, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Rd2sM", "Errors.Internal")
+	}
+	b, err = json.Marshal(&redisAuthRequest{Type: request.Request.Type(), Request: b})
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Rd3sM", "Errors.Internal")
+	}
+	return b, nil
+}
+
+//subscribe removes the auth requests of users which can no longer authenticate
+// the events are pushed by this instance, but the removal applies to all instances sharing the redis
+func (c *RedisAuthRequestCache) subscribe() {
+	events := make(chan eventstore.Event, 100)
+	eventstore.SubscribeEventTypes(events, map[eventstore.AggregateType][]eventstore.EventType{
+		user.AggregateType: {
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType,
+		},
+	})
+	go func() {
+		for event := range events {
+			c.reduce(event)
+		}
+	}()
+}
+
+func (c *RedisAuthRequestCache) reduce(event eventstore.Event) {
+	err := c.DeleteAuthRequestsOfUser(context.Background(), event.Aggregate().ID)
+	logging.LogWithFields("CACHE-Rd2rE", "userID", event.Aggregate().ID, "eventType", event.Type()).OnError(err).Warn("unable to delete auth requests of user")
+}
+
+func (c *RedisAuthRequestCache) idKey(id string) string {
+	return c.prefix + authRequestKeyPrefix + id
+}
+
+func (c *RedisAuthRequestCache) codeKey(code string) string {
+	return c.prefix + authRequestCodeKeyPrefix + code
+}
+
+func (c *RedisAuthRequestCache) userKey(userID string) string {
+	return c.prefix + authRequestUserKeyPrefix + userID
+}
@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/caos/zitadel/internal/cache/redis"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+func newRedisAuthRequestCache(t *testing.T) (*RedisAuthRequestCache, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	return &RedisAuthRequestCache{
+		client: redis.NewClient(&redis.Config{Address: server.Addr()}),
+		prefix: "zitadel:",
+		ttl:    time.Hour,
+	}, server
+}
+
+func testAuthRequest(id, userID, code string) *domain.AuthRequest {
+	return &domain.AuthRequest{
+		ID:        id,
+		AgentID:   "agent1",
+		UserID:    userID,
+		Code:      code,
+		Request:   &domain.AuthRequestOIDC{Scopes: []string{"openid"}, Nonce: "nonce"},
+		Prompt:    []domain.Prompt{domain.PromptLogin},
+		LoginHint: "hint",
+	}
+}
+
+func TestRedisAuthRequestCache_Get(t *testing.T) {
+	type args struct {
+		stored      []*domain.AuthRequest
+		updated     []*domain.AuthRequest
+		deleted     []string
+		fastForward time.Duration
+		id          string
+		code        string
+	}
+	type res struct {
+		request *domain.AuthRequest
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "get by id",
+			args: args{
+				stored: []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				id:     "id1",
+			},
+			res: res{
+				request: testAuthRequest("id1", "", ""),
+			},
+		},
+		{
+			name: "get by id not found",
+			args: args{
+				stored: []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				id:     "id2",
+			},
+			res: res{
+				errFunc: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "get by id expired",
+			args: args{
+				stored:      []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				fastForward: 2 * time.Hour,
+				id:          "id1",
+			},
+			res: res{
+				errFunc: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "get by code",
+			args: args{
+				stored:  []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				updated: []*domain.AuthRequest{testAuthRequest("id1", "user1", "code1")},
+				code:    "code1",
+			},
+			res: res{
+				request: testAuthRequest("id1", "user1", "code1"),
+			},
+		},
+		{
+			name: "get by code changed",
+			args: args{
+				stored: []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				updated: []*domain.AuthRequest{
+					testAuthRequest("id1", "user1", "code1"),
+					testAuthRequest("id1", "user1", "code2"),
+				},
+				code: "code2",
+			},
+			res: res{
+				request: testAuthRequest("id1", "user1", "code2"),
+			},
+		},
+		{
+			name: "get by previous code",
+			args: args{
+				stored: []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				updated: []*domain.AuthRequest{
+					testAuthRequest("id1", "user1", "code1"),
+					testAuthRequest("id1", "user1", "code2"),
+				},
+				code: "code1",
+			},
+			res: res{
+				errFunc: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "get by code deleted",
+			args: args{
+				stored:  []*domain.AuthRequest{testAuthRequest("id1", "", "")},
+				updated: []*domain.AuthRequest{testAuthRequest("id1", "user1", "code1")},
+				deleted: []string{"id1"},
+				code:    "code1",
+			},
+			res: res{
+				errFunc: caos_errs.IsNotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, server := newRedisAuthRequestCache(t)
+			ctx := context.Background()
+			for _, request := range tt.args.stored {
+				if err := c.SaveAuthRequest(ctx, request); err != nil {
+					t.Fatalf("unable to save auth request: %v", err)
+				}
+			}
+			for _, request := range tt.args.updated {
+				if err := c.UpdateAuthRequest(ctx, request); err != nil {
+					t.Fatalf("unable to update auth request: %v", err)
+				}
+			}
+			for _, id := range tt.args.deleted {
+				if err := c.DeleteAuthRequest(ctx, id); err != nil {
+					t.Fatalf("unable to delete auth request: %v", err)
+				}
+			}
+			server.FastForward(tt.args.fastForward)
+
+			var got *domain.AuthRequest
+			var err error
+			if tt.args.code != "" {
+				got, err = c.GetAuthRequestByCode(ctx, tt.args.code)
+			} else {
+				got, err = c.GetAuthRequestByID(ctx, tt.args.id)
+			}
+			if tt.res.errFunc == nil && err != nil {
+				t.Fatalf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc != nil {
+				if !tt.res.errFunc(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			//the change date is set on update
+			got.ChangeDate = tt.res.request.ChangeDate
+			if !reflect.DeepEqual(got, tt.res.request) {
+				t.Errorf("got wrong result expected: %+v actual: %+v", tt.res.request, got)
+			}
+		})
+	}
+}
+
+func TestRedisAuthRequestCache_reduce(t *testing.T) {
+	c, _ := newRedisAuthRequestCache(t)
+	ctx := context.Background()
+	for _, request := range []*domain.AuthRequest{
+		testAuthRequest("id1", "user1", ""),
+		testAuthRequest("id2", "user1", "code2"),
+		testAuthRequest("id3", "user2", ""),
+	} {
+		if err := c.UpdateAuthRequest(ctx, request); err != nil {
+			t.Fatalf("unable to save auth request: %v", err)
+		}
+	}
+	event, err := user.UserLockedEventMapper(&repository.Event{
+		AggregateID:   "user1",
+		AggregateType: user.AggregateType,
+		Type:          repository.EventType(user.UserLockedType),
+	})
+	if err != nil {
+		t.Fatalf("unable to map event: %v", err)
+	}
+
+	c.reduce(event)
+
+	for _, id := range []string{"id1", "id2"} {
+		if _, err = c.GetAuthRequestByID(ctx, id); !caos_errs.IsNotFound(err) {
+			t.Errorf("auth request %s of locked user not deleted: %v", id, err)
+		}
+	}
+	if _, err = c.GetAuthRequestByCode(ctx, "code2"); !caos_errs.IsNotFound(err) {
+		t.Errorf("auth request of locked user found by code: %v", err)
+	}
+	if _, err = c.GetAuthRequestByID(ctx, "id3"); err != nil {
+		t.Errorf("auth request of other user deleted: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/caos/logging"
 
+	"github.com/caos/zitadel/internal/api/authz"
 	"github.com/caos/zitadel/internal/authz/repository/eventsourcing/view"
 	"github.com/caos/zitadel/internal/crypto"
 	"github.com/caos/zitadel/internal/domain"
@@ -113,6 +114,22 @@ func (repo *TokenVerifierRepo) CheckOrgFeatures(ctx context.Context, orgID strin
 	return checkFeatures(features, requiredFeatures...)
 }
 
+//CustomRoleMappings returns the permissions of the roles defined at runtime
+func (repo *TokenVerifierRepo) CustomRoleMappings(ctx context.Context) ([]authz.RoleMapping, error) {
+	roles, err := repo.Query.SearchCustomRoles(ctx, &query.CustomRoleSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	mappings := make([]authz.RoleMapping, len(roles.CustomRoles))
+	for i, role := range roles.CustomRoles {
+		mappings[i] = authz.RoleMapping{
+			Role:        role.Role,
+			Permissions: role.Permissions,
+		}
+	}
+	return mappings, nil
+}
+
 func checkFeatures(features *query.Features, requiredFeatures ...string) error {
 	for _, requiredFeature := range requiredFeatures {
 		if strings.HasPrefix(requiredFeature, domain.FeatureLoginPolicy) {
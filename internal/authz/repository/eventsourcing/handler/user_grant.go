@@ -282,7 +282,7 @@ func (u *UserGrant) setIamProjectID() error {
 	if err != nil {
 		return err
 	}
-	if iam.SetUpDone < domain.StepCount-1 {
+	if iam.IAMProjectID == "" {
 		return caos_errs.ThrowPreconditionFailed(nil, "HANDL-s5DTs", "Setup not done")
 	}
 	u.iamProjectID = iam.IAMProjectID
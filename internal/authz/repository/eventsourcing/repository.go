@@ -34,7 +34,7 @@ func Start(conf Config, systemDefaults sd.SystemDefaults, queries *query.Queries
 		return nil, err
 	}
 
-	sqlClient, err := conf.View.Start()
+	sqlClient, err := conf.View.StartCockroach()
 	if err != nil {
 		return nil, err
 	}
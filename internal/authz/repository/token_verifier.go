@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+
+	"github.com/caos/zitadel/internal/api/authz"
 )
 
 type TokenVerifierRepository interface {
@@ -9,4 +11,5 @@ type TokenVerifierRepository interface {
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	CheckOrgFeatures(ctx context.Context, orgID string, requiredFeatures ...string) error
 	VerifierClientID(ctx context.Context, appName string) (clientID, projectID string, err error)
+	CustomRoleMappings(ctx context.Context) ([]authz.RoleMapping, error)
 }
@@ -0,0 +1,336 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+//Version of the archive written by Backup
+// archives of other versions are rejected by Restore
+const Version = "v1"
+
+const manifestFile = "manifest.json"
+
+//Manifest describes the content of an archive
+// it's the first file of the archive, followed by the files of the tables in the listed order
+type Manifest struct {
+	Version      string    `json:"version"`
+	CreationDate time.Time `json:"creationDate"`
+	DatabaseType string    `json:"databaseType"`
+	//EventSequence is the highest sequence of the eventstore at the time of the backup
+	EventSequence uint64   `json:"eventSequence"`
+	Tables        []*Table `json:"tables"`
+}
+
+//writeArchive writes the manifest and the files of the tables stored in dir as gzipped tar to w
+// the archive is encrypted if a key is provided
+func writeArchive(w io.Writer, key []byte, manifest *Manifest, dir string) (err error) {
+	var encrypted io.WriteCloser
+	if len(key) > 0 {
+		if encrypted, err = encrypt(w, key); err != nil {
+			return err
+		}
+		w = encrypted
+	}
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Ma2nF", "unable to marshal manifest")
+	}
+	header := &tar.Header{Name: manifestFile, Mode: 0600, Size: int64(len(data)), ModTime: manifest.CreationDate}
+	if err = archive.WriteHeader(header); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Wr2hM", "unable to write archive")
+	}
+	if _, err = archive.Write(data); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Wr3mF", "unable to write archive")
+	}
+	for _, table := range manifest.Tables {
+		if err = addFile(archive, filepath.Join(dir, table.fileName()), table.fileName(), manifest.CreationDate); err != nil {
+			return err
+		}
+	}
+	if err = archive.Close(); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Wr2cA", "unable to write archive")
+	}
+	if err = gz.Close(); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Wr2cG", "unable to write archive")
+	}
+	if encrypted != nil {
+		return encrypted.Close()
+	}
+	return nil
+}
+
+func addFile(archive *tar.Writer, path, name string, modTime time.Time) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Ad2oF", "unable to open table file")
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Ad2sF", "unable to open table file")
+	}
+	header := &tar.Header{Name: name, Mode: 0600, Size: info.Size(), ModTime: modTime}
+	if err = archive.WriteHeader(header); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Ad2hT", "unable to write archive")
+	}
+	if _, err = io.Copy(archive, file); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Ad2cT", "unable to write archive")
+	}
+	return nil
+}
+
+//archiveReader reads the files of an archive written by writeArchive in order
+type archiveReader struct {
+	gz      *gzip.Reader
+	archive *tar.Reader
+}
+
+func newArchiveReader(r io.Reader, key []byte) (_ *archiveReader, err error) {
+	if len(key) > 0 {
+		if r, err = decrypt(r, key); err != nil {
+			return nil, err
+		}
+	}
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(2)
+	if err != nil && caos_errs.IsErrorInvalidArgument(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BACKUP-Rd2eM", "archive is empty")
+	}
+	if magic[0] != 0x1f || magic[1] != 0x8b {
+		if len(key) > 0 {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "BACKUP-Rd2kY", "archive can't be decrypted, wrong key or corrupted archive")
+		}
+		return nil, caos_errs.ThrowInvalidArgument(nil, "BACKUP-Rd3eN", "archive is encrypted or corrupted")
+	}
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BACKUP-Rd2gZ", "archive is corrupted")
+	}
+	return &archiveReader{gz: gz, archive: tar.NewReader(gz)}, nil
+}
+
+//manifest reads the manifest which must be the first file of the archive
+func (a *archiveReader) manifest() (*Manifest, error) {
+	r, err := a.next(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(Manifest)
+	if err = json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BACKUP-Rd2mA", "manifest is invalid")
+	}
+	if manifest.Version != Version {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rd2vE", "archive version %s is not supported", manifest.Version)
+	}
+	return manifest, nil
+}
+
+//next returns the content of the next file of the archive which must be called name
+func (a *archiveReader) next(name string) (io.Reader, error) {
+	header, err := a.archive.Next()
+	if err == io.EOF {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rd2nE", "file %s is missing in archive", name)
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BACKUP-Rd3gZ", "archive is corrupted")
+	}
+	if header.Name != name {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rd2fN", "expected file %s in archive but got %s", name, header.Name)
+	}
+	return a.archive, nil
+}
+
+const (
+	//encryptionChunkSize is the size of the plaintext of an encrypted chunk of the archive
+	encryptionChunkSize = 64 * 1024
+	encryptionSaltSize  = 32
+	//minKeyLength is the minimal length of the key material,
+	// the key of the archive is derived from it with HKDF-SHA256 and a random salt
+	minKeyLength  = 16
+	encryptionKDF = "zitadel backup archive"
+)
+
+//end verifies that no further files follow and reads the archive to its end,
+// so the checksum of the compression and the final chunk of an encrypted archive are verified
+func (a *archiveReader) end() error {
+	header, err := a.archive.Next()
+	if err == nil {
+		return caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rd2uF", "unexpected file %s in archive", header.Name)
+	}
+	if err != io.EOF {
+		return caos_errs.ThrowInvalidArgument(err, "BACKUP-Rd4gZ", "archive is corrupted")
+	}
+	if _, err = io.Copy(ioutil.Discard, a.gz); err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "BACKUP-Rd5gZ", "archive is corrupted")
+	}
+	return nil
+}
+
+//encrypt writes the salt of the key derivation followed by the archive as AES-256-GCM encrypted chunks,
+// the nonce of a chunk is its index and the last chunk is authenticated as final,
+// so reordered, truncated or modified archives are detected on decryption.
+// The returned writer must be closed to write the final chunk
+func encrypt(w io.Writer, key []byte) (io.WriteCloser, error) {
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-En2iV", "unable to generate salt")
+	}
+	aead, err := archiveCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(salt); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-En3wR", "unable to write archive")
+	}
+	return &encryptWriter{aead: aead, w: w, buf: make([]byte, 0, encryptionChunkSize)}, nil
+}
+
+func decrypt(r io.Reader, key []byte) (io.Reader, error) {
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BACKUP-De2iV", "archive is corrupted")
+	}
+	aead, err := archiveCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{aead: aead, r: r, chunk: make([]byte, encryptionChunkSize+aead.Overhead())}, nil
+}
+
+func archiveCipher(key, salt []byte) (cipher.AEAD, error) {
+	if len(key) < minKeyLength {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-En2kY", "key must be at least %d bytes long", minKeyLength)
+	}
+	derived := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, salt, []byte(encryptionKDF)), derived); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-En2dK", "unable to derive key")
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-En3kY", "unable to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-En2gC", "unable to create cipher")
+	}
+	return aead, nil
+}
+
+//chunkNonce returns the nonce of the chunk at index
+// the nonces are unique, because every archive has its own key
+func chunkNonce(aead cipher.AEAD, index uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], index)
+	return nonce
+}
+
+//chunkData marks the final chunk as additional authenticated data
+func chunkData(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+type encryptWriter struct {
+	aead  cipher.AEAD
+	w     io.Writer
+	buf   []byte
+	index uint64
+}
+
+func (e *encryptWriter) Write(p []byte) (written int, err error) {
+	for len(p) > 0 {
+		n := copy(e.buf[len(e.buf):cap(e.buf)], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+		if len(e.buf) == cap(e.buf) {
+			if err = e.seal(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+//Close writes the final chunk, which is always shorter than encryptionChunkSize
+func (e *encryptWriter) Close() error {
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(final bool) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.aead, e.index), e.buf, chunkData(final))
+	if _, err := e.w.Write(sealed); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-En4wR", "unable to write archive")
+	}
+	e.buf = e.buf[:0]
+	e.index++
+	return nil
+}
+
+type decryptReader struct {
+	aead  cipher.AEAD
+	r     io.Reader
+	chunk []byte
+	plain []byte
+	index uint64
+	done  bool
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+//open reads and authenticates the next chunk
+// a full chunk is never the final chunk, so a missing final chunk is detected as truncation
+func (d *decryptReader) open() error {
+	n, err := io.ReadFull(d.r, d.chunk)
+	final := err == io.ErrUnexpectedEOF
+	if err == io.EOF {
+		return caos_errs.ThrowInvalidArgument(nil, "BACKUP-De2tR", "archive is truncated")
+	}
+	if err != nil && !final {
+		return caos_errs.ThrowInvalidArgument(err, "BACKUP-De3rA", "unable to read archive")
+	}
+	d.plain, err = d.aead.Open(d.chunk[:0], chunkNonce(d.aead, d.index), d.chunk[:n], chunkData(final))
+	if err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "BACKUP-De2aU", "archive can't be decrypted, wrong key or corrupted archive")
+	}
+	d.index++
+	d.done = final
+	return nil
+}
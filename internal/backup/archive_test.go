@@ -0,0 +1,171 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func TestArchive(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	type args struct {
+		rows     int
+		writeKey []byte
+		readKey  []byte
+		modify   func([]byte) []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		err  func(error) bool
+	}{
+		{
+			name: "unencrypted",
+			args: args{
+				rows: 1,
+			},
+		},
+		{
+			name: "encrypted",
+			args: args{
+				rows:     1,
+				writeKey: key,
+				readKey:  key,
+			},
+		},
+		{
+			name: "encrypted in multiple chunks",
+			args: args{
+				rows:     2000,
+				writeKey: key,
+				readKey:  key,
+			},
+		},
+		{
+			name: "wrong key, invalid argument error",
+			args: args{
+				rows:     1,
+				writeKey: key,
+				readKey:  []byte("fedcba9876543210fedcba9876543210"),
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "encrypted without key, invalid argument error",
+			args: args{
+				rows:     1,
+				writeKey: key,
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "key too short, invalid argument error",
+			args: args{
+				rows:     1,
+				writeKey: key,
+				readKey:  []byte("short"),
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "modified, invalid argument error",
+			args: args{
+				rows:     2000,
+				writeKey: key,
+				readKey:  key,
+				modify: func(archive []byte) []byte {
+					archive[len(archive)/2] ^= 1
+					return archive
+				},
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "final chunk missing, invalid argument error",
+			args: args{
+				rows:     2000,
+				writeKey: key,
+				readKey:  key,
+				modify: func(archive []byte) []byte {
+					return archive[:encryptionSaltSize+encryptionChunkSize+16]
+				},
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "truncated chunk, invalid argument error",
+			args: args{
+				rows:     2000,
+				writeKey: key,
+				readKey:  key,
+				modify: func(archive []byte) []byte {
+					return archive[:len(archive)-1]
+				},
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := new(bytes.Buffer)
+			content.WriteString("[\"event_sequence\",\"payload\"]\n")
+			for i := 0; i < tt.args.rows; i++ {
+				payload := make([]byte, 64)
+				_, err := rand.Read(payload)
+				require.NoError(t, err)
+				fmt.Fprintf(content, "[\"%d\",\"%s\"]\n", i+1, hex.EncodeToString(payload))
+			}
+			checksum := sha256.Sum256(content.Bytes())
+			table := &Table{Database: "eventstore", Schema: "public", Name: "events", Rows: uint64(tt.args.rows), Checksum: hex.EncodeToString(checksum[:])}
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "tables"), 0700))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, table.fileName()), content.Bytes(), 0600))
+			manifest := &Manifest{
+				Version:       Version,
+				CreationDate:  time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+				DatabaseType:  "cockroach",
+				EventSequence: uint64(tt.args.rows),
+				Tables:        []*Table{table},
+			}
+
+			archive := new(bytes.Buffer)
+			require.NoError(t, writeArchive(archive, tt.args.writeKey, manifest, dir))
+			data := archive.Bytes()
+			if tt.args.modify != nil {
+				data = tt.args.modify(data)
+			}
+
+			_, err := Verify(bytes.NewReader(data), tt.args.readKey)
+			if tt.err != nil {
+				if !tt.err(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			reader, err := newArchiveReader(bytes.NewReader(data), tt.args.readKey)
+			require.NoError(t, err)
+			gotManifest, err := reader.manifest()
+			require.NoError(t, err)
+			assert.Equal(t, manifest, gotManifest)
+			file, err := reader.next(table.fileName())
+			require.NoError(t, err)
+			gotContent, err := ioutil.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, content.Bytes(), gotContent)
+			require.NoError(t, reader.end())
+		})
+	}
+}
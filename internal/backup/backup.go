@@ -0,0 +1,281 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/caos/zitadel/internal/config/types"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+const eventSequenceStmt = "SELECT COALESCE(MAX(event_sequence), 0) FROM eventstore.events"
+
+//Schema contains tables which are backed up
+type Schema struct {
+	//Database is only set on cockroach,
+	// on postgres all schemas are part of the connected database
+	Database string
+	Name     string
+}
+
+//Schemas returns the schemas of the eventstore and the projections
+// on cockroach the views of the spoolers are stored in their own databases and are backed up as well,
+// otherwise they would be ahead of the restored eventstore
+func Schemas(databaseType string) []Schema {
+	if databaseType == types.DatabasePostgres {
+		return []Schema{
+			{Name: "eventstore"},
+			{Name: "projections"},
+		}
+	}
+	return []Schema{
+		{Database: "eventstore", Name: "public"},
+		{Database: "zitadel", Name: "projections"},
+		{Database: "management", Name: "public"},
+		{Database: "auth", Name: "public"},
+		{Database: "adminapi", Name: "public"},
+		{Database: "authz", Name: "public"},
+		{Database: "notification", Name: "public"},
+	}
+}
+
+//Table is a backed up table
+type Table struct {
+	Database string `json:"database,omitempty"`
+	Schema   string `json:"schema"`
+	Name     string `json:"name"`
+	Rows     uint64 `json:"rows"`
+	//Checksum is the hex encoded sha256 checksum of the file of the table in the archive
+	Checksum string `json:"checksum"`
+
+	columns []string
+	//dependsOn are the tables of the same schema referenced by foreign keys
+	dependsOn []string
+}
+
+func (t *Table) qualifiedName() string {
+	return strings.Join(t.nameParts(), ".")
+}
+
+func (t *Table) quotedName() string {
+	parts := t.nameParts()
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func (t *Table) nameParts() []string {
+	if t.Database == "" {
+		return []string{t.Schema, t.Name}
+	}
+	return []string{t.Database, t.Schema, t.Name}
+}
+
+func (t *Table) fileName() string {
+	return "tables/" + t.qualifiedName() + ".jsonl"
+}
+
+//Backup writes the tables of the eventstore and the projections as archive to w
+// all tables are read in the same transaction, so the archive is consistent.
+// The archive is encrypted if a key (at least 16 bytes) is provided
+func Backup(ctx context.Context, db *sql.DB, databaseType string, w io.Writer, key []byte) (*Manifest, error) {
+	dir, err := ioutil.TempDir("", "zitadel-backup")
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Bk2tD", "unable to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Bk2tX", "unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	manifest := &Manifest{
+		Version:      Version,
+		CreationDate: time.Now().UTC(),
+		DatabaseType: databaseTypeOrDefault(databaseType),
+	}
+	if err = tx.QueryRowContext(ctx, eventSequenceStmt).Scan(&manifest.EventSequence); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Bk2sQ", "unable to query event sequence")
+	}
+	for _, schema := range Schemas(databaseType) {
+		tables, err := listTables(ctx, tx, schema)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if err = dumpTable(ctx, tx, table, dir); err != nil {
+				return nil, err
+			}
+			manifest.Tables = append(manifest.Tables, table)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Bk2cT", "unable to commit transaction")
+	}
+	return manifest, writeArchive(w, key, manifest, dir)
+}
+
+func databaseTypeOrDefault(databaseType string) string {
+	if databaseType == "" {
+		return types.DatabaseCockroach
+	}
+	return databaseType
+}
+
+//listTables returns the tables of the schema ordered by their foreign keys,
+// referenced tables are listed before the referencing tables
+func listTables(ctx context.Context, tx *sql.Tx, schema Schema) ([]*Table, error) {
+	infoSchema := "information_schema"
+	columnFilter := "is_generated = 'NEVER'"
+	if schema.Database != "" {
+		infoSchema = pq.QuoteIdentifier(schema.Database) + ".information_schema"
+		columnFilter = "is_hidden = 'NO' AND generation_expression = ''"
+	}
+	tables := make(map[string]*Table)
+	rows, err := tx.QueryContext(ctx, "SELECT table_name, column_name FROM "+infoSchema+".columns"+
+		" WHERE table_schema = $1 AND "+columnFilter+
+		" AND table_name IN (SELECT table_name FROM "+infoSchema+".tables WHERE table_schema = $1 AND table_type = 'BASE TABLE')"+
+		" ORDER BY table_name, ordinal_position", schema.Name)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Ls2tQ", "unable to list tables")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, column string
+		if err = rows.Scan(&tableName, &column); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "BACKUP-Ls2sC", "unable to list tables")
+		}
+		table, ok := tables[tableName]
+		if !ok {
+			table = &Table{Database: schema.Database, Schema: schema.Name, Name: tableName}
+			tables[tableName] = table
+		}
+		table.columns = append(table.columns, column)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Ls3tQ", "unable to list tables")
+	}
+
+	rows, err = tx.QueryContext(ctx, "SELECT DISTINCT tc.table_name, ccu.table_name FROM "+infoSchema+".table_constraints tc"+
+		" JOIN "+infoSchema+".constraint_column_usage ccu ON tc.constraint_schema = ccu.constraint_schema AND tc.constraint_name = ccu.constraint_name"+
+		" WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = $1", schema.Name)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Ls2fK", "unable to list foreign keys")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, referenced string
+		if err = rows.Scan(&tableName, &referenced); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "BACKUP-Ls3fK", "unable to list foreign keys")
+		}
+		if table, ok := tables[tableName]; ok && tableName != referenced {
+			table.dependsOn = append(table.dependsOn, referenced)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "BACKUP-Ls4fK", "unable to list foreign keys")
+	}
+
+	list := make([]*Table, 0, len(tables))
+	for _, table := range tables {
+		list = append(list, table)
+	}
+	return sortTables(list)
+}
+
+//sortTables orders the tables by name and moves referenced tables before the referencing tables
+func sortTables(tables []*Table) ([]*Table, error) {
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+	sorted := make([]*Table, 0, len(tables))
+	added := make(map[string]bool, len(tables))
+	for len(sorted) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if added[table.Name] || !dependenciesAdded(table, added) {
+				continue
+			}
+			sorted = append(sorted, table)
+			added[table.Name] = true
+			progress = true
+		}
+		if !progress {
+			return nil, caos_errs.ThrowInternal(nil, "BACKUP-So2cY", "tables reference each other")
+		}
+	}
+	return sorted, nil
+}
+
+func dependenciesAdded(table *Table, added map[string]bool) bool {
+	for _, dependency := range table.dependsOn {
+		if !added[dependency] {
+			return false
+		}
+	}
+	return true
+}
+
+//dumpTable writes the rows of the table as json lines to a file in dir
+// the first line contains the names of the columns, the following lines the values of a row as text
+func dumpTable(ctx context.Context, tx *sql.Tx, table *Table, dir string) error {
+	path := filepath.Join(dir, table.fileName())
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Du2mD", "unable to create table file")
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Du2cF", "unable to create table file")
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	encoder := json.NewEncoder(io.MultiWriter(file, hash))
+	if err = encoder.Encode(table.columns); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Du2wC", "unable to write table file")
+	}
+
+	columns := make([]string, len(table.columns))
+	for i, column := range table.columns {
+		columns[i] = pq.QuoteIdentifier(column) + "::TEXT"
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT "+strings.Join(columns, ", ")+" FROM "+table.quotedName())
+	if err != nil {
+		return caos_errs.ThrowInternalf(err, "BACKUP-Du2qT", "unable to query table %s", table.qualifiedName())
+	}
+	defer rows.Close()
+	values := make([]*string, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return caos_errs.ThrowInternalf(err, "BACKUP-Du2sR", "unable to read table %s", table.qualifiedName())
+		}
+		if err = encoder.Encode(values); err != nil {
+			return caos_errs.ThrowInternal(err, "BACKUP-Du3wR", "unable to write table file")
+		}
+		table.Rows++
+	}
+	if err = rows.Err(); err != nil {
+		return caos_errs.ThrowInternalf(err, "BACKUP-Du3qT", "unable to read table %s", table.qualifiedName())
+	}
+	table.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
@@ -0,0 +1,72 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func TestSortTables(t *testing.T) {
+	type res struct {
+		names []string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		tables []*Table
+		res    res
+	}{
+		{
+			name: "without references, ordered by name",
+			tables: []*Table{
+				{Name: "users"},
+				{Name: "apps"},
+			},
+			res: res{
+				names: []string{"apps", "users"},
+			},
+		},
+		{
+			name: "referenced tables first",
+			tables: []*Table{
+				{Name: "apps_oidc_configs", dependsOn: []string{"apps"}},
+				{Name: "apps"},
+				{Name: "apps_api_configs", dependsOn: []string{"apps"}},
+				{Name: "actions"},
+				{Name: "app_keys", dependsOn: []string{"apps_api_configs"}},
+			},
+			res: res{
+				names: []string{"actions", "apps", "apps_api_configs", "apps_oidc_configs", "app_keys"},
+			},
+		},
+		{
+			name: "cyclic references, internal error",
+			tables: []*Table{
+				{Name: "a", dependsOn: []string{"b"}},
+				{Name: "b", dependsOn: []string{"a"}},
+			},
+			res: res{
+				err: caos_errs.IsInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sortTables(tt.tables)
+			if tt.res.err != nil {
+				if !tt.res.err(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			names := make([]string, len(got))
+			for i, table := range got {
+				names[i] = table.Name
+			}
+			assert.Equal(t, tt.res.names, names)
+		})
+	}
+}
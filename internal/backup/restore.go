@@ -0,0 +1,231 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+const (
+	insertBatchSize = 100
+
+	brokenAggregateSequencesStmt = "SELECT COUNT(*) FROM eventstore.events e" +
+		" WHERE e.previous_aggregate_sequence > 0 AND NOT EXISTS (" +
+		" SELECT 1 FROM eventstore.events p" +
+		" WHERE p.event_sequence = e.previous_aggregate_sequence" +
+		" AND p.aggregate_type = e.aggregate_type AND p.aggregate_id = e.aggregate_id" +
+		")"
+
+	setEventSequenceStmt = "SELECT setval('eventstore.event_seq', $1)"
+
+	currentSequencesTable = "current_sequences"
+)
+
+//Verify reads the whole archive and checks the manifest and the checksum and the number of rows of each table
+// the chunks of an encrypted archive are authenticated as well, the database isn't used
+func Verify(r io.Reader, key []byte) (*Manifest, error) {
+	archive, err := newArchiveReader(r, key)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := archive.manifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range manifest.Tables {
+		file, err := archive.next(table.fileName())
+		if err != nil {
+			return nil, err
+		}
+		if err = readTable(table, file, func([]interface{}) error { return nil }); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, archive.end()
+}
+
+//Restore inserts the backed up rows into the tables of the archive
+// the tables must exist and be empty, e.g. a new database with the migrations of the backed up version.
+// The rows are inserted in batches of their own transaction, because a single transaction would exceed the limits of CockroachDB,
+// so the archive should be checked with Verify before and the database must be dropped and recreated if the restore fails.
+// After the rows are inserted the sequences of the restored eventstore and projections are checked,
+// ZITADEL must not be started on the database until the restore succeeded
+func Restore(ctx context.Context, db *sql.DB, databaseType string, r io.Reader, key []byte) (*Manifest, error) {
+	archive, err := newArchiveReader(r, key)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := archive.manifest()
+	if err != nil {
+		return nil, err
+	}
+	if manifest.DatabaseType != databaseTypeOrDefault(databaseType) {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rs2dT", "archive of %s database can't be restored to %s database", manifest.DatabaseType, databaseTypeOrDefault(databaseType))
+	}
+	if err = ensureEmpty(ctx, db, manifest.Tables); err != nil {
+		return nil, err
+	}
+	for _, table := range manifest.Tables {
+		file, err := archive.next(table.fileName())
+		if err != nil {
+			return nil, err
+		}
+		if err = restoreTable(ctx, db, table, file); err != nil {
+			return nil, err
+		}
+	}
+	if err = archive.end(); err != nil {
+		return nil, err
+	}
+	if err = validateSequences(ctx, db, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.EventSequence > 0 {
+		if _, err = db.ExecContext(ctx, setEventSequenceStmt, manifest.EventSequence); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "BACKUP-Rs2sQ", "unable to set event sequence")
+		}
+	}
+	return manifest, nil
+}
+
+//ensureEmpty checks that none of the tables contains rows
+func ensureEmpty(ctx context.Context, db *sql.DB, tables []*Table) error {
+	for _, table := range tables {
+		var exists bool
+		if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+table.quotedName()+")").Scan(&exists); err != nil {
+			return caos_errs.ThrowInternalf(err, "BACKUP-Rs2eQ", "unable to query table %s", table.qualifiedName())
+		}
+		if exists {
+			return caos_errs.ThrowPreconditionFailedf(nil, "BACKUP-Rs2eN", "table %s is not empty, the archive can only be restored to a new database", table.qualifiedName())
+		}
+	}
+	return nil
+}
+
+//restoreTable inserts the rows of the file written by dumpTable in batches
+// and verifies the checksum and the count of the rows
+func restoreTable(ctx context.Context, db *sql.DB, table *Table, file io.Reader) error {
+	return readTable(table, file, func(values []interface{}) error {
+		return insertRows(ctx, db, table, values)
+	})
+}
+
+//readTable passes the values of the rows of the file written by dumpTable in batches to insert
+// and verifies the checksum and the count of the rows
+func readTable(table *Table, file io.Reader, insert func(values []interface{}) error) error {
+	hash := sha256.New()
+	content := io.TeeReader(file, hash)
+	decoder := json.NewDecoder(content)
+	if err := decoder.Decode(&table.columns); err != nil {
+		return caos_errs.ThrowInvalidArgumentf(err, "BACKUP-Rt2cL", "file of table %s is invalid", table.qualifiedName())
+	}
+
+	var rows uint64
+	batch := make([]interface{}, 0, insertBatchSize*len(table.columns))
+	for decoder.More() {
+		values := make([]*string, 0, len(table.columns))
+		if err := decoder.Decode(&values); err != nil {
+			return caos_errs.ThrowInvalidArgumentf(err, "BACKUP-Rt2rW", "file of table %s is invalid", table.qualifiedName())
+		}
+		if len(values) != len(table.columns) {
+			return caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rt3rW", "file of table %s is invalid", table.qualifiedName())
+		}
+		for _, value := range values {
+			if value == nil {
+				batch = append(batch, nil)
+				continue
+			}
+			batch = append(batch, *value)
+		}
+		rows++
+		if len(batch) == cap(batch) {
+			if err := insert(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := insert(batch); err != nil {
+			return err
+		}
+	}
+	if _, err := io.Copy(ioutil.Discard, content); err != nil {
+		return caos_errs.ThrowInvalidArgumentf(err, "BACKUP-Rt2rD", "file of table %s is invalid", table.qualifiedName())
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != table.Checksum {
+		return caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rt2cS", "checksum of table %s doesn't match", table.qualifiedName())
+	}
+	if rows != table.Rows {
+		return caos_errs.ThrowInvalidArgumentf(nil, "BACKUP-Rt2cR", "expected %d rows of table %s but got %d", table.Rows, table.qualifiedName(), rows)
+	}
+	return nil
+}
+
+//insertRows inserts the values of multiple rows,
+// the values are passed as text and converted to the types of the columns by the database
+func insertRows(ctx context.Context, db *sql.DB, table *Table, values []interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+	columns := make([]string, len(table.columns))
+	for i, column := range table.columns {
+		columns[i] = pq.QuoteIdentifier(column)
+	}
+	rows := make([]string, 0, len(values)/len(columns))
+	placeholders := make([]string, len(columns))
+	for i := 0; i < len(values); i += len(columns) {
+		for j := range placeholders {
+			placeholders[j] = "$" + strconv.Itoa(i+j+1)
+		}
+		rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+	}
+	stmt := "INSERT INTO " + table.quotedName() + " (" + strings.Join(columns, ", ") + ") VALUES " + strings.Join(rows, ", ")
+	if _, err := db.ExecContext(ctx, stmt, values...); err != nil {
+		return caos_errs.ThrowInternalf(err, "BACKUP-In2sT", "unable to restore table %s", table.qualifiedName())
+	}
+	return nil
+}
+
+//validateSequences checks if the restored eventstore ends with the backed up sequence,
+// no event references a missing previous event of its aggregate
+// and no projection or view is ahead of the eventstore
+func validateSequences(ctx context.Context, db *sql.DB, manifest *Manifest) error {
+	var sequence uint64
+	if err := db.QueryRowContext(ctx, eventSequenceStmt).Scan(&sequence); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Va2sQ", "unable to query event sequence")
+	}
+	if sequence != manifest.EventSequence {
+		return caos_errs.ThrowPreconditionFailedf(nil, "BACKUP-Va2sM", "restored event sequence %d doesn't match backed up sequence %d", sequence, manifest.EventSequence)
+	}
+	var broken uint64
+	if err := db.QueryRowContext(ctx, brokenAggregateSequencesStmt).Scan(&broken); err != nil {
+		return caos_errs.ThrowInternal(err, "BACKUP-Va2aQ", "unable to validate aggregate sequences")
+	}
+	if broken > 0 {
+		return caos_errs.ThrowPreconditionFailedf(nil, "BACKUP-Va2aB", "%d events reference a missing previous event", broken)
+	}
+	for _, table := range manifest.Tables {
+		if table.Name != currentSequencesTable {
+			continue
+		}
+		var ahead uint64
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table.quotedName()+" WHERE current_sequence > $1", sequence).Scan(&ahead); err != nil {
+			return caos_errs.ThrowInternalf(err, "BACKUP-Va2cQ", "unable to validate %s", table.qualifiedName())
+		}
+		if ahead > 0 {
+			return caos_errs.ThrowPreconditionFailedf(nil, "BACKUP-Va2cA", "%d sequences of %s are ahead of the eventstore", ahead, table.qualifiedName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func TestRestoreTable(t *testing.T) {
+	const content = "[\"id\",\"data\"]\n[\"1\",\"{}\"]\n[\"2\",null]\n"
+	checksum := sha256.Sum256([]byte(content))
+	type args struct {
+		rows     uint64
+		checksum string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		expect func(sqlmock.Sqlmock)
+		err    func(error) bool
+	}{
+		{
+			name: "rows inserted",
+			args: args{
+				rows:     2,
+				checksum: hex.EncodeToString(checksum[:]),
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(`INSERT INTO "zitadel"."projections"."actions" ("id", "data") VALUES ($1, $2), ($3, $4)`)).
+					WithArgs("1", "{}", "2", nil).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "checksum doesn't match, invalid argument error",
+			args: args{
+				rows:     2,
+				checksum: "checksum",
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "rows don't match, invalid argument error",
+			args: args{
+				rows:     3,
+				checksum: hex.EncodeToString(checksum[:]),
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			err: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			table := &Table{Database: "zitadel", Schema: "projections", Name: "actions", Rows: tt.args.rows, Checksum: tt.args.checksum}
+			err = restoreTable(context.Background(), db, table, strings.NewReader(content))
+			if tt.err == nil {
+				require.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEnsureEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(sqlmock.Sqlmock)
+		err    func(error) bool
+	}{
+		{
+			name: "tables empty",
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM "eventstore"."public"."events")`)).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM "zitadel"."projections"."actions")`)).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+		},
+		{
+			name: "table not empty, precondition failed error",
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM "eventstore"."public"."events")`)).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			err: caos_errs.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			tables := []*Table{
+				{Database: "eventstore", Schema: "public", Name: "events"},
+				{Database: "zitadel", Schema: "projections", Name: "actions"},
+			}
+			err = ensureEmpty(context.Background(), db, tables)
+			if tt.err == nil {
+				require.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/caos/zitadel/internal/cache"
 	"github.com/caos/zitadel/internal/cache/bigcache"
 	"github.com/caos/zitadel/internal/cache/fastcache"
+	"github.com/caos/zitadel/internal/cache/redis"
 	"github.com/caos/zitadel/internal/errors"
 )
 
@@ -16,6 +17,7 @@ type CacheConfig struct {
 var caches = map[string]func() cache.Config{
 	"bigcache":  func() cache.Config { return &bigcache.Config{} },
 	"fastcache": func() cache.Config { return &fastcache.Config{} },
+	"redis":     func() cache.Config { return &redis.Config{} },
 }
 
 func (c *CacheConfig) UnmarshalJSON(data []byte) error {
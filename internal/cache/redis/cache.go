@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"reflect"
+	"time"
+
+	"github.com/caos/logging"
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+type Redis struct {
+	client *goredis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+func NewRedis(c *Config) (*Redis, error) {
+	if c.Address == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "REDIS-Ne2aD", "address of redis missing")
+	}
+	return &Redis{
+		client: NewClient(c),
+		prefix: c.KeyPrefix,
+		ttl:    c.TTL.Duration,
+	}, nil
+}
+
+func (c *Redis) Set(key string, object interface{}) error {
+	if key == "" || reflect.ValueOf(object).IsNil() {
+		return errors.ThrowInvalidArgument(nil, "REDIS-St2eV", "key or value should not be empty")
+	}
+	var b bytes.Buffer
+	enc := gob.NewEncoder(&b)
+	if err := enc.Encode(object); err != nil {
+		return errors.ThrowInvalidArgument(err, "REDIS-St3eN", "unable to encode object")
+	}
+	return MapError(c.client.Set(context.Background(), c.prefix+key, b.Bytes(), c.ttl).Err())
+}
+
+func (c *Redis) Get(key string, ptrToObject interface{}) error {
+	if key == "" || reflect.ValueOf(ptrToObject).IsNil() {
+		return errors.ThrowInvalidArgument(nil, "REDIS-Gt2eV", "key or value should not be empty")
+	}
+	value, err := c.client.Get(context.Background(), c.prefix+key).Bytes()
+	if err == goredis.Nil {
+		return errors.ThrowNotFound(nil, "REDIS-Gt5nF", "not in cache")
+	}
+	if err != nil {
+		logging.Log("REDIS-Gt3rE").WithError(err).Info("read from cache failed")
+		return errors.ThrowInvalidArgument(err, "REDIS-Gt4rE", "error in reading from cache")
+	}
+	b := bytes.NewBuffer(value)
+	dec := gob.NewDecoder(b)
+
+	return dec.Decode(ptrToObject)
+}
+
+func (c *Redis) Delete(key string) error {
+	if key == "" {
+		return errors.ThrowInvalidArgument(nil, "REDIS-De2eV", "key should not be empty")
+	}
+	return MapError(c.client.Del(context.Background(), c.prefix+key).Err())
+}
@@ -0,0 +1,374 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/caos/zitadel/internal/config/types"
+	"github.com/caos/zitadel/internal/errors"
+)
+
+type TestStruct struct {
+	Test string
+}
+
+func getRedisMock(t *testing.T, config *Config) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	if config.Password != "" {
+		server.RequireAuth(config.Password)
+	}
+	config.Address = server.Addr()
+	cache, err := NewRedis(config)
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	return cache, server
+}
+
+func TestSet(t *testing.T) {
+	type args struct {
+		config *Config
+		key    string
+		value  *TestStruct
+	}
+	type res struct {
+		result  *TestStruct
+		key     string
+		ttl     time.Duration
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "set cache no err",
+			args: args{
+				config: &Config{},
+				key:    "KEY",
+				value:  &TestStruct{Test: "Test"},
+			},
+			res: res{
+				result: &TestStruct{Test: "Test"},
+				key:    "KEY",
+			},
+		},
+		{
+			name: "set cache with prefix and ttl",
+			args: args{
+				config: &Config{KeyPrefix: "zitadel:", TTL: types.Duration{Duration: time.Minute}},
+				key:    "KEY",
+				value:  &TestStruct{Test: "Test"},
+			},
+			res: res{
+				result: &TestStruct{Test: "Test"},
+				key:    "zitadel:KEY",
+				ttl:    time.Minute,
+			},
+		},
+		{
+			name: "set cache with password",
+			args: args{
+				config: &Config{Password: "secret", DB: 1},
+				key:    "KEY",
+				value:  &TestStruct{Test: "Test"},
+			},
+			res: res{
+				result: &TestStruct{Test: "Test"},
+				key:    "KEY",
+			},
+		},
+		{
+			name: "key empty",
+			args: args{
+				config: &Config{},
+				key:    "",
+				value:  &TestStruct{Test: "Test"},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set cache nil value",
+			args: args{
+				config: &Config{},
+				key:    "KEY",
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, server := getRedisMock(t, tt.args.config)
+			err := cache.Set(tt.args.key, tt.args.value)
+
+			if tt.res.errFunc == nil && err != nil {
+				t.Fatalf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc != nil {
+				if !tt.res.errFunc(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			db := server.DB(tt.args.config.DB)
+			if !db.Exists(tt.res.key) {
+				t.Errorf("key %s not stored", tt.res.key)
+			}
+			if ttl := db.TTL(tt.res.key); ttl > tt.res.ttl || (tt.res.ttl > 0 && ttl <= 0) {
+				t.Errorf("got wrong ttl expected: %v actual: %v", tt.res.ttl, ttl)
+			}
+			result := new(TestStruct)
+			if err = cache.Get(tt.args.key, result); err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if !reflect.DeepEqual(result, tt.res.result) {
+				t.Errorf("got wrong result expected: %v actual: %v", tt.res.result, result)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	type args struct {
+		config      *Config
+		key         string
+		setValue    *TestStruct
+		getValue    *TestStruct
+		fastForward time.Duration
+	}
+	type res struct {
+		result  *TestStruct
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "get cache no err",
+			args: args{
+				config:   &Config{},
+				key:      "KEY",
+				setValue: &TestStruct{Test: "Test"},
+				getValue: &TestStruct{},
+			},
+			res: res{
+				result: &TestStruct{Test: "Test"},
+			},
+		},
+		{
+			name: "get cache not found",
+			args: args{
+				config:   &Config{},
+				key:      "OTHER",
+				setValue: &TestStruct{Test: "Test"},
+				getValue: &TestStruct{},
+			},
+			res: res{
+				errFunc: errors.IsNotFound,
+			},
+		},
+		{
+			name: "get cache expired",
+			args: args{
+				config:      &Config{TTL: types.Duration{Duration: time.Minute}},
+				key:         "KEY",
+				setValue:    &TestStruct{Test: "Test"},
+				getValue:    &TestStruct{},
+				fastForward: 2 * time.Minute,
+			},
+			res: res{
+				errFunc: errors.IsNotFound,
+			},
+		},
+		{
+			name: "get cache no key",
+			args: args{
+				config:   &Config{},
+				setValue: &TestStruct{Test: "Test"},
+				getValue: &TestStruct{},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "get cache no value",
+			args: args{
+				config:   &Config{},
+				key:      "KEY",
+				setValue: &TestStruct{Test: "Test"},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, server := getRedisMock(t, tt.args.config)
+			err := cache.Set("KEY", tt.args.setValue)
+			if err != nil {
+				t.Fatalf("something went wrong: %v", err)
+			}
+			server.FastForward(tt.args.fastForward)
+
+			err = cache.Get(tt.args.key, tt.args.getValue)
+
+			if tt.res.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+
+			if tt.res.errFunc == nil && !reflect.DeepEqual(tt.args.getValue, tt.res.result) {
+				t.Errorf("got wrong result expected: %v actual: %v", tt.res.result, tt.args.getValue)
+			}
+
+			if tt.res.errFunc != nil && !tt.res.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		config   *Config
+		key      string
+		setValue *TestStruct
+	}
+	type res struct {
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "delete cache no err",
+			args: args{
+				config:   &Config{},
+				key:      "KEY",
+				setValue: &TestStruct{Test: "Test"},
+			},
+			res: res{},
+		},
+		{
+			name: "delete cache no key",
+			args: args{
+				config:   &Config{},
+				setValue: &TestStruct{Test: "Test"},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, server := getRedisMock(t, tt.args.config)
+			err := cache.Set("KEY", tt.args.setValue)
+			if err != nil {
+				t.Fatalf("something went wrong: %v", err)
+			}
+
+			err = cache.Delete(tt.args.key)
+
+			if tt.res.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc == nil && server.Exists(tt.args.key) {
+				t.Errorf("key %s not deleted", tt.args.key)
+			}
+
+			if tt.res.errFunc != nil && !tt.res.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestClient_Unauthenticated(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+	client := NewClient(&Config{Address: server.Addr(), Password: "wrong"})
+	if err := MapError(client.Ping(context.Background()).Err()); !errors.IsUnauthenticated(err) {
+		t.Errorf("got wrong err: %v ", err)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		//accepts connections but never replies
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	type args struct {
+		ctx         func() (context.Context, context.CancelFunc)
+		readTimeout time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "read timeout",
+			args: args{
+				ctx: func() (context.Context, context.CancelFunc) {
+					return context.WithCancel(context.Background())
+				},
+				readTimeout: 50 * time.Millisecond,
+			},
+		},
+		{
+			name: "context deadline",
+			args: args{
+				ctx: func() (context.Context, context.CancelFunc) {
+					return context.WithTimeout(context.Background(), 50*time.Millisecond)
+				},
+				readTimeout: time.Minute,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(&Config{Address: listener.Addr().String(), ReadTimeout: types.Duration{Duration: tt.args.readTimeout}})
+			ctx, cancel := tt.args.ctx()
+			defer cancel()
+			done := make(chan error, 1)
+			go func() {
+				done <- MapError(client.Ping(ctx).Err())
+			}()
+			select {
+			case err := <-done:
+				if !errors.IsUnavailable(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("command not aborted")
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+//NewClient returns a client for the configured redis server
+// the connections are pooled, MaxIdleConns limits the size of the pool
+func NewClient(c *Config) *goredis.Client {
+	poolSize := c.MaxIdleConns
+	if poolSize <= 0 {
+		poolSize = defaultMaxIdleConns
+	}
+	options := &goredis.Options{
+		Addr:         c.Address,
+		Password:     c.Password,
+		DB:           c.DB,
+		DialTimeout:  durationOrDefault(c.DialTimeout.Duration, defaultDialTimeout),
+		ReadTimeout:  durationOrDefault(c.ReadTimeout.Duration, defaultReadTimeout),
+		WriteTimeout: durationOrDefault(c.WriteTimeout.Duration, defaultWriteTimeout),
+		PoolSize:     poolSize,
+	}
+	if c.TLS {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return goredis.NewClient(options)
+}
+
+func durationOrDefault(duration, defaultDuration time.Duration) time.Duration {
+	if duration <= 0 {
+		return defaultDuration
+	}
+	return duration
+}
+
+//MapError maps the errors of the client to zitadel errors
+// a missing key (redis.Nil) is mapped to not found
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if err == goredis.Nil {
+		return errors.ThrowNotFound(err, "REDIS-Mp1nF", "key not found")
+	}
+	if isAuthError(err) {
+		return errors.ThrowUnauthenticated(err, "REDIS-Di3aU", "unable to authenticate")
+	}
+	if isTimeout(err) {
+		return errors.ThrowUnavailable(err, "REDIS-Ds2tO", "command timed out")
+	}
+	if _, ok := err.(goredis.Error); ok {
+		return errors.ThrowInternal(err, "REDIS-Ds3rE", "command failed")
+	}
+	return errors.ThrowUnavailable(err, "REDIS-Di2aL", "unable to connect to redis")
+}
+
+func isAuthError(err error) bool {
+	message := err.Error()
+	return strings.HasPrefix(message, "WRONGPASS") ||
+		strings.HasPrefix(message, "NOAUTH") ||
+		strings.HasPrefix(message, "ERR invalid password")
+}
+
+func isTimeout(err error) bool {
+	if err == context.DeadlineExceeded || err == context.Canceled {
+		return true
+	}
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
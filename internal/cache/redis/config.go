@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/caos/zitadel/internal/cache"
+	"github.com/caos/zitadel/internal/config/types"
+)
+
+const (
+	defaultMaxIdleConns = 10
+	defaultDialTimeout  = 5 * time.Second
+	defaultReadTimeout  = 3 * time.Second
+	defaultWriteTimeout = 3 * time.Second
+)
+
+type Config struct {
+	//Address of the redis server (host:port)
+	Address  string
+	Password string
+	DB       int
+	TLS      bool
+	//KeyPrefix is prepended to all keys, it allows multiple caches on the same database
+	KeyPrefix string
+	//TTL if set, entries expire after the ttl
+	TTL         types.Duration
+	DialTimeout types.Duration
+	//ReadTimeout is the deadline to read the reply of a command
+	ReadTimeout types.Duration
+	//WriteTimeout is the deadline to send a command
+	WriteTimeout types.Duration
+	MaxIdleConns int
+}
+
+func (c *Config) NewCache() (cache.Cache, error) {
+	return NewRedis(c)
+}
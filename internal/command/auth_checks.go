@@ -21,6 +21,23 @@ func checkExplicitProjectPermission(ctx context.Context, grantID, projectID stri
 	return caos_errors.ThrowPermissionDenied(nil, "EVENT-Shu7e", "Errors.UserGrant.NoPermissionForProject")
 }
 
+//checkPermissionForProject checks if the permission is granted globally or for the project (grant)
+// in contrast to checkExplicitProjectPermission the permission doesn't have to be the one of the request
+func checkPermissionForProject(ctx context.Context, permission, grantID, projectID string) error {
+	permissions := authz.GetAllPermissionsFromCtx(ctx)
+	if authz.HasGlobalExplicitPermission(permissions, permission) {
+		return nil
+	}
+	ids := authz.GetExplicitPermissionCtxIDs(permissions, permission)
+	if grantID != "" && listContainsID(ids, grantID) {
+		return nil
+	}
+	if listContainsID(ids, projectID) {
+		return nil
+	}
+	return caos_errors.ThrowPermissionDenied(nil, "COMMAND-Gw2pD", "Errors.UserGrant.NoPermissionForProject")
+}
+
 func listContainsID(ids []string, id string) bool {
 	for _, i := range ids {
 		if i == id {
@@ -16,6 +16,7 @@ import (
 	"github.com/caos/zitadel/internal/repository/action"
 	iam_repo "github.com/caos/zitadel/internal/repository/iam"
 	"github.com/caos/zitadel/internal/repository/keypair"
+	notification_repo "github.com/caos/zitadel/internal/repository/notification"
 	"github.com/caos/zitadel/internal/repository/org"
 	proj_repo "github.com/caos/zitadel/internal/repository/project"
 	usr_repo "github.com/caos/zitadel/internal/repository/user"
@@ -34,7 +35,7 @@ type Commands struct {
 
 	idpConfigSecretCrypto crypto.EncryptionAlgorithm
 
-	userPasswordAlg             crypto.HashAlgorithm
+	userPasswordAlg             *crypto.PasswordHasher
 	initializeUserCode          crypto.Generator
 	emailVerificationCode       crypto.Generator
 	phoneVerificationCode       crypto.Generator
@@ -55,6 +56,7 @@ type Commands struct {
 	privateKeyLifetime time.Duration
 	publicKeyLifetime  time.Duration
 	tokenVerifier      orgFeatureChecker
+	ipThrottle         ipThrottle
 }
 
 type orgFeatureChecker interface {
@@ -69,6 +71,8 @@ func (o OrgFeatureCheckerFunc) CheckOrgFeatures(ctx context.Context, orgID strin
 
 type Config struct {
 	Eventstore types.SQLUser
+	Snapshots  eventstore.SnapshotConfig
+	IPThrottle IPThrottleConfig
 }
 
 func StartCommands(
@@ -77,6 +81,7 @@ func StartCommands(
 	authZConfig authz.Config,
 	staticStore static.Storage,
 	authZRepo authz_repo.Repository,
+	ipThrottleConfig IPThrottleConfig,
 ) (repo *Commands, err error) {
 	repo = &Commands{
 		eventstore:         es,
@@ -88,6 +93,10 @@ func StartCommands(
 		privateKeyLifetime: defaults.KeyConfig.PrivateKeyLifetime.Duration,
 		publicKeyLifetime:  defaults.KeyConfig.PublicKeyLifetime.Duration,
 	}
+	repo.ipThrottle, err = newIPThrottle(ipThrottleConfig, repo.idGenerator)
+	if err != nil {
+		return nil, err
+	}
 	iam_repo.RegisterEventMappers(repo.eventstore)
 	org.RegisterEventMappers(repo.eventstore)
 	usr_repo.RegisterEventMappers(repo.eventstore)
@@ -95,6 +104,7 @@ func StartCommands(
 	proj_repo.RegisterEventMappers(repo.eventstore)
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
+	notification_repo.RegisterEventMappers(repo.eventstore)
 
 	repo.idpConfigSecretCrypto, err = crypto.NewAESCrypto(defaults.IDPConfigVerificationKey)
 	if err != nil {
@@ -109,7 +119,10 @@ func StartCommands(
 	repo.phoneVerificationCode = crypto.NewEncryptionGenerator(defaults.SecretGenerators.PhoneVerificationCode, userEncryptionAlgorithm)
 	repo.passwordVerificationCode = crypto.NewEncryptionGenerator(defaults.SecretGenerators.PasswordVerificationCode, userEncryptionAlgorithm)
 	repo.passwordlessInitCode = crypto.NewEncryptionGenerator(defaults.SecretGenerators.PasswordlessInitCode, userEncryptionAlgorithm)
-	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
+	repo.userPasswordAlg, err = defaults.PasswordHasher.NewPasswordHasher()
+	if err != nil {
+		return nil, err
+	}
 	repo.machineKeyAlg = userEncryptionAlgorithm
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
 	repo.applicationKeySize = int(defaults.SecretGenerators.ApplicationKeySize)
@@ -116,6 +116,10 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	return &domain.LockoutPolicy{
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
+		LockoutDuration:     wm.LockoutDuration,
+		MaxIPAttempts:       wm.MaxIPAttempts,
+		IPThrottleDuration:  wm.IPThrottleDuration,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
 	}
 }
@@ -129,6 +133,19 @@ func writeModelToPrivacyPolicy(wm *PrivacyPolicyWriteModel) *domain.PrivacyPolic
 	}
 }
 
+func writeModelToNotificationPolicy(wm *NotificationPolicyWriteModel) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		ObjectRoot:      writeModelToObjectRoot(wm.WriteModel),
+		State:           wm.State,
+		PasswordChanged: wm.PasswordChanged,
+		MFAAdded:        wm.MFAAdded,
+		MFARemoved:      wm.MFARemoved,
+		NewUserAgent:    wm.NewUserAgent,
+		UserLocked:      wm.UserLocked,
+		EmailChanged:    wm.EmailChanged,
+	}
+}
+
 func writeModelToIDPConfig(wm *IDPConfigWriteModel) *domain.IDPConfig {
 	return &domain.IDPConfig{
 		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
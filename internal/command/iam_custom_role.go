@@ -0,0 +1,168 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+//AddCustomRole defines a new role which can be assigned to members of the scope of the role
+// the permissions must be granted by at least one role of the authz config of the same scope
+func (c *Commands) AddCustomRole(ctx context.Context, role *domain.CustomRole) (*domain.ObjectDetails, error) {
+	if !role.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cr2aI", "Errors.IAM.CustomRole.Invalid")
+	}
+	if c.isZitadelRole(role.Role) {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Cr2bR", "Errors.IAM.CustomRole.AlreadyExists")
+	}
+	if !c.areZitadelPermissions(role.Scope.RolePrefix(), role.Permissions) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cr2pI", "Errors.IAM.CustomRole.PermissionInvalid")
+	}
+	writeModel, err := c.customRoleWriteModelByID(ctx, role.Role)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.CustomRoleStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Cr3aE", "Errors.IAM.CustomRole.AlreadyExists")
+	}
+	iamAgg := IAMAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam.NewCustomRoleAddedEvent(ctx, iamAgg, role.Role, role.Scope, role.Permissions))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+//ChangeCustomRole replaces the permissions of the role
+// the scope of a role can't be changed as it's part of the name
+func (c *Commands) ChangeCustomRole(ctx context.Context, role string, permissions []string) (*domain.ObjectDetails, error) {
+	if role == "" || len(permissions) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cr3aI", "Errors.IAM.CustomRole.Invalid")
+	}
+	writeModel, err := c.customRoleWriteModelByID(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.CustomRoleStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Cr3nF", "Errors.IAM.CustomRole.NotFound")
+	}
+	if !c.areZitadelPermissions(writeModel.Scope.RolePrefix(), permissions) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cr3pI", "Errors.IAM.CustomRole.PermissionInvalid")
+	}
+	if reflect.DeepEqual(writeModel.Permissions, permissions) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Cr3cN", "Errors.NoChangesFound")
+	}
+	iamAgg := IAMAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam.NewCustomRoleChangedEvent(ctx, iamAgg, role, permissions))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+//RemoveCustomRole removes the role
+// members keep the role, but it doesn't grant any permissions anymore
+func (c *Commands) RemoveCustomRole(ctx context.Context, role string) (*domain.ObjectDetails, error) {
+	if role == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cr4aI", "Errors.IAM.CustomRole.Invalid")
+	}
+	writeModel, err := c.customRoleWriteModelByID(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.CustomRoleStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Cr4nF", "Errors.IAM.CustomRole.NotFound")
+	}
+	iamAgg := IAMAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam.NewCustomRoleRemovedEvent(ctx, iamAgg, role))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+//invalidMemberRoles returns the roles which are neither a role of the authz config nor a custom role
+// starting with one of the prefixes.
+// The custom roles are only loaded if a role isn't in the authz config
+func (c *Commands) invalidMemberRoles(ctx context.Context, roles []string, rolePrefixes ...string) ([]string, error) {
+	invalidRoles := checkForInvalidRoles(roles, c.zitadelRoles, rolePrefixes)
+	if len(invalidRoles) == 0 {
+		return nil, nil
+	}
+	customRoles := NewCustomRolesWriteModel()
+	err := c.eventstore.FilterToQueryReducer(ctx, customRoles)
+	if err != nil {
+		return nil, err
+	}
+	return checkForInvalidRoles(invalidRoles, customRoles.RoleMappings(), rolePrefixes), nil
+}
+
+func checkForInvalidRoles(roles []string, validRoles []authz.RoleMapping, rolePrefixes []string) []string {
+	for _, rolePrefix := range rolePrefixes {
+		roles = domain.CheckForInvalidRoles(roles, rolePrefix, validRoles)
+	}
+	return roles
+}
+
+func (c *Commands) isZitadelRole(role string) bool {
+	for _, mapping := range c.zitadelRoles {
+		if mapping.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+//areZitadelPermissions checks if the permissions are granted by roles of the authz config with the prefix
+// this prevents custom roles from granting permissions of a broader scope (e.g. iam.write in an org role)
+func (c *Commands) areZitadelPermissions(rolePrefix string, permissions []string) bool {
+	if rolePrefix == "" {
+		return false
+	}
+	for _, permission := range permissions {
+		if !c.isZitadelPermission(rolePrefix, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Commands) isZitadelPermission(rolePrefix, permission string) bool {
+	for _, mapping := range c.zitadelRoles {
+		if !strings.HasPrefix(mapping.Role, rolePrefix+"_") {
+			continue
+		}
+		for _, p := range mapping.Permissions {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Commands) customRoleWriteModelByID(ctx context.Context, role string) (*CustomRoleWriteModel, error) {
+	writeModel := NewCustomRoleWriteModel(role)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
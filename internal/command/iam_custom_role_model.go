@@ -0,0 +1,127 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type CustomRoleWriteModel struct {
+	eventstore.WriteModel
+
+	Role        string
+	Scope       domain.CustomRoleScope
+	Permissions []string
+	State       domain.CustomRoleState
+}
+
+func NewCustomRoleWriteModel(role string) *CustomRoleWriteModel {
+	return &CustomRoleWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   domain.IAMID,
+			ResourceOwner: domain.IAMID,
+		},
+		Role: role,
+	}
+}
+
+func (wm *CustomRoleWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *iam.CustomRoleAddedEvent:
+			if e.Role == wm.Role {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *iam.CustomRoleChangedEvent:
+			if e.Role == wm.Role {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *iam.CustomRoleRemovedEvent:
+			if e.Role == wm.Role {
+				wm.WriteModel.AppendEvents(e)
+			}
+		}
+	}
+}
+
+func (wm *CustomRoleWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *iam.CustomRoleAddedEvent:
+			wm.Scope = e.Scope
+			wm.Permissions = e.Permissions
+			wm.State = domain.CustomRoleStateActive
+		case *iam.CustomRoleChangedEvent:
+			wm.Permissions = e.Permissions
+		case *iam.CustomRoleRemovedEvent:
+			wm.State = domain.CustomRoleStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *CustomRoleWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(iam.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			iam.CustomRoleAddedEventType,
+			iam.CustomRoleChangedEventType,
+			iam.CustomRoleRemovedEventType).
+		Builder()
+}
+
+//CustomRolesWriteModel contains all active custom roles
+type CustomRolesWriteModel struct {
+	eventstore.WriteModel
+
+	Roles map[string][]string
+}
+
+func NewCustomRolesWriteModel() *CustomRolesWriteModel {
+	return &CustomRolesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   domain.IAMID,
+			ResourceOwner: domain.IAMID,
+		},
+		Roles: make(map[string][]string),
+	}
+}
+
+func (wm *CustomRolesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *iam.CustomRoleAddedEvent:
+			wm.Roles[e.Role] = e.Permissions
+		case *iam.CustomRoleChangedEvent:
+			wm.Roles[e.Role] = e.Permissions
+		case *iam.CustomRoleRemovedEvent:
+			delete(wm.Roles, e.Role)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *CustomRolesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(iam.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			iam.CustomRoleAddedEventType,
+			iam.CustomRoleChangedEventType,
+			iam.CustomRoleRemovedEventType).
+		Builder()
+}
+
+func (wm *CustomRolesWriteModel) RoleMappings() []authz.RoleMapping {
+	mappings := make([]authz.RoleMapping, 0, len(wm.Roles))
+	for role, permissions := range wm.Roles {
+		mappings = append(mappings, authz.RoleMapping{Role: role, Permissions: permissions})
+	}
+	return mappings
+}
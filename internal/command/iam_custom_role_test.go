@@ -0,0 +1,487 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+var testZitadelRoles = []authz.RoleMapping{
+	{
+		Role:        "IAM_OWNER",
+		Permissions: []string{"iam.read", "iam.write", "org.read", "org.write"},
+	},
+	{
+		Role:        "ORG_OWNER",
+		Permissions: []string{"org.read", "org.write"},
+	},
+}
+
+func TestCommandSide_AddCustomRole(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx  context.Context
+		role *domain.CustomRole
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "role without scope prefix, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Role:        "AUDITOR",
+					Scope:       domain.CustomRoleScopeIAM,
+					Permissions: []string{"iam.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "role of authz config, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Role:        "IAM_OWNER",
+					Scope:       domain.CustomRoleScopeIAM,
+					Permissions: []string{"iam.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "unknown permission, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Role:        "IAM_AUDITOR",
+					Scope:       domain.CustomRoleScopeIAM,
+					Permissions: []string{"iam.read", "iam.everything"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "permission of other scope, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Role:        "ORG_ADMIN",
+					Scope:       domain.CustomRoleScopeOrg,
+					Permissions: []string{"org.write", "iam.write"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "role already exists, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Role:        "IAM_AUDITOR",
+					Scope:       domain.CustomRoleScopeIAM,
+					Permissions: []string{"iam.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read", "org.read"},
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(iam.NewAddCustomRoleUniqueConstraint("IAM_AUDITOR")),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Role:        "IAM_AUDITOR",
+					Scope:       domain.CustomRoleScopeIAM,
+					Permissions: []string{"iam.read", "org.read"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "IAM",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				zitadelRoles: testZitadelRoles,
+			}
+			got, err := r.AddCustomRole(tt.args.ctx, tt.args.role)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeCustomRole(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		role        string
+		permissions []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no permissions, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:  context.Background(),
+				role: "IAM_AUDITOR",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "role not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				role:        "IAM_AUDITOR",
+				permissions: []string{"iam.read"},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "role removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read"},
+							),
+						),
+						eventFromEventPusher(
+							iam.NewCustomRoleRemovedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				role:        "IAM_AUDITOR",
+				permissions: []string{"iam.read"},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				role:        "IAM_AUDITOR",
+				permissions: []string{"iam.read"},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "permission of other scope, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"ORG_ADMIN",
+								domain.CustomRoleScopeOrg,
+								[]string{"org.read"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				role:        "ORG_ADMIN",
+				permissions: []string{"org.read", "iam.write"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(iam.NewCustomRoleChangedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								[]string{"iam.read", "org.read"},
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				role:        "IAM_AUDITOR",
+				permissions: []string{"iam.read", "org.read"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "IAM",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				zitadelRoles: testZitadelRoles,
+			}
+			got, err := r.ChangeCustomRole(tt.args.ctx, tt.args.role, tt.args.permissions)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveCustomRole(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx  context.Context
+		role string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "role not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:  context.Background(),
+				role: "IAM_AUDITOR",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(iam.NewCustomRoleRemovedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(iam.NewRemoveCustomRoleUniqueConstraint("IAM_AUDITOR")),
+					),
+				),
+			},
+			args: args{
+				ctx:  context.Background(),
+				role: "IAM_AUDITOR",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "IAM",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				zitadelRoles: testZitadelRoles,
+			}
+			got, err := r.RemoveCustomRole(tt.args.ctx, tt.args.role)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -43,10 +43,14 @@ func (c *Commands) addIAMMember(ctx context.Context, iamAgg *eventstore.Aggregat
 	if !member.IsIAMValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-GR34U", "Errors.IAM.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.IAMRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.IAMRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-4m0fS", "Errors.IAM.MemberInvalid")
 	}
-	err := c.eventstore.FilterToQueryReducer(ctx, addedMember)
+	err = c.eventstore.FilterToQueryReducer(ctx, addedMember)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +66,11 @@ func (c *Commands) ChangeIAMMember(ctx context.Context, member *domain.Member) (
 	if !member.IsIAMValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-LiaZi", "Errors.IAM.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.IAMRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.IAMRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-3m9fs", "Errors.IAM.MemberInvalid")
 	}
 
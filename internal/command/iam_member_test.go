@@ -90,6 +90,7 @@ func TestCommandSide_AddIAMMember(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -257,6 +258,73 @@ func TestCommandSide_AddIAMMember(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "member add custom role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewCustomRoleAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"IAM_AUDITOR",
+								domain.CustomRoleScopeIAM,
+								[]string{"iam.read"},
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(iam.NewMemberAddedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								"user1",
+								[]string{"IAM_OWNER", "IAM_AUDITOR"}...,
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(member.NewAddMemberUniqueConstraint("IAM", "user1")),
+					),
+				),
+				zitadelRoles: []authz.RoleMapping{
+					{
+						Role: "IAM_OWNER",
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				member: &domain.Member{
+					UserID: "user1",
+					Roles:  []string{"IAM_OWNER", "IAM_AUDITOR"},
+				},
+			},
+			res: res{
+				want: &domain.Member{
+					ObjectRoot: models.ObjectRoot{
+						ResourceOwner: "IAM",
+						AggregateID:   "IAM",
+					},
+					UserID: "user1",
+					Roles:  []string{"IAM_OWNER", "IAM_AUDITOR"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -317,6 +385,7 @@ func TestCommandSide_ChangeIAMMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/repository/iam"
@@ -57,6 +59,42 @@ func (wm *IAMWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+func (wm *IAMWriteModel) SnapshotName() string {
+	return "iam_write_model"
+}
+
+func (wm *IAMWriteModel) SnapshotVersion() uint32 {
+	return 2
+}
+
+type iamWriteModelSnapshot struct {
+	SetUpStarted domain.Step `json:"setUpStarted"`
+	SetUpDone    domain.Step `json:"setUpDone"`
+	GlobalOrgID  string      `json:"globalOrgId"`
+	ProjectID    string      `json:"projectId"`
+}
+
+func (wm *IAMWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(&iamWriteModelSnapshot{
+		SetUpStarted: wm.SetUpStarted,
+		SetUpDone:    wm.SetUpDone,
+		GlobalOrgID:  wm.GlobalOrgID,
+		ProjectID:    wm.ProjectID,
+	})
+}
+
+func (wm *IAMWriteModel) UnmarshalSnapshot(data []byte) error {
+	snapshot := new(iamWriteModelSnapshot)
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+	wm.SetUpStarted = snapshot.SetUpStarted
+	wm.SetUpDone = snapshot.SetUpDone
+	wm.GlobalOrgID = snapshot.GlobalOrgID
+	wm.ProjectID = snapshot.ProjectID
+	return nil
+}
+
 func IAMAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, iam.AggregateType, iam.AggregateVersion)
 }
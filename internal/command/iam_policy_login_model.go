@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/caos/zitadel/internal/eventstore"
 
@@ -56,6 +57,51 @@ func (wm *IAMLoginPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+func (wm *IAMLoginPolicyWriteModel) SnapshotName() string {
+	return "iam_login_policy_write_model"
+}
+
+func (wm *IAMLoginPolicyWriteModel) SnapshotVersion() uint32 {
+	return 2
+}
+
+type loginPolicyWriteModelSnapshot struct {
+	AllowUserNamePassword bool                    `json:"allowUserNamePassword"`
+	AllowRegister         bool                    `json:"allowRegister"`
+	AllowExternalIDP      bool                    `json:"allowExternalIdp"`
+	ForceMFA              bool                    `json:"forceMfa"`
+	HidePasswordReset     bool                    `json:"hidePasswordReset"`
+	PasswordlessType      domain.PasswordlessType `json:"passwordlessType"`
+	State                 domain.PolicyState      `json:"state"`
+}
+
+func (wm *IAMLoginPolicyWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(&loginPolicyWriteModelSnapshot{
+		AllowUserNamePassword: wm.AllowUserNamePassword,
+		AllowRegister:         wm.AllowRegister,
+		AllowExternalIDP:      wm.AllowExternalIDP,
+		ForceMFA:              wm.ForceMFA,
+		HidePasswordReset:     wm.HidePasswordReset,
+		PasswordlessType:      wm.PasswordlessType,
+		State:                 wm.State,
+	})
+}
+
+func (wm *IAMLoginPolicyWriteModel) UnmarshalSnapshot(data []byte) error {
+	snapshot := new(loginPolicyWriteModelSnapshot)
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+	wm.AllowUserNamePassword = snapshot.AllowUserNamePassword
+	wm.AllowRegister = snapshot.AllowRegister
+	wm.AllowExternalIDP = snapshot.AllowExternalIDP
+	wm.ForceMFA = snapshot.ForceMFA
+	wm.HidePasswordReset = snapshot.HidePasswordReset
+	wm.PasswordlessType = snapshot.PasswordlessType
+	wm.State = snapshot.State
+	return nil
+}
+
 func (wm *IAMLoginPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
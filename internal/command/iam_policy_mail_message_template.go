@@ -0,0 +1,64 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	iam_repo "github.com/caos/zitadel/internal/repository/iam"
+)
+
+//SetDefaultMailMessageTemplate sets the template which replaces the default mail template
+// for the emails of the message type
+func (c *Commands) SetDefaultMailMessageTemplate(ctx context.Context, messageTemplate *domain.MailMessageTemplate) (*domain.ObjectDetails, error) {
+	if !messageTemplate.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-Mt4iV", "Errors.IAM.MailMessageTemplate.Invalid")
+	}
+	existingTemplate := NewIAMMailMessageTemplateWriteModel(messageTemplate.MessageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State == domain.PolicyStateActive && bytes.Equal(existingTemplate.Template, messageTemplate.Template) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "IAM-Mt4nC", "Errors.IAM.MailMessageTemplate.NotChanged")
+	}
+
+	iamAgg := IAMAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam_repo.NewMailMessageTemplateSetEvent(ctx, iamAgg, messageTemplate.MessageType, messageTemplate.Template))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+//ResetDefaultMailMessageTemplate removes the default template of the message type,
+// the emails are sent with the default mail template afterwards
+func (c *Commands) ResetDefaultMailMessageTemplate(ctx context.Context, messageType string) (*domain.ObjectDetails, error) {
+	if !domain.IsMailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-Mt5iV", "Errors.IAM.MailMessageTemplate.Invalid")
+	}
+	existingTemplate := NewIAMMailMessageTemplateWriteModel(messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "IAM-Mt5nF", "Errors.IAM.MailMessageTemplate.NotFound")
+	}
+
+	iamAgg := IAMAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam_repo.NewMailMessageTemplateResetEvent(ctx, iamAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/eventstore"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type IAMMailMessageTemplateWriteModel struct {
+	MailMessageTemplateWriteModel
+}
+
+func NewIAMMailMessageTemplateWriteModel(messageType string) *IAMMailMessageTemplateWriteModel {
+	return &IAMMailMessageTemplateWriteModel{
+		MailMessageTemplateWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   domain.IAMID,
+				ResourceOwner: domain.IAMID,
+			},
+			MessageType: messageType,
+		},
+	}
+}
+
+func (wm *IAMMailMessageTemplateWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *iam.MailMessageTemplateSetEvent:
+			wm.MailMessageTemplateWriteModel.AppendEvents(&e.MailMessageTemplateSetEvent)
+		case *iam.MailMessageTemplateResetEvent:
+			wm.MailMessageTemplateWriteModel.AppendEvents(&e.MailMessageTemplateResetEvent)
+		}
+	}
+}
+
+func (wm *IAMMailMessageTemplateWriteModel) Reduce() error {
+	return wm.MailMessageTemplateWriteModel.Reduce()
+}
+
+func (wm *IAMMailMessageTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(iam.AggregateType).
+		AggregateIDs(wm.MailMessageTemplateWriteModel.AggregateID).
+		EventTypes(
+			iam.MailMessageTemplateSetEventType,
+			iam.MailMessageTemplateResetEventType).
+		Builder()
+}
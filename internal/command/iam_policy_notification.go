@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	iam_repo "github.com/caos/zitadel/internal/repository/iam"
+)
+
+func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, policy *domain.NotificationPolicy) (*domain.NotificationPolicy, error) {
+	addedPolicy := NewIAMNotificationPolicyWriteModel()
+	iamAgg := IAMAggregateFromWriteModel(&addedPolicy.WriteModel)
+	event, err := c.addDefaultNotificationPolicy(ctx, iamAgg, addedPolicy, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToNotificationPolicy(&addedPolicy.NotificationPolicyWriteModel), nil
+}
+
+func (c *Commands) addDefaultNotificationPolicy(ctx context.Context, iamAgg *eventstore.Aggregate, addedPolicy *IAMNotificationPolicyWriteModel, policy *domain.NotificationPolicy) (eventstore.Command, error) {
+	err := c.eventstore.FilterToQueryReducer(ctx, addedPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "IAM-Np4aE", "Errors.IAM.NotificationPolicy.AlreadyExists")
+	}
+
+	return iam_repo.NewNotificationPolicyAddedEvent(
+		ctx,
+		iamAgg,
+		policy.PasswordChanged,
+		policy.MFAAdded,
+		policy.MFARemoved,
+		policy.NewUserAgent,
+		policy.UserLocked,
+		policy.EmailChanged,
+	), nil
+}
+
+func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, policy *domain.NotificationPolicy) (*domain.NotificationPolicy, error) {
+	existingPolicy := NewIAMNotificationPolicyWriteModel()
+	err := c.eventstore.FilterToQueryReducer(ctx, existingPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "IAM-Np4nF", "Errors.IAM.NotificationPolicy.NotFound")
+	}
+
+	iamAgg := IAMAggregateFromWriteModel(&existingPolicy.NotificationPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, iamAgg, policy)
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "IAM-Np4nC", "Errors.IAM.NotificationPolicy.NotChanged")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToNotificationPolicy(&existingPolicy.NotificationPolicyWriteModel), nil
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/caos/zitadel/internal/eventstore"
 
@@ -55,12 +56,28 @@ func (wm *IAMLockoutPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
 func (wm *IAMLockoutPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	maxAttempts uint64,
+	maxAttempts,
+	maxOTPAttempts uint64,
+	lockoutDuration time.Duration,
+	maxIPAttempts uint64,
+	ipThrottleDuration time.Duration,
 	showLockoutFailure bool) (*iam.LockoutPolicyChangedEvent, bool) {
 	changes := make([]policy.LockoutPolicyChanges, 0)
 	if wm.MaxPasswordAttempts != maxAttempts {
 		changes = append(changes, policy.ChangeMaxAttempts(maxAttempts))
 	}
+	if wm.MaxOTPAttempts != maxOTPAttempts {
+		changes = append(changes, policy.ChangeMaxOTPAttempts(maxOTPAttempts))
+	}
+	if wm.LockoutDuration != lockoutDuration {
+		changes = append(changes, policy.ChangeLockoutDuration(lockoutDuration))
+	}
+	if wm.MaxIPAttempts != maxIPAttempts {
+		changes = append(changes, policy.ChangeMaxIPAttempts(maxIPAttempts))
+	}
+	if wm.IPThrottleDuration != ipThrottleDuration {
+		changes = append(changes, policy.ChangeIPThrottleDuration(ipThrottleDuration))
+	}
 	if wm.ShowLockOutFailures != showLockoutFailure {
 		changes = append(changes, policy.ChangeShowLockOutFailures(showLockoutFailure))
 	}
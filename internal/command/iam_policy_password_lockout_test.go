@@ -41,6 +41,10 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 							iam.NewLockoutPolicyAddedEvent(context.Background(),
 								&iam.NewAggregate().Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
@@ -70,6 +74,10 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 								iam.NewLockoutPolicyAddedEvent(context.Background(),
 									&iam.NewAggregate().Aggregate,
 									10,
+									0,
+									0,
+									0,
+									0,
 									true,
 								),
 							),
@@ -162,6 +170,10 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							iam.NewLockoutPolicyAddedEvent(context.Background(),
 								&iam.NewAggregate().Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
@@ -189,6 +201,10 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							iam.NewLockoutPolicyAddedEvent(context.Background(),
 								&iam.NewAggregate().Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+//SetTranslationBundle adds or replaces the texts of a component in the language of the bundle
+// the bundle must contain a text for every key of the reference (the english texts of the component)
+func (c *Commands) SetTranslationBundle(ctx context.Context, bundle *domain.TranslationBundle, reference map[string]string) (*domain.ObjectDetails, error) {
+	if !bundle.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Tb2aI", "Errors.TranslationBundle.Invalid")
+	}
+	if missing := bundle.MissingKeys(reference); len(missing) > 0 {
+		return nil, caos_errs.ThrowInvalidArgument(fmt.Errorf("missing keys: %s", strings.Join(missing, ", ")), "COMMAND-Tb2mK", "Errors.TranslationBundle.MissingKeys")
+	}
+	writeModel, err := c.translationBundleWriteModel(ctx, bundle.Component, bundle.Language)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.TranslationBundleStateActive && reflect.DeepEqual(writeModel.Messages, bundle.Messages) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tb2cN", "Errors.NoChangesFound")
+	}
+	iamAgg := IAMAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam.NewTranslationBundleSetEvent(ctx, iamAgg, bundle.Component, bundle.Language, bundle.Messages))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+//RemoveTranslationBundle removes the uploaded texts of the component in the language
+// the texts shipped with zitadel are used again if the language is supported
+func (c *Commands) RemoveTranslationBundle(ctx context.Context, component domain.TranslationComponent, lang language.Tag) (*domain.ObjectDetails, error) {
+	if !component.Valid() || lang == language.Und {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Tb3aI", "Errors.TranslationBundle.Invalid")
+	}
+	writeModel, err := c.translationBundleWriteModel(ctx, component, lang)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.TranslationBundleStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Tb3nF", "Errors.TranslationBundle.NotFound")
+	}
+	iamAgg := IAMAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, iam.NewTranslationBundleRemovedEvent(ctx, iamAgg, component, lang))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) translationBundleWriteModel(ctx context.Context, component domain.TranslationComponent, lang language.Tag) (*TranslationBundleWriteModel, error) {
+	writeModel := NewTranslationBundleWriteModel(component, lang)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type TranslationBundleWriteModel struct {
+	eventstore.WriteModel
+
+	Component domain.TranslationComponent
+	Language  language.Tag
+	Messages  map[string]string
+	State     domain.TranslationBundleState
+}
+
+func NewTranslationBundleWriteModel(component domain.TranslationComponent, language language.Tag) *TranslationBundleWriteModel {
+	return &TranslationBundleWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   domain.IAMID,
+			ResourceOwner: domain.IAMID,
+		},
+		Component: component,
+		Language:  language,
+	}
+}
+
+func (wm *TranslationBundleWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *iam.TranslationBundleSetEvent:
+			if e.Component == wm.Component && e.Language == wm.Language {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *iam.TranslationBundleRemovedEvent:
+			if e.Component == wm.Component && e.Language == wm.Language {
+				wm.WriteModel.AppendEvents(e)
+			}
+		}
+	}
+}
+
+func (wm *TranslationBundleWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *iam.TranslationBundleSetEvent:
+			wm.Messages = e.Messages
+			wm.State = domain.TranslationBundleStateActive
+		case *iam.TranslationBundleRemovedEvent:
+			wm.Messages = nil
+			wm.State = domain.TranslationBundleStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *TranslationBundleWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(iam.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			iam.TranslationBundleSetEventType,
+			iam.TranslationBundleRemovedEventType).
+		Builder()
+}
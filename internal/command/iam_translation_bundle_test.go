@@ -0,0 +1,341 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+func TestCommandSide_SetTranslationBundle(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		bundle    *domain.TranslationBundle
+		reference map[string]string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	reference := map[string]string{
+		"Login.Title":       "Welcome",
+		"Login.Description": "Login with your account",
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid component, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				bundle: &domain.TranslationBundle{
+					Language: language.French,
+					Messages: map[string]string{
+						"Login.Title":       "Bienvenue",
+						"Login.Description": "Connectez-vous avec votre compte",
+					},
+				},
+				reference: reference,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "missing keys, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				bundle: &domain.TranslationBundle{
+					Component: domain.TranslationComponentLogin,
+					Language:  language.French,
+					Messages: map[string]string{
+						"Login.Title": "Bienvenue",
+					},
+				},
+				reference: reference,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewTranslationBundleSetEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentLogin,
+								language.French,
+								map[string]string{
+									"Login.Title":       "Bienvenue",
+									"Login.Description": "Connectez-vous avec votre compte",
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				bundle: &domain.TranslationBundle{
+					Component: domain.TranslationComponentLogin,
+					Language:  language.French,
+					Messages: map[string]string{
+						"Login.Title":       "Bienvenue",
+						"Login.Description": "Connectez-vous avec votre compte",
+					},
+				},
+				reference: reference,
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set bundle of region variant, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewTranslationBundleSetEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentLogin,
+								language.French,
+								map[string]string{
+									"Login.Title":       "Bienvenue",
+									"Login.Description": "Connectez-vous avec votre compte",
+								},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(iam.NewTranslationBundleSetEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentLogin,
+								language.MustParse("fr-CH"),
+								map[string]string{
+									"Login.Title":       "Bienvenue",
+									"Login.Description": "Connectez-vous avec votre compte",
+									"Login.Unknown":     "inconnu",
+								},
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				bundle: &domain.TranslationBundle{
+					Component: domain.TranslationComponentLogin,
+					Language:  language.MustParse("fr-CH"),
+					Messages: map[string]string{
+						"Login.Title":       "Bienvenue",
+						"Login.Description": "Connectez-vous avec votre compte",
+						"Login.Unknown":     "inconnu",
+					},
+				},
+				reference: reference,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "IAM",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetTranslationBundle(tt.args.ctx, tt.args.bundle, tt.args.reference)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveTranslationBundle(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		component domain.TranslationComponent
+		language  language.Tag
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "language missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				component: domain.TranslationComponentNotification,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "bundle removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewTranslationBundleSetEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentNotification,
+								language.French,
+								map[string]string{"InitCode.Title": "Bienvenue"},
+							),
+						),
+						eventFromEventPusher(
+							iam.NewTranslationBundleRemovedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentNotification,
+								language.French,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				component: domain.TranslationComponentNotification,
+				language:  language.French,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "bundle of other component, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewTranslationBundleSetEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentLogin,
+								language.French,
+								map[string]string{"Login.Title": "Bienvenue"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				component: domain.TranslationComponentNotification,
+				language:  language.French,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove bundle, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewTranslationBundleSetEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentNotification,
+								language.French,
+								map[string]string{"InitCode.Title": "Bienvenue"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(iam.NewTranslationBundleRemovedEvent(context.Background(),
+								&iam.NewAggregate().Aggregate,
+								domain.TranslationComponentNotification,
+								language.French,
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				component: domain.TranslationComponentNotification,
+				language:  language.French,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "IAM",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveTranslationBundle(tt.args.ctx, tt.args.component, tt.args.language)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
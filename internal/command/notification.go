@@ -0,0 +1,185 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/repository/notification"
+)
+
+func (c *Commands) AddNotification(ctx context.Context, resourceOwner string, addNotification *domain.Notification) (_ string, _ *domain.ObjectDetails, err error) {
+	if resourceOwner == "" || !addNotification.IsValid() {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Nf8wq", "Errors.Notification.Invalid")
+	}
+	addNotification.AggregateID, err = c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	notificationModel := NewNotificationWriteModel(addNotification.AggregateID, resourceOwner)
+	notificationAgg := NotificationAggregateFromWriteModel(&notificationModel.WriteModel)
+
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewQueuedEvent(
+		ctx,
+		notificationAgg,
+		addNotification.UserID,
+		addNotification.MessageType,
+		addNotification.Channel,
+		addNotification.Recipient,
+		addNotification.Message,
+		addNotification.IdempotencyKey,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(notificationModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return notificationModel.AggregateID, writeModelToObjectDetails(&notificationModel.WriteModel), nil
+}
+
+func (c *Commands) NotificationSent(ctx context.Context, resourceOwner, notificationID string) (*domain.ObjectDetails, error) {
+	existingNotification, err := c.getDueNotificationWriteModel(ctx, resourceOwner, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	notificationAgg := NotificationAggregateFromWriteModel(&existingNotification.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewSentEvent(ctx, notificationAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingNotification, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingNotification.WriteModel), nil
+}
+
+func (c *Commands) NotificationRetryScheduled(ctx context.Context, resourceOwner, notificationID, deliveryErr string, nextAttempt time.Time) (*domain.ObjectDetails, error) {
+	existingNotification, err := c.getDueNotificationWriteModel(ctx, resourceOwner, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	notificationAgg := NotificationAggregateFromWriteModel(&existingNotification.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewRetryScheduledEvent(ctx, notificationAgg, deliveryErr, nextAttempt))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingNotification, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingNotification.WriteModel), nil
+}
+
+func (c *Commands) NotificationFailed(ctx context.Context, resourceOwner, notificationID, deliveryErr string) (*domain.ObjectDetails, error) {
+	existingNotification, err := c.getDueNotificationWriteModel(ctx, resourceOwner, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	notificationAgg := NotificationAggregateFromWriteModel(&existingNotification.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewFailedEvent(ctx, notificationAgg, deliveryErr))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingNotification, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingNotification.WriteModel), nil
+}
+
+//ResendNotification sends an already sent or failed notification again
+// messages containing a code (e.g. password reset) aren't queued again, as their code might be used or expired,
+// instead a new code is created which the notification handler sends to the current address of the user
+// the other messages are queued again as they are
+func (c *Commands) ResendNotification(ctx context.Context, resourceOwner, notificationID string) (*domain.ObjectDetails, error) {
+	existingNotification, err := c.getNotificationWriteModelByID(ctx, resourceOwner, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if !existingNotification.State.IsFinished() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nf9sk", "Errors.Notification.NotResendable")
+	}
+	if isCodeMessageType(existingNotification.MessageType) {
+		return c.resendCode(ctx, existingNotification)
+	}
+	notificationAgg := NotificationAggregateFromWriteModel(&existingNotification.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewResendRequestedEvent(ctx, notificationAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingNotification, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingNotification.WriteModel), nil
+}
+
+//isCodeMessageType returns true for the messages which contain a code or a link with a code
+func isCodeMessageType(messageType string) bool {
+	switch messageType {
+	case domain.InitCodeMessageType,
+		domain.PasswordResetMessageType,
+		domain.VerifyEmailMessageType,
+		domain.VerifyPhoneMessageType,
+		domain.PasswordlessRegistrationMessageType:
+		return true
+	default:
+		return false
+	}
+}
+
+//resendCode creates a new code of the message type of the notification
+func (c *Commands) resendCode(ctx context.Context, existingNotification *NotificationWriteModel) (*domain.ObjectDetails, error) {
+	userID, resourceOwner := existingNotification.UserID, existingNotification.ResourceOwner
+	switch existingNotification.MessageType {
+	case domain.InitCodeMessageType:
+		return c.ResendInitialMail(ctx, userID, "", resourceOwner)
+	case domain.PasswordResetMessageType:
+		return c.RequestSetPassword(ctx, userID, resourceOwner, existingNotification.Channel)
+	case domain.VerifyEmailMessageType:
+		return c.CreateHumanEmailVerificationCode(ctx, userID, resourceOwner)
+	case domain.VerifyPhoneMessageType:
+		return c.CreateHumanPhoneVerificationCode(ctx, userID, resourceOwner)
+	case domain.PasswordlessRegistrationMessageType:
+		initCode, err := c.HumanSendPasswordlessInitCode(ctx, userID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.ObjectDetails{
+			Sequence:      initCode.Sequence,
+			EventDate:     initCode.ChangeDate,
+			ResourceOwner: initCode.ResourceOwner,
+		}, nil
+	}
+	return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nf2rc", "Errors.Notification.NotResendable")
+}
+
+func (c *Commands) getDueNotificationWriteModel(ctx context.Context, resourceOwner, notificationID string) (*NotificationWriteModel, error) {
+	existingNotification, err := c.getNotificationWriteModelByID(ctx, resourceOwner, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if !existingNotification.State.IsDue() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nf7sp", "Errors.Notification.NotDue")
+	}
+	return existingNotification, nil
+}
+
+func (c *Commands) getNotificationWriteModelByID(ctx context.Context, resourceOwner, notificationID string) (*NotificationWriteModel, error) {
+	if notificationID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Nf6ab", "Errors.IDMissing")
+	}
+	notificationModel := NewNotificationWriteModel(notificationID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, notificationModel)
+	if err != nil {
+		return nil, err
+	}
+	if !notificationModel.State.Valid() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Nf5qz", "Errors.Notification.NotFound")
+	}
+	return notificationModel, nil
+}
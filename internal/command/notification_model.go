@@ -0,0 +1,84 @@
+package command
+
+import (
+	"time"
+
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/notification"
+)
+
+type NotificationWriteModel struct {
+	eventstore.WriteModel
+
+	UserID      string
+	MessageType string
+	Channel     domain.NotificationType
+	Recipient   string
+	Message     *crypto.CryptoValue
+	State       domain.NotificationState
+	Attempts    uint64
+	LastError   string
+	NextAttempt time.Time
+}
+
+func NewNotificationWriteModel(notificationID, resourceOwner string) *NotificationWriteModel {
+	return &NotificationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   notificationID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.QueuedEvent:
+			wm.UserID = e.UserID
+			wm.MessageType = e.MessageType
+			wm.Channel = e.Channel
+			wm.Recipient = e.Recipient
+			wm.Message = e.Message
+			wm.State = domain.NotificationStateQueued
+		case *notification.SentEvent:
+			wm.State = domain.NotificationStateSent
+			wm.Attempts++
+			wm.LastError = ""
+		case *notification.RetryScheduledEvent:
+			wm.State = domain.NotificationStateRetrying
+			wm.Attempts++
+			wm.LastError = e.Error
+			wm.NextAttempt = e.NextAttempt
+		case *notification.FailedEvent:
+			wm.State = domain.NotificationStateFailed
+			wm.Attempts++
+			wm.LastError = e.Error
+		case *notification.ResendRequestedEvent:
+			wm.State = domain.NotificationStateQueued
+			wm.Attempts = 0
+			wm.LastError = ""
+			wm.NextAttempt = time.Time{}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(notification.QueuedEventType,
+			notification.SentEventType,
+			notification.RetryScheduledEventType,
+			notification.FailedEventType,
+			notification.ResendRequestedEventType).
+		Builder()
+}
+
+func NotificationAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, notification.AggregateType, notification.AggregateVersion)
+}
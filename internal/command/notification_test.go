@@ -0,0 +1,499 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/id"
+	"github.com/caos/zitadel/internal/id/mock"
+	"github.com/caos/zitadel/internal/repository/notification"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+func testNotificationMessage() *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("message"),
+	}
+}
+
+func testNotificationQueuedEvent(id, messageType, idempotencyKey string) *notification.QueuedEvent {
+	return notification.NewQueuedEvent(context.Background(),
+		&notification.NewAggregate(id, "org1").Aggregate,
+		"user1",
+		messageType,
+		domain.NotificationTypeEmail,
+		"user@caos.ch",
+		testNotificationMessage(),
+		idempotencyKey,
+	)
+}
+
+func TestCommands_AddNotification(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx             context.Context
+		resourceOwner   string
+		addNotification *domain.Notification
+	}
+	type res struct {
+		id      string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no recipient, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				addNotification: &domain.Notification{
+					UserID:  "user1",
+					Channel: domain.NotificationTypeEmail,
+					Message: testNotificationMessage(),
+				},
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								testNotificationQueuedEvent("id1", domain.InitCodeMessageType, ""),
+							),
+						},
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				addNotification: &domain.Notification{
+					UserID:      "user1",
+					MessageType: "InitCode",
+					Channel:     domain.NotificationTypeEmail,
+					Recipient:   "user@caos.ch",
+					Message:     testNotificationMessage(),
+				},
+			},
+			res{
+				id: "id1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"idempotency key already queued, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						errors.ThrowAlreadyExists(nil, "ERROR", "already queued"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								testNotificationQueuedEvent("id1", domain.InitCodeMessageType, "key1"),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(notification.NewAddIdempotencyKeyUniqueConstraint("key1")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				addNotification: &domain.Notification{
+					UserID:         "user1",
+					MessageType:    "InitCode",
+					Channel:        domain.NotificationTypeEmail,
+					Recipient:      "user@caos.ch",
+					Message:        testNotificationMessage(),
+					IdempotencyKey: "key1",
+				},
+			},
+			res{
+				err: errors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"push with idempotency key ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								testNotificationQueuedEvent("id1", domain.InitCodeMessageType, "key1"),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(notification.NewAddIdempotencyKeyUniqueConstraint("key1")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				addNotification: &domain.Notification{
+					UserID:         "user1",
+					MessageType:    "InitCode",
+					Channel:        domain.NotificationTypeEmail,
+					Recipient:      "user@caos.ch",
+					Message:        testNotificationMessage(),
+					IdempotencyKey: "key1",
+				},
+			},
+			res{
+				id: "id1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, details, err := c.AddNotification(tt.args.ctx, tt.args.resourceOwner, tt.args.addNotification)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_NotificationRetryScheduled(t *testing.T) {
+	nextAttempt := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx            context.Context
+		resourceOwner  string
+		notificationID string
+		deliveryErr    string
+		nextAttempt    time.Time
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				notificationID: "id1",
+				deliveryErr:    "smtp unavailable",
+				nextAttempt:    nextAttempt,
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"already sent, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							testNotificationQueuedEvent("id1", domain.InitCodeMessageType, ""),
+						),
+						eventFromEventPusher(
+							notification.NewSentEvent(context.Background(),
+								&notification.NewAggregate("id1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				notificationID: "id1",
+				deliveryErr:    "smtp unavailable",
+				nextAttempt:    nextAttempt,
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"retry scheduled, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							testNotificationQueuedEvent("id1", domain.InitCodeMessageType, ""),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								notification.NewRetryScheduledEvent(context.Background(),
+									&notification.NewAggregate("id1", "org1").Aggregate,
+									"smtp unavailable",
+									nextAttempt,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				notificationID: "id1",
+				deliveryErr:    "smtp unavailable",
+				nextAttempt:    nextAttempt,
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.NotificationRetryScheduled(tt.args.ctx, tt.args.resourceOwner, tt.args.notificationID, tt.args.deliveryErr, tt.args.nextAttempt)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ResendNotification(t *testing.T) {
+	type fields struct {
+		eventstore      *eventstore.Eventstore
+		secretGenerator crypto.Generator
+	}
+	type args struct {
+		ctx            context.Context
+		resourceOwner  string
+		notificationID string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"still queued, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							testNotificationQueuedEvent("id1", domain.InitCodeMessageType, ""),
+						),
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				notificationID: "id1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"failed notification, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							testNotificationQueuedEvent("id1", domain.PasswordChangedMessageType, ""),
+						),
+						eventFromEventPusher(
+							notification.NewFailedEvent(context.Background(),
+								&notification.NewAggregate("id1", "org1").Aggregate,
+								"smtp unavailable",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								notification.NewResendRequestedEvent(context.Background(),
+									&notification.NewAggregate("id1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				notificationID: "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"failed code notification, new code",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							testNotificationQueuedEvent("id1", domain.PasswordResetMessageType, ""),
+						),
+						eventFromEventPusher(
+							notification.NewFailedEvent(context.Background(),
+								&notification.NewAggregate("id1", "org1").Aggregate,
+								"smtp unavailable",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanInitializedCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanPasswordCodeAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+									time.Hour*1,
+									domain.NotificationTypeEmail,
+								),
+							),
+						},
+					),
+				),
+				secretGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				notificationID: "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:               tt.fields.eventstore,
+				passwordVerificationCode: tt.fields.secretGenerator,
+			}
+			details, err := c.ResendNotification(tt.args.ctx, tt.args.resourceOwner, tt.args.notificationID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
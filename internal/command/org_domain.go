@@ -151,6 +151,63 @@ func (c *Commands) SetPrimaryOrgDomain(ctx context.Context, orgDomain *domain.Or
 	return writeModelToObjectDetails(&domainWriteModel.WriteModel), nil
 }
 
+//SetOrgLoginDomain allows the verified domain to be used as host of the login ui and the oidc endpoints
+// the login resolves the organisation from the host
+func (c *Commands) SetOrgLoginDomain(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
+	if orgDomain == nil || !orgDomain.IsValid() || orgDomain.AggregateID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Lg2aI", "Errors.Org.InvalidDomain")
+	}
+	domainWriteModel, err := c.getOrgDomainWriteModel(ctx, orgDomain.AggregateID, orgDomain.Domain)
+	if err != nil {
+		return nil, err
+	}
+	if domainWriteModel.State != domain.OrgDomainStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Lg2nF", "Errors.Org.DomainNotOnOrg")
+	}
+	if !domainWriteModel.Verified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Lg2nV", "Errors.Org.DomainNotVerified")
+	}
+	if domainWriteModel.LoginDomain {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Lg2aL", "Errors.Org.DomainAlreadyLoginDomain")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&domainWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainLoginSetEvent(ctx, orgAgg, orgDomain.Domain))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(domainWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&domainWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveOrgLoginDomain(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
+	if orgDomain == nil || !orgDomain.IsValid() || orgDomain.AggregateID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Lg3aI", "Errors.Org.InvalidDomain")
+	}
+	domainWriteModel, err := c.getOrgDomainWriteModel(ctx, orgDomain.AggregateID, orgDomain.Domain)
+	if err != nil {
+		return nil, err
+	}
+	if domainWriteModel.State != domain.OrgDomainStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Lg3nF", "Errors.Org.DomainNotOnOrg")
+	}
+	if !domainWriteModel.LoginDomain {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Lg3nL", "Errors.Org.DomainNotLoginDomain")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&domainWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewDomainLoginRemovedEvent(ctx, orgAgg, orgDomain.Domain))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(domainWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&domainWriteModel.WriteModel), nil
+}
+
 func (c *Commands) RemoveOrgDomain(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
 	if orgDomain == nil || !orgDomain.IsValid() || orgDomain.AggregateID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-SJsK3", "Errors.Org.InvalidDomain")
@@ -15,6 +15,7 @@ type OrgDomainWriteModel struct {
 	ValidationCode *crypto.CryptoValue
 	Primary        bool
 	Verified       bool
+	LoginDomain    bool
 
 	State domain.OrgDomainState
 }
@@ -54,6 +55,16 @@ func (wm *OrgDomainWriteModel) AppendEvents(events ...eventstore.Event) {
 			wm.WriteModel.AppendEvents(e)
 		case *org.DomainPrimarySetEvent:
 			wm.WriteModel.AppendEvents(e)
+		case *org.DomainLoginSetEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.DomainLoginRemovedEvent:
+			if e.Domain != wm.Domain {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		case *org.DomainRemovedEvent:
 			if e.Domain != wm.Domain {
 				continue
@@ -76,10 +87,15 @@ func (wm *OrgDomainWriteModel) Reduce() error {
 			wm.Verified = true
 		case *org.DomainPrimarySetEvent:
 			wm.Primary = e.Domain == wm.Domain
+		case *org.DomainLoginSetEvent:
+			wm.LoginDomain = true
+		case *org.DomainLoginRemovedEvent:
+			wm.LoginDomain = false
 		case *org.DomainRemovedEvent:
 			wm.State = domain.OrgDomainStateRemoved
 			wm.Verified = false
 			wm.Primary = false
+			wm.LoginDomain = false
 			wm.ValidationType = domain.OrgDomainValidationTypeUnspecified
 			wm.ValidationCode = nil
 		}
@@ -99,6 +115,8 @@ func (wm *OrgDomainWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.OrgDomainVerificationAddedEventType,
 			org.OrgDomainVerifiedEventType,
 			org.OrgDomainPrimarySetEventType,
+			org.OrgDomainLoginSetEventType,
+			org.OrgDomainLoginRemovedEventType,
 			org.OrgDomainRemovedEventType).
 		Builder()
 }
@@ -123,6 +123,13 @@ func (c *Commands) ensureOrgSettingsToFeatures(ctx context.Context, orgID string
 		return nil, err
 	}
 	events = append(events, labelPolicyEvents...)
+	if !features.LabelPolicyPrivateLabel {
+		removeLoginTemplateEvents, err := c.removeOrgLoginTemplatesIfExists(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, removeLoginTemplateEvents...)
+	}
 
 	if !features.CustomDomain {
 		removeCustomDomainsEvents, err := c.removeCustomDomains(ctx, orgID)
@@ -141,6 +148,11 @@ func (c *Commands) ensureOrgSettingsToFeatures(ctx context.Context, orgID string
 		if removeCustomMessageTextEvents != nil {
 			events = append(events, removeCustomMessageTextEvents...)
 		}
+		removeMailMessageTemplateEvents, err := c.removeOrgMailMessageTemplatesIfExists(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, removeMailMessageTemplateEvents...)
 	}
 	if !features.CustomTextLogin {
 		removeCustomLoginTextEvents, err := c.removeOrgLoginTextsIfExists(ctx, orgID)
@@ -200,6 +200,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewOrgAddedEvent(
@@ -242,6 +243,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							iam.NewCustomTextSetEvent(
@@ -271,6 +273,10 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 								context.Background(),
 								&iam.NewAggregate().Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
@@ -379,6 +385,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewOrgAddedEvent(
@@ -442,6 +449,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							iam.NewCustomTextSetEvent(
@@ -471,6 +479,10 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 								context.Background(),
 								&iam.NewAggregate().Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
@@ -584,6 +596,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewOrgAddedEvent(
@@ -654,6 +667,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							iam.NewCustomTextSetEvent(
@@ -683,6 +697,10 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 								context.Background(),
 								&iam.NewAggregate().Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
@@ -799,6 +817,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewOrgAddedEvent(
@@ -876,6 +895,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							iam.NewCustomTextSetEvent(
@@ -905,6 +925,10 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 								context.Background(),
 								&iam.NewAggregate().Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
@@ -1110,6 +1134,8 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 					//removeLabelPolicy
 					expectFilter(),
 					//end setDefaultAuthFactorsInCustomLoginPolicy
+					//removeLoginTemplates
+					expectFilter(),
 					//removeCustomDomains
 					expectFilter(
 						eventFromEventPusher(
@@ -1153,6 +1179,16 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailMessageTemplateSetEvent(
+								context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								domain.InitCodeMessageType,
+								[]byte("template"),
+							),
+						),
+					),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewCustomTextSetEvent(
@@ -1182,6 +1218,10 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 								context.Background(),
 								&org.NewAggregate("org1", "org1").Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
@@ -1211,6 +1251,9 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							eventFromEventPusher(
 								org.NewCustomTextTemplateRemovedEvent(context.Background(), &org.NewAggregate("org1", "org1").Aggregate, domain.InitCodeMessageType, language.English),
 							),
+							eventFromEventPusher(
+								org.NewMailMessageTemplateResetEvent(context.Background(), &org.NewAggregate("org1", "org1").Aggregate, domain.InitCodeMessageType),
+							),
 							eventFromEventPusher(
 								org.NewCustomTextTemplateRemovedEvent(context.Background(), &org.NewAggregate("org1", "org1").Aggregate, domain.LoginCustomText, language.English),
 							),
@@ -1318,6 +1361,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewOrgAddedEvent(
@@ -1360,6 +1404,7 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							iam.NewCustomTextSetEvent(
@@ -1389,6 +1434,10 @@ func TestCommandSide_SetOrgFeatures(t *testing.T) {
 								context.Background(),
 								&iam.NewAggregate().Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
@@ -1578,6 +1627,7 @@ func TestCommandSide_RemoveOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							org.NewOrgAddedEvent(
@@ -1620,6 +1670,7 @@ func TestCommandSide_RemoveOrgFeatures(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							iam.NewCustomTextSetEvent(
@@ -1649,6 +1700,10 @@ func TestCommandSide_RemoveOrgFeatures(t *testing.T) {
 								context.Background(),
 								&iam.NewAggregate().Aggregate,
 								5,
+								0,
+								0,
+								0,
+								0,
 								false,
 							),
 						),
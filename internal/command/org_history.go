@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+//OrgAt returns the organisation as it was at the point in time
+func (c *Commands) OrgAt(ctx context.Context, orgID string, point eventstore.PointInTime) (*domain.Org, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Or2aI", "Errors.Org.Invalid")
+	}
+	writeModel, err := c.orgWriteModelAt(ctx, orgID, point)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.OrgStateUnspecified || writeModel.State == domain.OrgStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Or2nF", "Errors.Org.NotFound")
+	}
+	return orgWriteModelToOrg(writeModel), nil
+}
+
+//RevertOrg changes the name of the organisation back to the name at the point in time
+// the default domain is changed as well, if it's derived from the name
+func (c *Commands) RevertOrg(ctx context.Context, orgID string, point eventstore.PointInTime) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Or3aI", "Errors.Org.Invalid")
+	}
+	previous, err := c.orgWriteModelAt(ctx, orgID, point)
+	if err != nil {
+		return nil, err
+	}
+	if previous.State == domain.OrgStateUnspecified || previous.State == domain.OrgStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Or3nF", "Errors.Org.NotFound")
+	}
+	existingOrg, err := c.getOrgWriteModelByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if existingOrg.State == domain.OrgStateUnspecified || existingOrg.State == domain.OrgStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Or4nF", "Errors.Org.NotFound")
+	}
+	if existingOrg.Name == previous.Name {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Or2cN", "Errors.NoChangesFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingOrg.WriteModel)
+	events := []eventstore.Command{org.NewOrgChangedEvent(ctx, orgAgg, existingOrg.Name, previous.Name)}
+	changeDomainEvents, err := c.changeDefaultDomain(ctx, orgID, previous.Name)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, changeDomainEvents...)
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOrg, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOrg.WriteModel), nil
+}
+
+func (c *Commands) orgWriteModelAt(ctx context.Context, orgID string, point eventstore.PointInTime) (*OrgWriteModel, error) {
+	writeModel := NewOrgWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducerAt(ctx, writeModel, point)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/renderer"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+const loginTemplateContentType = "text/html"
+
+//SetOrgLoginTemplate validates the template and stores it as asset,
+// the login renders it instead of the default template for the organisation
+func (c *Commands) SetOrgLoginTemplate(ctx context.Context, resourceOwner string, loginTemplate *domain.LoginTemplate) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Tp7oR", "Errors.ResourceOwnerMissing")
+	}
+	if !loginTemplate.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Tp7iV", "Errors.Org.LoginTemplate.Invalid")
+	}
+	if err := renderer.ValidateTemplate(loginTemplate.Template, string(loginTemplate.Content), domain.LoginTemplateFuncs); err != nil {
+		return nil, err
+	}
+	existingTemplate := NewOrgLoginTemplateWriteModel(resourceOwner, loginTemplate.Template)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	suffixID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	asset, err := c.UploadAsset(ctx,
+		resourceOwner,
+		domain.LoginTemplatePath+"/"+loginTemplate.Template+"-"+suffixID,
+		loginTemplateContentType,
+		bytes.NewReader(loginTemplate.Content),
+		int64(len(loginTemplate.Content)),
+	)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ORG-Tp7uP", "Errors.Assets.Object.PutFailed")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewLoginTemplateSetEvent(ctx, orgAgg, loginTemplate.Template, asset.Key))
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.AssetKey != "" {
+		err = c.RemoveAsset(ctx, resourceOwner, existingTemplate.AssetKey)
+		logging.LogWithFields("ORG-Tp7rA", "orgID", resourceOwner, "template", loginTemplate.Template).OnError(err).Warn("unable to remove asset of replaced login template")
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+//RemoveOrgLoginTemplate removes the template and its asset,
+// the login renders the default template for the organisation afterwards
+func (c *Commands) RemoveOrgLoginTemplate(ctx context.Context, resourceOwner, template string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Tp8oR", "Errors.ResourceOwnerMissing")
+	}
+	if !domain.IsLoginTemplate(template) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Tp8iV", "Errors.Org.LoginTemplate.Invalid")
+	}
+	existingTemplate := NewOrgLoginTemplateWriteModel(resourceOwner, template)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Tp8nF", "Errors.Org.LoginTemplate.NotFound")
+	}
+	err = c.RemoveAsset(ctx, resourceOwner, existingTemplate.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewLoginTemplateRemovedEvent(ctx, orgAgg, template))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+func (c *Commands) removeOrgLoginTemplatesIfExists(ctx context.Context, orgID string) ([]eventstore.Command, error) {
+	existingTemplates := NewOrgLoginTemplatesWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplates)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingTemplates.Templates) == 0 {
+		return nil, nil
+	}
+	err = c.RemoveAssetsFolder(ctx, orgID, domain.LoginTemplatePath+"/", true)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplates.WriteModel)
+	templates := make([]string, 0, len(existingTemplates.Templates))
+	for template := range existingTemplates.Templates {
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+	events := make([]eventstore.Command, 0, len(templates))
+	for _, template := range templates {
+		events = append(events, org.NewLoginTemplateRemovedEvent(ctx, orgAgg, template))
+	}
+	return events, nil
+}
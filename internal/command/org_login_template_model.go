@@ -0,0 +1,99 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+type OrgLoginTemplateWriteModel struct {
+	eventstore.WriteModel
+
+	Template string
+	AssetKey string
+
+	State domain.PolicyState
+}
+
+func NewOrgLoginTemplateWriteModel(orgID, template string) *OrgLoginTemplateWriteModel {
+	return &OrgLoginTemplateWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		Template: template,
+	}
+}
+
+func (wm *OrgLoginTemplateWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.LoginTemplateSetEvent:
+			if e.Template != wm.Template {
+				continue
+			}
+			wm.AssetKey = e.AssetKey
+			wm.State = domain.PolicyStateActive
+		case *org.LoginTemplateRemovedEvent:
+			if e.Template != wm.Template {
+				continue
+			}
+			wm.AssetKey = ""
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgLoginTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.LoginTemplateSetEventType,
+			org.LoginTemplateRemovedEventType).
+		Builder()
+}
+
+//OrgLoginTemplatesWriteModel holds the templates of the organisation and their asset keys
+type OrgLoginTemplatesWriteModel struct {
+	eventstore.WriteModel
+
+	Templates map[string]string
+}
+
+func NewOrgLoginTemplatesWriteModel(orgID string) *OrgLoginTemplatesWriteModel {
+	return &OrgLoginTemplatesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		Templates: make(map[string]string),
+	}
+}
+
+func (wm *OrgLoginTemplatesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.LoginTemplateSetEvent:
+			wm.Templates[e.Template] = e.AssetKey
+		case *org.LoginTemplateRemovedEvent:
+			delete(wm.Templates, e.Template)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgLoginTemplatesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.LoginTemplateSetEventType,
+			org.LoginTemplateRemovedEventType).
+		Builder()
+}
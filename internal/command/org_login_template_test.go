@@ -0,0 +1,398 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/id"
+	id_mock "github.com/caos/zitadel/internal/id/mock"
+	"github.com/caos/zitadel/internal/repository/org"
+	"github.com/caos/zitadel/internal/static"
+	"github.com/caos/zitadel/internal/static/mock"
+)
+
+func TestCommandSide_SetOrgLoginTemplate(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		storage     static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		loginTemplate *domain.LoginTemplate
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{template "main-top" .}}{{template "main-bottom" .}}`),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown template, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "unknown.html",
+					Content:  []byte(`<div></div>`),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not parsable, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{if .}}`),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown function, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{ env "SECRET" }}`),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "builtin call used, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{ call .Func }}`),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "upload failed, internal error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				storage:     mock.NewStorage(t).ExpectAddObjectError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{template "main-top" .}}{{t "Login.Title"}}{{template "main-bottom" .}}`),
+				},
+			},
+			res: res{
+				err: caos_errs.IsInternal,
+			},
+		},
+		{
+			name: "set template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewLoginTemplateSetEvent(context.Background(),
+									&org.NewAggregate("org1", "org1").Aggregate,
+									"login.html",
+									"login/templates/login.html-id1",
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				storage:     mock.NewStorage(t).ExpectAddObjectWithKey("login/templates/login.html-id1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{template "main-top" .}}{{t "Login.Title"}}{{template "main-bottom" .}}`),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "replace template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								"login.html",
+								"login/templates/login.html-id0",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewLoginTemplateSetEvent(context.Background(),
+									&org.NewAggregate("org1", "org1").Aggregate,
+									"login.html",
+									"login/templates/login.html-id1",
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				storage: mock.NewStorage(t).
+					ExpectAddObjectWithKey("login/templates/login.html-id1").
+					ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				loginTemplate: &domain.LoginTemplate{
+					Template: "login.html",
+					Content:  []byte(`{{template "main-top" .}}{{t "Login.Title"}}{{template "main-bottom" .}}`),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+				static:      tt.fields.storage,
+			}
+			got, err := r.SetOrgLoginTemplate(tt.args.ctx, tt.args.resourceOwner, tt.args.loginTemplate)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgLoginTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		template      string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				template: "login.html",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown template, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template:      "unknown.html",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								"login.html",
+								"login/templates/login.html-id1",
+							),
+						),
+						eventFromEventPusher(
+							org.NewLoginTemplateRemovedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								"login.html",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template:      "login.html",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								"login.html",
+								"login/templates/login.html-id1",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewLoginTemplateRemovedEvent(context.Background(),
+									&org.NewAggregate("org1", "org1").Aggregate,
+									"login.html",
+								),
+							),
+						},
+					),
+				),
+				storage: mock.NewStorage(t).ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template:      "login.html",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.storage,
+			}
+			got, err := r.RemoveOrgLoginTemplate(tt.args.ctx, tt.args.resourceOwner, tt.args.template)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -42,10 +42,14 @@ func (c *Commands) addOrgMember(ctx context.Context, orgAgg *eventstore.Aggregat
 	if !member.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-W8m4l", "Errors.Org.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 && len(domain.CheckForInvalidRoles(member.Roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.OrgRolePrefix, domain.RoleSelfManagementGlobal)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid")
 	}
-	err := c.eventstore.FilterToQueryReducer(ctx, addedMember)
+	err = c.eventstore.FilterToQueryReducer(ctx, addedMember)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +65,11 @@ func (c *Commands) ChangeOrgMember(ctx context.Context, member *domain.Member) (
 	if !member.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-LiaZi", "Errors.Org.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.OrgRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "IAM-m9fG8", "Errors.Org.MemberInvalid")
 	}
 
@@ -100,6 +100,7 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -343,6 +344,7 @@ func TestCommandSide_ChangeOrgMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/repository/org"
@@ -55,6 +57,39 @@ func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+func (wm *OrgWriteModel) SnapshotName() string {
+	return "org_write_model"
+}
+
+func (wm *OrgWriteModel) SnapshotVersion() uint32 {
+	return 2
+}
+
+type orgWriteModelSnapshot struct {
+	Name          string          `json:"name"`
+	State         domain.OrgState `json:"state"`
+	PrimaryDomain string          `json:"primaryDomain"`
+}
+
+func (wm *OrgWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(&orgWriteModelSnapshot{
+		Name:          wm.Name,
+		State:         wm.State,
+		PrimaryDomain: wm.PrimaryDomain,
+	})
+}
+
+func (wm *OrgWriteModel) UnmarshalSnapshot(data []byte) error {
+	snapshot := new(orgWriteModelSnapshot)
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+	wm.Name = snapshot.Name
+	wm.State = snapshot.State
+	wm.PrimaryDomain = snapshot.PrimaryDomain
+	return nil
+}
+
 func OrgAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
 	return eventstore.AggregateFromWriteModel(wm, org.AggregateType, org.AggregateVersion)
 }
@@ -62,6 +62,10 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1", "org1").Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
@@ -92,6 +96,10 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 								org.NewLockoutPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1", "org1").Aggregate,
 									10,
+									0,
+									0,
+									0,
+									0,
 									true,
 								),
 							),
@@ -205,6 +213,10 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1", "org1").Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
@@ -233,6 +245,10 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1", "org1").Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
@@ -343,6 +359,10 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1", "org1").Aggregate,
 								10,
+								0,
+								0,
+								0,
+								0,
 								true,
 							),
 						),
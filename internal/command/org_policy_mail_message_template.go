@@ -0,0 +1,86 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+//SetOrgMailMessageTemplate sets the template which replaces the mail template of the organisation
+// for the emails of the message type
+func (c *Commands) SetOrgMailMessageTemplate(ctx context.Context, resourceOwner string, messageTemplate *domain.MailMessageTemplate) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt4oR", "Errors.ResourceOwnerMissing")
+	}
+	if !messageTemplate.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt4iV", "Errors.Org.MailMessageTemplate.Invalid")
+	}
+	existingTemplate := NewOrgMailMessageTemplateWriteModel(resourceOwner, messageTemplate.MessageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State == domain.PolicyStateActive && bytes.Equal(existingTemplate.Template, messageTemplate.Template) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Mt4nC", "Errors.Org.MailMessageTemplate.NotChanged")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailMessageTemplateSetEvent(ctx, orgAgg, messageTemplate.MessageType, messageTemplate.Template))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+//ResetOrgMailMessageTemplate removes the template of the message type,
+// the emails are sent with the default template of the message type or the mail template afterwards
+func (c *Commands) ResetOrgMailMessageTemplate(ctx context.Context, resourceOwner, messageType string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt5oR", "Errors.ResourceOwnerMissing")
+	}
+	if !domain.IsMailMessageType(messageType) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORG-Mt5iV", "Errors.Org.MailMessageTemplate.Invalid")
+	}
+	existingTemplate := NewOrgMailMessageTemplateWriteModel(resourceOwner, messageType)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.State != domain.PolicyStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Mt5nF", "Errors.Org.MailMessageTemplate.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplate.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailMessageTemplateResetEvent(ctx, orgAgg, messageType))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingTemplate, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingTemplate.WriteModel), nil
+}
+
+func (c *Commands) removeOrgMailMessageTemplatesIfExists(ctx context.Context, orgID string) ([]eventstore.Command, error) {
+	existingTemplates := NewOrgMailMessageTemplatesWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingTemplates.WriteModel)
+	events := make([]eventstore.Command, 0, len(existingTemplates.MessageTypes))
+	for _, messageType := range existingTemplates.MessageTypes {
+		events = append(events, org.NewMailMessageTemplateResetEvent(ctx, orgAgg, messageType))
+	}
+	return events, nil
+}
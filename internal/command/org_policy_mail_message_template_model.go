@@ -0,0 +1,92 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/eventstore"
+
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+type OrgMailMessageTemplateWriteModel struct {
+	MailMessageTemplateWriteModel
+}
+
+func NewOrgMailMessageTemplateWriteModel(orgID, messageType string) *OrgMailMessageTemplateWriteModel {
+	return &OrgMailMessageTemplateWriteModel{
+		MailMessageTemplateWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			MessageType: messageType,
+		},
+	}
+}
+
+func (wm *OrgMailMessageTemplateWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.MailMessageTemplateSetEvent:
+			wm.MailMessageTemplateWriteModel.AppendEvents(&e.MailMessageTemplateSetEvent)
+		case *org.MailMessageTemplateResetEvent:
+			wm.MailMessageTemplateWriteModel.AppendEvents(&e.MailMessageTemplateResetEvent)
+		}
+	}
+}
+
+func (wm *OrgMailMessageTemplateWriteModel) Reduce() error {
+	return wm.MailMessageTemplateWriteModel.Reduce()
+}
+
+func (wm *OrgMailMessageTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.MailMessageTemplateWriteModel.AggregateID).
+		EventTypes(
+			org.MailMessageTemplateSetEventType,
+			org.MailMessageTemplateResetEventType).
+		Builder()
+}
+
+type OrgMailMessageTemplatesWriteModel struct {
+	MailMessageTemplatesWriteModel
+}
+
+func NewOrgMailMessageTemplatesWriteModel(orgID string) *OrgMailMessageTemplatesWriteModel {
+	return &OrgMailMessageTemplatesWriteModel{
+		MailMessageTemplatesWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgMailMessageTemplatesWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.MailMessageTemplateSetEvent:
+			wm.MailMessageTemplatesWriteModel.AppendEvents(&e.MailMessageTemplateSetEvent)
+		case *org.MailMessageTemplateResetEvent:
+			wm.MailMessageTemplatesWriteModel.AppendEvents(&e.MailMessageTemplateResetEvent)
+		}
+	}
+}
+
+func (wm *OrgMailMessageTemplatesWriteModel) Reduce() error {
+	return wm.MailMessageTemplatesWriteModel.Reduce()
+}
+
+func (wm *OrgMailMessageTemplatesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.MailMessageTemplatesWriteModel.AggregateID).
+		EventTypes(
+			org.MailMessageTemplateSetEventType,
+			org.MailMessageTemplateResetEventType).
+		Builder()
+}
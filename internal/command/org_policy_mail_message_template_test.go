@@ -0,0 +1,300 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+func TestCommandSide_SetOrgMailMessageTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		orgID    string
+		template *domain.MailMessageTemplate
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sms message type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.VerifyPhoneMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not parsable, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not changed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailMessageTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								domain.InitCodeMessageType,
+								[]byte("<html>{{.Text}}</html>"),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailMessageTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								domain.PasswordResetMessageType,
+								[]byte("<html>{{.Text}}</html>"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewMailMessageTemplateSetEvent(context.Background(),
+									&org.NewAggregate("org1", "org1").Aggregate,
+									domain.InitCodeMessageType,
+									[]byte("<html>{{.Text}}</html>"),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				template: &domain.MailMessageTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Template:    []byte("<html>{{.Text}}</html>"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetOrgMailMessageTemplate(tt.args.ctx, tt.args.orgID, tt.args.template)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ResetOrgMailMessageTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		orgID       string
+		messageType string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailMessageTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								domain.InitCodeMessageType,
+								[]byte("<html>{{.Text}}</html>"),
+							),
+						),
+						eventFromEventPusher(
+							org.NewMailMessageTemplateResetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								domain.InitCodeMessageType,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "reset template, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailMessageTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								domain.InitCodeMessageType,
+								[]byte("<html>{{.Text}}</html>"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								org.NewMailMessageTemplateResetEvent(context.Background(),
+									&org.NewAggregate("org1", "org1").Aggregate,
+									domain.InitCodeMessageType,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				messageType: domain.InitCodeMessageType,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ResetOrgMailMessageTemplate(tt.args.ctx, tt.args.orgID, tt.args.messageType)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
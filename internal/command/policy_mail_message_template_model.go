@@ -0,0 +1,65 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/policy"
+)
+
+type MailMessageTemplateWriteModel struct {
+	eventstore.WriteModel
+
+	MessageType string
+	Template    []byte
+
+	State domain.PolicyState
+}
+
+func (wm *MailMessageTemplateWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.MailMessageTemplateSetEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.Template = e.Template
+			wm.State = domain.PolicyStateActive
+		case *policy.MailMessageTemplateResetEvent:
+			if e.MessageType != wm.MessageType {
+				continue
+			}
+			wm.Template = nil
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+//MailMessageTemplatesWriteModel holds the message types which have a template
+type MailMessageTemplatesWriteModel struct {
+	eventstore.WriteModel
+
+	MessageTypes []string
+}
+
+func (wm *MailMessageTemplatesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.MailMessageTemplateSetEvent:
+			wm.removeMessageType(e.MessageType)
+			wm.MessageTypes = append(wm.MessageTypes, e.MessageType)
+		case *policy.MailMessageTemplateResetEvent:
+			wm.removeMessageType(e.MessageType)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *MailMessageTemplatesWriteModel) removeMessageType(messageType string) {
+	for i, existing := range wm.MessageTypes {
+		if existing == messageType {
+			wm.MessageTypes = append(wm.MessageTypes[:i], wm.MessageTypes[i+1:]...)
+			return
+		}
+	}
+}
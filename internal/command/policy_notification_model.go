@@ -0,0 +1,79 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/policy"
+)
+
+type NotificationPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	PasswordChanged bool
+	MFAAdded        bool
+	MFARemoved      bool
+	NewUserAgent    bool
+	UserLocked      bool
+	EmailChanged    bool
+	State           domain.PolicyState
+}
+
+func (wm *NotificationPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.NotificationPolicyAddedEvent:
+			wm.PasswordChanged = e.PasswordChanged
+			wm.MFAAdded = e.MFAAdded
+			wm.MFARemoved = e.MFARemoved
+			wm.NewUserAgent = e.NewUserAgent
+			wm.UserLocked = e.UserLocked
+			wm.EmailChanged = e.EmailChanged
+			wm.State = domain.PolicyStateActive
+		case *policy.NotificationPolicyChangedEvent:
+			if e.PasswordChanged != nil {
+				wm.PasswordChanged = *e.PasswordChanged
+			}
+			if e.MFAAdded != nil {
+				wm.MFAAdded = *e.MFAAdded
+			}
+			if e.MFARemoved != nil {
+				wm.MFARemoved = *e.MFARemoved
+			}
+			if e.NewUserAgent != nil {
+				wm.NewUserAgent = *e.NewUserAgent
+			}
+			if e.UserLocked != nil {
+				wm.UserLocked = *e.UserLocked
+			}
+			if e.EmailChanged != nil {
+				wm.EmailChanged = *e.EmailChanged
+			}
+		case *policy.NotificationPolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationPolicyWriteModel) changes(notificationPolicy *domain.NotificationPolicy) []policy.NotificationPolicyChanges {
+	changes := make([]policy.NotificationPolicyChanges, 0)
+	if wm.PasswordChanged != notificationPolicy.PasswordChanged {
+		changes = append(changes, policy.ChangePasswordChangedAlert(notificationPolicy.PasswordChanged))
+	}
+	if wm.MFAAdded != notificationPolicy.MFAAdded {
+		changes = append(changes, policy.ChangeMFAAddedAlert(notificationPolicy.MFAAdded))
+	}
+	if wm.MFARemoved != notificationPolicy.MFARemoved {
+		changes = append(changes, policy.ChangeMFARemovedAlert(notificationPolicy.MFARemoved))
+	}
+	if wm.NewUserAgent != notificationPolicy.NewUserAgent {
+		changes = append(changes, policy.ChangeNewUserAgentAlert(notificationPolicy.NewUserAgent))
+	}
+	if wm.UserLocked != notificationPolicy.UserLocked {
+		changes = append(changes, policy.ChangeUserLockedAlert(notificationPolicy.UserLocked))
+	}
+	if wm.EmailChanged != notificationPolicy.EmailChanged {
+		changes = append(changes, policy.ChangeEmailChangedAlert(notificationPolicy.EmailChanged))
+	}
+	return changes
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/repository/policy"
@@ -10,6 +12,10 @@ type LockoutPolicyWriteModel struct {
 	eventstore.WriteModel
 
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
+	LockoutDuration     time.Duration
+	MaxIPAttempts       uint64
+	IPThrottleDuration  time.Duration
 	ShowLockOutFailures bool
 	State               domain.PolicyState
 }
@@ -19,12 +25,28 @@ func (wm *LockoutPolicyWriteModel) Reduce() error {
 		switch e := event.(type) {
 		case *policy.LockoutPolicyAddedEvent:
 			wm.MaxPasswordAttempts = e.MaxPasswordAttempts
+			wm.MaxOTPAttempts = e.MaxOTPAttempts
+			wm.LockoutDuration = e.LockoutDuration
+			wm.MaxIPAttempts = e.MaxIPAttempts
+			wm.IPThrottleDuration = e.IPThrottleDuration
 			wm.ShowLockOutFailures = e.ShowLockOutFailures
 			wm.State = domain.PolicyStateActive
 		case *policy.LockoutPolicyChangedEvent:
 			if e.MaxPasswordAttempts != nil {
 				wm.MaxPasswordAttempts = *e.MaxPasswordAttempts
 			}
+			if e.MaxOTPAttempts != nil {
+				wm.MaxOTPAttempts = *e.MaxOTPAttempts
+			}
+			if e.LockoutDuration != nil {
+				wm.LockoutDuration = *e.LockoutDuration
+			}
+			if e.MaxIPAttempts != nil {
+				wm.MaxIPAttempts = *e.MaxIPAttempts
+			}
+			if e.IPThrottleDuration != nil {
+				wm.IPThrottleDuration = *e.IPThrottleDuration
+			}
 			if e.ShowLockOutFailures != nil {
 				wm.ShowLockOutFailures = *e.ShowLockOutFailures
 			}
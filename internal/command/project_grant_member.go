@@ -16,10 +16,14 @@ func (c *Commands) AddProjectGrantMember(ctx context.Context, member *domain.Pro
 	if !member.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-8fi7G", "Errors.Project.Grant.Member.Invalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.ProjectGrantRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.ProjectGrantRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-m9gKK", "Errors.Project.Grant.Member.Invalid")
 	}
-	err := c.checkUserExists(ctx, member.UserID, "")
+	err = c.checkUserExists(ctx, member.UserID, "")
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +55,11 @@ func (c *Commands) ChangeProjectGrantMember(ctx context.Context, member *domain.
 	if !member.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-109fs", "Errors.Project.Member.Invalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.ProjectGrantRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.ProjectGrantRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-m0sDf", "Errors.Project.Member.Invalid")
 	}
 
@@ -58,6 +58,7 @@ func TestCommandSide_AddProjectGrantMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -336,6 +337,7 @@ func TestCommandSide_ChangeProjectGrantMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
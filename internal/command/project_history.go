@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+)
+
+//ProjectAt returns the project as it was at the point in time
+func (c *Commands) ProjectAt(ctx context.Context, projectID, resourceOwner string, point eventstore.PointInTime) (*domain.Project, error) {
+	if projectID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pr2aI", "Errors.Project.ProjectIDMissing")
+	}
+	writeModel, err := c.projectWriteModelAt(ctx, projectID, resourceOwner, point)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.ProjectStateUnspecified || writeModel.State == domain.ProjectStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pr2nF", "Errors.Project.NotFound")
+	}
+	project := projectWriteModelToProject(writeModel)
+	project.State = writeModel.State
+	return project, nil
+}
+
+//RevertProject changes the name and the settings of the project back to their state at the point in time
+// roles, applications and grants are not reverted
+func (c *Commands) RevertProject(ctx context.Context, projectID, resourceOwner string, point eventstore.PointInTime) (*domain.Project, error) {
+	if projectID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pr3aI", "Errors.Project.ProjectIDMissing")
+	}
+	previous, err := c.projectWriteModelAt(ctx, projectID, resourceOwner, point)
+	if err != nil {
+		return nil, err
+	}
+	if previous.State == domain.ProjectStateUnspecified || previous.State == domain.ProjectStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pr3nF", "Errors.Project.NotFound")
+	}
+	existingProject, err := c.getProjectWriteModelByID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingProject.State == domain.ProjectStateUnspecified || existingProject.State == domain.ProjectStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Pr4nF", "Errors.Project.NotFound")
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&existingProject.WriteModel)
+	changedEvent, hasChanged, err := existingProject.NewChangedEvent(
+		ctx,
+		projectAgg,
+		previous.Name,
+		previous.ProjectRoleAssertion,
+		previous.ProjectRoleCheck,
+		previous.HasProjectCheck,
+		previous.PrivateLabelingSetting)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pr2cN", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingProject, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return projectWriteModelToProject(existingProject), nil
+}
+
+func (c *Commands) projectWriteModelAt(ctx context.Context, projectID, resourceOwner string, point eventstore.PointInTime) (*ProjectWriteModel, error) {
+	writeModel := NewProjectWriteModel(projectID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducerAt(ctx, writeModel, point)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
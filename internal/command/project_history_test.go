@@ -0,0 +1,381 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+	"github.com/caos/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_ProjectAt(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		resourceOwner string
+		point         eventstore.PointInTime
+	}
+	type res struct {
+		want *domain.Project
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing project id, invalid error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 1},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid point in time, invalid error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing at point in time, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								project.NewProjectAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"project", true, true, true,
+									domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+							),
+							5,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 4},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "later changes ignored, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								project.NewProjectAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"project", true, true, true,
+									domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+							),
+							5,
+						),
+						eventWithSequence(
+							eventFromEventPusher(
+								newProjectChangedEvent(context.Background(),
+									"project1",
+									"org1",
+									"project",
+									"project-new",
+									false,
+									false,
+									false,
+									domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy),
+							),
+							8,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 7},
+			},
+			res: res{
+				want: &domain.Project{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+						Sequence:      5,
+					},
+					Name:                   "project",
+					ProjectRoleAssertion:   true,
+					ProjectRoleCheck:       true,
+					HasProjectCheck:        true,
+					PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+					State:                  domain.ProjectStateActive,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ProjectAt(tt.args.ctx, tt.args.projectID, tt.args.resourceOwner, tt.args.point)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RevertProject(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		resourceOwner string
+		point         eventstore.PointInTime
+	}
+	type res struct {
+		want *domain.Project
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner, invalid error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				point:     eventstore.PointInTime{Sequence: 1},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								project.NewProjectAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"project", true, true, true,
+									domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+							),
+							5,
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+						),
+						eventFromEventPusher(
+							project.NewProjectRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 5},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes since point in time, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								project.NewProjectAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"project", true, true, true,
+									domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+							),
+							5,
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 5},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "revert name and settings, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								project.NewProjectAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"project", true, true, true,
+									domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+							),
+							5,
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+						),
+						eventFromEventPusher(
+							newProjectChangedEvent(context.Background(),
+								"project1",
+								"org1",
+								"project",
+								"project-new",
+								false,
+								false,
+								false,
+								domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newProjectChangedEvent(context.Background(),
+									"project1",
+									"org1",
+									"project-new",
+									"project",
+									true,
+									true,
+									true,
+									domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewRemoveProjectNameUniqueConstraint("project-new", "org1")),
+						uniqueConstraintsFromEventConstraint(project.NewAddProjectNameUniqueConstraint("project", "org1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 5},
+			},
+			res: res{
+				want: &domain.Project{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					Name:                   "project",
+					ProjectRoleAssertion:   true,
+					ProjectRoleCheck:       true,
+					HasProjectCheck:        true,
+					PrivateLabelingSetting: domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RevertProject(tt.args.ctx, tt.args.projectID, tt.args.resourceOwner, tt.args.point)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func eventWithSequence(event *repository.Event, sequence uint64) *repository.Event {
+	event.Sequence = sequence
+	return event
+}
@@ -37,11 +37,15 @@ func (c *Commands) addProjectMember(ctx context.Context, projectAgg *eventstore.
 	if !member.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-W8m4l", "Errors.Project.Member.Invalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.ProjectRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.ProjectRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-3m9ds", "Errors.Project.Member.Invalid")
 	}
 
-	err := c.checkUserExists(ctx, addedMember.UserID, "")
+	err = c.checkUserExists(ctx, addedMember.UserID, "")
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +65,11 @@ func (c *Commands) ChangeProjectMember(ctx context.Context, member *domain.Membe
 	if !member.IsValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-LiaZi", "Errors.Project.Member.Invalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.ProjectRolePrefix, c.zitadelRoles)) > 0 {
+	invalidRoles, err := c.invalidMemberRoles(ctx, member.Roles, domain.ProjectRolePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(invalidRoles) > 0 {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "PROJECT-3m9d", "Errors.Project.Member.Invalid")
 	}
 
@@ -9,7 +9,6 @@ import (
 	"github.com/caos/zitadel/internal/api/authz"
 	"github.com/caos/zitadel/internal/domain"
 	caos_errs "github.com/caos/zitadel/internal/errors"
-	"github.com/caos/zitadel/internal/eventstore/v1/models"
 	iam_repo "github.com/caos/zitadel/internal/repository/iam"
 )
 
@@ -22,27 +21,26 @@ const (
 	SetupUser = "SETUP"
 )
 
+//ExecuteSetupSteps executes the steps which are not done yet in the given order
+// a step which was started but not finished (e.g. because the setup crashed) is executed again,
+// this is possible because the steps push their events together with the done event
 func (c *Commands) ExecuteSetupSteps(ctx context.Context, steps []Step) error {
-	iam, err := c.GetIAM(ctx)
-	if err != nil && !caos_errs.IsNotFound(err) {
-		return err
-	}
-	if iam != nil && (iam.SetUpDone == domain.StepCount-1 || iam.SetUpStarted != iam.SetUpDone) {
-		logging.Log("COMMA-dgd2z").Info("all steps done")
-		return nil
-	}
-
-	if iam == nil {
-		iam = &domain.IAM{ObjectRoot: models.ObjectRoot{}}
-	}
-
 	ctx = setSetUpContextData(ctx)
 
 	for _, step := range steps {
-		iam, err = c.StartSetup(ctx, step.Step())
+		iam, err := c.getIAMWriteModel(ctx)
 		if err != nil {
 			return err
 		}
+		if iam.SetUpDone >= step.Step() {
+			logging.LogWithFields("COMMA-dgd2z", "step", step.Step()).Debug("step already done")
+			continue
+		}
+		if iam.SetUpStarted == step.Step() {
+			logging.LogWithFields("COMMA-Ru2mE", "step", step.Step()).Info("resume interrupted setup step")
+		} else if _, err = c.StartSetup(ctx, step.Step()); err != nil {
+			return err
+		}
 
 		err = step.execute(ctx, c)
 		if err != nil {
@@ -52,6 +50,16 @@ func (c *Commands) ExecuteSetupSteps(ctx context.Context, steps []Step) error {
 	return nil
 }
 
+//SetupStepStatus returns the state of the setup steps which were started
+func (c *Commands) SetupStepStatus(ctx context.Context) (map[domain.Step]*domain.StepStatus, error) {
+	writeModel := NewSetupStepsWriteModel()
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel.Steps, nil
+}
+
 func setSetUpContextData(ctx context.Context) context.Context {
 	return authz.SetCtxData(ctx, authz.CtxData{UserID: SetupUser})
 }
@@ -83,7 +91,7 @@ func (c *Commands) setup(ctx context.Context, step Step, iamAggregateProvider fu
 	if err != nil && !caos_errs.IsNotFound(err) {
 		return err
 	}
-	if iam.SetUpStarted != step.Step() && iam.SetUpDone+1 != step.Step() {
+	if iam.SetUpStarted != step.Step() || iam.SetUpDone >= step.Step() {
 		return caos_errs.ThrowPreconditionFailed(nil, "EVENT-Dge32", "wrong step")
 	}
 	events, err := iamAggregateProvider(iam)
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type SetupStepsWriteModel struct {
+	eventstore.WriteModel
+
+	Steps map[domain.Step]*domain.StepStatus
+}
+
+func NewSetupStepsWriteModel() *SetupStepsWriteModel {
+	return &SetupStepsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   domain.IAMID,
+			ResourceOwner: domain.IAMID,
+		},
+		Steps: make(map[domain.Step]*domain.StepStatus),
+	}
+}
+
+func (wm *SetupStepsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		e, ok := event.(*iam.SetupStepEvent)
+		if !ok {
+			continue
+		}
+		status, ok := wm.Steps[e.Step]
+		if !ok {
+			status = &domain.StepStatus{Step: e.Step}
+			wm.Steps[e.Step] = status
+		}
+		status.ChangeDate = e.CreationDate()
+		if e.Done {
+			status.State = domain.StepStateDone
+		} else if status.State != domain.StepStateDone {
+			status.State = domain.StepStateStarted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SetupStepsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(iam.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			iam.SetupStartedEventType,
+			iam.SetupDoneEventType).
+		Builder()
+}
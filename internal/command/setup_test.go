@@ -0,0 +1,171 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type testStep struct {
+	step     domain.Step
+	executed bool
+}
+
+func (s *testStep) Step() domain.Step {
+	return s.step
+}
+
+func (s *testStep) execute(context.Context, *Commands) error {
+	s.executed = true
+	return nil
+}
+
+func TestCommandSide_ExecuteSetupSteps(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		step *testStep
+	}
+	type res struct {
+		executed bool
+		err      func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "step done, not executed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step2),
+						),
+						eventFromEventPusher(
+							iam.NewSetupStepDoneEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step2),
+						),
+					),
+				),
+			},
+			args: args{
+				step: &testStep{step: domain.Step2},
+			},
+			res: res{
+				executed: false,
+			},
+		},
+		{
+			name: "step interrupted, resumed without start event",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1),
+						),
+						eventFromEventPusher(
+							iam.NewSetupStepDoneEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1),
+						),
+						eventFromEventPusher(
+							iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step2),
+						),
+					),
+				),
+			},
+			args: args{
+				step: &testStep{step: domain.Step2},
+			},
+			res: res{
+				executed: true,
+			},
+		},
+		{
+			name: "step pending, started and executed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1),
+						),
+						eventFromEventPusher(
+							iam.NewSetupStepDoneEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1),
+						),
+						eventFromEventPusher(
+							iam.NewSetupStepDoneEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								iam.NewSetupStepStartedEvent(setSetUpContextData(context.Background()), &iam.NewAggregate().Aggregate, domain.Step2),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(iam.NewAddSetupStepStartedUniqueConstraint(domain.Step2)),
+					),
+				),
+			},
+			args: args{
+				step: &testStep{step: domain.Step2},
+			},
+			res: res{
+				executed: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.ExecuteSetupSteps(context.Background(), []Step{tt.args.step})
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			assert.Equal(t, tt.res.executed, tt.args.step.executed)
+		})
+	}
+}
+
+func TestSetupStepsWriteModel_Reduce(t *testing.T) {
+	started := time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC)
+	done := started.Add(time.Minute)
+	events := []*repository.Event{
+		eventFromEventPusher(iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1)),
+		eventFromEventPusher(iam.NewSetupStepDoneEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step1)),
+		eventFromEventPusher(iam.NewSetupStepStartedEvent(context.Background(), &iam.NewAggregate().Aggregate, domain.Step2)),
+	}
+	events[0].CreationDate = started
+	events[1].CreationDate = done
+	events[2].CreationDate = done
+
+	r := &Commands{
+		eventstore: eventstoreExpect(t, expectFilter(events...)),
+	}
+	got, err := r.SetupStepStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[domain.Step]*domain.StepStatus{
+		domain.Step1: {Step: domain.Step1, State: domain.StepStateDone, ChangeDate: done},
+		domain.Step2: {Step: domain.Step2, State: domain.StepStateStarted, ChangeDate: done},
+	}, got)
+}
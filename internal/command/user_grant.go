@@ -30,10 +30,20 @@ func (c *Commands) AddUserGrant(ctx context.Context, usergrant *domain.UserGrant
 }
 
 func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (command eventstore.Command, _ *UserGrantWriteModel, err error) {
+	return c.newUserGrant(ctx, userGrant, resourceOwner, false)
+}
+
+//newUserGrant creates the added event of the grant
+// userPending is set if the user is created in the same push as the grant
+func (c *Commands) newUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string, userPending bool) (command eventstore.Command, _ *UserGrantWriteModel, err error) {
 	if !userGrant.IsValid() {
 		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-4M0fs", "Errors.UserGrant.Invalid")
 	}
-	err = c.checkUserGrantPreCondition(ctx, userGrant)
+	if userPending {
+		err = c.checkUserGrantProjectPreCondition(ctx, userGrant)
+	} else {
+		err = c.checkUserGrantPreCondition(ctx, userGrant)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -294,14 +304,29 @@ func (c *Commands) checkUserGrantPreCondition(ctx context.Context, usergrant *do
 	if !preConditions.UserExists {
 		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-4f8sg", "Errors.User.NotFound")
 	}
+	return checkUserGrantProject(preConditions, usergrant)
+}
+
+//checkUserGrantProjectPreCondition checks the project, project grant and roles of the grant
+// but not the user, which doesn't exist yet
+func (c *Commands) checkUserGrantProjectPreCondition(ctx context.Context, usergrant *domain.UserGrant) error {
+	preConditions := NewUserGrantPreConditionReadModel(usergrant.UserID, usergrant.ProjectID, usergrant.ProjectGrantID)
+	err := c.eventstore.FilterToQueryReducer(ctx, preConditions)
+	if err != nil {
+		return err
+	}
+	return checkUserGrantProject(preConditions, usergrant)
+}
+
+func checkUserGrantProject(preConditions *UserGrantPreConditionReadModel, usergrant *domain.UserGrant) error {
 	if !preConditions.ProjectExists {
-		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-3n77S", "Errors.Project.NotFound")
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3n77S", "Errors.Project.NotFound")
 	}
 	if usergrant.ProjectGrantID != "" && !preConditions.ProjectGrantExists {
-		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-4m9ff", "Errors.Project.Grant.NotFound")
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-4m9ff", "Errors.Project.Grant.NotFound")
 	}
 	if usergrant.HasInvalidRoles(preConditions.ExistingRoleKeys) {
-		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-mm9F4", "Errors.Project.Role.NotFound")
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-mm9F4", "Errors.Project.Role.NotFound")
 	}
 	return nil
 }
@@ -102,6 +102,9 @@ func (c *Commands) importHuman(ctx context.Context, orgID string, human *domain.
 	if orgID == "" || !human.IsValid() {
 		return nil, nil, nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-00p2b", "Errors.User.Invalid")
 	}
+	if human.Password != nil && human.SecretString != "" && human.EncodedHash != "" {
+		return nil, nil, nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hs9pw", "Errors.User.Invalid")
+	}
 	events, humanWriteModel, err = c.createHuman(ctx, orgID, human, nil, false, passwordless, orgIAMPolicy, pwPolicy)
 	if err != nil {
 		return nil, nil, nil, "", err
@@ -211,6 +214,11 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 			return nil, nil, err
 		}
 	}
+	if human.Password != nil && human.EncodedHash != "" {
+		if human.SecretCrypto, err = c.userPasswordAlg.Import(human.EncodedHash); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	addedHuman := NewHumanWriteModel(human.AggregateID, orgID)
 	//TODO: adlerhurst maybe we could simplify the code below
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/user"
+	"github.com/caos/zitadel/internal/telemetry/tracing"
+)
+
+//HumanAt returns the human as it was at the point in time
+func (c *Commands) HumanAt(ctx context.Context, userID, resourceOwner string, point eventstore.PointInTime) (*domain.Human, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hu2aI", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.humanWriteModelAt(ctx, userID, resourceOwner, point)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(writeModel.UserState) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hu2nF", "Errors.User.NotFound")
+	}
+	return writeModelToHuman(writeModel), nil
+}
+
+//RevertHuman changes the user name and the profile of the human back to their state at the point in time
+// email and phone are not reverted because they would have to be verified again
+func (c *Commands) RevertHuman(ctx context.Context, userID, resourceOwner string, point eventstore.PointInTime) (*domain.ObjectDetails, error) {
+	if userID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hu3aI", "Errors.User.UserIDMissing")
+	}
+	previous, err := c.humanWriteModelAt(ctx, userID, resourceOwner, point)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(previous.UserState) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hu3nF", "Errors.User.NotFound")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hu4nF", "Errors.User.NotFound")
+	}
+	existingProfile, err := c.profileWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	events := make([]eventstore.Command, 0, 2)
+	if existingUser.UserName != previous.UserName {
+		orgIAMPolicy, err := c.getOrgIAMPolicy(ctx, resourceOwner)
+		if err != nil {
+			return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Hu2oP", "Errors.Org.OrgIAMPolicy.NotExisting")
+		}
+		if err = CheckOrgIAMPolicyForUserName(previous.UserName, orgIAMPolicy); err != nil {
+			return nil, err
+		}
+		events = append(events, user.NewUsernameChangedEvent(ctx, userAgg, existingUser.UserName, previous.UserName, orgIAMPolicy.UserLoginMustBeDomain))
+	}
+	profileEvent, hasChanged, err := existingProfile.NewChangedEvent(ctx, userAgg,
+		previous.FirstName,
+		previous.LastName,
+		previous.NickName,
+		previous.DisplayName,
+		previous.PreferredLanguage,
+		previous.Gender)
+	if err != nil {
+		return nil, err
+	}
+	if hasChanged {
+		events = append(events, profileEvent)
+	}
+	if len(events) == 0 {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hu2cN", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
+func (c *Commands) humanWriteModelAt(ctx context.Context, userID, resourceOwner string, point eventstore.PointInTime) (writeModel *HumanWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducerAt(ctx, writeModel, point)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
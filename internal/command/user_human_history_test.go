@@ -0,0 +1,218 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+func TestCommandSide_RevertHuman(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		point         eventstore.PointInTime
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user id, invalid error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 1},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing at point in time, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								newAddHumanEvent("", false, ""),
+							),
+							5,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 4},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes since point in time, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								newAddHumanEvent("", false, ""),
+							),
+							5,
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, ""),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 5},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "revert profile, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventWithSequence(
+							eventFromEventPusher(
+								newAddHumanEvent("", false, ""),
+							),
+							5,
+						),
+						eventWithSequence(
+							eventFromEventPusher(
+								newProfileChangedEvent(context.Background(),
+									"user1", "org1",
+									"firstname2",
+									"lastname2",
+									"nickname2",
+									"displayname2",
+									language.English,
+									domain.GenderMale,
+								),
+							),
+							6,
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, ""),
+						),
+						eventFromEventPusher(
+							newProfileChangedEvent(context.Background(),
+								"user1", "org1",
+								"firstname2",
+								"lastname2",
+								"nickname2",
+								"displayname2",
+								language.English,
+								domain.GenderMale,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, ""),
+						),
+						eventFromEventPusher(
+							newProfileChangedEvent(context.Background(),
+								"user1", "org1",
+								"firstname2",
+								"lastname2",
+								"nickname2",
+								"displayname2",
+								language.English,
+								domain.GenderMale,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newProfileChangedEvent(context.Background(),
+									"user1", "org1",
+									"firstname",
+									"lastname",
+									"",
+									"firstname lastname",
+									language.Und,
+									domain.GenderUnspecified,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				point:         eventstore.PointInTime{Sequence: 5},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RevertHuman(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.point)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	importHumansBatchSize = 100
+	//userGrantWritePermission is required for every imported grant
+	// the import itself only requires the permission to write users
+	userGrantWritePermission = "user.grant.write"
+)
+
+//BulkImportHumans creates the users with their idp links, metadata and grants
+// the users are pushed in batches, a failing user doesn't prevent the others from being created
+// the returned results contain one entry per user in the order of the input
+func (c *Commands) BulkImportHumans(ctx context.Context, orgID string, users []*domain.ImportUser) (_ []*domain.ImportUserResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bi2Or", "Errors.ResourceOwnerMissing")
+	}
+	if len(users) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bi2Em", "Errors.User.Import.NoUsers")
+	}
+	orgIAMPolicy, err := c.getOrgIAMPolicy(ctx, orgID)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Bi3Po", "Errors.Org.OrgIAMPolicy.NotFound")
+	}
+	pwPolicy, err := c.getOrgPasswordComplexityPolicy(ctx, orgID)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Bi4Po", "Errors.Org.PasswordComplexityPolicy.NotFound")
+	}
+
+	results := make([]*domain.ImportUserResult, len(users))
+	for start := 0; start < len(users); start += importHumansBatchSize {
+		end := start + importHumansBatchSize
+		if end > len(users) {
+			end = len(users)
+		}
+		c.importHumansBatch(ctx, orgID, users[start:end], results[start:end], start, orgIAMPolicy, pwPolicy)
+	}
+	return results, nil
+}
+
+func (c *Commands) importHumansBatch(ctx context.Context, orgID string, users []*domain.ImportUser, results []*domain.ImportUserResult, offset int, orgIAMPolicy *domain.OrgIAMPolicy, pwPolicy *domain.PasswordComplexityPolicy) {
+	rows := make([][]eventstore.Command, len(users))
+	batch := make([]eventstore.Command, 0, len(users))
+	for i, user := range users {
+		results[i] = &domain.ImportUserResult{Row: offset + i}
+		rows[i], results[i].Err = c.importHumanWithRelations(ctx, orgID, user, orgIAMPolicy, pwPolicy)
+		if results[i].Err != nil {
+			continue
+		}
+		results[i].UserID = user.Human.AggregateID
+		batch = append(batch, rows[i]...)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	if _, err := c.eventstore.Push(ctx, batch...); err == nil {
+		return
+	}
+	//the batch is pushed in a single transaction
+	//push the users one by one to find the ones which caused the failure (e.g. duplicate user names)
+	for i, events := range rows {
+		if results[i].Err != nil {
+			continue
+		}
+		if _, err := c.eventstore.Push(ctx, events...); err != nil {
+			results[i].UserID = ""
+			results[i].Err = err
+		}
+	}
+}
+
+func (c *Commands) importHumanWithRelations(ctx context.Context, orgID string, user *domain.ImportUser, orgIAMPolicy *domain.OrgIAMPolicy, pwPolicy *domain.PasswordComplexityPolicy) ([]eventstore.Command, error) {
+	if user == nil || user.Human == nil {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bi3Hu", "Errors.User.Invalid")
+	}
+	events, addedHuman, _, _, err := c.importHuman(ctx, orgID, user.Human, false, orgIAMPolicy, pwPolicy)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&addedHuman.WriteModel)
+	for _, link := range user.IDPLinks {
+		event, err := c.addUserIDPLink(ctx, userAgg, link)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	for _, metadata := range user.Metadata {
+		event, err := c.setUserMetadata(ctx, userAgg, metadata)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	for _, grant := range user.Grants {
+		if err = checkPermissionForProject(ctx, userGrantWritePermission, grant.ProjectGrantID, grant.ProjectID); err != nil {
+			return nil, err
+		}
+		grant.UserID = user.Human.AggregateID
+		event, _, err := c.newUserGrant(ctx, grant, orgID, true)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,431 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/id"
+	id_mock "github.com/caos/zitadel/internal/id/mock"
+	"github.com/caos/zitadel/internal/repository/org"
+	"github.com/caos/zitadel/internal/repository/project"
+	"github.com/caos/zitadel/internal/repository/user"
+	"github.com/caos/zitadel/internal/repository/usergrant"
+)
+
+func TestCommandSide_BulkImportHumans(t *testing.T) {
+	type fields struct {
+		eventstore      *eventstore.Eventstore
+		idGenerator     id.Generator
+		userPasswordAlg *crypto.PasswordHasher
+	}
+	type args struct {
+		ctx   context.Context
+		orgID string
+		users []*domain.ImportUser
+	}
+	type res struct {
+		want []*domain.ImportUserResult
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "orgid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "",
+				users: []*domain.ImportUser{newBulkImportUser("username")},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no users, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user with metadata and grant, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgIAMPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey1",
+								"rolekey",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newBulkImportHumanAddedEvent("user1", "username"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate),
+							),
+							eventFromEventPusher(
+								user.NewMetadataSetEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"key",
+									[]byte("value"),
+								),
+							),
+							eventFromEventPusher(
+								usergrant.NewUserGrantAddedEvent(context.Background(),
+									&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+									"user1",
+									"project1",
+									"",
+									[]string{"rolekey1"},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username", "org1", true)),
+						uniqueConstraintsFromEventConstraint(usergrant.NewAddUserGrantUniqueConstraint("org1", "user1", "project1", "")),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1", "usergrant1"),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:   authz.NewMockContextWithAllPermissions("org1", "", []string{"user.grant.write"}),
+				orgID: "org1",
+				users: []*domain.ImportUser{
+					{
+						Human: newBulkImportUser("username").Human,
+						Metadata: []*domain.Metadata{
+							{Key: "key", Value: []byte("value")},
+						},
+						Grants: []*domain.UserGrant{
+							{ProjectID: "project1", RoleKeys: []string{"rolekey1"}},
+						},
+					},
+				},
+			},
+			res: res{
+				want: []*domain.ImportUserResult{
+					{Row: 0, UserID: "user1"},
+				},
+			},
+		},
+		{
+			name: "grant without user grant write permission, user not imported",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgIAMPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:   authz.NewMockContextWithAllPermissions("org1", "admin", []string{"user.write", "user.grant.write:project2"}),
+				orgID: "org1",
+				users: []*domain.ImportUser{
+					{
+						Human: newBulkImportUser("username").Human,
+						Grants: []*domain.UserGrant{
+							{ProjectID: "project1", RoleKeys: []string{"PROJECT_OWNER"}},
+						},
+					},
+				},
+			},
+			res: res{
+				want: []*domain.ImportUserResult{
+					{Row: 0, Err: caos_errs.ThrowPermissionDenied(nil, "COMMAND-Gw2pD", "Errors.UserGrant.NoPermissionForProject")},
+				},
+			},
+		},
+		{
+			name: "invalid user, other users imported",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgIAMPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newBulkImportHumanAddedEvent("user1", "username"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username", "org1", true)),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				users: []*domain.ImportUser{
+					{Human: &domain.Human{Username: "invalid"}},
+					newBulkImportUser("username"),
+				},
+			},
+			res: res{
+				want: []*domain.ImportUserResult{
+					{Row: 0, Err: caos_errs.ThrowInvalidArgument(nil, "COMMAND-00p2b", "Errors.User.Invalid")},
+					{Row: 1, UserID: "user1"},
+				},
+			},
+		},
+		{
+			name: "batch push failed, users pushed separately",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgIAMPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectPushFailed(
+						caos_errs.ThrowAlreadyExists(nil, "ERROR", "internl"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								newBulkImportHumanAddedEvent("user1", "username"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate),
+							),
+							eventFromEventPusher(
+								newBulkImportHumanAddedEvent("user2", "username2"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user2", "org1").Aggregate),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username", "org1", true)),
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username2", "org1", true)),
+					),
+					expectPushFailed(
+						caos_errs.ThrowAlreadyExists(nil, "ERROR", "internl"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								newBulkImportHumanAddedEvent("user1", "username"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username", "org1", true)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newBulkImportHumanAddedEvent("user2", "username2"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user2", "org1").Aggregate),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username2", "org1", true)),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1", "user2"),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				users: []*domain.ImportUser{
+					newBulkImportUser("username"),
+					newBulkImportUser("username2"),
+				},
+			},
+			res: res{
+				want: []*domain.ImportUserResult{
+					{Row: 0, Err: caos_errs.ThrowAlreadyExists(nil, "ERROR", "internl")},
+					{Row: 1, UserID: "user2"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore,
+				idGenerator:     tt.fields.idGenerator,
+				userPasswordAlg: tt.fields.userPasswordAlg,
+			}
+			got, err := r.BulkImportHumans(tt.args.ctx, tt.args.orgID, tt.args.users)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, len(tt.res.want), len(got))
+				for i, want := range tt.res.want {
+					assert.Equal(t, want.Row, got[i].Row)
+					assert.Equal(t, want.UserID, got[i].UserID)
+					assert.Equal(t, want.Succeeded(), got[i].Succeeded())
+				}
+			}
+		})
+	}
+}
+
+func newBulkImportUser(username string) *domain.ImportUser {
+	return &domain.ImportUser{
+		Human: &domain.Human{
+			Username: username,
+			Password: &domain.Password{
+				SecretString: "password",
+			},
+			Profile: &domain.Profile{
+				FirstName: "firstname",
+				LastName:  "lastname",
+			},
+			Email: &domain.Email{
+				EmailAddress:    "email@test.ch",
+				IsEmailVerified: true,
+			},
+		},
+	}
+}
+
+func newBulkImportHumanAddedEvent(userID, username string) *user.HumanAddedEvent {
+	event := user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate(userID, "org1").Aggregate,
+		username,
+		"firstname",
+		"lastname",
+		"",
+		"firstname lastname",
+		language.Und,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+	event.AddPasswordData(&crypto.CryptoValue{
+		CryptoType: crypto.TypeHash,
+		Algorithm:  "hash",
+		Crypted:    []byte("password"),
+	}, false)
+	return event
+}
@@ -308,7 +308,7 @@ func TestCommandSide_VerifyInitCode(t *testing.T) {
 	type fields struct {
 		eventstore      *eventstore.Eventstore
 		secretGenerator crypto.Generator
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 	}
 	type args struct {
 		ctx           context.Context
@@ -597,7 +597,7 @@ func TestCommandSide_VerifyInitCode(t *testing.T) {
 					),
 				),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
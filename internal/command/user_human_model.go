@@ -76,6 +76,8 @@ func (wm *HumanWriteModel) Reduce() error {
 			wm.reduceHumanPhoneRemovedEvent()
 		case *user.HumanPasswordChangedEvent:
 			wm.reduceHumanPasswordChangedEvent(e)
+		case *user.HumanPasswordHashUpdatedEvent:
+			wm.Secret = e.Secret
 		case *user.HumanAvatarAddedEvent:
 			wm.Avatar = e.StoreKey
 		case *user.HumanAvatarRemovedEvent:
@@ -123,6 +125,7 @@ func (wm *HumanWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanAvatarAddedType,
 			user.HumanAvatarRemovedType,
 			user.HumanPasswordChangedType,
+			user.HumanPasswordHashUpdatedType,
 			user.UserLockedType,
 			user.UserUnlockedType,
 			user.UserDeactivatedType,
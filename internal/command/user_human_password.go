@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/caos/logging"
 
@@ -101,7 +102,7 @@ func (c *Commands) ChangePassword(ctx context.Context, orgID, userID, oldPasswor
 		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Fds3s", "Errors.User.Password.Empty")
 	}
 	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
-	err = crypto.CompareHash(existingPassword.Secret, []byte(oldPassword), c.userPasswordAlg)
+	_, err = c.userPasswordAlg.Verify(existingPassword.Secret, []byte(oldPassword))
 	spanPasswordComparison.EndWithError(err)
 
 	if err != nil {
@@ -227,19 +228,28 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3n77z", "Errors.User.Password.NotSet")
 	}
 
+	if err = c.checkIPThrottle(ctx, authRequest, lockoutPolicy); err != nil {
+		return err
+	}
+
 	userAgg := UserAggregateFromWriteModel(&existingPassword.WriteModel)
 	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
-	err = crypto.CompareHash(existingPassword.Secret, []byte(password), c.userPasswordAlg)
+	rehash, err := c.userPasswordAlg.Verify(existingPassword.Secret, []byte(password))
 	spanPasswordComparison.EndWithError(err)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		events := []eventstore.Command{user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest))}
+		if rehash {
+			events = append(events, c.rehashPasswordEvents(ctx, userAgg, password)...)
+		}
+		_, err = c.eventstore.Push(ctx, events...)
 		return err
 	}
 	events := make([]eventstore.Command, 0)
 	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	c.ipCheckFailed(ctx, authRequest, lockoutPolicy)
 	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
 		if existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			events = append(events, user.NewUserLockedEvent(ctx, userAgg))
+			events = append(events, user.NewUserLockedEvent(ctx, userAgg, domain.UserLockReasonPasswordAttempts, lockoutPolicy.LockedUntil(time.Now())))
 		}
 
 	}
@@ -248,6 +258,17 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
 }
 
+//rehashPasswordEvents returns the event replacing the password hash with a hash of the default algorithm
+// the check must not fail if the hash can't be updated, it will be retried on the next check
+func (c *Commands) rehashPasswordEvents(ctx context.Context, userAgg *eventstore.Aggregate, password string) []eventstore.Command {
+	secret, err := crypto.Hash([]byte(password), c.userPasswordAlg)
+	if err != nil {
+		logging.Log("COMMAND-Rh4sh").WithError(err).Warn("unable to rehash password")
+		return nil
+	}
+	return []eventstore.Command{user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, secret)}
+}
+
 func (c *Commands) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -53,6 +53,8 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.Code = nil
 			wm.PasswordCheckFailedCount = 0
+		case *user.HumanPasswordHashUpdatedEvent:
+			wm.Secret = e.Secret
 		case *user.HumanPasswordCodeAddedEvent:
 			wm.Code = e.Code
 			wm.CodeCreationDate = e.CreationDate()
@@ -84,6 +86,7 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanInitialCodeAddedType,
 			user.HumanInitializedCheckSucceededType,
 			user.HumanPasswordChangedType,
+			user.HumanPasswordHashUpdatedType,
 			user.HumanPasswordCodeAddedType,
 			user.HumanEmailVerifiedType,
 			user.HumanPasswordCheckFailedType,
@@ -21,7 +21,7 @@ import (
 func TestCommandSide_SetOneTimePassword(t *testing.T) {
 	type fields struct {
 		eventstore      *eventstore.Eventstore
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 	}
 	type args struct {
 		ctx           context.Context
@@ -128,7 +128,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 						},
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -199,7 +199,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 						},
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -238,7 +238,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 func TestCommandSide_SetPassword(t *testing.T) {
 	type fields struct {
 		eventstore      *eventstore.Eventstore
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 		secretGenerator crypto.Generator
 	}
 	type args struct {
@@ -460,7 +460,7 @@ func TestCommandSide_SetPassword(t *testing.T) {
 					),
 				),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -497,7 +497,7 @@ func TestCommandSide_SetPassword(t *testing.T) {
 func TestCommandSide_ChangePassword(t *testing.T) {
 	type fields struct {
 		eventstore      *eventstore.Eventstore
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 	}
 	type args struct {
 		ctx           context.Context
@@ -609,7 +609,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 						),
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -660,7 +660,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 								"")),
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -740,7 +740,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 						},
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -1074,7 +1074,7 @@ func TestCommandSide_PasswordCodeSent(t *testing.T) {
 func TestCommandSide_CheckPassword(t *testing.T) {
 	type fields struct {
 		eventstore      *eventstore.Eventstore
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 	}
 	type args struct {
 		ctx           context.Context
@@ -1240,7 +1240,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 						),
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -1316,7 +1316,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 						},
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -1397,12 +1397,14 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							eventFromEventPusher(
 								user.NewUserLockedEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
+									domain.UserLockReasonPasswordAttempts,
+									nil,
 								),
 							),
 						},
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -1485,7 +1487,95 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 						},
 					),
 				),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				password:      "password",
+				authReq: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "check imported password, ok and rehashed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1", "org1").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								domain.PasswordlessTypeNotAllowed,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPasswordChangedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "ssha",
+									KeyID:      "",
+									Crypted:    []byte("{SSHA}Z7N+wiy3KbHavqUiuBhrdx4f9xdzYWx0c2FsdHNhbHRzYWx0"),
+								},
+								false,
+								"")),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanPasswordCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanPasswordHashUpdatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeHash,
+										Algorithm:  "hash",
+										Crypted:    []byte("password"),
+									},
+								),
+							),
+						},
+					),
+				),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:           context.Background(),
@@ -0,0 +1,30 @@
+package command
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+//HumanSecurityAlertSent marks the security alert of the event with the sequence as sent
+func (c *Commands) HumanSecurityAlertSent(ctx context.Context, orgID, userID, messageType string, eventSequence uint64) (err error) {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sa4iD", "Errors.User.UserIDMissing")
+	}
+	if !domain.IsSecurityAlertMessageType(messageType) {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sa4mT", "Errors.User.SecurityAlert.Invalid")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Sa4nF", "Errors.User.NotFound")
+	}
+
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanSecurityAlertSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), messageType, eventSequence))
+	return err
+}
@@ -26,7 +26,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 		eventstore      *eventstore.Eventstore
 		idGenerator     id.Generator
 		secretGenerator crypto.Generator
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 	}
 	type args struct {
 		ctx   context.Context
@@ -313,7 +313,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -392,7 +392,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -655,7 +655,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 		eventstore           *eventstore.Eventstore
 		idGenerator          id.Generator
 		secretGenerator      crypto.Generator
-		userPasswordAlg      crypto.HashAlgorithm
+		userPasswordAlg      *crypto.PasswordHasher
 		passwordlessInitCode crypto.Generator
 	}
 	type args struct {
@@ -851,7 +851,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -931,7 +931,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -973,6 +973,143 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add human with imported password hash, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgIAMPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newAddHumanEventWithSecret(&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "ssha",
+									Crypted:    []byte("{SSHA}Z7N+wiy3KbHavqUiuBhrdx4f9xdzYWx0c2FsdHNhbHRzYWx0"),
+								}),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username", "org1", true)),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				secretGenerator: GetMockSecretGenerator(t),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				human: &domain.Human{
+					Username: "username",
+					Password: &domain.Password{
+						EncodedHash:    "{SSHA}Z7N+wiy3KbHavqUiuBhrdx4f9xdzYWx0c2FsdHNhbHRzYWx0",
+						ChangeRequired: false,
+					},
+					Profile: &domain.Profile{
+						FirstName: "firstname",
+						LastName:  "lastname",
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+				},
+			},
+			res: res{
+				wantHuman: &domain.Human{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "user1",
+						ResourceOwner: "org1",
+					},
+					Username: "username",
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						DisplayName:       "firstname lastname",
+						PreferredLanguage: language.Und,
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+					State: domain.UserStateActive,
+				},
+			},
+		},
+		{
+			name: "password and imported password hash, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgIAMPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+				),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				human: &domain.Human{
+					Username: "username",
+					Password: &domain.Password{
+						SecretString: "password",
+						EncodedHash:  "{SSHA}Z7N+wiy3KbHavqUiuBhrdx4f9xdzYWx0c2FsdHNhbHRzYWx0",
+					},
+					Profile: &domain.Profile{
+						FirstName: "firstname",
+						LastName:  "lastname",
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "add human email verified passwordless only, ok",
 			fields: fields{
@@ -1027,7 +1164,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				),
 				idGenerator:          id_mock.NewIDGeneratorExpectIDs(t, "user1", "code1"),
 				secretGenerator:      GetMockSecretGenerator(t),
-				userPasswordAlg:      crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg:      crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 				passwordlessInitCode: GetMockSecretGenerator(t),
 			},
 			args: args{
@@ -1131,7 +1268,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				),
 				idGenerator:          id_mock.NewIDGeneratorExpectIDs(t, "user1", "code1"),
 				secretGenerator:      GetMockSecretGenerator(t),
-				userPasswordAlg:      crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg:      crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 				passwordlessInitCode: GetMockSecretGenerator(t),
 			},
 			args: args{
@@ -1243,7 +1380,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -1341,7 +1478,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -1419,7 +1556,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 		eventstore      *eventstore.Eventstore
 		idGenerator     id.Generator
 		secretGenerator crypto.Generator
-		userPasswordAlg crypto.HashAlgorithm
+		userPasswordAlg *crypto.PasswordHasher
 	}
 	type args struct {
 		ctx            context.Context
@@ -1859,7 +1996,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -1958,7 +2095,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -2050,7 +2187,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -2162,7 +2299,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -2272,7 +2409,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 				),
 				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
 				secretGenerator: GetMockSecretGenerator(t),
-				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+				userPasswordAlg: crypto.NewPasswordHasher(crypto.CreateMockHashAlg(gomock.NewController(t))),
 			},
 			args: args{
 				ctx:   context.Background(),
@@ -2679,6 +2816,12 @@ func newAddHumanEvent(password string, changeRequired bool, phone string) *user.
 	return event
 }
 
+func newAddHumanEventWithSecret(secret *crypto.CryptoValue) *user.HumanAddedEvent {
+	event := newAddHumanEvent("", false, "")
+	event.AddPasswordData(secret, false)
+	return event
+}
+
 func newRegisterHumanEvent(username, password string, changeRequired bool, phone string) *user.HumanRegisteredEvent {
 	event := user.NewHumanRegisteredEvent(context.Background(),
 		&user.NewAggregate("user1", "org1").Aggregate,
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/caos/zitadel/internal/cache/redis"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/id"
+	"github.com/caos/zitadel/internal/repository/user"
+	"github.com/caos/zitadel/internal/telemetry/tracing"
+)
+
+//UnlockExpiredUserLock unlocks the user if it was locked temporarily and the lock expired
+// it returns true if the user got unlocked
+func (c *Commands) UnlockExpiredUserLock(ctx context.Context, userID, resourceOwner string) (bool, error) {
+	if userID == "" {
+		return false, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ul9xS", "Errors.User.UserIDMissing")
+	}
+	existingLockout, err := c.userLockoutWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if !existingLockout.lockExpired(time.Now()) {
+		return false, nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&existingLockout.WriteModel)))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+//checkFailedEvents returns the check failed event
+// and the locked event if the user exceeded the max attempts of the lockout policy
+func (c *Commands) checkFailedEvents(ctx context.Context, userAgg *eventstore.Aggregate, failedEvent eventstore.Command, reason domain.UserLockReason, lockoutPolicy *domain.LockoutPolicy, authRequest *domain.AuthRequest) []eventstore.Command {
+	events := []eventstore.Command{failedEvent}
+	c.ipCheckFailed(ctx, authRequest, lockoutPolicy)
+	if lockoutPolicy == nil || lockoutPolicy.MaxOTPAttempts == 0 {
+		return events
+	}
+	existingLockout, err := c.userLockoutWriteModelByID(ctx, userAgg.ID, userAgg.ResourceOwner)
+	if err != nil {
+		return events
+	}
+	failedCount := existingLockout.MFACheckFailedCount
+	if reason == domain.UserLockReasonPasswordlessAttempts {
+		failedCount = existingLockout.PasswordlessCheckFailedCount
+	}
+	if existingLockout.UserState != domain.UserStateLocked && failedCount+1 >= lockoutPolicy.MaxOTPAttempts {
+		events = append(events, user.NewUserLockedEvent(ctx, userAgg, reason, lockoutPolicy.LockedUntil(time.Now())))
+	}
+	return events
+}
+
+//checkIPThrottle returns an error if too many checks failed from the ip address of the auth request
+func (c *Commands) checkIPThrottle(ctx context.Context, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if c.ipThrottle == nil {
+		return nil
+	}
+	if c.ipThrottle.exceeded(ctx, remoteIP(authRequest), lockoutPolicy, time.Now()) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Th0tl", "Errors.User.TooManyAttempts")
+	}
+	return nil
+}
+
+//ipCheckFailed counts the failed check for the ip address of the auth request
+func (c *Commands) ipCheckFailed(ctx context.Context, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) {
+	if c.ipThrottle == nil {
+		return
+	}
+	c.ipThrottle.fail(ctx, remoteIP(authRequest), lockoutPolicy, time.Now())
+}
+
+func (c *Commands) userLockoutWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanLockoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanLockoutWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func remoteIP(authRequest *domain.AuthRequest) net.IP {
+	if authRequest == nil || authRequest.BrowserInfo == nil {
+		return nil
+	}
+	return authRequest.BrowserInfo.RemoteIP
+}
+
+type IPThrottleConfig struct {
+	//Redis if set, the failed checks are counted in redis and shared between the instances
+	// otherwise each instance counts the failed checks in memory
+	Redis *redis.Config
+}
+
+//ipThrottle counts the failed checks per ip address
+type ipThrottle interface {
+	//exceeded returns true if the ip address reached the max attempts of the policy
+	// within the throttle duration
+	exceeded(ctx context.Context, ip net.IP, policy *domain.LockoutPolicy, now time.Time) bool
+	fail(ctx context.Context, ip net.IP, policy *domain.LockoutPolicy, now time.Time)
+}
+
+func newIPThrottle(config IPThrottleConfig, idGenerator id.Generator) (ipThrottle, error) {
+	if config.Redis == nil {
+		return newMemoryIPThrottle(), nil
+	}
+	return newRedisIPThrottle(config.Redis, idGenerator)
+}
+
+//memoryIPThrottle counts the failed checks per ip address in memory
+// the failures aren't shared between instances and are lost on restart,
+// so with n instances an ip address gets up to n times the max attempts of the policy
+type memoryIPThrottle struct {
+	mu       sync.Mutex
+	failures map[string][]time.Time
+	//maxDuration is the longest throttle duration used so far
+	// failures older than it are removed on cleanup
+	maxDuration time.Duration
+	lastCleanup time.Time
+}
+
+const ipThrottleCleanupInterval = 10 * time.Minute
+
+func newMemoryIPThrottle() *memoryIPThrottle {
+	return &memoryIPThrottle{
+		failures: make(map[string][]time.Time),
+	}
+}
+
+func (t *memoryIPThrottle) exceeded(_ context.Context, ip net.IP, policy *domain.LockoutPolicy, now time.Time) bool {
+	if ip == nil || !throttlesIPs(policy) {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	failures := recentFailures(t.failures[ip.String()], now.Add(-policy.IPThrottleDuration))
+	return uint64(len(failures)) >= policy.MaxIPAttempts
+}
+
+func (t *memoryIPThrottle) fail(_ context.Context, ip net.IP, policy *domain.LockoutPolicy, now time.Time) {
+	if ip == nil || !throttlesIPs(policy) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := ip.String()
+	t.failures[key] = append(recentFailures(t.failures[key], now.Add(-policy.IPThrottleDuration)), now)
+	if policy.IPThrottleDuration > t.maxDuration {
+		t.maxDuration = policy.IPThrottleDuration
+	}
+	if now.Sub(t.lastCleanup) < ipThrottleCleanupInterval {
+		return
+	}
+	for key, failures := range t.failures {
+		if failures = recentFailures(failures, now.Add(-t.maxDuration)); len(failures) == 0 {
+			delete(t.failures, key)
+			continue
+		}
+		t.failures[key] = failures
+	}
+	t.lastCleanup = now
+}
+
+func throttlesIPs(policy *domain.LockoutPolicy) bool {
+	return policy != nil && policy.MaxIPAttempts > 0 && policy.IPThrottleDuration > 0
+}
+
+func recentFailures(failures []time.Time, since time.Time) []time.Time {
+	for i, failure := range failures {
+		if failure.After(since) {
+			return failures[i:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+//HumanLockoutWriteModel counts the failed second factor and passwordless checks
+// since the last successful check or unlock of the user
+type HumanLockoutWriteModel struct {
+	eventstore.WriteModel
+
+	UserState                    domain.UserState
+	LockedUntil                  *time.Time
+	MFACheckFailedCount          uint64
+	PasswordlessCheckFailedCount uint64
+}
+
+func NewHumanLockoutWriteModel(userID, resourceOwner string) *HumanLockoutWriteModel {
+	return &HumanLockoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanLockoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent,
+			*user.HumanRegisteredEvent,
+			*user.HumanInitializedCheckSucceededEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanInitialCodeAddedEvent:
+			wm.UserState = domain.UserStateInitial
+		case *user.HumanOTPCheckFailedEvent,
+			*user.HumanU2FCheckFailedEvent:
+			wm.MFACheckFailedCount++
+		case *user.HumanOTPCheckSucceededEvent,
+			*user.HumanU2FCheckSucceededEvent:
+			wm.MFACheckFailedCount = 0
+		case *user.HumanPasswordlessCheckFailedEvent:
+			wm.PasswordlessCheckFailedCount++
+		case *user.HumanPasswordlessCheckSucceededEvent:
+			wm.PasswordlessCheckFailedCount = 0
+		case *user.UserLockedEvent:
+			wm.UserState = domain.UserStateLocked
+			wm.LockedUntil = e.LockedUntil
+		case *user.UserUnlockedEvent:
+			wm.UserState = domain.UserStateActive
+			wm.LockedUntil = nil
+			wm.MFACheckFailedCount = 0
+			wm.PasswordlessCheckFailedCount = 0
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLockoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanInitialCodeAddedType,
+			user.HumanInitializedCheckSucceededType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckFailedType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserRemovedType,
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.UserV1InitialCodeAddedType,
+			user.UserV1InitializedCheckSucceededType,
+			user.UserV1MFAOTPCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+//lockExpired returns true if the user is locked temporarily and the lock is expired
+func (wm *HumanLockoutWriteModel) lockExpired(now time.Time) bool {
+	return wm.UserState == domain.UserStateLocked && wm.LockedUntil != nil && !now.Before(*wm.LockedUntil)
+}
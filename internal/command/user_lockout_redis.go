@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/caos/logging"
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/caos/zitadel/internal/cache/redis"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/id"
+)
+
+const ipThrottleKeyPrefix = "ip_throttle:"
+
+//redisIPThrottle counts the failed checks per ip address in a sorted set of redis
+// scored by the time of the failure in microseconds
+// so the max attempts of the policy apply to all instances together
+// if redis is unavailable the ip addresses aren't throttled, the lockout of the users still applies
+type redisIPThrottle struct {
+	client      *goredis.Client
+	keyPrefix   string
+	idGenerator id.Generator
+}
+
+func newRedisIPThrottle(config *redis.Config, idGenerator id.Generator) (*redisIPThrottle, error) {
+	if config.Address == "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ip3rA", "address of redis missing")
+	}
+	return &redisIPThrottle{
+		client:      redis.NewClient(config),
+		keyPrefix:   config.KeyPrefix + ipThrottleKeyPrefix,
+		idGenerator: idGenerator,
+	}, nil
+}
+
+func (t *redisIPThrottle) exceeded(ctx context.Context, ip net.IP, policy *domain.LockoutPolicy, now time.Time) bool {
+	if ip == nil || !throttlesIPs(policy) {
+		return false
+	}
+	since := now.Add(-policy.IPThrottleDuration).UnixMicro()
+	failures, err := t.client.ZCount(ctx, t.key(ip), "("+strconv.FormatInt(since, 10), "+inf").Result()
+	if err != nil {
+		logging.LogWithFields("COMMAND-Ip2eR", "ip", ip.String()).OnError(redis.MapError(err)).Warn("unable to read failed checks of ip")
+		return false
+	}
+	return uint64(failures) >= policy.MaxIPAttempts
+}
+
+func (t *redisIPThrottle) fail(ctx context.Context, ip net.IP, policy *domain.LockoutPolicy, now time.Time) {
+	if ip == nil || !throttlesIPs(policy) {
+		return
+	}
+	//the member must be unique, otherwise failures of multiple instances at the same time are counted once
+	failureID, err := t.idGenerator.Next()
+	if err != nil {
+		logging.LogWithFields("COMMAND-Ip4fI", "ip", ip.String()).OnError(err).Warn("unable to generate id of failed check")
+		return
+	}
+	key := t.key(ip)
+	since := now.Add(-policy.IPThrottleDuration).UnixMicro()
+	_, err = t.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(since, 10))
+		pipe.ZAdd(ctx, key, &goredis.Z{Score: float64(now.UnixMicro()), Member: failureID})
+		pipe.PExpire(ctx, key, policy.IPThrottleDuration)
+		return nil
+	})
+	logging.LogWithFields("COMMAND-Ip5wF", "ip", ip.String()).OnError(redis.MapError(err)).Warn("unable to store failed check of ip")
+}
+
+func (t *redisIPThrottle) key(ip net.IP) string {
+	return t.keyPrefix + ip.String()
+}
@@ -0,0 +1,446 @@
+package command
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/cache/redis"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	id_mock "github.com/caos/zitadel/internal/id/mock"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_UnlockExpiredUserLock(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		orgID  string
+		userID string
+	}
+	type res struct {
+		want bool
+		err  func(error) bool
+	}
+	expired := time.Now().Add(-time.Minute)
+	notExpired := time.Now().Add(time.Hour)
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not locked, not unlocked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				want: false,
+			},
+		},
+		{
+			name: "user locked permanently, not unlocked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								domain.UserLockReasonPasswordAttempts,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				want: false,
+			},
+		},
+		{
+			name: "user lock not expired, not unlocked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								domain.UserLockReasonOTPAttempts,
+								&notExpired,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				want: false,
+			},
+		},
+		{
+			name: "user lock expired, unlocked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								domain.UserLockReasonOTPAttempts,
+								&expired,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewUserUnlockedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				want: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.UnlockExpiredUserLock(tt.args.ctx, tt.args.userID, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_checkFailedEvents(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		reason        domain.UserLockReason
+		lockoutPolicy *domain.LockoutPolicy
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantLocked bool
+	}{
+		{
+			name: "no max otp attempts, not locked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				reason:        domain.UserLockReasonOTPAttempts,
+				lockoutPolicy: &domain.LockoutPolicy{},
+			},
+		},
+		{
+			name: "max otp attempts not reached, not locked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				reason:        domain.UserLockReasonOTPAttempts,
+				lockoutPolicy: &domain.LockoutPolicy{MaxOTPAttempts: 3},
+			},
+		},
+		{
+			name: "max otp attempts reached, locked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				reason:        domain.UserLockReasonOTPAttempts,
+				lockoutPolicy: &domain.LockoutPolicy{MaxOTPAttempts: 3},
+			},
+			wantLocked: true,
+		},
+		{
+			name: "failed attempts reset by successful check, not locked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				reason:        domain.UserLockReasonOTPAttempts,
+				lockoutPolicy: &domain.LockoutPolicy{MaxOTPAttempts: 3},
+			},
+		},
+		{
+			name: "passwordless attempts counted separately, not locked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newLockoutTestHumanAddedEvent(),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				reason:        domain.UserLockReasonPasswordlessAttempts,
+				lockoutPolicy: &domain.LockoutPolicy{MaxOTPAttempts: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			userAgg := &user.NewAggregate("user1", "org1").Aggregate
+			failedEvent := user.NewHumanOTPCheckFailedEvent(context.Background(), userAgg, nil)
+			got := r.checkFailedEvents(context.Background(), userAgg, failedEvent, tt.args.reason, tt.args.lockoutPolicy, nil)
+			if !tt.wantLocked {
+				assert.Equal(t, []eventstore.Command{failedEvent}, got)
+				return
+			}
+			if assert.Len(t, got, 2) {
+				lockedEvent, ok := got[1].(*user.UserLockedEvent)
+				if assert.True(t, ok) {
+					assert.Equal(t, tt.args.reason, lockedEvent.Reason)
+				}
+			}
+		})
+	}
+}
+
+func Test_ipThrottle(t *testing.T) {
+	now := time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	ip := net.ParseIP("192.168.0.1")
+	policy := &domain.LockoutPolicy{MaxIPAttempts: 2, IPThrottleDuration: time.Minute}
+	tests := []struct {
+		name     string
+		policy   *domain.LockoutPolicy
+		failures []time.Time
+		ip       net.IP
+		exceeded bool
+	}{
+		{
+			name:     "no policy, not exceeded",
+			failures: []time.Time{now, now},
+			ip:       ip,
+		},
+		{
+			name:     "throttling disabled, not exceeded",
+			policy:   &domain.LockoutPolicy{MaxIPAttempts: 2},
+			failures: []time.Time{now, now},
+			ip:       ip,
+		},
+		{
+			name:     "no ip, not exceeded",
+			policy:   policy,
+			failures: []time.Time{now, now},
+		},
+		{
+			name:     "max attempts not reached, not exceeded",
+			policy:   policy,
+			failures: []time.Time{now},
+			ip:       ip,
+		},
+		{
+			name:     "failures outside throttle duration, not exceeded",
+			policy:   policy,
+			failures: []time.Time{now.Add(-2 * time.Minute), now.Add(-time.Minute), now},
+			ip:       ip,
+		},
+		{
+			name:     "max attempts reached, exceeded",
+			policy:   policy,
+			failures: []time.Time{now.Add(-30 * time.Second), now},
+			ip:       ip,
+			exceeded: true,
+		},
+	}
+	throttles := map[string]func(t *testing.T) ipThrottle{
+		"memory": func(*testing.T) ipThrottle {
+			return newMemoryIPThrottle()
+		},
+		"redis": func(t *testing.T) ipThrottle {
+			var failureID int
+			idGenerator := id_mock.NewMockGenerator(gomock.NewController(t))
+			idGenerator.EXPECT().Next().DoAndReturn(func() (string, error) {
+				failureID++
+				return strconv.Itoa(failureID), nil
+			}).AnyTimes()
+			throttle, err := newRedisIPThrottle(&redis.Config{Address: miniredis.RunT(t).Addr()}, idGenerator)
+			require.NoError(t, err)
+			return throttle
+		},
+	}
+	for throttleName, newThrottle := range throttles {
+		for _, tt := range tests {
+			t.Run(throttleName+" "+tt.name, func(t *testing.T) {
+				throttle := newThrottle(t)
+				for _, failure := range tt.failures {
+					throttle.fail(context.Background(), tt.ip, tt.policy, failure)
+				}
+				assert.Equal(t, tt.exceeded, throttle.exceeded(context.Background(), tt.ip, tt.policy, now))
+				assert.False(t, throttle.exceeded(context.Background(), net.ParseIP("192.168.0.2"), tt.policy, now))
+			})
+		}
+	}
+}
+
+func newLockoutTestHumanAddedEvent() *user.HumanAddedEvent {
+	return user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"nickname",
+		"displayname",
+		language.German,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+}
@@ -682,6 +682,8 @@ func TestCommandSide_LockUser(t *testing.T) {
 						eventFromEventPusher(
 							user.NewUserLockedEvent(context.Background(),
 								&user.NewAggregate("user1", "org1").Aggregate,
+								domain.UserLockReasonUnspecified,
+								nil,
 							),
 						),
 					),
@@ -722,6 +724,8 @@ func TestCommandSide_LockUser(t *testing.T) {
 							eventFromEventPusher(
 								user.NewUserLockedEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
+									domain.UserLockReasonUnspecified,
+									nil,
 								),
 							),
 						},
@@ -867,7 +871,10 @@ func TestCommandSide_UnlockUser(t *testing.T) {
 						),
 						eventFromEventPusher(
 							user.NewUserLockedEvent(context.Background(),
-								&user.NewAggregate("user1", "org1").Aggregate),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								domain.UserLockReasonUnspecified,
+								nil,
+							),
 						),
 					),
 					expectPush(
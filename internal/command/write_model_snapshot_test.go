@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/iam"
+	"github.com/caos/zitadel/internal/repository/org"
+	"github.com/caos/zitadel/internal/repository/policy"
+)
+
+type snapshotWriteModel interface {
+	AppendEvents(...eventstore.Event)
+	Reduce() error
+	MarshalSnapshot() ([]byte, error)
+	UnmarshalSnapshot([]byte) error
+}
+
+func TestWriteModel_SnapshotEqualsReplay(t *testing.T) {
+	ctx := context.Background()
+	iamAgg := &iam.NewAggregate().Aggregate
+	orgAgg := &org.NewAggregate("org1", "org1").Aggregate
+	loginPolicyChanged, err := iam.NewLoginPolicyChangedEvent(ctx, iamAgg, []policy.LoginPolicyChanges{
+		policy.ChangeAllowRegister(false),
+		policy.ChangePasswordlessType(domain.PasswordlessTypeAllowed),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		writeModel func() snapshotWriteModel
+		events     []eventstore.Event
+	}{
+		{
+			name: "iam",
+			writeModel: func() snapshotWriteModel {
+				return NewIAMWriteModel()
+			},
+			events: []eventstore.Event{
+				iam.NewSetupStepStartedEvent(ctx, iamAgg, domain.Step1),
+				iam.NewGlobalOrgSetEventEvent(ctx, iamAgg, "org1"),
+				iam.NewIAMProjectSetEvent(ctx, iamAgg, "project1"),
+				iam.NewSetupStepDoneEvent(ctx, iamAgg, domain.Step1),
+			},
+		},
+		{
+			name: "org",
+			writeModel: func() snapshotWriteModel {
+				return NewOrgWriteModel("org1")
+			},
+			events: []eventstore.Event{
+				org.NewOrgAddedEvent(ctx, orgAgg, "org"),
+				org.NewOrgChangedEvent(ctx, orgAgg, "org", "changed"),
+				org.NewDomainPrimarySetEvent(ctx, orgAgg, "org.io"),
+				org.NewOrgDeactivatedEvent(ctx, orgAgg),
+			},
+		},
+		{
+			name: "iam login policy",
+			writeModel: func() snapshotWriteModel {
+				return NewIAMLoginPolicyWriteModel()
+			},
+			events: []eventstore.Event{
+				iam.NewLoginPolicyAddedEvent(ctx, iamAgg, true, true, true, true, true, domain.PasswordlessTypeNotAllowed),
+				loginPolicyChanged,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayed := tt.writeModel()
+			replayed.AppendEvents(tt.events...)
+			if err := replayed.Reduce(); err != nil {
+				t.Fatal(err)
+			}
+			for i := 1; i < len(tt.events); i++ {
+				snapshotted := tt.writeModel()
+				snapshotted.AppendEvents(tt.events[:i]...)
+				if err := snapshotted.Reduce(); err != nil {
+					t.Fatal(err)
+				}
+				data, err := snapshotted.MarshalSnapshot()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				restored := tt.writeModel()
+				if err = restored.UnmarshalSnapshot(data); err != nil {
+					t.Fatal(err)
+				}
+				restored.AppendEvents(tt.events[i:]...)
+				if err = restored.Reduce(); err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, withoutEvents(replayed), withoutEvents(restored), "snapshot after %d events", i)
+			}
+		})
+	}
+}
+
+//withoutEvents clears the appended events, which are not part of the reduced state
+func withoutEvents(wm snapshotWriteModel) snapshotWriteModel {
+	events := reflect.ValueOf(wm).Elem().FieldByName("Events")
+	events.Set(reflect.Zero(events.Type()))
+	return wm
+}
@@ -18,6 +18,7 @@ type SystemDefaults struct {
 	Domain                   string
 	ZitadelDocs              ZitadelDocs
 	SecretGenerators         SecretGenerators
+	PasswordHasher           crypto.PasswordHashConfig
 	UserVerificationKey      *crypto.KeyConfig
 	IDPConfigVerificationKey *crypto.KeyConfig
 	Multifactors             MultifactorConfig
@@ -81,6 +82,7 @@ type Endpoints struct {
 	VerifyEmail              string
 	DomainClaimed            string
 	PasswordlessRegistration string
+	SecurityAlert            string
 }
 
 type Channels struct {
@@ -11,9 +11,15 @@ import (
 
 const (
 	sslDisabledMode = "disable"
+
+	DatabaseCockroach = "cockroach"
+	DatabasePostgres  = "postgres"
 )
 
 type SQL struct {
+	//Type of the database (cockroach or postgres)
+	//cockroach is used if empty
+	Type            string
 	Host            string
 	Port            string
 	User            string
@@ -31,6 +37,9 @@ type SQL struct {
 }
 
 type SQLBase struct {
+	//Type of the database (cockroach or postgres)
+	//cockroach is used if empty
+	Type     string
 	Host     string
 	Port     string
 	Database string
@@ -110,6 +119,25 @@ func (s *SQL) Start() (*sql.DB, error) {
 	return client, nil
 }
 
+//StartCockroach opens the connection to a database which is only supported on cockroach
+// the views of the v1 spoolers (including their locks tables) are not migrated to postgres
+func (s *SQL) StartCockroach() (*sql.DB, error) {
+	if s.IsPostgres() {
+		return nil, errors.ThrowPreconditionFailed(nil, "TYPES-Vw3Pg", "postgres is only supported for the eventstore and projections")
+	}
+	return s.Start()
+}
+
+//IsPostgres returns true if the configured database is a postgres database
+func (s *SQL) IsPostgres() bool {
+	return s.Type == DatabasePostgres
+}
+
+//IsPostgres returns true if the configured database is a postgres database
+func (b SQLBase) IsPostgres() bool {
+	return b.Type == DatabasePostgres
+}
+
 func (s *SQL) checkSSL() {
 	if s.SSL == nil || s.SSL.Mode == sslDisabledMode || s.SSL.Mode == "" {
 		s.SSL = &SSL{SSLBase: SSLBase{Mode: sslDisabledMode}}
@@ -126,6 +154,7 @@ func (s *SQL) checkSSL() {
 
 func (u SQLUser) Start(base SQLBase) (*sql.DB, error) {
 	return (&SQL{
+		Type:     base.Type,
 		Host:     base.Host,
 		Port:     base.Port,
 		User:     u.User,
@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*Argon2id)(nil)
+
+const argon2idPrefix = "$argon2id$"
+
+//Argon2id hashes in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+type Argon2id struct {
+	iterations  uint32
+	memory      uint32
+	parallelism uint8
+}
+
+func NewArgon2id(iterations, memory uint32, parallelism uint8) *Argon2id {
+	return &Argon2id{
+		iterations:  iterations,
+		memory:      memory,
+		parallelism: parallelism,
+	}
+}
+
+func (a *Argon2id) Algorithm() string {
+	return "argon2id"
+}
+
+func (a *Argon2id) Hash(value []byte) ([]byte, error) {
+	salt, err := newHashSalt()
+	if err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(value, salt, a.iterations, a.memory, a.parallelism, hashKeyLength)
+	return []byte(fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version, a.memory, a.iterations, a.parallelism, encodeHashBase64(salt), encodeHashBase64(key))), nil
+}
+
+func (a *Argon2id) CompareHash(hashed, value []byte) error {
+	params, salt, key, err := parseArgon2id(hashed)
+	if err != nil {
+		return err
+	}
+	compare := argon2.IDKey(value, salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, compare) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ar2Mm", "hash does not match")
+	}
+	return nil
+}
+
+func (a *Argon2id) ValidHash(hashed []byte) bool {
+	_, _, _, err := parseArgon2id(hashed)
+	return err == nil
+}
+
+func (a *Argon2id) NeedsRehash(hashed []byte) bool {
+	params, _, _, err := parseArgon2id(hashed)
+	return err != nil || params.memory < a.memory || params.iterations < a.iterations
+}
+
+func (a *Argon2id) withinLimits(hashed []byte, limits *PasswordHashLimits) bool {
+	params, _, _, err := parseArgon2id(hashed)
+	return err == nil &&
+		params.memory <= limits.Argon2idMemory &&
+		params.iterations <= limits.Argon2idIterations &&
+		params.parallelism <= limits.Argon2idParallelism
+}
+
+func parseArgon2id(hashed []byte) (params *Argon2id, salt, key []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(string(hashed), argon2idPrefix), "$")
+	if !strings.HasPrefix(string(hashed), argon2idPrefix) || len(parts) != 4 {
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ar2Fo", "invalid argon2id hash")
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-Ar2Vs", "unsupported argon2id version")
+	}
+	params = new(Argon2id)
+	if _, err = fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-Ar2Pa", "invalid argon2id parameters")
+	}
+	if salt, err = decodeHashBase64(parts[2]); err != nil {
+		return nil, nil, nil, err
+	}
+	if key, err = decodeHashBase64(parts[3]); err != nil {
+		return nil, nil, nil, err
+	}
+	return params, salt, key, nil
+}
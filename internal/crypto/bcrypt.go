@@ -25,3 +25,24 @@ func (b *BCrypt) Hash(value []byte) ([]byte, error) {
 func (b *BCrypt) CompareHash(hashed, value []byte) error {
 	return bcrypt.CompareHashAndPassword(hashed, value)
 }
+
+func (b *BCrypt) ValidHash(hashed []byte) bool {
+	_, err := bcrypt.Cost(hashed)
+	return err == nil
+}
+
+func (b *BCrypt) NeedsRehash(hashed []byte) bool {
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		return true
+	}
+	if b.cost < bcrypt.MinCost {
+		return cost < bcrypt.DefaultCost
+	}
+	return cost < b.cost
+}
+
+func (b *BCrypt) withinLimits(hashed []byte, limits *PasswordHashLimits) bool {
+	cost, err := bcrypt.Cost(hashed)
+	return err == nil && cost <= limits.BCryptCost
+}
@@ -0,0 +1,235 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*PasswordHasher)(nil)
+
+const (
+	hashKeyLength  = 32
+	hashSaltLength = 16
+)
+
+type PasswordHashConfig struct {
+	//Algorithm used to hash new passwords: bcrypt (default), argon2id, scrypt or pbkdf2
+	Algorithm string
+	//Cost is the bcrypt cost, the argon2id and pbkdf2 iterations or the scrypt log2(N)
+	Cost int
+	//Memory used by argon2id in KiB
+	Memory uint32
+	//Parallelism of argon2id and scrypt
+	Parallelism uint8
+	//Digest used by pbkdf2: sha1, sha256 (default) or sha512
+	Digest string
+	//ImportLimits are the maximum cost parameters of imported hashes
+	ImportLimits PasswordHashLimits
+}
+
+//PasswordHashLimits prevent imported hashes from exhausting memory or cpu on every password check
+// zero values are replaced by the defaults
+type PasswordHashLimits struct {
+	//BCryptCost defaults to 16
+	BCryptCost int
+	//Argon2idMemory in KiB defaults to 262144 (256 MiB)
+	Argon2idMemory uint32
+	//Argon2idIterations defaults to 10
+	Argon2idIterations uint32
+	//Argon2idParallelism defaults to 16
+	Argon2idParallelism uint8
+	//ScryptLogN is the maximum log2(N) and defaults to 17
+	ScryptLogN int
+	//ScryptBlockSize defaults to 16
+	ScryptBlockSize int
+	//ScryptParallelism defaults to 16
+	ScryptParallelism int
+	//PBKDF2Iterations defaults to 1000000
+	PBKDF2Iterations int
+}
+
+func (l PasswordHashLimits) withDefaults() *PasswordHashLimits {
+	return &PasswordHashLimits{
+		BCryptCost:          valueOrDefault(l.BCryptCost, 16),
+		Argon2idMemory:      uint32(valueOrDefault(int(l.Argon2idMemory), 256*1024)),
+		Argon2idIterations:  uint32(valueOrDefault(int(l.Argon2idIterations), 10)),
+		Argon2idParallelism: uint8(valueOrDefault(int(l.Argon2idParallelism), 16)),
+		ScryptLogN:          valueOrDefault(l.ScryptLogN, 17),
+		ScryptBlockSize:     valueOrDefault(l.ScryptBlockSize, 16),
+		ScryptParallelism:   valueOrDefault(l.ScryptParallelism, 16),
+		PBKDF2Iterations:    valueOrDefault(l.PBKDF2Iterations, 1000000),
+	}
+}
+
+//PasswordHasher hashes new passwords with the configured algorithm
+// and verifies hashes of all supported algorithms,
+// so hashes imported from other systems can be replaced after a successful verification
+type PasswordHasher struct {
+	defaultAlg   HashAlgorithm
+	verifiers    map[string]HashAlgorithm
+	importLimits *PasswordHashLimits
+}
+
+//passwordHashAlgorithm is implemented by the algorithms able to verify imported hashes
+type passwordHashAlgorithm interface {
+	HashAlgorithm
+	ValidHash(hashed []byte) bool
+	//NeedsRehash returns true if the hash is weaker than the configured parameters
+	NeedsRehash(hashed []byte) bool
+	//withinLimits returns false if the cost parameters of the hash exceed the limits
+	withinLimits(hashed []byte, limits *PasswordHashLimits) bool
+}
+
+func NewPasswordHasher(defaultAlg HashAlgorithm) *PasswordHasher {
+	pbkdf2Alg, _ := NewPBKDF2("sha256", 0)
+	saltedSHA, _ := NewSaltedSHA("sha256")
+	hasher := &PasswordHasher{
+		defaultAlg:   defaultAlg,
+		verifiers:    make(map[string]HashAlgorithm),
+		importLimits: PasswordHashLimits{}.withDefaults(),
+	}
+	for _, alg := range []HashAlgorithm{
+		NewBCrypt(bcrypt.DefaultCost),
+		NewArgon2id(0, 0, 0),
+		NewScrypt(0, 0, 0),
+		pbkdf2Alg,
+		saltedSHA,
+		defaultAlg,
+	} {
+		hasher.verifiers[alg.Algorithm()] = alg
+	}
+	return hasher
+}
+
+func (c *PasswordHashConfig) NewPasswordHasher() (*PasswordHasher, error) {
+	var defaultAlg HashAlgorithm
+	switch c.Algorithm {
+	case "", "bcrypt":
+		defaultAlg = NewBCrypt(c.Cost)
+	case "argon2id":
+		defaultAlg = NewArgon2id(uint32(valueOrDefault(c.Cost, 3)), uint32(valueOrDefault(int(c.Memory), 64*1024)), uint8(valueOrDefault(int(c.Parallelism), 4)))
+	case "scrypt":
+		defaultAlg = NewScrypt(valueOrDefault(c.Cost, 15), 8, valueOrDefault(int(c.Parallelism), 1))
+	case "pbkdf2":
+		digest := c.Digest
+		if digest == "" {
+			digest = "sha256"
+		}
+		alg, err := NewPBKDF2(digest, valueOrDefault(c.Cost, 310000))
+		if err != nil {
+			return nil, err
+		}
+		defaultAlg = alg
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ph7Al", "unsupported password hash algorithm")
+	}
+	hasher := NewPasswordHasher(defaultAlg)
+	hasher.importLimits = c.ImportLimits.withDefaults()
+	return hasher, nil
+}
+
+func (h *PasswordHasher) Algorithm() string {
+	return h.defaultAlg.Algorithm()
+}
+
+func (h *PasswordHasher) Hash(value []byte) ([]byte, error) {
+	return h.defaultAlg.Hash(value)
+}
+
+//CompareHash compares the value with a hash of any supported algorithm
+func (h *PasswordHasher) CompareHash(hashed, value []byte) error {
+	alg, ok := h.verifiers[hashAlgorithmOf(string(hashed))]
+	if !ok {
+		alg = h.defaultAlg
+	}
+	return alg.CompareHash(hashed, value)
+}
+
+//Verify compares the password with the hashed value
+// and returns true if the password must be rehashed with the default algorithm
+func (h *PasswordHasher) Verify(value *CryptoValue, password []byte) (rehash bool, err error) {
+	alg, ok := h.verifiers[value.Algorithm]
+	if !ok {
+		return false, errors.ThrowInvalidArgument(nil, "CRYPT-Ph8Vr", "value was hashed with an unsupported algorithm")
+	}
+	if err = alg.CompareHash(value.Crypted, password); err != nil {
+		return false, err
+	}
+	if value.Algorithm != h.defaultAlg.Algorithm() {
+		return true, nil
+	}
+	rehasher, ok := h.defaultAlg.(passwordHashAlgorithm)
+	return ok && rehasher.NeedsRehash(value.Crypted), nil
+}
+
+//Import returns the crypto value of a password hash created by another system
+// the algorithm is detected by the format of the hash
+func (h *PasswordHasher) Import(encoded string) (*CryptoValue, error) {
+	algorithm := hashAlgorithmOf(encoded)
+	alg, ok := h.verifiers[algorithm].(passwordHashAlgorithm)
+	if !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ph9Im", "Errors.User.Password.HashUnsupported")
+	}
+	if !alg.ValidHash([]byte(encoded)) {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ph9In", "Errors.User.Password.HashInvalid")
+	}
+	if !alg.withinLimits([]byte(encoded), h.importLimits) {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ph9Li", "Errors.User.Password.HashCostTooHigh")
+	}
+	return &CryptoValue{
+		CryptoType: TypeHash,
+		Algorithm:  algorithm,
+		Crypted:    []byte(encoded),
+	}, nil
+}
+
+func hashAlgorithmOf(encoded string) string {
+	switch {
+	case strings.HasPrefix(encoded, "$2a$"),
+		strings.HasPrefix(encoded, "$2b$"),
+		strings.HasPrefix(encoded, "$2y$"):
+		return "bcrypt"
+	case strings.HasPrefix(encoded, argon2idPrefix):
+		return "argon2id"
+	case strings.HasPrefix(encoded, scryptPrefix):
+		return "scrypt"
+	case strings.HasPrefix(encoded, pbkdf2Prefix):
+		return "pbkdf2"
+	case strings.HasPrefix(encoded, "{SSHA"):
+		return "ssha"
+	}
+	return ""
+}
+
+func newHashSalt() ([]byte, error) {
+	salt := make([]byte, hashSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Ph2Sa", "unable to generate salt")
+	}
+	return salt, nil
+}
+
+func encodeHashBase64(value []byte) string {
+	return base64.RawStdEncoding.EncodeToString(value)
+}
+
+//decodeHashBase64 decodes standard and passlib's adapted base64 (. instead of +) with or without padding
+func decodeHashBase64(value string) ([]byte, error) {
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(strings.ReplaceAll(value, ".", "+"), "="))
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Ph2B6", "invalid base64 in hash")
+	}
+	return decoded, nil
+}
+
+func valueOrDefault(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,323 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func TestPasswordHasher_Verify(t *testing.T) {
+	bcryptHasher := NewPasswordHasher(NewBCrypt(4))
+	bcryptHash, err := bcryptHasher.Hash([]byte("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	strongerBcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	argon2idHash, err := NewArgon2id(1, 64, 1).Hash([]byte("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		hasher   *PasswordHasher
+		encoded  string
+		password string
+	}
+	type res struct {
+		rehash bool
+		err    bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "default algorithm, no rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  string(bcryptHash),
+				password: "password",
+			},
+		},
+		{
+			name: "default algorithm with other cost, rehash",
+			args: args{
+				hasher:   NewPasswordHasher(NewBCrypt(5)),
+				encoded:  string(bcryptHash),
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+		{
+			name: "default algorithm with higher cost, no rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  string(strongerBcryptHash),
+				password: "password",
+			},
+		},
+		{
+			name: "default algorithm without configured cost, rehash to default cost",
+			args: args{
+				hasher:   NewPasswordHasher(NewBCrypt(0)),
+				encoded:  string(bcryptHash),
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+		{
+			name: "wrong password, error",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  string(bcryptHash),
+				password: "wrong",
+			},
+			res: res{
+				err: true,
+			},
+		},
+		{
+			name: "argon2id, rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  string(argon2idHash),
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+		{
+			name: "pbkdf2 sha256 (passlib), rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  "$pbkdf2-sha256$1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+		{
+			name: "scrypt, rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  "$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+		{
+			name: "scrypt wrong password, error",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  "$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+				password: "wrong",
+			},
+			res: res{
+				err: true,
+			},
+		},
+		{
+			name: "salted sha1, rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  "{SSHA}Z7N+wiy3KbHavqUiuBhrdx4f9xdzYWx0c2FsdHNhbHRzYWx0",
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+		{
+			name: "salted sha512, rehash",
+			args: args{
+				hasher:   bcryptHasher,
+				encoded:  "{SSHA512}YYvJB6mZFy+oGgGaEbmJNffyjV717Ivr8pgi8lNutfe0KzI8GtBGS+ZeZzlVVsOlmFV4EWpdArxkZsnUyPTmkXNhbHRzYWx0c2FsdHNhbHQ=",
+				password: "password",
+			},
+			res: res{
+				rehash: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.args.hasher.Import(tt.args.encoded)
+			if err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+			rehash, err := tt.args.hasher.Verify(value, []byte(tt.args.password))
+			if tt.res.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.res.rehash, rehash)
+		})
+	}
+}
+
+func TestPasswordHasher_Import(t *testing.T) {
+	hasher := NewPasswordHasher(NewBCrypt(4))
+	tests := []struct {
+		name      string
+		encoded   string
+		algorithm string
+		wantErr   bool
+	}{
+		{
+			name:      "bcrypt",
+			encoded:   "$2a$04$Z5Jyy5Ja5MDDUxPIh5ueTuhCaJNnP7.vE9JwRq1/OSIvZRW8S0ZUm",
+			algorithm: "bcrypt",
+		},
+		{
+			name:      "pbkdf2",
+			encoded:   "$pbkdf2-sha256$1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			algorithm: "pbkdf2",
+		},
+		{
+			name:    "pbkdf2 unsupported digest",
+			encoded: "$pbkdf2-md5$1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr: true,
+		},
+		{
+			name:    "argon2id without hash",
+			encoded: "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA",
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			encoded: "5f4dcc3b5aa765d61d8327deb882cf99",
+			wantErr: true,
+		},
+		{
+			name:    "bcrypt cost too high",
+			encoded: "$2a$31$Z5Jyy5Ja5MDDUxPIh5ueTuhCaJNnP7.vE9JwRq1/OSIvZRW8S0ZUm",
+			wantErr: true,
+		},
+		{
+			name:    "argon2id memory too high",
+			encoded: "$argon2id$v=19$m=4194304,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdHNhbHRzYWx0c2FsdHNhbHQ",
+			wantErr: true,
+		},
+		{
+			name:    "argon2id iterations too high",
+			encoded: "$argon2id$v=19$m=64,t=1000,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdHNhbHRzYWx0c2FsdHNhbHQ",
+			wantErr: true,
+		},
+		{
+			name:    "argon2id parallelism too high",
+			encoded: "$argon2id$v=19$m=64,t=1,p=255$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdHNhbHRzYWx0c2FsdHNhbHQ",
+			wantErr: true,
+		},
+		{
+			name:    "scrypt N too high",
+			encoded: "$scrypt$ln=30,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+			wantErr: true,
+		},
+		{
+			name:    "scrypt block size too high",
+			encoded: "$scrypt$ln=4,r=1024,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+			wantErr: true,
+		},
+		{
+			name:    "scrypt parallelism too high",
+			encoded: "$scrypt$ln=4,r=8,p=1024$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+			wantErr: true,
+		},
+		{
+			name:    "pbkdf2 iterations too high",
+			encoded: "$pbkdf2-sha256$100000000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hasher.Import(tt.encoded)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.algorithm, got.Algorithm)
+			assert.Equal(t, TypeHash, got.CryptoType)
+		})
+	}
+}
+
+func TestPasswordHashConfig_NewPasswordHasher(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    PasswordHashConfig
+		algorithm string
+		wantErr   bool
+	}{
+		{
+			name:      "default bcrypt",
+			config:    PasswordHashConfig{Cost: 4},
+			algorithm: "bcrypt",
+		},
+		{
+			name:      "argon2id",
+			config:    PasswordHashConfig{Algorithm: "argon2id", Cost: 1, Memory: 64, Parallelism: 1},
+			algorithm: "argon2id",
+		},
+		{
+			name:      "scrypt",
+			config:    PasswordHashConfig{Algorithm: "scrypt", Cost: 4},
+			algorithm: "scrypt",
+		},
+		{
+			name:      "pbkdf2",
+			config:    PasswordHashConfig{Algorithm: "pbkdf2", Cost: 1000, Digest: "sha512"},
+			algorithm: "pbkdf2",
+		},
+		{
+			name:    "salted sha not allowed",
+			config:  PasswordHashConfig{Algorithm: "ssha"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := tt.config.NewPasswordHasher()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.algorithm, hasher.Algorithm())
+			value, err := Hash([]byte("password"), hasher)
+			if !assert.NoError(t, err) {
+				return
+			}
+			rehash, err := hasher.Verify(value, []byte("password"))
+			assert.NoError(t, err)
+			assert.False(t, rehash)
+		})
+	}
+}
+
+func TestPasswordHasher_Import_configuredLimits(t *testing.T) {
+	hasher, err := (&PasswordHashConfig{Cost: 4, ImportLimits: PasswordHashLimits{PBKDF2Iterations: 500}}).NewPasswordHasher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = hasher.Import("$pbkdf2-sha256$1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA")
+	assert.True(t, caos_errs.IsErrorInvalidArgument(err), "got wrong err: %v", err)
+}
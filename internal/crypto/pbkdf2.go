@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*PBKDF2)(nil)
+
+const pbkdf2Prefix = "$pbkdf2-"
+
+var hashDigests = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+//PBKDF2 hashes in the modular crypt format used by passlib:
+// $pbkdf2-<digest>$<iterations>$<salt>$<hash>
+// digest is one of sha1, sha256 or sha512
+type PBKDF2 struct {
+	digest     string
+	iterations int
+}
+
+func NewPBKDF2(digest string, iterations int) (*PBKDF2, error) {
+	if _, ok := hashDigests[digest]; !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Pb2Dg", "unsupported pbkdf2 digest")
+	}
+	return &PBKDF2{
+		digest:     digest,
+		iterations: iterations,
+	}, nil
+}
+
+func (p *PBKDF2) Algorithm() string {
+	return "pbkdf2"
+}
+
+func (p *PBKDF2) Hash(value []byte) ([]byte, error) {
+	salt, err := newHashSalt()
+	if err != nil {
+		return nil, err
+	}
+	digest := hashDigests[p.digest]
+	key := pbkdf2.Key(value, salt, p.iterations, digest().Size(), digest)
+	return []byte(fmt.Sprintf("%s%s$%d$%s$%s", pbkdf2Prefix, p.digest, p.iterations, encodeHashBase64(salt), encodeHashBase64(key))), nil
+}
+
+func (p *PBKDF2) CompareHash(hashed, value []byte) error {
+	params, salt, key, err := parsePBKDF2(hashed)
+	if err != nil {
+		return err
+	}
+	compare := pbkdf2.Key(value, salt, params.iterations, len(key), hashDigests[params.digest])
+	if subtle.ConstantTimeCompare(key, compare) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Pb2Mm", "hash does not match")
+	}
+	return nil
+}
+
+func (p *PBKDF2) ValidHash(hashed []byte) bool {
+	_, _, _, err := parsePBKDF2(hashed)
+	return err == nil
+}
+
+func (p *PBKDF2) NeedsRehash(hashed []byte) bool {
+	params, _, _, err := parsePBKDF2(hashed)
+	return err != nil || params.digest != p.digest || params.iterations < p.iterations
+}
+
+func (p *PBKDF2) withinLimits(hashed []byte, limits *PasswordHashLimits) bool {
+	params, _, _, err := parsePBKDF2(hashed)
+	return err == nil && params.iterations <= limits.PBKDF2Iterations
+}
+
+func parsePBKDF2(hashed []byte) (params *PBKDF2, salt, key []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(string(hashed), pbkdf2Prefix), "$")
+	if !strings.HasPrefix(string(hashed), pbkdf2Prefix) || len(parts) != 4 {
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Pb2Fo", "invalid pbkdf2 hash")
+	}
+	if _, ok := hashDigests[parts[0]]; !ok {
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Pb3Dg", "unsupported pbkdf2 digest")
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return nil, nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-Pb2It", "invalid pbkdf2 iterations")
+	}
+	if salt, err = decodeHashBase64(parts[2]); err != nil {
+		return nil, nil, nil, err
+	}
+	if key, err = decodeHashBase64(parts[3]); err != nil {
+		return nil, nil, nil, err
+	}
+	return &PBKDF2{digest: parts[0], iterations: iterations}, salt, key, nil
+}
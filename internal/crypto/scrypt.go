@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*Scrypt)(nil)
+
+const scryptPrefix = "$scrypt$"
+
+//Scrypt hashes in the PHC string format:
+// $scrypt$ln=<log2(N)>,r=<block size>,p=<parallelism>$<salt>$<hash>
+type Scrypt struct {
+	logN        int
+	blockSize   int
+	parallelism int
+}
+
+func NewScrypt(logN, blockSize, parallelism int) *Scrypt {
+	return &Scrypt{
+		logN:        logN,
+		blockSize:   blockSize,
+		parallelism: parallelism,
+	}
+}
+
+func (s *Scrypt) Algorithm() string {
+	return "scrypt"
+}
+
+func (s *Scrypt) Hash(value []byte) ([]byte, error) {
+	salt, err := newHashSalt()
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(value, salt, 1<<s.logN, s.blockSize, s.parallelism, hashKeyLength)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Sc9Hs", "unable to hash with scrypt")
+	}
+	return []byte(fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s", scryptPrefix, s.logN, s.blockSize, s.parallelism, encodeHashBase64(salt), encodeHashBase64(key))), nil
+}
+
+func (s *Scrypt) CompareHash(hashed, value []byte) error {
+	params, salt, key, err := parseScrypt(hashed)
+	if err != nil {
+		return err
+	}
+	compare, err := scrypt.Key(value, salt, 1<<params.logN, params.blockSize, params.parallelism, len(key))
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-Sc8Pa", "invalid scrypt parameters")
+	}
+	if subtle.ConstantTimeCompare(key, compare) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Sc2Mm", "hash does not match")
+	}
+	return nil
+}
+
+func (s *Scrypt) ValidHash(hashed []byte) bool {
+	_, _, _, err := parseScrypt(hashed)
+	return err == nil
+}
+
+func (s *Scrypt) NeedsRehash(hashed []byte) bool {
+	params, _, _, err := parseScrypt(hashed)
+	return err != nil || params.logN < s.logN || params.blockSize < s.blockSize
+}
+
+func (s *Scrypt) withinLimits(hashed []byte, limits *PasswordHashLimits) bool {
+	params, _, _, err := parseScrypt(hashed)
+	return err == nil &&
+		params.logN <= limits.ScryptLogN &&
+		params.blockSize <= limits.ScryptBlockSize &&
+		params.parallelism <= limits.ScryptParallelism
+}
+
+func parseScrypt(hashed []byte) (params *Scrypt, salt, key []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(string(hashed), scryptPrefix), "$")
+	if !strings.HasPrefix(string(hashed), scryptPrefix) || len(parts) != 3 {
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Sc2Fo", "invalid scrypt hash")
+	}
+	params = new(Scrypt)
+	if _, err = fmt.Sscanf(parts[0], "ln=%d,r=%d,p=%d", &params.logN, &params.blockSize, &params.parallelism); err != nil {
+		return nil, nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-Sc2Pa", "invalid scrypt parameters")
+	}
+	if params.logN <= 0 || params.logN >= 32 {
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Sc3Pa", "invalid scrypt parameters")
+	}
+	if salt, err = decodeHashBase64(parts[1]); err != nil {
+		return nil, nil, nil, err
+	}
+	if key, err = decodeHashBase64(parts[2]); err != nil {
+		return nil, nil, nil, err
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*SaltedSHA)(nil)
+
+var saltedSHAPrefixes = map[string]string{
+	"{SSHA}":    "sha1",
+	"{SSHA256}": "sha256",
+	"{SSHA512}": "sha512",
+}
+
+//SaltedSHA hashes in the format used by LDAP directories:
+// {SSHA}, {SSHA256} or {SSHA512} followed by base64(sha(value + salt) + salt)
+// it's only meant to verify imported hashes, use a key derivation function to hash new values
+type SaltedSHA struct {
+	digest string
+}
+
+func NewSaltedSHA(digest string) (*SaltedSHA, error) {
+	if _, ok := hashDigests[digest]; !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ssh2D", "unsupported salted sha digest")
+	}
+	return &SaltedSHA{digest: digest}, nil
+}
+
+func (s *SaltedSHA) Algorithm() string {
+	return "ssha"
+}
+
+func (s *SaltedSHA) Hash(value []byte) ([]byte, error) {
+	salt, err := newHashSalt()
+	if err != nil {
+		return nil, err
+	}
+	prefix := "{SSHA}"
+	for p, digest := range saltedSHAPrefixes {
+		if digest == s.digest {
+			prefix = p
+		}
+	}
+	return []byte(prefix + base64.StdEncoding.EncodeToString(append(saltedSHASum(s.digest, value, salt), salt...))), nil
+}
+
+func (s *SaltedSHA) CompareHash(hashed, value []byte) error {
+	digest, salt, sum, err := parseSaltedSHA(hashed)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(sum, saltedSHASum(digest, value, salt)) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ssh2M", "hash does not match")
+	}
+	return nil
+}
+
+func (s *SaltedSHA) ValidHash(hashed []byte) bool {
+	_, _, _, err := parseSaltedSHA(hashed)
+	return err == nil
+}
+
+func (s *SaltedSHA) NeedsRehash(hashed []byte) bool {
+	digest, _, _, err := parseSaltedSHA(hashed)
+	return err != nil || digest != s.digest
+}
+
+//withinLimits is always true, salted sha has no cost parameters
+func (s *SaltedSHA) withinLimits(hashed []byte, _ *PasswordHashLimits) bool {
+	return true
+}
+
+func saltedSHASum(digest string, value, salt []byte) []byte {
+	h := hashDigests[digest]()
+	h.Write(value)
+	h.Write(salt)
+	return h.Sum(nil)
+}
+
+func parseSaltedSHA(hashed []byte) (digest string, salt, sum []byte, err error) {
+	end := strings.Index(string(hashed), "}")
+	if end < 0 {
+		return "", nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ssh2F", "invalid salted sha hash")
+	}
+	digest, ok := saltedSHAPrefixes[string(hashed[:end+1])]
+	if !ok {
+		return "", nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ssh3D", "unsupported salted sha digest")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(hashed[end+1:]))
+	if err != nil {
+		return "", nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-Ssh2B", "invalid salted sha hash")
+	}
+	size := hashDigests[digest]().Size()
+	if len(decoded) <= size {
+		return "", nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ssh2S", "salted sha hash without salt")
+	}
+	return digest, decoded[size:], decoded[:size], nil
+}
@@ -19,6 +19,8 @@ const (
 	LabelPolicyLogoPath = labelPolicyLogoPrefix
 	LabelPolicyIconPath = labelPolicyIconPrefix
 	LabelPolicyFontPath = labelPolicyFontPrefix
+
+	LoginTemplatePath = "login/templates"
 )
 
 type AssetInfo struct {
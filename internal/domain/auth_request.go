@@ -51,6 +51,8 @@ type AuthRequest struct {
 	LabelPolicy              *LabelPolicy
 	PrivacyPolicy            *PrivacyPolicy
 	LockoutPolicy            *LockoutPolicy
+	PasswordAgePolicy        *PasswordAgePolicy
+	PasswordWarningSkipped   bool
 	DefaultTranslations      []*CustomText
 	OrgTranslations          []*CustomText
 }
@@ -13,6 +13,12 @@ const (
 	VerifyPhoneMessageType              = "VerifyPhone"
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
+	PasswordChangedMessageType          = "PasswordChanged"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	NewUserAgentMessageType             = "NewUserAgent"
+	UserLockedMessageType               = "UserLocked"
+	EmailChangedMessageType             = "EmailChanged"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -29,6 +35,12 @@ type MessageTexts struct {
 	VerifyPhone              CustomMessageText
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
+	PasswordChanged          CustomMessageText
+	MFAAdded                 CustomMessageText
+	MFARemoved               CustomMessageText
+	NewUserAgent             CustomMessageText
+	UserLocked               CustomMessageText
+	EmailChanged             CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -65,6 +77,18 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.DomainClaimed
 	case PasswordlessRegistrationMessageType:
 		return &m.PasswordlessRegistration
+	case PasswordChangedMessageType:
+		return &m.PasswordChanged
+	case MFAAddedMessageType:
+		return &m.MFAAdded
+	case MFARemovedMessageType:
+		return &m.MFARemoved
+	case NewUserAgentMessageType:
+		return &m.NewUserAgent
+	case UserLockedMessageType:
+		return &m.UserLocked
+	case EmailChangedMessageType:
+		return &m.EmailChanged
 	}
 	return nil
 }
@@ -75,5 +99,16 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailMessageType ||
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
-		textType == PasswordlessRegistrationMessageType
+		textType == PasswordlessRegistrationMessageType ||
+		IsSecurityAlertMessageType(textType)
+}
+
+//IsSecurityAlertMessageType checks if the message alerts the user about a security relevant change
+func IsSecurityAlertMessageType(textType string) bool {
+	return textType == PasswordChangedMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == NewUserAgentMessageType ||
+		textType == UserLockedMessageType ||
+		textType == EmailChangedMessageType
 }
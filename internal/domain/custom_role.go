@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+)
+
+//CustomRole is a zitadel role defined at runtime
+// it can be assigned to members like the roles of the authz config
+// the name of the role must start with the prefix of its scope (e.g. IAM_AUDITOR)
+type CustomRole struct {
+	models.ObjectRoot
+
+	Role        string
+	Scope       CustomRoleScope
+	Permissions []string
+}
+
+type CustomRoleState int32
+
+const (
+	CustomRoleStateUnspecified CustomRoleState = iota
+	CustomRoleStateActive
+	CustomRoleStateRemoved
+)
+
+type CustomRoleScope int32
+
+const (
+	CustomRoleScopeUnspecified CustomRoleScope = iota
+	CustomRoleScopeIAM
+	CustomRoleScopeOrg
+	CustomRoleScopeProject
+
+	customRoleScopeCount
+)
+
+func (s CustomRoleScope) Valid() bool {
+	return s > CustomRoleScopeUnspecified && s < customRoleScopeCount
+}
+
+//RolePrefix returns the prefix of the roles which can be assigned to members of the scope
+// roles with the project prefix can be assigned to project grant members if they start with PROJECT_GRANT
+func (s CustomRoleScope) RolePrefix() string {
+	switch s {
+	case CustomRoleScopeIAM:
+		return IAMRolePrefix
+	case CustomRoleScopeOrg:
+		return OrgRolePrefix
+	case CustomRoleScopeProject:
+		return ProjectRolePrefix
+	default:
+		return ""
+	}
+}
+
+func (r *CustomRole) IsValid() bool {
+	return r.Scope.Valid() &&
+		len(r.Role) > len(r.Scope.RolePrefix()) &&
+		strings.HasPrefix(r.Role, r.Scope.RolePrefix()) &&
+		len(r.Permissions) > 0
+}
@@ -13,6 +13,8 @@ type Password struct {
 	SecretString   string
 	SecretCrypto   *crypto.CryptoValue
 	ChangeRequired bool
+	//EncodedHash is a password hash imported from another system
+	EncodedHash string
 }
 
 func NewPassword(password string) *Password {
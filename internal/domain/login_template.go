@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+)
+
+const (
+	LoginTemplateMaxSize = 1 << 18
+)
+
+//LoginTemplate replaces a template (file) of the login of the organisation
+type LoginTemplate struct {
+	models.ObjectRoot
+
+	Template string
+	Content  []byte
+	AssetKey string
+}
+
+func (t *LoginTemplate) IsValid() bool {
+	return IsLoginTemplate(t.Template) && len(t.Content) > 0 && len(t.Content) <= LoginTemplateMaxSize
+}
+
+//LoginTemplates are the templates of the login which can be replaced by an organisation
+// they must match the files in internal/ui/login/static/templates
+var LoginTemplates = []string{
+	"main.html",
+	"header.html",
+	"footer.html",
+	"error-message.html",
+	"user_profile.html",
+	"error.html",
+	"login.html",
+	"select_user.html",
+	"password.html",
+	"passwordless.html",
+	"passwordless_registration.html",
+	"passwordless_registration_done.html",
+	"passwordless_prompt.html",
+	"mfa_verify_otp.html",
+	"mfa_prompt.html",
+	"mfa_init_otp.html",
+	"mfa_init_u2f.html",
+	"mfa_verification_u2f.html",
+	"mfa_init_done.html",
+	"mail_verification.html",
+	"mail_verified.html",
+	"init_password.html",
+	"init_password_done.html",
+	"init_user.html",
+	"init_user_done.html",
+	"password_reset_done.html",
+	"change_password.html",
+	"change_password_done.html",
+	"register_option.html",
+	"register.html",
+	"external_register_overview.html",
+	"logout_done.html",
+	"register_org.html",
+	"change_username.html",
+	"change_username_done.html",
+	"link_users_done.html",
+	"external_not_found_option.html",
+	"login_success.html",
+}
+
+//LoginTemplateFuncs are the functions of the login renderer which can be used in the templates of an organisation
+var LoginTemplateFuncs = []string{
+	"resourceUrl",
+	"resourceThemeUrl",
+	"hasCustomPolicy",
+	"hasWatermark",
+	"variablesCssFileUrl",
+	"customLogoResource",
+	"avatarResource",
+	"loginUrl",
+	"externalIDPAuthURL",
+	"externalIDPRegisterURL",
+	"registerUrl",
+	"loginNameUrl",
+	"loginNameChangeUrl",
+	"userSelectionUrl",
+	"passwordLessVerificationUrl",
+	"passwordLessRegistrationUrl",
+	"passwordlessPromptUrl",
+	"passwordResetUrl",
+	"passwordUrl",
+	"mfaVerifyUrl",
+	"mfaPromptUrl",
+	"mfaPromptChangeUrl",
+	"mfaInitVerifyUrl",
+	"mfaInitU2FVerifyUrl",
+	"mfaInitU2FLoginUrl",
+	"mailVerificationUrl",
+	"initPasswordUrl",
+	"initUserUrl",
+	"changePasswordUrl",
+	"changePasswordSkipUrl",
+	"registerOptionUrl",
+	"registrationUrl",
+	"orgRegistrationUrl",
+	"externalRegistrationUrl",
+	"changeUsernameUrl",
+	"externalNotFoundOptionUrl",
+	"selectedLanguage",
+	"selectedGender",
+	"hasUsernamePasswordLogin",
+	"showPasswordReset",
+	"hasExternalLogin",
+	"idpProviderClass",
+}
+
+func IsLoginTemplate(template string) bool {
+	for _, loginTemplate := range LoginTemplates {
+		if loginTemplate == template {
+			return true
+		}
+	}
+	return false
+}
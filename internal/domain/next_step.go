@@ -117,7 +117,13 @@ func (s *PasswordlessRegistrationPromptStep) Type() NextStepType {
 	return NextStepPasswordlessRegistrationPrompt
 }
 
-type ChangePasswordStep struct{}
+type ChangePasswordStep struct {
+	//Expired is true if the password is older than the max age of the password age policy
+	Expired bool
+	//Warning is true if the password expires soon, the user is allowed to skip the step
+	Warning  bool
+	DaysLeft uint64
+}
 
 func (s *ChangePasswordStep) Type() NextStepType {
 	return NextStepChangePassword
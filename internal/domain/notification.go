@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"github.com/caos/zitadel/internal/crypto"
+	es_models "github.com/caos/zitadel/internal/eventstore/v1/models"
+)
+
 type NotificationType int32
 
 const (
@@ -12,3 +17,48 @@ const (
 func (f NotificationType) Valid() bool {
 	return f >= 0 && f < notificationCount
 }
+
+//Notification is a message in the outbox which is delivered to the user
+type Notification struct {
+	es_models.ObjectRoot
+
+	UserID      string
+	MessageType string
+	Channel     NotificationType
+	Recipient   string
+	Message     *crypto.CryptoValue
+	State       NotificationState
+	Attempts    uint64
+	//IdempotencyKey identifies the origin of the notification, it can only be queued once per key
+	IdempotencyKey string
+}
+
+func (n *Notification) IsValid() bool {
+	return n.UserID != "" && n.Channel.Valid() && n.Recipient != "" && n.Message != nil
+}
+
+type NotificationState int32
+
+const (
+	NotificationStateUnspecified NotificationState = iota
+	NotificationStateQueued
+	NotificationStateSent
+	NotificationStateRetrying
+	NotificationStateFailed
+
+	notificationStateCount
+)
+
+func (s NotificationState) Valid() bool {
+	return s > NotificationStateUnspecified && s < notificationStateCount
+}
+
+//IsDue returns true if the notification has to be delivered
+func (s NotificationState) IsDue() bool {
+	return s == NotificationStateQueued || s == NotificationStateRetrying
+}
+
+//IsFinished returns true if no further delivery of the notification is planned
+func (s NotificationState) IsFinished() bool {
+	return s == NotificationStateSent || s == NotificationStateFailed
+}
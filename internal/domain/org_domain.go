@@ -14,6 +14,7 @@ type OrgDomain struct {
 	Domain         string
 	Primary        bool
 	Verified       bool
+	LoginDomain    bool
 	ValidationType OrgDomainValidationType
 	ValidationCode *crypto.CryptoValue
 }
@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/caos/zitadel/internal/eventstore/v1/models"
+import (
+	"html/template"
+
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+)
 
 type MailTemplate struct {
 	models.ObjectRoot
@@ -13,3 +17,35 @@ type MailTemplate struct {
 func (m *MailTemplate) IsValid() bool {
 	return m.Template != nil
 }
+
+//MailMessageTemplate replaces the mail template for the emails of the message type
+type MailMessageTemplate struct {
+	models.ObjectRoot
+
+	State       PolicyState
+	Default     bool
+	MessageType string
+	Template    []byte
+}
+
+func (m *MailMessageTemplate) IsValid() bool {
+	return IsMailMessageType(m.MessageType) && IsValidMailTemplate(m.Template)
+}
+
+//IsMailMessageType checks if the message type is sent as email
+func IsMailMessageType(messageType string) bool {
+	return messageType == InitCodeMessageType ||
+		messageType == PasswordResetMessageType ||
+		messageType == VerifyEmailMessageType ||
+		messageType == DomainClaimedMessageType ||
+		messageType == PasswordlessRegistrationMessageType
+}
+
+//IsValidMailTemplate checks if the template can be parsed as html template
+func IsValidMailTemplate(mailTemplate []byte) bool {
+	if len(mailTemplate) == 0 {
+		return false
+	}
+	_, err := template.New("template").Parse(string(mailTemplate))
+	return err == nil
+}
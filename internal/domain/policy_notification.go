@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+)
+
+//NotificationPolicy defines which security alerts are sent to the users
+type NotificationPolicy struct {
+	models.ObjectRoot
+
+	State   PolicyState
+	Default bool
+
+	PasswordChanged bool
+	MFAAdded        bool
+	MFARemoved      bool
+	NewUserAgent    bool
+	UserLocked      bool
+	EmailChanged    bool
+}
+
+//IsAlertEnabled checks if the security alert of the message type is sent
+func (p *NotificationPolicy) IsAlertEnabled(messageType string) bool {
+	if p == nil {
+		return false
+	}
+	switch messageType {
+	case PasswordChangedMessageType:
+		return p.PasswordChanged
+	case MFAAddedMessageType:
+		return p.MFAAdded
+	case MFARemovedMessageType:
+		return p.MFARemoved
+	case NewUserAgentMessageType:
+		return p.NewUserAgent
+	case UserLockedMessageType:
+		return p.UserLocked
+	case EmailChangedMessageType:
+		return p.EmailChanged
+	default:
+		return false
+	}
+}
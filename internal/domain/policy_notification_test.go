@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestNotificationPolicy_IsAlertEnabled(t *testing.T) {
+	type args struct {
+		policy      *NotificationPolicy
+		messageType string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		enabled bool
+	}{
+		{
+			name: "no policy, not enabled",
+			args: args{
+				messageType: PasswordChangedMessageType,
+			},
+		},
+		{
+			name: "alert disabled, not enabled",
+			args: args{
+				policy:      &NotificationPolicy{MFAAdded: true},
+				messageType: PasswordChangedMessageType,
+			},
+		},
+		{
+			name: "alert enabled, enabled",
+			args: args{
+				policy:      &NotificationPolicy{EmailChanged: true},
+				messageType: EmailChangedMessageType,
+			},
+			enabled: true,
+		},
+		{
+			name: "no security alert, not enabled",
+			args: args{
+				policy: &NotificationPolicy{
+					PasswordChanged: true,
+					MFAAdded:        true,
+					MFARemoved:      true,
+					NewUserAgent:    true,
+					UserLocked:      true,
+					EmailChanged:    true,
+				},
+				messageType: InitCodeMessageType,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.args.policy.IsAlertEnabled(tt.args.messageType); got != tt.enabled {
+				t.Errorf("IsAlertEnabled() = %v, want %v", got, tt.enabled)
+			}
+		})
+	}
+}
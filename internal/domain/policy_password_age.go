@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/caos/zitadel/internal/eventstore/v1/models"
 )
 
@@ -10,3 +12,26 @@ type PasswordAgePolicy struct {
 	MaxAgeDays     uint64
 	ExpireWarnDays uint64
 }
+
+//PasswordExpired returns true if the password changed at the given time
+// is older than MaxAgeDays. Passwords never expire if MaxAgeDays is 0
+func (p *PasswordAgePolicy) PasswordExpired(changed, now time.Time) bool {
+	if p == nil || p.MaxAgeDays == 0 || changed.IsZero() {
+		return false
+	}
+	return !now.Before(p.expiry(changed))
+}
+
+//PasswordExpiryWarning returns the remaining days until the password expires
+// and true if the password expires within the ExpireWarnDays
+func (p *PasswordAgePolicy) PasswordExpiryWarning(changed, now time.Time) (daysLeft uint64, warn bool) {
+	if p == nil || p.MaxAgeDays == 0 || p.ExpireWarnDays == 0 || changed.IsZero() || p.PasswordExpired(changed, now) {
+		return 0, false
+	}
+	daysLeft = uint64(p.expiry(changed).Sub(now) / (24 * time.Hour))
+	return daysLeft, daysLeft < p.ExpireWarnDays
+}
+
+func (p *PasswordAgePolicy) expiry(changed time.Time) time.Time {
+	return changed.AddDate(0, 0, int(p.MaxAgeDays))
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordAgePolicy_PasswordExpired(t *testing.T) {
+	now := time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		policy  *PasswordAgePolicy
+		changed time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		expired bool
+	}{
+		{
+			name: "no policy, not expired",
+			args: args{
+				changed: now.AddDate(-1, 0, 0),
+			},
+		},
+		{
+			name: "max age 0, not expired",
+			args: args{
+				policy:  &PasswordAgePolicy{},
+				changed: now.AddDate(-1, 0, 0),
+			},
+		},
+		{
+			name: "never changed, not expired",
+			args: args{
+				policy: &PasswordAgePolicy{MaxAgeDays: 10},
+			},
+		},
+		{
+			name: "younger than max age, not expired",
+			args: args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 10},
+				changed: now.AddDate(0, 0, -9),
+			},
+		},
+		{
+			name: "older than max age, expired",
+			args: args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 10},
+				changed: now.AddDate(0, 0, -10),
+			},
+			expired: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.args.policy.PasswordExpired(tt.args.changed, now))
+		})
+	}
+}
+
+func TestPasswordAgePolicy_PasswordExpiryWarning(t *testing.T) {
+	now := time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		policy  *PasswordAgePolicy
+		changed time.Time
+	}
+	type res struct {
+		daysLeft uint64
+		warn     bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "no warn days, no warning",
+			args: args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 10},
+				changed: now.AddDate(0, 0, -9),
+			},
+		},
+		{
+			name: "before warning window, no warning",
+			args: args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 10, ExpireWarnDays: 3},
+				changed: now.AddDate(0, 0, -5),
+			},
+			res: res{
+				daysLeft: 5,
+			},
+		},
+		{
+			name: "in warning window, warning",
+			args: args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 10, ExpireWarnDays: 3},
+				changed: now.AddDate(0, 0, -8),
+			},
+			res: res{
+				daysLeft: 2,
+				warn:     true,
+			},
+		},
+		{
+			name: "expired, no warning",
+			args: args{
+				policy:  &PasswordAgePolicy{MaxAgeDays: 10, ExpireWarnDays: 3},
+				changed: now.AddDate(0, 0, -11),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daysLeft, warn := tt.args.policy.PasswordExpiryWarning(tt.args.changed, now)
+			assert.Equal(t, tt.res.daysLeft, daysLeft)
+			assert.Equal(t, tt.res.warn, warn)
+		})
+	}
+}
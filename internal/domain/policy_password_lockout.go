@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/caos/zitadel/internal/eventstore/v1/models"
 )
 
@@ -9,5 +11,24 @@ type LockoutPolicy struct {
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	//MaxOTPAttempts limits the failed checks of second factors and passwordless logins
+	MaxOTPAttempts uint64
+	//LockoutDuration defines how long a user stays locked
+	// users are locked until an admin unlocks them if it's 0
+	LockoutDuration time.Duration
+	//MaxIPAttempts limits the failed checks per ip address within the IPThrottleDuration
+	// the failed checks are counted per instance unless they are stored in redis
+	MaxIPAttempts       uint64
+	IPThrottleDuration  time.Duration
 	ShowLockOutFailures bool
 }
+
+//LockedUntil returns the time when a user locked at the given time is unlocked automatically
+// nil is returned if the lock is permanent
+func (p *LockoutPolicy) LockedUntil(locked time.Time) *time.Time {
+	if p == nil || p.LockoutDuration <= 0 {
+		return nil
+	}
+	until := locked.Add(p.LockoutDuration)
+	return &until
+}
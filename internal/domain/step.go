@@ -1,5 +1,10 @@
 package domain
 
+import "time"
+
+//Step is the number of a setup step
+// the steps are executed in ascending order,
+// new steps are registered in the setup package and don't need a constant here
 type Step int
 
 const (
@@ -24,6 +29,34 @@ const (
 	Step19
 	Step20
 	Step21
-	//StepCount marks the the length of possible steps (StepCount-1 == last possible step)
-	StepCount
+	Step22
 )
+
+type StepState int32
+
+const (
+	StepStatePending StepState = iota
+	//StepStateStarted marks a step which was started but not finished, e.g. because the setup was interrupted
+	StepStateStarted
+	StepStateDone
+)
+
+func (s StepState) String() string {
+	switch s {
+	case StepStateStarted:
+		return "started"
+	case StepStateDone:
+		return "done"
+	default:
+		return "pending"
+	}
+}
+
+//StepStatus is the state of a setup step
+// ChangeDate is the creation date of the last event of the step
+type StepStatus struct {
+	Step       Step
+	Name       string
+	State      StepState
+	ChangeDate time.Time
+}
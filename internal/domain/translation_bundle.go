@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"sort"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+)
+
+//TranslationBundle contains all texts of a component in one language
+// it adds languages to the component which aren't shipped with zitadel or replaces the shipped texts of a language
+type TranslationBundle struct {
+	models.ObjectRoot
+
+	Component TranslationComponent
+	Language  language.Tag
+	Messages  map[string]string
+}
+
+type TranslationBundleState int32
+
+const (
+	TranslationBundleStateUnspecified TranslationBundleState = iota
+	TranslationBundleStateActive
+	TranslationBundleStateRemoved
+)
+
+type TranslationComponent int32
+
+const (
+	TranslationComponentUnspecified TranslationComponent = iota
+	TranslationComponentLogin
+	TranslationComponentNotification
+
+	translationComponentCount
+)
+
+func (c TranslationComponent) Valid() bool {
+	return c > TranslationComponentUnspecified && c < translationComponentCount
+}
+
+func (b *TranslationBundle) IsValid() bool {
+	return b.Component.Valid() && b.Language != language.Und && len(b.Messages) > 0
+}
+
+//MissingKeys returns the keys of the reference which have no text in the bundle
+func (b *TranslationBundle) MissingKeys(reference map[string]string) []string {
+	missing := make([]string, 0)
+	for key := range reference {
+		if b.Messages[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
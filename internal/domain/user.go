@@ -27,6 +27,21 @@ func (s UserState) Exists() bool {
 	return s != UserStateUnspecified && s != UserStateDeleted
 }
 
+type UserLockReason int32
+
+const (
+	//UserLockReasonUnspecified is used if the user was locked manually
+	UserLockReasonUnspecified UserLockReason = iota
+	UserLockReasonPasswordAttempts
+	UserLockReasonOTPAttempts
+	UserLockReasonPasswordlessAttempts
+	userLockReasonCount
+)
+
+func (f UserLockReason) Valid() bool {
+	return f >= 0 && f < userLockReasonCount
+}
+
 type UserType int32
 
 const (
@@ -0,0 +1,21 @@
+package domain
+
+//ImportUser is a human user with its related objects created by a bulk import
+type ImportUser struct {
+	Human    *Human
+	IDPLinks []*UserIDPLink
+	Metadata []*Metadata
+	Grants   []*UserGrant
+}
+
+//ImportUserResult reports the outcome of a single row of a bulk import
+// UserID is only set if the user was created, otherwise Err contains the reason
+type ImportUserResult struct {
+	Row    int
+	UserID string
+	Err    error
+}
+
+func (r *ImportUserResult) Succeeded() bool {
+	return r.Err == nil
+}
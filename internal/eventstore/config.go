@@ -1,17 +1,25 @@
 package eventstore
 
 import (
+	database "database/sql"
+
 	"github.com/caos/zitadel/internal/config/types"
+	"github.com/caos/zitadel/internal/eventstore/repository"
 	"github.com/caos/zitadel/internal/eventstore/repository/sql"
 )
 
+type sqlRepository interface {
+	repository.Repository
+	repository.SnapshotRepository
+}
+
 func Start(sqlConfig types.SQL) (*Eventstore, error) {
 	sqlClient, err := sqlConfig.Start()
 	if err != nil {
 		return nil, err
 	}
 
-	return NewEventstore(sql.NewCRDB(sqlClient)), nil
+	return NewEventstore(newSQLRepository(sqlClient, sqlConfig.IsPostgres())), nil
 }
 
 func StartWithUser(baseConfig types.SQLBase, userConfig types.SQLUser) (*Eventstore, error) {
@@ -20,5 +28,37 @@ func StartWithUser(baseConfig types.SQLBase, userConfig types.SQLUser) (*Eventst
 		return nil, err
 	}
 
-	return NewEventstore(sql.NewCRDB(sqlClient)), nil
+	return NewEventstore(newSQLRepository(sqlClient, baseConfig.IsPostgres())), nil
+}
+
+//newSQLRepository returns the repository matching the configured database
+func newSQLRepository(client *database.DB, isPostgres bool) sqlRepository {
+	if isPostgres {
+		return sql.NewPostgres(client)
+	}
+	return sql.NewCRDB(client)
+}
+
+//SnapshotConfig configures the snapshots of write models
+type SnapshotConfig struct {
+	Enabled bool
+	//MinEvents is the count of events which must be reduced since the previous snapshot
+	// before a new snapshot is stored
+	MinEvents uint64
+}
+
+//StartWithSnapshots starts the eventstore like StartWithUser
+// if enabled the snapshots of write models are stored in the eventstore database
+func StartWithSnapshots(baseConfig types.SQLBase, userConfig types.SQLUser, snapshotConfig SnapshotConfig) (*Eventstore, error) {
+	sqlClient, err := userConfig.Start(baseConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := newSQLRepository(sqlClient, baseConfig.IsPostgres())
+	es := NewEventstore(repo)
+	if snapshotConfig.Enabled {
+		es.UseSnapshots(repo, snapshotConfig.MinEvents)
+	}
+	return es, nil
 }
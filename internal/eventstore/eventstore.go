@@ -17,6 +17,9 @@ type Eventstore struct {
 	repo              repository.Repository
 	interceptorMutex  sync.Mutex
 	eventInterceptors map[EventType]eventTypeInterceptors
+
+	snapshots         repository.SnapshotRepository
+	snapshotMinEvents uint64
 }
 
 type eventTypeInterceptors struct {
@@ -183,8 +186,17 @@ type queryReducer interface {
 
 //FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
+// if snapshots are enabled and the reducer implements Snapshotter
+// only the events since the latest snapshot are filtered
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r queryReducer) error {
-	events, err := es.Filter(ctx, r.Query())
+	if s, ok := es.snapshotsEnabled(r); ok {
+		return es.filterToSnapshotter(ctx, s)
+	}
+	return es.filterToQuery(ctx, r.Query(), r)
+}
+
+func (es *Eventstore) filterToQuery(ctx context.Context, query *SearchQueryBuilder, r reducer) error {
+	events, err := es.Filter(ctx, query)
 	if err != nil {
 		return err
 	}
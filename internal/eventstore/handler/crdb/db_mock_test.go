@@ -15,19 +15,40 @@ type mockExpectation func(sqlmock.Sqlmock)
 
 func expectFailureCount(tableName string, projectionName string, failedSeq, failureCount uint64) func(sqlmock.Sqlmock) {
 	return func(m sqlmock.Sqlmock) {
-		m.ExpectQuery(`WITH failures AS \(SELECT failure_count FROM `+tableName+` WHERE projection_name = \$1 AND failed_sequence = \$2\) SELECT IF\(EXISTS\(SELECT failure_count FROM failures\), \(SELECT failure_count FROM failures\), 0\) AS failure_count`).
+		m.ExpectQuery(`WITH failures AS \(SELECT failure_count, skipped FROM `+tableName+` WHERE projection_name = \$1 AND failed_sequence = \$2\) SELECT IF\(EXISTS\(SELECT failure_count FROM failures\), \(SELECT failure_count FROM failures\), 0\) AS failure_count, IF\(EXISTS\(SELECT skipped FROM failures\), \(SELECT skipped FROM failures\), false\) AS skipped`).
 			WithArgs(projectionName, failedSeq).
 			WillReturnRows(
-				sqlmock.NewRows([]string{"failure_count"}).
-					AddRow(failureCount),
+				sqlmock.NewRows([]string{"failure_count", "skipped"}).
+					AddRow(failureCount, false),
 			)
 	}
 }
 
-func expectUpdateFailureCount(tableName string, projectionName string, seq, failureCount uint64) func(sqlmock.Sqlmock) {
+func expectUpdateFailureCount(tableName, errorsTableName string, projectionName string, seq, failureCount uint64) func(sqlmock.Sqlmock) {
 	return func(m sqlmock.Sqlmock) {
-		m.ExpectExec(`UPSERT INTO `+tableName+` \(projection_name, failed_sequence, failure_count, error\) VALUES \(\$1, \$2, \$3, \$4\)`).
+		m.ExpectExec(`UPSERT INTO `+tableName+` \(projection_name, failed_sequence, failure_count, error, last_failed, retry_requested\) VALUES \(\$1, \$2, \$3, \$4, now\(\), false\)`).
 			WithArgs(projectionName, seq, failureCount, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+		m.ExpectExec(`INSERT INTO `+errorsTableName+` \(projection_name, failed_sequence, failure_count, error, creation_date\) VALUES \(\$1, \$2, \$3, \$4, now\(\)\)`).
+			WithArgs(projectionName, seq, failureCount, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+}
+
+func expectSkipFailedEvent(tableName string, projectionName string, seq uint64, reason string) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`UPDATE `+tableName+` SET skipped = true, skip_reason = \$3 WHERE projection_name = \$1 AND failed_sequence = \$2`).
+			WithArgs(projectionName, seq, reason).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+}
+
+func expectRequestedRetries(tableName string, projectionName string, sequences ...uint64) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"failed_sequence"})
+		for _, sequence := range sequences {
+			rows.AddRow(sequence)
+		}
+		m.ExpectQuery(`SELECT failed_sequence FROM ` + tableName + ` WHERE projection_name = \$1 AND retry_requested`).
+			WithArgs(projectionName).
+			WillReturnRows(rows)
 	}
 }
 
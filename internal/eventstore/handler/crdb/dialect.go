@@ -0,0 +1,72 @@
+package crdb
+
+import (
+	"strings"
+
+	"github.com/caos/zitadel/internal/eventstore/handler"
+)
+
+//Dialect defines the sql syntax of the database the projections are stored in
+type Dialect int32
+
+const (
+	//DialectCockroach is the default dialect
+	DialectCockroach Dialect = iota
+	DialectPostgres
+)
+
+const (
+	pgUpdateCurrentSequencesStmtFormat = `INSERT INTO %s (projection_name, aggregate_type, current_sequence, timestamp) VALUES `
+	pgUpdateCurrentSequencesConflict   = ` ON CONFLICT (projection_name, aggregate_type)` +
+		` DO UPDATE SET current_sequence = EXCLUDED.current_sequence, timestamp = EXCLUDED.timestamp`
+	pgSetFailureCountStmtFormat = "INSERT INTO %s" +
+		" (projection_name, failed_sequence, failure_count, error, last_failed, retry_requested)" +
+		" VALUES ($1, $2, $3, $4, now(), false)" +
+		" ON CONFLICT (projection_name, failed_sequence)" +
+		" DO UPDATE SET failure_count = EXCLUDED.failure_count, error = EXCLUDED.error," +
+		" last_failed = EXCLUDED.last_failed, retry_requested = EXCLUDED.retry_requested"
+	pgFailureCountStmtFormat = "WITH failures AS (SELECT failure_count, skipped FROM %s WHERE projection_name = $1 AND failed_sequence = $2)" +
+		" SELECT COALESCE((SELECT failure_count FROM failures), 0) AS failure_count," +
+		" COALESCE((SELECT skipped FROM failures), false) AS skipped"
+)
+
+//dialectExecuter passes the dialect of the statement handler
+// to the statements executed in the transaction
+type dialectExecuter struct {
+	handler.Executer
+	dialect Dialect
+}
+
+func (h *StatementHandler) executer(ex handler.Executer) handler.Executer {
+	if h.dialect == DialectCockroach {
+		return ex
+	}
+	return &dialectExecuter{Executer: ex, dialect: h.dialect}
+}
+
+func dialectOf(ex handler.Executer) Dialect {
+	if ex, ok := ex.(*dialectExecuter); ok {
+		return ex.dialect
+	}
+	return DialectCockroach
+}
+
+//upsertQuery inserts the row or updates the given columns
+// if a row with the same primary key exists
+func upsertQuery(dialect Dialect, tableName string, columnNames []string, values string) string {
+	if dialect != DialectPostgres {
+		return "UPSERT INTO " + tableName + " (" + strings.Join(columnNames, ", ") + ") " + values
+	}
+	updates := make([]string, len(columnNames))
+	for i, name := range columnNames {
+		updates[i] = name + " = EXCLUDED." + name
+	}
+	return "INSERT INTO " + tableName + " (" + strings.Join(columnNames, ", ") + ") " + values +
+		" ON CONFLICT ON CONSTRAINT " + primaryKeyName(tableName) +
+		" DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+//primaryKeyName returns the name postgres uses for the primary key of the table
+func primaryKeyName(tableName string) string {
+	return tableName[strings.LastIndex(tableName, ".")+1:] + "_pkey"
+}
@@ -0,0 +1,137 @@
+package crdb
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/eventstore/handler"
+)
+
+func TestPostgresStatements(t *testing.T) {
+	type args struct {
+		table string
+		stmt  *handler.Statement
+	}
+	type want struct {
+		executer *wantExecuter
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "upsert",
+			args: args{
+				table: "projections.my_table",
+				stmt: NewUpsertStatement(
+					&testEvent{
+						aggregateType: "agg",
+						sequence:      1,
+					},
+					[]handler.Column{
+						{
+							Name:  "col1",
+							Value: "val",
+						},
+						{
+							Name:  "col2",
+							Value: 1,
+						},
+					},
+				),
+			},
+			want: want{
+				executer: &wantExecuter{
+					params: []params{
+						{
+							query: "INSERT INTO projections.my_table (col1, col2) VALUES ($1, $2)" +
+								" ON CONFLICT ON CONSTRAINT my_table_pkey DO UPDATE SET col1 = EXCLUDED.col1, col2 = EXCLUDED.col2",
+							args: []interface{}{"val", 1},
+						},
+					},
+					shouldExecute: true,
+				},
+			},
+		},
+		{
+			name: "copy",
+			args: args{
+				table: "projections.my_table",
+				stmt: NewCopyStatement(
+					&testEvent{
+						aggregateType: "agg",
+						sequence:      1,
+					},
+					[]handler.Column{
+						{
+							Name:  "state",
+							Value: 1,
+						},
+						{
+							Name: "id",
+						},
+					},
+					[]handler.Condition{
+						{
+							Name:  "id",
+							Value: 2,
+						},
+					},
+				),
+			},
+			want: want{
+				executer: &wantExecuter{
+					params: []params{
+						{
+							query: "INSERT INTO projections.my_table (state, id) SELECT $1, id FROM projections.my_table AS copy_table WHERE copy_table.id = $2" +
+								" ON CONFLICT ON CONSTRAINT my_table_pkey DO UPDATE SET state = EXCLUDED.state, id = EXCLUDED.id",
+							args: []interface{}{1, 2},
+						},
+					},
+					shouldExecute: true,
+				},
+			},
+		},
+		{
+			name: "create unchanged",
+			args: args{
+				table: "projections.my_table",
+				stmt: NewCreateStatement(
+					&testEvent{
+						aggregateType: "agg",
+						sequence:      1,
+					},
+					[]handler.Column{
+						{
+							Name:  "col1",
+							Value: "val",
+						},
+					},
+				),
+			},
+			want: want{
+				executer: &wantExecuter{
+					params: []params{
+						{
+							query: "INSERT INTO projections.my_table (col1) VALUES ($1)",
+							args:  []interface{}{"val"},
+						},
+					},
+					shouldExecute: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.executer.t = t
+			ex := &dialectExecuter{Executer: tt.want.executer, dialect: DialectPostgres}
+
+			err := tt.args.stmt.Execute(ex, tt.args.table)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			tt.want.executer.check(t)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package crdb
+
+import (
+	"sync"
+
+	"github.com/caos/zitadel/internal/eventstore"
+)
+
+var (
+	reducedEventTypesMu sync.RWMutex
+	reducedEventTypes   = make(map[string]map[eventstore.AggregateType][]eventstore.EventType)
+)
+
+//registerEventTypes stores the event types reduced by the projection
+// so the state of the projection only considers the events it reduces
+func registerEventTypes(projectionName string, eventTypes map[eventstore.AggregateType][]eventstore.EventType) {
+	reducedEventTypesMu.Lock()
+	defer reducedEventTypesMu.Unlock()
+	reducedEventTypes[projectionName] = eventTypes
+}
+
+//ReducedEventTypes returns the event types per aggregate type
+// of the projections started by this instance
+func ReducedEventTypes() map[string]map[eventstore.AggregateType][]eventstore.EventType {
+	reducedEventTypesMu.RLock()
+	defer reducedEventTypesMu.RUnlock()
+	eventTypes := make(map[string]map[eventstore.AggregateType][]eventstore.EventType, len(reducedEventTypes))
+	for projectionName, types := range reducedEventTypes {
+		eventTypes[projectionName] = types
+	}
+	return eventTypes
+}
@@ -1,47 +1,99 @@
 package crdb
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/caos/logging"
 	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/eventstore/handler"
 )
 
 const (
 	setFailureCountStmtFormat = "UPSERT INTO %s" +
-		" (projection_name, failed_sequence, failure_count, error)" +
-		" VALUES ($1, $2, $3, $4)"
-	failureCountStmtFormat = "WITH failures AS (SELECT failure_count FROM %s WHERE projection_name = $1 AND failed_sequence = $2)" +
+		" (projection_name, failed_sequence, failure_count, error, last_failed, retry_requested)" +
+		" VALUES ($1, $2, $3, $4, now(), false)"
+	failureCountStmtFormat = "WITH failures AS (SELECT failure_count, skipped FROM %s WHERE projection_name = $1 AND failed_sequence = $2)" +
 		" SELECT IF(" +
 		"EXISTS(SELECT failure_count FROM failures)," +
 		" (SELECT failure_count FROM failures)," +
 		" 0" +
-		") AS failure_count"
+		") AS failure_count," +
+		" IF(" +
+		"EXISTS(SELECT skipped FROM failures)," +
+		" (SELECT skipped FROM failures)," +
+		" false" +
+		") AS skipped"
+	skipFailedEventStmtFormat = "UPDATE %s SET skipped = true, skip_reason = $3" +
+		" WHERE projection_name = $1 AND failed_sequence = $2"
+	removeFailedEventStmtFormat   = "DELETE FROM %s WHERE projection_name = $1 AND failed_sequence = $2"
+	addFailedEventErrorStmtFormat = "INSERT INTO %s" +
+		" (projection_name, failed_sequence, failure_count, error, creation_date)" +
+		" VALUES ($1, $2, $3, $4, now())"
+	requestedRetriesStmtFormat = "SELECT failed_sequence FROM %s WHERE projection_name = $1 AND retry_requested"
+
+	maxFailureCountReason = "max failure count reached"
+	retryFailedReason     = "retry failed"
 )
 
-func (h *StatementHandler) handleFailedStmt(tx *sql.Tx, stmt *handler.Statement, execErr error) (shouldContinue bool) {
-	failureCount, err := h.failureCount(tx, stmt.Sequence)
+//EventSkipped is called as soon as the handler gives up on an event
+// because it reached the max failure count
+// it's called after the transaction which skipped the event is committed
+type EventSkipped func(ctx context.Context, projectionName string, sequence uint64, failureCount uint, err error)
+
+//skippedStmt is a statement the handler gave up on in the current transaction
+type skippedStmt struct {
+	sequence     uint64
+	failureCount uint
+	err          error
+}
+
+func (h *StatementHandler) handleFailedStmt(tx *sql.Tx, stmt *handler.Statement, execErr error) (shouldContinue bool, skip *skippedStmt) {
+	failureCount, skipped, err := h.failureCount(tx, stmt.Sequence)
 	if err != nil {
 		logging.LogWithFields("CRDB-WJaFk", "projection", h.ProjectionName, "seq", stmt.Sequence).WithError(err).Warn("unable to get failure count")
-		return false
+		return false, nil
 	}
 	failureCount += 1
 	err = h.setFailureCount(tx, stmt.Sequence, failureCount, execErr)
 	logging.LogWithFields("CRDB-cI0dB", "projection", h.ProjectionName, "seq", stmt.Sequence).OnError(err).Warn("unable to update failure count")
 
-	return failureCount >= h.maxFailureCount
+	//skipped manually
+	if skipped {
+		return true, nil
+	}
+	if failureCount < h.maxFailureCount {
+		return false, nil
+	}
+
+	err = h.skipFailedEvent(tx, stmt.Sequence, maxFailureCountReason)
+	if err != nil {
+		logging.LogWithFields("CRDB-Ssf1L", "projection", h.ProjectionName, "seq", stmt.Sequence).WithError(err).Warn("unable to skip failed event")
+		return true, nil
+	}
+	return true, &skippedStmt{sequence: stmt.Sequence, failureCount: failureCount, err: execErr}
+}
+
+//eventsSkipped calls the event skipped callback for the statements skipped in the committed transaction
+func (h *StatementHandler) eventsSkipped(ctx context.Context, skipped []*skippedStmt) {
+	if h.eventSkipped == nil {
+		return
+	}
+	for _, stmt := range skipped {
+		h.eventSkipped(ctx, h.ProjectionName, stmt.sequence, stmt.failureCount, stmt.err)
+	}
 }
 
-func (h *StatementHandler) failureCount(tx *sql.Tx, seq uint64) (count uint, err error) {
+func (h *StatementHandler) failureCount(tx *sql.Tx, seq uint64) (count uint, skipped bool, err error) {
 	row := tx.QueryRow(h.failureCountStmt, h.ProjectionName, seq)
 	if err = row.Err(); err != nil {
-		return 0, errors.ThrowInternal(err, "CRDB-Unnex", "unable to update failure count")
+		return 0, false, errors.ThrowInternal(err, "CRDB-Unnex", "unable to update failure count")
 	}
-	if err = row.Scan(&count); err != nil {
-		return 0, errors.ThrowInternal(err, "CRDB-RwSMV", "unable to scann count")
+	if err = row.Scan(&count, &skipped); err != nil {
+		return 0, false, errors.ThrowInternal(err, "CRDB-RwSMV", "unable to scann count")
 	}
-	return count, nil
+	return count, skipped, nil
 }
 
 func (h *StatementHandler) setFailureCount(tx *sql.Tx, seq uint64, count uint, err error) error {
@@ -49,5 +101,110 @@ func (h *StatementHandler) setFailureCount(tx *sql.Tx, seq uint64, count uint, e
 	if dbErr != nil {
 		return errors.ThrowInternal(dbErr, "CRDB-4Ht4x", "set failure count failed")
 	}
+	_, dbErr = tx.Exec(h.addFailedEventErrorStmt, h.ProjectionName, seq, count, err.Error())
+	if dbErr != nil {
+		return errors.ThrowInternal(dbErr, "CRDB-g7nPK", "add failure to history failed")
+	}
+	return nil
+}
+
+func (h *StatementHandler) skipFailedEvent(tx *sql.Tx, seq uint64, reason string) error {
+	_, err := tx.Exec(h.skipFailedEventStmt, h.ProjectionName, seq, reason)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-ZwK1t", "skip failed event failed")
+	}
 	return nil
 }
+
+func (h *StatementHandler) removeFailedEvent(tx *sql.Tx, seq uint64) error {
+	_, err := tx.Exec(h.removeFailedEventStmt, h.ProjectionName, seq)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-0fWqa", "remove failed event failed")
+	}
+	_, err = tx.Exec(h.removeFailedEventErrorsStmt, h.ProjectionName, seq)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-pLe3c", "remove failed event history failed")
+	}
+	return nil
+}
+
+func (h *StatementHandler) requestedRetries(tx *sql.Tx) (sequences []uint64, err error) {
+	rows, err := tx.Query(h.requestedRetriesStmt, h.ProjectionName)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-Kx8lA", "unable to query requested retries")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sequence uint64
+		if err = rows.Scan(&sequence); err != nil {
+			return nil, errors.ThrowInternal(err, "CRDB-vB0sR", "scan failed")
+		}
+		sequences = append(sequences, sequence)
+	}
+
+	if err = rows.Close(); err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-1mQwD", "close rows failed")
+	}
+
+	return sequences, rows.Err()
+}
+
+//executeRetries executes the statements of skipped events
+// an administrator requested to retry
+// events which were not skipped are retried by the regular processing
+func (h *StatementHandler) executeRetries(
+	ctx context.Context,
+	tx *sql.Tx,
+	sequences currentSequences,
+	reduce handler.Reduce,
+) error {
+	retries, err := h.requestedRetries(tx)
+	if err != nil || len(retries) == 0 {
+		return err
+	}
+
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent)
+	for _, sequence := range retries {
+		query.
+			AddQuery().
+			AggregateTypes(h.aggregates...).
+			SequenceGreater(sequence - 1).
+			SequenceLess(sequence + 1)
+	}
+
+	events, err := h.Eventstore.Filter(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if event.Sequence() > sequences[event.Aggregate().Type] {
+			continue
+		}
+		stmt, err := reduce(event)
+		if err == nil {
+			err = h.executeStmt(tx, stmt)
+		}
+		if err != nil {
+			h.handleFailedRetry(tx, event.Sequence(), err)
+			continue
+		}
+		err = h.removeFailedEvent(tx, event.Sequence())
+		logging.LogWithFields("CRDB-3Mgg1", "projection", h.ProjectionName, "seq", event.Sequence()).OnError(err).Warn("unable to remove retried event")
+	}
+	return nil
+}
+
+//handleFailedRetry adds the failure of the retry to the history
+// and skips the event again
+func (h *StatementHandler) handleFailedRetry(tx *sql.Tx, seq uint64, execErr error) {
+	failureCount, _, err := h.failureCount(tx, seq)
+	if err == nil {
+		err = h.setFailureCount(tx, seq, failureCount+1, execErr)
+	}
+	if err == nil {
+		err = h.skipFailedEvent(tx, seq, retryFailedReason)
+	}
+	logging.LogWithFields("CRDB-xY2vK", "projection", h.ProjectionName, "seq", seq).OnError(err).Warn("unable to handle failed retry")
+}
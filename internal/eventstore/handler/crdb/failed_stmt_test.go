@@ -0,0 +1,222 @@
+package crdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	es_repo_mock "github.com/caos/zitadel/internal/eventstore/repository/mock"
+)
+
+func expectRemoveFailedEvent(tableName, errorsTableName, projectionName string, seq uint64) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`DELETE FROM `+tableName+` WHERE projection_name = \$1 AND failed_sequence = \$2`).
+			WithArgs(projectionName, seq).WillReturnResult(sqlmock.NewResult(1, 1))
+		m.ExpectExec(`DELETE FROM `+errorsTableName+` WHERE projection_name = \$1 AND failed_sequence = \$2`).
+			WithArgs(projectionName, seq).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+}
+
+func TestStatementHandler_executeRetries(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		sequences currentSequences
+		reduce    handler.Reduce
+	}
+	type want struct {
+		expectations []mockExpectation
+		isErr        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "no retries requested",
+			args: args{
+				sequences: currentSequences{"testAgg": 10},
+				reduce:    testReduce(),
+			},
+			want: want{
+				expectations: []mockExpectation{
+					expectRequestedRetries("failed_events", "my_projection"),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name: "filter fails",
+			fields: fields{
+				eventstore: eventstore.NewEventstore(
+					es_repo_mock.NewRepo(t).ExpectFilterEventsError(errFilter),
+				),
+			},
+			args: args{
+				sequences: currentSequences{"testAgg": 10},
+				reduce:    testReduce(),
+			},
+			want: want{
+				expectations: []mockExpectation{
+					expectRequestedRetries("failed_events", "my_projection", 7),
+				},
+				isErr: func(err error) bool {
+					return errors.Is(err, errFilter)
+				},
+			},
+		},
+		{
+			name: "event not processed yet",
+			fields: fields{
+				eventstore: eventstore.NewEventstore(
+					es_repo_mock.NewRepo(t).ExpectFilterEvents(
+						&repository.Event{
+							ID:            "id",
+							Sequence:      7,
+							CreationDate:  time.Now(),
+							Type:          "test.added",
+							Version:       "v1",
+							AggregateID:   "testid",
+							AggregateType: "testAgg",
+						},
+					),
+				),
+			},
+			args: args{
+				sequences: currentSequences{"testAgg": 5},
+				reduce:    testReduce(),
+			},
+			want: want{
+				expectations: []mockExpectation{
+					expectRequestedRetries("failed_events", "my_projection", 7),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name: "retry succeeds",
+			fields: fields{
+				eventstore: eventstore.NewEventstore(
+					es_repo_mock.NewRepo(t).ExpectFilterEvents(
+						&repository.Event{
+							ID:            "id",
+							Sequence:      7,
+							CreationDate:  time.Now(),
+							Type:          "test.added",
+							Version:       "v1",
+							AggregateID:   "testid",
+							AggregateType: "testAgg",
+						},
+					),
+				),
+			},
+			args: args{
+				sequences: currentSequences{"testAgg": 10},
+				reduce:    testReduce(),
+			},
+			want: want{
+				expectations: []mockExpectation{
+					expectRequestedRetries("failed_events", "my_projection", 7),
+					expectRemoveFailedEvent("failed_events", "failed_event_errors", "my_projection", 7),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name: "retry fails",
+			fields: fields{
+				eventstore: eventstore.NewEventstore(
+					es_repo_mock.NewRepo(t).ExpectFilterEvents(
+						&repository.Event{
+							ID:            "id",
+							Sequence:      7,
+							CreationDate:  time.Now(),
+							Type:          "test.added",
+							Version:       "v1",
+							AggregateID:   "testid",
+							AggregateType: "testAgg",
+						},
+					),
+				),
+			},
+			args: args{
+				sequences: currentSequences{"testAgg": 10},
+				reduce:    testReduceErr(errReduce),
+			},
+			want: want{
+				expectations: []mockExpectation{
+					expectRequestedRetries("failed_events", "my_projection", 7),
+					expectFailureCount("failed_events", "my_projection", 7, 0),
+					expectUpdateFailureCount("failed_events", "failed_event_errors", "my_projection", 7, 1),
+					expectSkipFailedEvent("failed_events", "my_projection", 7, retryFailedReason),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			h := NewStatementHandler(context.Background(), StatementHandlerConfig{
+				ProjectionHandlerConfig: handler.ProjectionHandlerConfig{
+					ProjectionName: "my_projection",
+					HandlerConfig: handler.HandlerConfig{
+						Eventstore: tt.fields.eventstore,
+					},
+					RequeueEvery: 0,
+				},
+				Client:                 client,
+				FailedEventsTable:      "failed_events",
+				FailedEventErrorsTable: "failed_event_errors",
+			})
+			h.aggregates = []eventstore.AggregateType{"testAgg"}
+
+			mock.ExpectBegin()
+			for _, expectation := range tt.want.expectations {
+				expectation(mock)
+			}
+			mock.ExpectCommit()
+
+			tx, err := client.Begin()
+			if err != nil {
+				t.Fatalf("unexpected err in begin: %v", err)
+			}
+
+			err = h.executeRetries(context.Background(), tx, tt.args.sequences, tt.args.reduce)
+			if !tt.want.isErr(err) {
+				t.Errorf("StatementHandler.executeRetries() error = %v", err)
+			}
+
+			if err := tx.Commit(); err != nil {
+				t.Fatalf("unexpected err in commit: %v", err)
+			}
+
+			mock.MatchExpectationsInOrder(true)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
@@ -19,12 +19,15 @@ var (
 type StatementHandlerConfig struct {
 	handler.ProjectionHandlerConfig
 
-	Client            *sql.DB
-	SequenceTable     string
-	LockTable         string
-	FailedEventsTable string
-	MaxFailureCount   uint
-	BulkLimit         uint64
+	Client                 *sql.DB
+	SequenceTable          string
+	LockTable              string
+	FailedEventsTable      string
+	FailedEventErrorsTable string
+	MaxFailureCount        uint
+	BulkLimit              uint64
+	EventSkipped           EventSkipped
+	Dialect                Dialect
 
 	Reducers []handler.AggregateReducer
 }
@@ -34,13 +37,22 @@ type StatementHandler struct {
 	Locker
 
 	client                  *sql.DB
+	dialect                 Dialect
 	sequenceTable           string
 	currentSequenceStmt     string
 	updateSequencesBaseStmt string
+	updateSequencesSuffix   string
 	maxFailureCount         uint
 	failureCountStmt        string
 	setFailureCountStmt     string
 
+	skipFailedEventStmt         string
+	removeFailedEventStmt       string
+	removeFailedEventErrorsStmt string
+	addFailedEventErrorStmt     string
+	requestedRetriesStmt        string
+	eventSkipped                EventSkipped
+
 	aggregates []eventstore.AggregateType
 	reduces    map[eventstore.EventType]handler.Reduce
 
@@ -53,26 +65,42 @@ func NewStatementHandler(
 ) StatementHandler {
 	aggregateTypes := make([]eventstore.AggregateType, 0, len(config.Reducers))
 	reduces := make(map[eventstore.EventType]handler.Reduce, len(config.Reducers))
+	eventTypes := make(map[eventstore.AggregateType][]eventstore.EventType, len(config.Reducers))
 	for _, aggReducer := range config.Reducers {
 		aggregateTypes = append(aggregateTypes, aggReducer.Aggregate)
 		for _, eventReducer := range aggReducer.EventRedusers {
 			reduces[eventReducer.Event] = eventReducer.Reduce
+			eventTypes[aggReducer.Aggregate] = append(eventTypes[aggReducer.Aggregate], eventReducer.Event)
 		}
 	}
+	registerEventTypes(config.ProjectionName, eventTypes)
 
 	h := StatementHandler{
-		ProjectionHandler:       handler.NewProjectionHandler(config.ProjectionHandlerConfig),
-		client:                  config.Client,
-		sequenceTable:           config.SequenceTable,
-		maxFailureCount:         config.MaxFailureCount,
-		currentSequenceStmt:     fmt.Sprintf(currentSequenceStmtFormat, config.SequenceTable),
-		updateSequencesBaseStmt: fmt.Sprintf(updateCurrentSequencesStmtFormat, config.SequenceTable),
-		failureCountStmt:        fmt.Sprintf(failureCountStmtFormat, config.FailedEventsTable),
-		setFailureCountStmt:     fmt.Sprintf(setFailureCountStmtFormat, config.FailedEventsTable),
-		aggregates:              aggregateTypes,
-		reduces:                 reduces,
-		bulkLimit:               config.BulkLimit,
-		Locker:                  NewLocker(config.Client, config.LockTable, config.ProjectionHandlerConfig.ProjectionName),
+		ProjectionHandler:           handler.NewProjectionHandler(config.ProjectionHandlerConfig),
+		client:                      config.Client,
+		dialect:                     config.Dialect,
+		sequenceTable:               config.SequenceTable,
+		maxFailureCount:             config.MaxFailureCount,
+		currentSequenceStmt:         fmt.Sprintf(currentSequenceStmtFormat, config.SequenceTable),
+		updateSequencesBaseStmt:     fmt.Sprintf(updateCurrentSequencesStmtFormat, config.SequenceTable),
+		failureCountStmt:            fmt.Sprintf(failureCountStmtFormat, config.FailedEventsTable),
+		setFailureCountStmt:         fmt.Sprintf(setFailureCountStmtFormat, config.FailedEventsTable),
+		skipFailedEventStmt:         fmt.Sprintf(skipFailedEventStmtFormat, config.FailedEventsTable),
+		removeFailedEventStmt:       fmt.Sprintf(removeFailedEventStmtFormat, config.FailedEventsTable),
+		removeFailedEventErrorsStmt: fmt.Sprintf(removeFailedEventStmtFormat, config.FailedEventErrorsTable),
+		addFailedEventErrorStmt:     fmt.Sprintf(addFailedEventErrorStmtFormat, config.FailedEventErrorsTable),
+		requestedRetriesStmt:        fmt.Sprintf(requestedRetriesStmtFormat, config.FailedEventsTable),
+		eventSkipped:                config.EventSkipped,
+		aggregates:                  aggregateTypes,
+		reduces:                     reduces,
+		bulkLimit:                   config.BulkLimit,
+		Locker:                      NewLocker(config.Client, config.LockTable, config.ProjectionHandlerConfig.ProjectionName),
+	}
+	if config.Dialect == DialectPostgres {
+		h.updateSequencesBaseStmt = fmt.Sprintf(pgUpdateCurrentSequencesStmtFormat, config.SequenceTable)
+		h.updateSequencesSuffix = pgUpdateCurrentSequencesConflict
+		h.failureCountStmt = fmt.Sprintf(pgFailureCountStmtFormat, config.FailedEventsTable)
+		h.setFailureCountStmt = fmt.Sprintf(pgSetFailureCountStmtFormat, config.FailedEventsTable)
 	}
 
 	go h.ProjectionHandler.Process(
@@ -119,6 +147,9 @@ func (h *StatementHandler) Update(ctx context.Context, stmts []*handler.Statemen
 		return stmts, err
 	}
 
+	err = h.executeRetries(ctx, tx, sequences, reduce)
+	logging.LogWithFields("CRDB-Ox5Pe", "projection", h.ProjectionName).OnError(err).Warn("unable to retry failed events")
+
 	//checks for events between create statement and current sequence
 	// because there could be events between current sequence and a creation event
 	// and we cannot check via stmt.PreviousSequence
@@ -131,7 +162,7 @@ func (h *StatementHandler) Update(ctx context.Context, stmts []*handler.Statemen
 		stmts = append(previousStmts, stmts...)
 	}
 
-	lastSuccessfulIdx := h.executeStmts(tx, stmts, sequences)
+	lastSuccessfulIdx, skipped := h.executeStmts(tx, stmts, sequences)
 
 	if lastSuccessfulIdx >= 0 {
 		err = h.updateCurrentSequences(tx, sequences)
@@ -144,6 +175,7 @@ func (h *StatementHandler) Update(ctx context.Context, stmts []*handler.Statemen
 	if err = tx.Commit(); err != nil {
 		return stmts, err
 	}
+	h.eventsSkipped(ctx, skipped)
 
 	if lastSuccessfulIdx == -1 {
 		return stmts, handler.ErrSomeStmtsFailed
@@ -206,9 +238,9 @@ func (h *StatementHandler) executeStmts(
 	tx *sql.Tx,
 	stmts []*handler.Statement,
 	sequences currentSequences,
-) int {
+) (lastSuccessfulIdx int, skipped []*skippedStmt) {
 
-	lastSuccessfulIdx := -1
+	lastSuccessfulIdx = -1
 	for i, stmt := range stmts {
 		if stmt.Sequence <= sequences[stmt.AggregateType] {
 			continue
@@ -223,14 +255,17 @@ func (h *StatementHandler) executeStmts(
 			continue
 		}
 
-		shouldContinue := h.handleFailedStmt(tx, stmt, err)
+		shouldContinue, skippedStmt := h.handleFailedStmt(tx, stmt, err)
+		if skippedStmt != nil {
+			skipped = append(skipped, skippedStmt)
+		}
 		if !shouldContinue {
 			break
 		}
 
 		sequences[stmt.AggregateType], lastSuccessfulIdx = stmt.Sequence, i
 	}
-	return lastSuccessfulIdx
+	return lastSuccessfulIdx, skipped
 }
 
 //executeStmt handles sql statements
@@ -243,7 +278,7 @@ func (h *StatementHandler) executeStmt(tx *sql.Tx, stmt *handler.Statement) erro
 	if err != nil {
 		return errors.ThrowInternal(err, "CRDB-i1wp6", "unable to create savepoint")
 	}
-	err = stmt.Execute(tx, h.ProjectionName)
+	err = stmt.Execute(h.executer(tx), h.ProjectionName)
 	if err != nil {
 		_, rollbackErr := tx.Exec("ROLLBACK TO SAVEPOINT push_stmt")
 		if rollbackErr != nil {
@@ -224,6 +224,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectRollback(),
 				},
 				isErr: func(err error) bool {
@@ -261,6 +262,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectCommit(),
 				},
 				isErr: func(err error) bool {
@@ -298,6 +300,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "agg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectSavePoint(),
 					expectCreate("my_projection", []string{"col"}, []string{"$1"}),
 					expectSavePointRelease(),
@@ -339,6 +342,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "agg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectSavePoint(),
 					expectCreate("my_projection", []string{"col"}, []string{"$1"}),
 					expectSavePointRelease(),
@@ -373,6 +377,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectUpdateCurrentSequence("my_sequences", "my_projection", 7, "testAgg"),
 					expectCommit(),
 				},
@@ -404,6 +409,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectUpdateCurrentSequence("my_sequences", "my_projection", 7, "testAgg"),
 					expectCommit(),
 				},
@@ -441,6 +447,7 @@ func TestStatementHandler_Update(t *testing.T) {
 				expectations: []mockExpectation{
 					expectBegin(),
 					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg"),
+					expectRequestedRetries("failed_events", "my_projection"),
 					expectUpdateCurrentSequence("my_sequences", "my_projection", 7, "testAgg"),
 					expectCommit(),
 				},
@@ -466,8 +473,9 @@ func TestStatementHandler_Update(t *testing.T) {
 					},
 					RequeueEvery: 0,
 				},
-				SequenceTable: "my_sequences",
-				Client:        client,
+				SequenceTable:     "my_sequences",
+				FailedEventsTable: "failed_events",
+				Client:            client,
 			})
 
 			h.aggregates = tt.fields.aggregates
@@ -690,6 +698,7 @@ func TestStatementHandler_executeStmts(t *testing.T) {
 	type want struct {
 		expectations []mockExpectation
 		idx          int
+		skipped      []*skippedStmt
 	}
 	tests := []struct {
 		name   string
@@ -831,7 +840,7 @@ func TestStatementHandler_executeStmts(t *testing.T) {
 					expectCreateErr("my_projection", []string{"col"}, []string{"$1"}, sql.ErrConnDone),
 					expectSavePointRollback(),
 					expectFailureCount("failed_events", "my_projection", 6, 3),
-					expectUpdateFailureCount("failed_events", "my_projection", 6, 4),
+					expectUpdateFailureCount("failed_events", "failed_event_errors", "my_projection", 6, 4),
 				},
 				idx: 0,
 			},
@@ -895,12 +904,19 @@ func TestStatementHandler_executeStmts(t *testing.T) {
 					expectCreateErr("my_projection", []string{"col2"}, []string{"$1"}, sql.ErrConnDone),
 					expectSavePointRollback(),
 					expectFailureCount("failed_events", "my_projection", 6, 4),
-					expectUpdateFailureCount("failed_events", "my_projection", 6, 5),
+					expectUpdateFailureCount("failed_events", "failed_event_errors", "my_projection", 6, 5),
+					expectSkipFailedEvent("failed_events", "my_projection", 6, maxFailureCountReason),
 					expectSavePoint(),
 					expectCreate("my_projection", []string{"col3"}, []string{"$1"}),
 					expectSavePointRelease(),
 				},
 				idx: 2,
+				skipped: []*skippedStmt{
+					{
+						sequence:     6,
+						failureCount: 5,
+					},
+				},
 			},
 		},
 		{
@@ -1012,9 +1028,10 @@ func TestStatementHandler_executeStmts(t *testing.T) {
 						ProjectionName: tt.fields.projectionName,
 						RequeueEvery:   0,
 					},
-					Client:            client,
-					FailedEventsTable: tt.fields.failedEventsTable,
-					MaxFailureCount:   tt.fields.maxFailureCount,
+					Client:                 client,
+					FailedEventsTable:      tt.fields.failedEventsTable,
+					FailedEventErrorsTable: "failed_event_errors",
+					MaxFailureCount:        tt.fields.maxFailureCount,
 				},
 			)
 
@@ -1031,10 +1048,18 @@ func TestStatementHandler_executeStmts(t *testing.T) {
 				t.Fatalf("unexpected err in begin: %v", err)
 			}
 
-			idx := h.executeStmts(tx, tt.args.stmts, tt.args.sequences)
+			idx, skipped := h.executeStmts(tx, tt.args.stmts, tt.args.sequences)
 			if idx != tt.want.idx {
 				t.Errorf("unexpected index want: %d got %d", tt.want.idx, idx)
 			}
+			if len(skipped) != len(tt.want.skipped) {
+				t.Fatalf("unexpected skipped count want: %d got %d", len(tt.want.skipped), len(skipped))
+			}
+			for i, skip := range skipped {
+				if skip.sequence != tt.want.skipped[i].sequence || skip.failureCount != tt.want.skipped[i].failureCount || skip.err == nil {
+					t.Errorf("unexpected skipped want: %+v got %+v", tt.want.skipped[i], skip)
+				}
+			}
 
 			if err := tx.Commit(); err != nil {
 				t.Fatalf("unexpected err in commit: %v", err)
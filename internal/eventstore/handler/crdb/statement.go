@@ -14,6 +14,7 @@ import (
 type execOption func(*execConfig)
 type execConfig struct {
 	tableName string
+	dialect   Dialect
 
 	args []interface{}
 	err  error
@@ -52,7 +53,6 @@ func NewCreateStatement(event eventstore.Event, values []handler.Column, opts ..
 
 func NewUpsertStatement(event eventstore.Event, values []handler.Column, opts ...execOption) *handler.Statement {
 	cols, params, args := columnsToQuery(values)
-	columnNames := strings.Join(cols, ", ")
 	valuesPlaceholder := strings.Join(params, ", ")
 
 	config := execConfig{
@@ -64,7 +64,7 @@ func NewUpsertStatement(event eventstore.Event, values []handler.Column, opts ..
 	}
 
 	q := func(config execConfig) string {
-		return "UPSERT INTO " + config.tableName + " (" + columnNames + ") VALUES (" + valuesPlaceholder + ")"
+		return upsertQuery(config.dialect, config.tableName, cols, "VALUES ("+valuesPlaceholder+")")
 	}
 
 	return &handler.Statement{
@@ -141,6 +141,18 @@ func NewNoOpStatement(event eventstore.Event) *handler.Statement {
 	}
 }
 
+//NewStatement creates a statement which runs the given exec
+// it's used by handlers which don't write the event to a table
+// but want to use the failed event handling of the projections
+func NewStatement(event eventstore.Event, execute Exec) *handler.Statement {
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateTypeSequence(),
+		Execute:          execute,
+	}
+}
+
 func NewMultiStatement(event eventstore.Event, opts ...func(eventstore.Event) Exec) *handler.Statement {
 	if len(opts) == 0 {
 		return NewNoOpStatement(event)
@@ -203,11 +215,21 @@ func NewArrayRemoveCol(column string, value interface{}) handler.Column {
 	}
 }
 
+func NewIncrementCol(column string, value interface{}) handler.Column {
+	return handler.Column{
+		Name:  column,
+		Value: value,
+		ParameterOpt: func(placeholder string) string {
+			return column + " + " + placeholder
+		},
+	}
+}
+
 func NewArrayIntersectCol(column string, value interface{}) handler.Column {
 	var arrayType string
 	switch value.(type) {
 	case pq.StringArray:
-		arrayType = "STRING"
+		arrayType = "TEXT"
 	case pq.Int32Array,
 		pq.Int64Array:
 		arrayType = "INT"
@@ -263,15 +285,12 @@ func NewCopyStatement(event eventstore.Event, cols []handler.Column, conds []han
 	}
 
 	q := func(config execConfig) string {
-		return "UPSERT INTO " +
-			config.tableName +
-			" (" +
-			strings.Join(columnNames, ", ") +
-			") SELECT " +
-			strings.Join(selectColumns, ", ") +
-			" FROM " +
-			config.tableName + " AS copy_table WHERE " +
-			strings.Join(wheres, " AND ")
+		return upsertQuery(config.dialect, config.tableName, columnNames,
+			"SELECT "+
+				strings.Join(selectColumns, ", ")+
+				" FROM "+
+				config.tableName+" AS copy_table WHERE "+
+				strings.Join(wheres, " AND "))
 	}
 
 	return &handler.Statement{
@@ -322,6 +341,7 @@ func exec(config execConfig, q query, opts []execOption) Exec {
 		}
 
 		config.tableName = projectionName
+		config.dialect = dialectOf(ex)
 		for _, opt := range opts {
 			opt(&config)
 		}
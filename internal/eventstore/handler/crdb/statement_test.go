@@ -627,6 +627,82 @@ func TestNewNoOpStatement(t *testing.T) {
 	}
 }
 
+func TestNewStatement(t *testing.T) {
+	type args struct {
+		event   *testEvent
+		execute Exec
+	}
+	type want struct {
+		aggregateType    eventstore.AggregateType
+		sequence         uint64
+		previousSequence uint64
+		isErr            func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "execute failed",
+			args: args{
+				event: &testEvent{
+					aggregateType:    "agg",
+					sequence:         5,
+					previousSequence: 3,
+				},
+				execute: func(handler.Executer, string) error {
+					return errTestErr
+				},
+			},
+			want: want{
+				aggregateType:    "agg",
+				sequence:         5,
+				previousSequence: 3,
+				isErr: func(err error) bool {
+					return errors.Is(err, errTestErr)
+				},
+			},
+		},
+		{
+			name: "correct",
+			args: args{
+				event: &testEvent{
+					aggregateType:    "agg",
+					sequence:         5,
+					previousSequence: 3,
+				},
+				execute: func(handler.Executer, string) error {
+					return nil
+				},
+			},
+			want: want{
+				aggregateType:    "agg",
+				sequence:         5,
+				previousSequence: 3,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := NewStatement(tt.args.event, tt.args.execute)
+			if stmt.AggregateType != tt.want.aggregateType || stmt.Sequence != tt.want.sequence || stmt.PreviousSequence != tt.want.previousSequence {
+				t.Errorf("NewStatement() = %v, want %v", stmt, tt.want)
+			}
+			if stmt.IsNoop() {
+				t.Fatal("expected executer, but was nil")
+			}
+			err := stmt.Execute(nil, "my_table")
+			if !tt.want.isErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestNewMultiStatement(t *testing.T) {
 	type args struct {
 		table string
@@ -1224,6 +1300,16 @@ func TestParameterOpts(t *testing.T) {
 			constructor: NewArrayRemoveCol,
 			want:        "array_remove(testCol, $1)",
 		},
+		{
+			name: "NewIncrementCol",
+			args: args{
+				column:      "testCol",
+				value:       1,
+				placeholder: "$1",
+			},
+			constructor: NewIncrementCol,
+			want:        "testCol + $1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,58 @@
+package eventstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/caos/zitadel/internal/errors"
+)
+
+//PointInTime limits the events reduced by FilterToQueryReducerAt
+// either to the events up to and including the sequence
+// or to the events created before or at the date
+type PointInTime struct {
+	Sequence uint64
+	Date     time.Time
+}
+
+func (p PointInTime) IsValid() bool {
+	return (p.Sequence > 0) != !p.Date.IsZero()
+}
+
+//includes returns true if the event happened before or at the point in time
+func (p PointInTime) includes(event Event) bool {
+	if p.Sequence > 0 {
+		return event.Sequence() <= p.Sequence
+	}
+	return !event.CreationDate().After(p.Date)
+}
+
+//FilterToQueryReducerAt filters the events of the query of the reducer
+// and only appends the events up to the point in time.
+// The query must return the events in ascending order as the queries of write models do.
+// Snapshots are never used as they could contain later events
+func (es *Eventstore) FilterToQueryReducerAt(ctx context.Context, r queryReducer, point PointInTime) error {
+	if !point.IsValid() {
+		return errors.ThrowInvalidArgument(nil, "V2-Pi2tV", "Errors.PointInTime.Invalid")
+	}
+	query := r.Query()
+	if point.Sequence > 0 {
+		for _, q := range query.queries {
+			if q.eventSequenceLess == 0 || q.eventSequenceLess > point.Sequence+1 {
+				q.eventSequenceLess = point.Sequence + 1
+			}
+		}
+	}
+	events, err := es.Filter(ctx, query)
+	if err != nil {
+		return err
+	}
+	for i, event := range events {
+		if !point.includes(event) {
+			events = events[:i]
+			break
+		}
+	}
+	r.AppendEvents(events...)
+	return r.Reduce()
+}
@@ -0,0 +1,112 @@
+package eventstore
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+func TestEventstore_FilterToQueryReducerAt(t *testing.T) {
+	events := []*repository.Event{
+		{AggregateID: "id", Type: "test.event", Sequence: 5, CreationDate: time.Unix(5, 0)},
+		{AggregateID: "id", Type: "test.event", Sequence: 6, CreationDate: time.Unix(6, 0)},
+		{AggregateID: "id", Type: "test.event", Sequence: 7, CreationDate: time.Unix(7, 0)},
+	}
+	type res struct {
+		err            func(error) bool
+		count          int
+		sequenceFilter *repository.Filter
+	}
+	tests := []struct {
+		name  string
+		point PointInTime
+		res   res
+	}{
+		{
+			name:  "sequence, later events ignored",
+			point: PointInTime{Sequence: 6},
+			res: res{
+				count:          2,
+				sequenceFilter: repository.NewFilter(repository.FieldSequence, uint64(7), repository.OperationLess),
+			},
+		},
+		{
+			name:  "date, later events ignored",
+			point: PointInTime{Date: time.Unix(6, 0)},
+			res: res{
+				count: 2,
+			},
+		},
+		{
+			name:  "date before first event, nothing reduced",
+			point: PointInTime{Date: time.Unix(1, 0)},
+			res: res{
+				count: 0,
+			},
+		},
+		{
+			name:  "no point in time, invalid argument error",
+			point: PointInTime{},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:  "sequence and date, invalid argument error",
+			point: PointInTime{Sequence: 6, Date: time.Unix(6, 0)},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &testSnapshotRepo{testRepo: testRepo{t: t, events: events}}
+			es := &Eventstore{
+				repo:              repo,
+				interceptorMutex:  sync.Mutex{},
+				eventInterceptors: map[EventType]eventTypeInterceptors{},
+			}
+			es.UseSnapshots(repo, 1)
+
+			wm := &testSnapshotWriteModel{
+				WriteModel: WriteModel{
+					AggregateID:   "id",
+					ResourceOwner: "ro",
+				},
+			}
+			err := es.FilterToQueryReducerAt(context.Background(), wm, tt.point)
+			if tt.res.err != nil {
+				if !tt.res.err(err) {
+					t.Errorf("got wrong err: %v ", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if wm.Count != tt.res.count {
+				t.Errorf("wrong count: want %d, got %d", tt.res.count, wm.Count)
+			}
+			if repo.saved != nil {
+				t.Errorf("snapshot must not be saved: %+v", repo.saved)
+			}
+			if tt.res.sequenceFilter != nil {
+				found := false
+				for _, filter := range repo.filtered.Filters[0] {
+					if reflect.DeepEqual(filter, tt.res.sequenceFilter) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("sequence filter %v not found in %v", tt.res.sequenceFilter, repo.filtered.Filters[0])
+				}
+			}
+		})
+	}
+}
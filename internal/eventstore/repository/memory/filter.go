@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"encoding/json"
+	"reflect"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+//matchesAny returns true if the event matches all filters of at least one group
+func matchesAny(event *repository.Event, filters [][]*repository.Filter) (bool, error) {
+	for _, group := range filters {
+		matches, err := matchesAll(event, group)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+func matchesAll(event *repository.Event, filters []*repository.Filter) (bool, error) {
+	for _, filter := range filters {
+		if err := filter.Validate(); err != nil {
+			return false, err
+		}
+		matches, err := matches(event, filter)
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matches(event *repository.Event, filter *repository.Filter) (bool, error) {
+	switch filter.Field {
+	case repository.FieldAggregateType:
+		return matchesString(string(event.AggregateType), filter)
+	case repository.FieldAggregateID:
+		return matchesString(event.AggregateID, filter)
+	case repository.FieldResourceOwner:
+		return matchesString(event.ResourceOwner.String, filter)
+	case repository.FieldEditorService:
+		return matchesString(event.EditorService, filter)
+	case repository.FieldEditorUser:
+		return matchesString(event.EditorUser, filter)
+	case repository.FieldEventType:
+		return matchesString(string(event.Type), filter)
+	case repository.FieldSequence:
+		return matchesSequence(event.Sequence, filter)
+	case repository.FieldEventData:
+		return matchesData(event.Data, filter)
+	}
+	return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-Ok3Xa", "field not supported")
+}
+
+func matchesString(value string, filter *repository.Filter) (bool, error) {
+	switch filter.Operation {
+	case repository.OperationEquals:
+		expected, ok := stringValue(filter.Value)
+		if !ok {
+			return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-yV3Nf", "value must be a string")
+		}
+		return value == expected, nil
+	case repository.OperationIn:
+		expected, ok := stringValues(filter.Value)
+		if !ok {
+			return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-b2Kqx", "value must be a list of strings")
+		}
+		for _, e := range expected {
+			if value == e {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-Zb4uY", "operation not supported")
+}
+
+func matchesSequence(sequence uint64, filter *repository.Filter) (bool, error) {
+	expected, ok := filter.Value.(uint64)
+	if !ok {
+		return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-7dMcA", "value must be an uint64")
+	}
+	switch filter.Operation {
+	case repository.OperationEquals:
+		return sequence == expected, nil
+	case repository.OperationGreater:
+		return sequence > expected, nil
+	case repository.OperationLess:
+		return sequence < expected, nil
+	}
+	return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-u8Vqe", "operation not supported")
+}
+
+//matchesData checks if the data contains the value of the filter
+// the same way as the jsonb @> operator
+func matchesData(data []byte, filter *repository.Filter) (bool, error) {
+	if filter.Operation != repository.OperationJSONContains {
+		return false, caos_errs.ThrowInvalidArgument(nil, "MEMOR-3NpRt", "operation not supported")
+	}
+	expected, err := jsonValue(filter.Value)
+	if err != nil {
+		return false, caos_errs.ThrowInvalidArgument(err, "MEMOR-Ws9oD", "value must be json")
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+	var stored interface{}
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return false, nil
+	}
+	return jsonContains(stored, expected), nil
+}
+
+func jsonValue(value interface{}) (v interface{}, err error) {
+	data, ok := value.([]byte)
+	if !ok {
+		data, err = json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+func jsonContains(stored, expected interface{}) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		s, ok := stored.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range e {
+			storedValue, ok := s[key]
+			if !ok || !jsonContains(storedValue, value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		s, ok := stored.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, value := range e {
+			if !containsElement(s, value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(stored, expected)
+	}
+}
+
+func containsElement(stored []interface{}, expected interface{}) bool {
+	for _, value := range stored {
+		if jsonContains(value, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case repository.AggregateType:
+		return string(v), true
+	case repository.EventType:
+		return string(v), true
+	}
+	return "", false
+}
+
+func stringValues(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []repository.AggregateType:
+		values := make([]string, len(v))
+		for i, aggregateType := range v {
+			values[i] = string(aggregateType)
+		}
+		return values, true
+	case []repository.EventType:
+		values := make([]string, len(v))
+		for i, eventType := range v {
+			values[i] = string(eventType)
+		}
+		return values, true
+	}
+	return nil, false
+}
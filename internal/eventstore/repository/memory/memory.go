@@ -0,0 +1,298 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+//Memory is an eventstore repository which keeps all data in memory
+// it's meant for tests and embedded use
+// events, unique constraints and snapshots are lost as soon as the process stops
+type Memory struct {
+	mu sync.RWMutex
+
+	events []*repository.Event
+	//aggregateSequences contains the latest sequence per aggregate
+	aggregateSequences map[aggregate]uint64
+	//aggregateTypeSequences contains the latest sequence per aggregate type
+	aggregateTypeSequences map[repository.AggregateType]uint64
+	//resourceOwners contains the resource owner of each aggregate
+	resourceOwners    map[aggregate]string
+	uniqueConstraints map[uniqueConstraint]struct{}
+	snapshots         map[snapshotKey]*repository.Snapshot
+}
+
+type aggregate struct {
+	aggregateType repository.AggregateType
+	id            string
+}
+
+type uniqueConstraint struct {
+	uniqueType  string
+	uniqueField string
+}
+
+type snapshotKey struct {
+	name          string
+	aggregateID   string
+	resourceOwner string
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		aggregateSequences:     make(map[aggregate]uint64),
+		aggregateTypeSequences: make(map[repository.AggregateType]uint64),
+		resourceOwners:         make(map[aggregate]string),
+		uniqueConstraints:      make(map[uniqueConstraint]struct{}),
+		snapshots:              make(map[snapshotKey]*repository.Snapshot),
+	}
+}
+
+//Health is always successful because no connection is needed
+func (m *Memory) Health(ctx context.Context) error { return nil }
+
+// Push adds all events to the eventstreams of the aggregates.
+// Either all events and unique constraints are stored or none of them
+func (m *Memory) Push(ctx context.Context, events []*repository.Event, uniqueConstraints ...*repository.UniqueConstraint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	constraints, err := m.applyUniqueConstraints(uniqueConstraints)
+	if err != nil {
+		return err
+	}
+
+	var (
+		sequence               = m.latestSequence()
+		aggregateSequences     = make(map[aggregate]uint64)
+		aggregateTypeSequences = make(map[repository.AggregateType]uint64)
+		resourceOwners         = make(map[aggregate]string)
+		stored                 = make([]*repository.Event, len(events))
+		creationDate           = time.Now()
+	)
+	for i, event := range events {
+		agg := aggregate{aggregateType: event.AggregateType, id: event.AggregateID}
+		resourceOwner, ok := resourceOwners[agg]
+		if !ok {
+			resourceOwner, ok = m.resourceOwners[agg]
+		}
+		if !ok {
+			if !event.ResourceOwner.Valid || event.ResourceOwner.String == "" {
+				return caos_errs.ThrowInternal(nil, "MEMOR-bHn2s", "unable to create event")
+			}
+			resourceOwner = event.ResourceOwner.String
+		}
+		sequence++
+
+		previousAggregateSequence, ok := aggregateSequences[agg]
+		if !ok {
+			previousAggregateSequence = m.aggregateSequences[agg]
+		}
+		previousAggregateTypeSequence, ok := aggregateTypeSequences[event.AggregateType]
+		if !ok {
+			previousAggregateTypeSequence = m.aggregateTypeSequences[event.AggregateType]
+		}
+
+		id, err := newID()
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "MEMOR-0hJ3d", "unable to create event")
+		}
+		stored[i] = copyEvent(event)
+		stored[i].ID = id
+		stored[i].Sequence = sequence
+		stored[i].PreviousAggregateSequence = previousAggregateSequence
+		stored[i].PreviousAggregateTypeSequence = previousAggregateTypeSequence
+		stored[i].CreationDate = creationDate
+		stored[i].ResourceOwner.String = resourceOwner
+		stored[i].ResourceOwner.Valid = true
+
+		aggregateSequences[agg] = sequence
+		aggregateTypeSequences[event.AggregateType] = sequence
+		resourceOwners[agg] = resourceOwner
+	}
+
+	//nothing can fail anymore, so the changes are applied
+	m.uniqueConstraints = constraints
+	for agg, sequence := range aggregateSequences {
+		m.aggregateSequences[agg] = sequence
+	}
+	for aggregateType, sequence := range aggregateTypeSequences {
+		m.aggregateTypeSequences[aggregateType] = sequence
+	}
+	for agg, resourceOwner := range resourceOwners {
+		m.resourceOwners[agg] = resourceOwner
+	}
+	m.events = append(m.events, stored...)
+	for i, event := range events {
+		event.ID = stored[i].ID
+		event.Sequence = stored[i].Sequence
+		event.PreviousAggregateSequence = stored[i].PreviousAggregateSequence
+		event.PreviousAggregateTypeSequence = stored[i].PreviousAggregateTypeSequence
+		event.CreationDate = stored[i].CreationDate
+		event.ResourceOwner = stored[i].ResourceOwner
+	}
+
+	return nil
+}
+
+//applyUniqueConstraints returns the unique constraints after the given changes
+// the stored unique constraints stay untouched
+func (m *Memory) applyUniqueConstraints(changes []*repository.UniqueConstraint) (map[uniqueConstraint]struct{}, error) {
+	constraints := make(map[uniqueConstraint]struct{}, len(m.uniqueConstraints)+len(changes))
+	for constraint := range m.uniqueConstraints {
+		constraints[constraint] = struct{}{}
+	}
+	for _, change := range changes {
+		if change == nil {
+			continue
+		}
+		constraint := uniqueConstraint{
+			uniqueType:  change.UniqueType,
+			uniqueField: strings.ToLower(change.UniqueField),
+		}
+		switch change.Action {
+		case repository.UniqueConstraintAdd:
+			if _, ok := constraints[constraint]; ok {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "MEMOR-t5Ez9", change.ErrorMessage)
+			}
+			constraints[constraint] = struct{}{}
+		case repository.UniqueConstraintRemoved:
+			delete(constraints, constraint)
+		}
+	}
+	return constraints, nil
+}
+
+// Filter returns all events matching the given search query
+func (m *Memory) Filter(ctx context.Context, searchQuery *repository.SearchQuery) ([]*repository.Event, error) {
+	if searchQuery.Columns != repository.ColumnsEvent {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "MEMOR-Rn2Nd", "invalid query factory")
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	found, err := m.filter(searchQuery.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if searchQuery.Desc {
+		sort.Slice(found, func(i, j int) bool {
+			return found[i].Sequence > found[j].Sequence
+		})
+	}
+	if searchQuery.Limit > 0 && uint64(len(found)) > searchQuery.Limit {
+		found = found[:searchQuery.Limit]
+	}
+
+	events := make([]*repository.Event, len(found))
+	for i, event := range found {
+		events[i] = copyEvent(event)
+	}
+	return events, nil
+}
+
+//LatestSequence returns the latests sequence found by the the search query
+func (m *Memory) LatestSequence(ctx context.Context, searchQuery *repository.SearchQuery) (uint64, error) {
+	if searchQuery.Columns != repository.ColumnsMaxSequence {
+		return 0, caos_errs.ThrowInvalidArgument(nil, "MEMOR-N8vQm", "invalid query factory")
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	found, err := m.filter(searchQuery.Filters)
+	if err != nil || len(found) == 0 {
+		return 0, err
+	}
+	return found[len(found)-1].Sequence, nil
+}
+
+//Step20 is a no-op because the previous aggregate type sequences
+// are always set in memory
+func (m *Memory) Step20(ctx context.Context, latestSequence uint64) error {
+	return nil
+}
+
+//Snapshot returns the stored snapshot of the write model
+// nil is returned if no snapshot exists
+func (m *Memory) Snapshot(ctx context.Context, name, aggregateID, resourceOwner string) (*repository.Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot, ok := m.snapshots[snapshotKey{name: name, aggregateID: aggregateID, resourceOwner: resourceOwner}]
+	if !ok {
+		return nil, nil
+	}
+	return copySnapshot(snapshot), nil
+}
+
+//SaveSnapshot replaces the stored snapshot of the write model
+func (m *Memory) SaveSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := snapshotKey{name: snapshot.Name, aggregateID: snapshot.AggregateID, resourceOwner: snapshot.ResourceOwner}
+	m.snapshots[key] = copySnapshot(snapshot)
+	return nil
+}
+
+//filter returns the stored events matching the filters ordered by sequence
+func (m *Memory) filter(filters [][]*repository.Filter) ([]*repository.Event, error) {
+	if len(filters) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "MEMOR-Hs1xp", "invalid query factory")
+	}
+	found := make([]*repository.Event, 0)
+	for _, event := range m.events {
+		matches, err := matchesAny(event, filters)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			found = append(found, event)
+		}
+	}
+	return found, nil
+}
+
+func (m *Memory) latestSequence() uint64 {
+	if len(m.events) == 0 {
+		return 0
+	}
+	return m.events[len(m.events)-1].Sequence
+}
+
+func copyEvent(event *repository.Event) *repository.Event {
+	e := *event
+	if event.Data != nil {
+		e.Data = make([]byte, len(event.Data))
+		copy(e.Data, event.Data)
+	}
+	return &e
+}
+
+func copySnapshot(snapshot *repository.Snapshot) *repository.Snapshot {
+	s := *snapshot
+	if snapshot.Data != nil {
+		s.Data = make([]byte, len(snapshot.Data))
+		copy(s.Data, snapshot.Data)
+	}
+	return &s
+}
+
+//newID returns a random uuid (version 4)
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+func testEvent(aggregateType repository.AggregateType, aggregateID, resourceOwner string, eventType repository.EventType, data []byte) *repository.Event {
+	return &repository.Event{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		ResourceOwner: sql.NullString{String: resourceOwner, Valid: resourceOwner != ""},
+		Type:          eventType,
+		Version:       "v1",
+		EditorService: "svc",
+		EditorUser:    "user",
+		Data:          data,
+	}
+}
+
+func TestMemory_Push(t *testing.T) {
+	db := NewMemory()
+	ctx := context.Background()
+
+	first := []*repository.Event{
+		testEvent("user", "1", "ro", "user.added", nil),
+		testEvent("user", "1", "ro", "user.changed", nil),
+		testEvent("org", "ro", "ro", "org.added", nil),
+	}
+	if err := db.Push(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second := []*repository.Event{
+		testEvent("user", "2", "other", "user.added", nil),
+		testEvent("user", "1", "other", "user.changed", nil),
+	}
+	if err := db.Push(ctx, second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name                          string
+		event                         *repository.Event
+		sequence                      uint64
+		previousAggregateSequence     uint64
+		previousAggregateTypeSequence uint64
+		resourceOwner                 string
+	}{
+		{"first event", first[0], 1, 0, 0, "ro"},
+		{"same aggregate", first[1], 2, 1, 1, "ro"},
+		{"other aggregate type", first[2], 3, 0, 0, "ro"},
+		{"new aggregate", second[0], 4, 0, 2, "other"},
+		{"resource owner of aggregate kept", second[1], 5, 2, 4, "ro"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.event.ID == "" {
+				t.Error("id not set")
+			}
+			if tt.event.Sequence != tt.sequence {
+				t.Errorf("wrong sequence: want %d got %d", tt.sequence, tt.event.Sequence)
+			}
+			if tt.event.PreviousAggregateSequence != tt.previousAggregateSequence {
+				t.Errorf("wrong previous aggregate sequence: want %d got %d", tt.previousAggregateSequence, tt.event.PreviousAggregateSequence)
+			}
+			if tt.event.PreviousAggregateTypeSequence != tt.previousAggregateTypeSequence {
+				t.Errorf("wrong previous aggregate type sequence: want %d got %d", tt.previousAggregateTypeSequence, tt.event.PreviousAggregateTypeSequence)
+			}
+			if tt.event.ResourceOwner.String != tt.resourceOwner {
+				t.Errorf("wrong resource owner: want %s got %s", tt.resourceOwner, tt.event.ResourceOwner.String)
+			}
+		})
+	}
+}
+
+func TestMemory_PushUniqueConstraints(t *testing.T) {
+	db := NewMemory()
+	ctx := context.Background()
+
+	err := db.Push(ctx,
+		[]*repository.Event{testEvent("user", "1", "ro", "user.added", nil)},
+		&repository.UniqueConstraint{UniqueType: "username", UniqueField: "Gigi", Action: repository.UniqueConstraintAdd, ErrorMessage: "Errors.User.AlreadyExists"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = db.Push(ctx,
+		[]*repository.Event{testEvent("user", "2", "ro", "user.added", nil)},
+		&repository.UniqueConstraint{UniqueType: "username", UniqueField: "gigi", Action: repository.UniqueConstraintAdd, ErrorMessage: "Errors.User.AlreadyExists"},
+	)
+	if !caos_errs.IsErrorAlreadyExists(err) {
+		t.Fatalf("expected already exists got: %v", err)
+	}
+	if sequence, _ := db.LatestSequence(ctx, sequenceQuery("user")); sequence != 1 {
+		t.Errorf("events of failed push must not be stored, latest sequence: %d", sequence)
+	}
+
+	err = db.Push(ctx,
+		[]*repository.Event{testEvent("user", "1", "ro", "user.removed", nil)},
+		&repository.UniqueConstraint{UniqueType: "username", UniqueField: "gigi", Action: repository.UniqueConstraintRemoved},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = db.Push(ctx,
+		[]*repository.Event{testEvent("user", "2", "ro", "user.added", nil)},
+		&repository.UniqueConstraint{UniqueType: "username", UniqueField: "gigi", Action: repository.UniqueConstraintAdd},
+	)
+	if err != nil {
+		t.Errorf("unique constraint should be removed: %v", err)
+	}
+}
+
+func TestMemory_PushWithoutResourceOwner(t *testing.T) {
+	db := NewMemory()
+	err := db.Push(context.Background(), []*repository.Event{testEvent("user", "1", "", "user.added", nil)})
+	if !caos_errs.IsInternal(err) {
+		t.Errorf("expected internal error got: %v", err)
+	}
+}
+
+func TestMemory_Filter(t *testing.T) {
+	db := NewMemory()
+	ctx := context.Background()
+	err := db.Push(ctx, []*repository.Event{
+		testEvent("user", "1", "ro", "user.added", []byte(`{"userName": "gigi", "roles": ["a", "b"]}`)),
+		testEvent("user", "2", "ro", "user.added", []byte(`{"userName": "hodor"}`)),
+		testEvent("org", "ro", "ro", "org.added", nil),
+		testEvent("user", "1", "ro", "user.changed", nil),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		query     *repository.SearchQuery
+		sequences []uint64
+		wantErr   func(error) bool
+	}{
+		{
+			name: "aggregate type",
+			query: eventQuery(false, 0,
+				repository.NewFilter(repository.FieldAggregateType, repository.AggregateType("user"), repository.OperationEquals),
+			),
+			sequences: []uint64{1, 2, 4},
+		},
+		{
+			name: "aggregate ids",
+			query: eventQuery(false, 0,
+				repository.NewFilter(repository.FieldAggregateType, repository.AggregateType("user"), repository.OperationEquals),
+				repository.NewFilter(repository.FieldAggregateID, []string{"2", "3"}, repository.OperationIn),
+			),
+			sequences: []uint64{2},
+		},
+		{
+			name: "event types",
+			query: eventQuery(false, 0,
+				repository.NewFilter(repository.FieldEventType, []repository.EventType{"user.changed", "org.added"}, repository.OperationIn),
+			),
+			sequences: []uint64{3, 4},
+		},
+		{
+			name: "sequence greater desc limit",
+			query: eventQuery(true, 2,
+				repository.NewFilter(repository.FieldSequence, uint64(1), repository.OperationGreater),
+			),
+			sequences: []uint64{4, 3},
+		},
+		{
+			name: "event data",
+			query: eventQuery(false, 0,
+				repository.NewFilter(repository.FieldEventData, map[string]interface{}{"roles": []string{"b"}}, repository.OperationJSONContains),
+			),
+			sequences: []uint64{1},
+		},
+		{
+			name: "or",
+			query: &repository.SearchQuery{
+				Columns: repository.ColumnsEvent,
+				Filters: [][]*repository.Filter{
+					{repository.NewFilter(repository.FieldAggregateType, repository.AggregateType("org"), repository.OperationEquals)},
+					{repository.NewFilter(repository.FieldAggregateID, "2", repository.OperationEquals)},
+				},
+			},
+			sequences: []uint64{2, 3},
+		},
+		{
+			name:    "no filters",
+			query:   eventQuery(false, 0),
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name: "invalid value",
+			query: eventQuery(false, 0,
+				repository.NewFilter(repository.FieldSequence, "1", repository.OperationGreater),
+			),
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := db.Filter(ctx, tt.query)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("wrong error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != len(tt.sequences) {
+				t.Fatalf("wrong count of events: want %d got %d", len(tt.sequences), len(events))
+			}
+			for i, event := range events {
+				if event.Sequence != tt.sequences[i] {
+					t.Errorf("wrong sequence at %d: want %d got %d", i, tt.sequences[i], event.Sequence)
+				}
+			}
+		})
+	}
+}
+
+func TestMemory_LatestSequence(t *testing.T) {
+	db := NewMemory()
+	ctx := context.Background()
+
+	sequence, err := db.LatestSequence(ctx, sequenceQuery("user"))
+	if err != nil || sequence != 0 {
+		t.Errorf("expected 0 without events got %d, %v", sequence, err)
+	}
+
+	err = db.Push(ctx, []*repository.Event{
+		testEvent("user", "1", "ro", "user.added", nil),
+		testEvent("org", "ro", "ro", "org.added", nil),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sequence, err = db.LatestSequence(ctx, sequenceQuery("user"))
+	if err != nil || sequence != 1 {
+		t.Errorf("expected 1 got %d, %v", sequence, err)
+	}
+}
+
+func eventQuery(desc bool, limit uint64, filters ...*repository.Filter) *repository.SearchQuery {
+	query := &repository.SearchQuery{
+		Columns: repository.ColumnsEvent,
+		Desc:    desc,
+		Limit:   limit,
+	}
+	if len(filters) > 0 {
+		query.Filters = [][]*repository.Filter{filters}
+	}
+	return query
+}
+
+func sequenceQuery(aggregateType repository.AggregateType) *repository.SearchQuery {
+	return &repository.SearchQuery{
+		Columns: repository.ColumnsMaxSequence,
+		Filters: [][]*repository.Filter{
+			{repository.NewFilter(repository.FieldAggregateType, aggregateType, repository.OperationEquals)},
+		},
+	}
+}
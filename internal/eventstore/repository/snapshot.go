@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+//Snapshot is the reduced state of a write model
+// at the sequence of the last reduced event
+type Snapshot struct {
+	//Name identifies the type of the write model
+	Name          string
+	AggregateID   string
+	ResourceOwner string
+	//Version is increased as soon as the reducer of the write model changes
+	Version uint32
+	//Sequence is the sequence of the last event reduced into the snapshot
+	Sequence uint64
+	//ChangeDate is the creation date of the last event reduced into the snapshot
+	ChangeDate time.Time
+	//Data is the state of the write model as json
+	Data []byte
+}
+
+//SnapshotRepository stores and loads snapshots of write models
+type SnapshotRepository interface {
+	//Snapshot returns the latest snapshot of the write model
+	// if no snapshot was stored nil is returned
+	Snapshot(ctx context.Context, name, aggregateID, resourceOwner string) (*Snapshot, error)
+	//SaveSnapshot stores the snapshot and replaces the previous one
+	SaveSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
@@ -415,9 +415,7 @@ func TestCRDB_Push_OneAggregate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db := &CRDB{
-				client: testCRDBClient,
-			}
+			db := testRepository()
 			if tt.args.uniqueDataType != "" && tt.args.uniqueDataField != "" {
 				err := fillUniqueData(tt.args.uniqueDataType, tt.args.uniqueDataField)
 				if err != nil {
@@ -539,9 +537,7 @@ func TestCRDB_Push_MultipleAggregate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db := &CRDB{
-				client: testCRDBClient,
-			}
+			db := testRepository()
 			if err := db.Push(context.Background(), tt.args.events); (err != nil) != tt.res.wantErr {
 				t.Errorf("CRDB.Push() error = %v, wantErr %v", err, tt.res.wantErr)
 			}
@@ -676,9 +672,7 @@ func TestCRDB_Push_Parallel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db := &CRDB{
-				client: testCRDBClient,
-			}
+			db := testRepository()
 			wg := sync.WaitGroup{}
 
 			errs := make([]error, 0, tt.res.errCount)
@@ -797,9 +791,7 @@ func TestCRDB_Filter(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db := &CRDB{
-				client: testCRDBClient,
-			}
+			db := testRepository()
 
 			// setup initial data for query
 			if err := db.Push(context.Background(), tt.fields.existingEvents); err != nil {
@@ -887,9 +879,7 @@ func TestCRDB_LatestSequence(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db := &CRDB{
-				client: testCRDBClient,
-			}
+			db := testRepository()
 
 			// setup initial data for query
 			if err := db.Push(context.Background(), tt.fields.existingEvents); err != nil {
@@ -1031,9 +1021,7 @@ func TestCRDB_Push_ResourceOwner(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db := &CRDB{
-				client: testCRDBClient,
-			}
+			db := testRepository()
 			if err := db.Push(context.Background(), tt.args.events); err != nil {
 				t.Errorf("CRDB.Push() error = %v", err)
 			}
@@ -13,14 +13,31 @@ import (
 
 	"github.com/caos/logging"
 	"github.com/cockroachdb/cockroach-go/v2/testserver"
+
+	"github.com/caos/zitadel/internal/eventstore/repository"
 )
 
 var (
 	migrationsPath = os.ExpandEnv("${GOPATH}/src/github.com/caos/zitadel/migrations/cockroach")
 	testCRDBClient *sql.DB
+
+	//postgresURL runs the tests against the given postgres database instead of cockroach
+	// the database must be empty
+	postgresURL = os.Getenv("ZITADEL_TEST_POSTGRES_URL")
 )
 
+func testRepository() repository.Repository {
+	if postgresURL != "" {
+		return NewPostgres(testCRDBClient)
+	}
+	return NewCRDB(testCRDBClient)
+}
+
 func TestMain(m *testing.M) {
+	if postgresURL != "" {
+		os.Exit(testMainPostgres(m))
+	}
+
 	ts, err := testserver.NewTestServer()
 	if err != nil {
 		logging.LogWithFields("REPOS-RvjLG", "error", err).Fatal("unable to start db")
@@ -47,6 +64,37 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func testMainPostgres(m *testing.M) int {
+	var err error
+	testCRDBClient, err = sql.Open("postgres", postgresURL)
+	if err != nil {
+		logging.LogWithFields("REPOS-Wd0Lv", "error", err).Fatal("unable to connect to db")
+	}
+	defer testCRDBClient.Close()
+
+	if err = testCRDBClient.Ping(); err != nil {
+		logging.LogWithFields("REPOS-3aEhp", "error", err).Fatal("unable to ping db")
+	}
+
+	migrationsPath = os.ExpandEnv("${GOPATH}/src/github.com/caos/zitadel/migrations/postgres")
+	files, err := migrationFilePaths()
+	if err != nil {
+		logging.LogWithFields("REPOS-l5mwH", "error", err).Fatal("migrations not found")
+	}
+	sort.Sort(files)
+	for _, file := range files {
+		migration, err := ioutil.ReadFile(file)
+		if err != nil {
+			logging.LogWithFields("REPOS-hg9Tf", "error", err).Fatal("unable to read migration")
+		}
+		if _, err = testCRDBClient.Exec(string(migration)); err != nil {
+			logging.LogWithFields("REPOS-E0Pw1", "error", err, "file", file).Fatal("migrations failed")
+		}
+	}
+
+	return m.Run()
+}
+
 func executeMigrations() error {
 	files, err := migrationFilePaths()
 	if err != nil {
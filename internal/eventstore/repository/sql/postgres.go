@@ -0,0 +1,174 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/caos/logging"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+)
+
+const (
+	//postgres runs in read committed isolation by default
+	// the advisory lock serialises the pushes per aggregate type
+	// so previous_aggregate_type_sequence stays consistent
+	pgAggregateTypeLock = "SELECT pg_advisory_xact_lock(hashtext($1))"
+
+	pgInsert = "WITH previous_data (aggregate_type_sequence, aggregate_sequence, resource_owner) AS (" +
+		"SELECT agg_type.seq, agg.seq, agg.ro FROM " +
+		"(" +
+		" SELECT MAX(event_sequence) seq, 1 join_me" +
+		" FROM eventstore.events" +
+		" WHERE aggregate_type = $2" +
+		") AS agg_type " +
+		"LEFT JOIN " +
+		"(" +
+		" SELECT event_sequence seq, resource_owner ro, 1 join_me" +
+		" FROM eventstore.events" +
+		" WHERE aggregate_type = $2 AND aggregate_id = $3" +
+		" ORDER BY event_sequence DESC" +
+		" LIMIT 1" +
+		") AS agg USING(join_me)" +
+		") " +
+		"INSERT INTO eventstore.events (" +
+		" event_type," +
+		" aggregate_type," +
+		" aggregate_id," +
+		" aggregate_version," +
+		" creation_date," +
+		" event_data," +
+		" editor_user," +
+		" editor_service," +
+		" resource_owner," +
+		" previous_aggregate_sequence," +
+		" previous_aggregate_type_sequence" +
+		") " +
+		"SELECT" +
+		" $1::VARCHAR AS event_type," +
+		" $2::VARCHAR AS aggregate_type," +
+		" $3::VARCHAR AS aggregate_id," +
+		" $4::VARCHAR AS aggregate_version," +
+		" NOW() AS creation_date," +
+		" $5::JSONB AS event_data," +
+		" $6::VARCHAR AS editor_user," +
+		" $7::VARCHAR AS editor_service," +
+		" COALESCE(resource_owner, $8::VARCHAR) AS resource_owner," +
+		" aggregate_sequence AS previous_aggregate_sequence," +
+		" aggregate_type_sequence AS previous_aggregate_type_sequence " +
+		"FROM previous_data " +
+		"RETURNING id, event_sequence, previous_aggregate_sequence, previous_aggregate_type_sequence, creation_date, resource_owner"
+
+	pgSnapshotUpsert = "INSERT INTO eventstore.snapshots" +
+		" (name, aggregate_id, resource_owner, version, event_sequence, change_date, creation_date, data)" +
+		" VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7)" +
+		" ON CONFLICT (name, aggregate_id, resource_owner) DO UPDATE SET" +
+		" version = EXCLUDED.version," +
+		" event_sequence = EXCLUDED.event_sequence," +
+		" change_date = EXCLUDED.change_date," +
+		" creation_date = EXCLUDED.creation_date," +
+		" data = EXCLUDED.data"
+)
+
+//Postgres is the eventstore repository for postgres databases
+// querying is the same as on cockroach, only the statements
+// which use cockroach specific syntax are replaced
+type Postgres struct {
+	*CRDB
+}
+
+func NewPostgres(client *sql.DB) *Postgres {
+	return &Postgres{CRDB: NewCRDB(client)}
+}
+
+// Push adds all events to the eventstreams of the aggregates.
+// This call is transaction save. The transaction will be rolled back if one event fails
+func (db *Postgres) Push(ctx context.Context, events []*repository.Event, uniqueConstraints ...*repository.UniqueConstraint) error {
+	//crdb.ExecuteTx only relies on savepoints which are supported by postgres
+	err := crdb.ExecuteTx(ctx, db.client, nil, func(tx *sql.Tx) error {
+		for _, aggregateType := range aggregateTypes(events) {
+			if _, err := tx.ExecContext(ctx, pgAggregateTypeLock, aggregateType); err != nil {
+				return caos_errs.ThrowInternal(err, "SQL-5xPQd", "unable to lock aggregate type")
+			}
+		}
+
+		var (
+			previousAggregateSequence     Sequence
+			previousAggregateTypeSequence Sequence
+		)
+		for _, event := range events {
+			err := tx.QueryRowContext(ctx, pgInsert,
+				event.Type,
+				event.AggregateType,
+				event.AggregateID,
+				event.Version,
+				Data(event.Data),
+				event.EditorUser,
+				event.EditorService,
+				event.ResourceOwner,
+			).Scan(&event.ID, &event.Sequence, &previousAggregateSequence, &previousAggregateTypeSequence, &event.CreationDate, &event.ResourceOwner)
+
+			event.PreviousAggregateSequence = uint64(previousAggregateSequence)
+			event.PreviousAggregateTypeSequence = uint64(previousAggregateTypeSequence)
+
+			if err != nil {
+				logging.LogWithFields("SQL-r1Ixe",
+					"aggregate", event.AggregateType,
+					"aggregateId", event.AggregateID,
+					"aggregateType", event.AggregateType,
+					"eventType", event.Type).WithError(err).Info("query failed")
+				return caos_errs.ThrowInternal(err, "SQL-6Ucgp", "unable to create event")
+			}
+		}
+
+		return db.handleUniqueConstraints(ctx, tx, uniqueConstraints...)
+	})
+	if err != nil && !errors.Is(err, &caos_errs.CaosError{}) {
+		err = caos_errs.ThrowInternal(err, "SQL-uSRsE", "unable to store events")
+	}
+
+	return err
+}
+
+//SaveSnapshot replaces the stored snapshot of the write model
+func (db *Postgres) SaveSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	_, err := db.client.ExecContext(ctx, pgSnapshotUpsert,
+		snapshot.Name,
+		snapshot.AggregateID,
+		snapshot.ResourceOwner,
+		snapshot.Version,
+		Sequence(snapshot.Sequence),
+		snapshot.ChangeDate,
+		Data(snapshot.Data),
+	)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-d3Fpa", "unable to store snapshot")
+	}
+	return nil
+}
+
+//aggregateTypes returns the distinct aggregate types of the events
+// sorted to prevent deadlocks between concurrent pushes
+func aggregateTypes(events []*repository.Event) []string {
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		if containsString(types, string(event.AggregateType)) {
+			continue
+		}
+		types = append(types, string(event.AggregateType))
+	}
+	sort.Strings(types)
+	return types
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+func TestPostgres_Push(t *testing.T) {
+	type args struct {
+		events            []*repository.Event
+		uniqueConstraints []*repository.UniqueConstraint
+	}
+	tests := []struct {
+		name    string
+		args    args
+		expect  func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "locks aggregate types sorted",
+			args: args{
+				events: []*repository.Event{
+					generateEvent(t, "1", withAggregateType("agg.type.b")),
+					generateEvent(t, "2", withAggregateType("agg.type.a")),
+					generateEvent(t, "3", withAggregateType("agg.type.b")),
+				},
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT cockroach_restart").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(pgAggregateTypeLock)).WithArgs("agg.type.a").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(pgAggregateTypeLock)).WithArgs("agg.type.b").WillReturnResult(sqlmock.NewResult(0, 0))
+				for i := 0; i < 3; i++ {
+					mock.ExpectQuery(regexp.QuoteMeta(pgInsert)).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_sequence", "previous_aggregate_sequence", "previous_aggregate_type_sequence", "creation_date", "resource_owner"}).
+							AddRow("id", i+1, nil, nil, time.Now(), "ro"))
+				}
+				mock.ExpectExec("RELEASE SAVEPOINT cockroach_restart").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "lock fails",
+			args: args{
+				events: []*repository.Event{
+					generateEvent(t, "1", withAggregateType("agg.type.a")),
+				},
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT cockroach_restart").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(pgAggregateTypeLock)).WithArgs("agg.type.a").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t)
+			defer client.client.Close()
+			tt.expect(client.mock)
+
+			db := NewPostgres(client.client)
+			err := db.Push(context.Background(), tt.args.events, tt.args.uniqueConstraints...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Postgres.Push() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := client.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("not all expectations met: %v", err)
+			}
+		})
+	}
+}
+
+func Test_aggregateTypes(t *testing.T) {
+	events := []*repository.Event{
+		{AggregateType: "user"},
+		{AggregateType: "org"},
+		{AggregateType: "user"},
+	}
+	want := []string{"org", "user"}
+	if got := aggregateTypes(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("aggregateTypes() = %v, want %v", got, want)
+	}
+}
+
+func withAggregateType(aggregateType repository.AggregateType) func(*repository.Event) {
+	return func(e *repository.Event) {
+		e.AggregateType = aggregateType
+	}
+}
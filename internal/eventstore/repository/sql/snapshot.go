@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	snapshotSelect = "SELECT version, event_sequence, change_date, data" +
+		" FROM eventstore.snapshots" +
+		" WHERE name = $1 AND aggregate_id = $2 AND resource_owner = $3"
+	snapshotUpsert = "UPSERT INTO eventstore.snapshots" +
+		" (name, aggregate_id, resource_owner, version, event_sequence, change_date, creation_date, data)" +
+		" VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7)"
+)
+
+//Snapshot returns the stored snapshot of the write model
+// nil is returned if no snapshot exists
+func (db *CRDB) Snapshot(ctx context.Context, name, aggregateID, resourceOwner string) (*repository.Snapshot, error) {
+	snapshot := &repository.Snapshot{
+		Name:          name,
+		AggregateID:   aggregateID,
+		ResourceOwner: resourceOwner,
+	}
+	var (
+		sequence Sequence
+		data     Data
+	)
+	err := db.client.QueryRowContext(ctx, snapshotSelect, name, aggregateID, resourceOwner).
+		Scan(&snapshot.Version, &sequence, &snapshot.ChangeDate, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "SQL-9Ngsl", "unable to load snapshot")
+	}
+	snapshot.Sequence = uint64(sequence)
+	snapshot.Data = data
+	return snapshot, nil
+}
+
+//SaveSnapshot replaces the stored snapshot of the write model
+func (db *CRDB) SaveSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	_, err := db.client.ExecContext(ctx, snapshotUpsert,
+		snapshot.Name,
+		snapshot.AggregateID,
+		snapshot.ResourceOwner,
+		snapshot.Version,
+		Sequence(snapshot.Sequence),
+		snapshot.ChangeDate,
+		Data(snapshot.Data),
+	)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Qm3xw", "unable to store snapshot")
+	}
+	return nil
+}
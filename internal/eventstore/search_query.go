@@ -138,6 +138,29 @@ func (query *SearchQuery) Builder() *SearchQueryBuilder {
 	return query.builder
 }
 
+//resumable checks if the events of the query can be reduced
+// starting from a previously reduced sequence
+func (builder *SearchQueryBuilder) resumable() bool {
+	if builder.columns != repository.ColumnsEvent || builder.desc || builder.limit != 0 {
+		return false
+	}
+	for _, query := range builder.queries {
+		if query.eventSequenceLess != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//resumeAfter restricts all sub queries to events with a sequence greater the given sequence
+func (builder *SearchQueryBuilder) resumeAfter(sequence uint64) {
+	for _, query := range builder.queries {
+		if query.eventSequenceGreater < sequence {
+			query.eventSequenceGreater = sequence
+		}
+	}
+}
+
 func (builder *SearchQueryBuilder) build() (*repository.SearchQuery, error) {
 	if builder == nil ||
 		len(builder.queries) < 1 ||
@@ -0,0 +1,113 @@
+package eventstore
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+//Snapshotter is implemented by write models which store their reduced state as snapshot
+// the write model serializes its state explicitly, so a restored write model
+// must be equal to a write model which reduced all events
+type Snapshotter interface {
+	queryReducer
+	//SnapshotName identifies the type of the write model
+	SnapshotName() string
+	//SnapshotVersion must be increased as soon as the reducer, the query or the state of the write model changes
+	// snapshots of other versions are ignored and replaced
+	SnapshotVersion() uint32
+	//MarshalSnapshot returns the complete reduced state of the write model
+	// the fields of the embedded WriteModel are handled by the eventstore
+	MarshalSnapshot() ([]byte, error)
+	//UnmarshalSnapshot restores the state returned by MarshalSnapshot
+	UnmarshalSnapshot(data []byte) error
+	writeModel() *WriteModel
+}
+
+//UseSnapshots enables the snapshots of write models implementing Snapshotter
+// a new snapshot is stored as soon as at least minEvents were reduced since the previous one
+func (es *Eventstore) UseSnapshots(repo repository.SnapshotRepository, minEvents uint64) *Eventstore {
+	es.snapshots = repo
+	es.snapshotMinEvents = minEvents
+	return es
+}
+
+func (es *Eventstore) snapshotsEnabled(r queryReducer) (Snapshotter, bool) {
+	if es.snapshots == nil {
+		return nil, false
+	}
+	s, ok := r.(Snapshotter)
+	if !ok || s.writeModel().AggregateID == "" {
+		return nil, false
+	}
+	return s, true
+}
+
+//filterToSnapshotter restores the latest snapshot of the write model
+// and only reduces the events created after the snapshot
+func (es *Eventstore) filterToSnapshotter(ctx context.Context, s Snapshotter) error {
+	query := s.Query()
+	if query == nil || !query.resumable() {
+		return es.filterToQuery(ctx, query, s)
+	}
+
+	wm := s.writeModel()
+	name, aggregateID, resourceOwner := s.SnapshotName(), wm.AggregateID, wm.ResourceOwner
+
+	snapshot := es.latestSnapshot(ctx, s, aggregateID, resourceOwner)
+	if snapshot != nil {
+		if err := s.UnmarshalSnapshot(snapshot.Data); err != nil {
+			return errors.ThrowInternal(err, "V2-Lq3Hs", "unable to restore snapshot")
+		}
+		wm.ProcessedSequence = snapshot.Sequence
+		wm.ChangeDate = snapshot.ChangeDate
+		query.resumeAfter(snapshot.Sequence)
+	}
+
+	events, err := es.Filter(ctx, query)
+	if err != nil {
+		return err
+	}
+	s.AppendEvents(events...)
+	if err = s.Reduce(); err != nil {
+		return err
+	}
+
+	if len(events) == 0 || uint64(len(events)) < es.snapshotMinEvents {
+		return nil
+	}
+	data, err := s.MarshalSnapshot()
+	if err != nil {
+		logging.LogWithFields("V2-Zp0mK", "name", name, "aggregateID", aggregateID).WithError(err).Warn("unable to marshal snapshot")
+		return nil
+	}
+	latest := events[len(events)-1]
+	err = es.snapshots.SaveSnapshot(ctx, &repository.Snapshot{
+		Name:          name,
+		AggregateID:   aggregateID,
+		ResourceOwner: resourceOwner,
+		Version:       s.SnapshotVersion(),
+		Sequence:      latest.Sequence(),
+		ChangeDate:    latest.CreationDate(),
+		Data:          data,
+	})
+	logging.LogWithFields("V2-2PwXl", "name", name, "aggregateID", aggregateID).OnError(err).Warn("unable to store snapshot")
+	return nil
+}
+
+//latestSnapshot returns the stored snapshot if it matches the version of the write model
+// snapshots are an optimisation, if they cannot be loaded all events are reduced
+func (es *Eventstore) latestSnapshot(ctx context.Context, s Snapshotter, aggregateID, resourceOwner string) *repository.Snapshot {
+	snapshot, err := es.snapshots.Snapshot(ctx, s.SnapshotName(), aggregateID, resourceOwner)
+	if err != nil {
+		logging.LogWithFields("V2-6EvRs", "name", s.SnapshotName(), "aggregateID", aggregateID).WithError(err).Warn("unable to load snapshot")
+		return nil
+	}
+	if snapshot == nil || snapshot.Version != s.SnapshotVersion() {
+		return nil
+	}
+	return snapshot
+}
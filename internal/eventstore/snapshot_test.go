@@ -0,0 +1,306 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+type testSnapshotRepo struct {
+	testRepo
+	snapshot    *repository.Snapshot
+	snapshotErr error
+	saved       *repository.Snapshot
+	filtered    *repository.SearchQuery
+}
+
+func (repo *testSnapshotRepo) Filter(ctx context.Context, searchQuery *repository.SearchQuery) ([]*repository.Event, error) {
+	repo.filtered = searchQuery
+	return repo.testRepo.Filter(ctx, searchQuery)
+}
+
+func (repo *testSnapshotRepo) Snapshot(ctx context.Context, name, aggregateID, resourceOwner string) (*repository.Snapshot, error) {
+	return repo.snapshot, repo.snapshotErr
+}
+
+func (repo *testSnapshotRepo) SaveSnapshot(ctx context.Context, snapshot *repository.Snapshot) error {
+	repo.saved = snapshot
+	return nil
+}
+
+type testSnapshotWriteModel struct {
+	WriteModel
+
+	Count          int
+	firstEventType EventType
+}
+
+func (wm *testSnapshotWriteModel) Reduce() error {
+	wm.Count += len(wm.Events)
+	if wm.firstEventType == "" && len(wm.Events) > 0 {
+		wm.firstEventType = wm.Events[0].Type()
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *testSnapshotWriteModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs(wm.AggregateID).
+		Builder()
+}
+
+func (wm *testSnapshotWriteModel) SnapshotName() string {
+	return "test_write_model"
+}
+
+func (wm *testSnapshotWriteModel) SnapshotVersion() uint32 {
+	return 2
+}
+
+type testSnapshot struct {
+	Count          int       `json:"count"`
+	FirstEventType EventType `json:"firstEventType"`
+}
+
+func (wm *testSnapshotWriteModel) MarshalSnapshot() ([]byte, error) {
+	return json.Marshal(&testSnapshot{Count: wm.Count, FirstEventType: wm.firstEventType})
+}
+
+func (wm *testSnapshotWriteModel) UnmarshalSnapshot(data []byte) error {
+	snapshot := new(testSnapshot)
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+	wm.Count = snapshot.Count
+	wm.firstEventType = snapshot.FirstEventType
+	return nil
+}
+
+func testSnapshotData(t *testing.T, count int, firstEventType EventType) []byte {
+	data, err := json.Marshal(&testSnapshot{Count: count, FirstEventType: firstEventType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestEventstore_FilterToQueryReducer_Snapshots(t *testing.T) {
+	type fields struct {
+		repo      *testSnapshotRepo
+		minEvents uint64
+	}
+	type res struct {
+		wantErr        bool
+		count          int
+		sequenceFilter *repository.Filter
+		saved          *repository.Snapshot
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "no snapshot",
+			fields: fields{
+				repo: &testSnapshotRepo{
+					testRepo: testRepo{
+						t: t,
+						events: []*repository.Event{
+							{AggregateID: "id", Type: "test.event", Sequence: 5},
+							{AggregateID: "id", Type: "test.event", Sequence: 6, CreationDate: time.Unix(6, 0)},
+						},
+					},
+				},
+				minEvents: 2,
+			},
+			res: res{
+				count: 2,
+				saved: &repository.Snapshot{
+					Name:          "test_write_model",
+					AggregateID:   "id",
+					ResourceOwner: "ro",
+					Version:       2,
+					Sequence:      6,
+					ChangeDate:    time.Unix(6, 0),
+					Data:          testSnapshotData(t, 2, "test.event"),
+				},
+			},
+		},
+		{
+			name: "snapshot restored",
+			fields: fields{
+				repo: &testSnapshotRepo{
+					testRepo: testRepo{
+						t: t,
+						events: []*repository.Event{
+							{AggregateID: "id", Type: "test.event", Sequence: 11},
+						},
+					},
+					snapshot: &repository.Snapshot{
+						Version:  2,
+						Sequence: 10,
+						Data:     testSnapshotData(t, 10, "test.event"),
+					},
+				},
+				minEvents: 2,
+			},
+			res: res{
+				count:          11,
+				sequenceFilter: repository.NewFilter(repository.FieldSequence, uint64(10), repository.OperationGreater),
+			},
+		},
+		{
+			name: "snapshot of other version ignored",
+			fields: fields{
+				repo: &testSnapshotRepo{
+					testRepo: testRepo{
+						t: t,
+						events: []*repository.Event{
+							{AggregateID: "id", Type: "test.event", Sequence: 11},
+						},
+					},
+					snapshot: &repository.Snapshot{
+						Version:  1,
+						Sequence: 10,
+						Data:     testSnapshotData(t, 10, "test.event"),
+					},
+				},
+				minEvents: 2,
+			},
+			res: res{
+				count: 1,
+			},
+		},
+		{
+			name: "snapshot error ignored",
+			fields: fields{
+				repo: &testSnapshotRepo{
+					testRepo: testRepo{
+						t: t,
+						events: []*repository.Event{
+							{AggregateID: "id", Type: "test.event", Sequence: 11},
+						},
+					},
+					snapshotErr: errors.ThrowInternal(nil, "V2-cL9aD", "test err"),
+				},
+				minEvents: 2,
+			},
+			res: res{
+				count: 1,
+			},
+		},
+		{
+			name: "invalid snapshot",
+			fields: fields{
+				repo: &testSnapshotRepo{
+					testRepo: testRepo{
+						t: t,
+					},
+					snapshot: &repository.Snapshot{
+						Version:  2,
+						Sequence: 10,
+						Data:     []byte("invalid"),
+					},
+				},
+				minEvents: 2,
+			},
+			res: res{
+				wantErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &Eventstore{
+				repo:              tt.fields.repo,
+				interceptorMutex:  sync.Mutex{},
+				eventInterceptors: map[EventType]eventTypeInterceptors{},
+			}
+			es.UseSnapshots(tt.fields.repo, tt.fields.minEvents)
+
+			wm := &testSnapshotWriteModel{
+				WriteModel: WriteModel{
+					AggregateID:   "id",
+					ResourceOwner: "ro",
+				},
+			}
+			err := es.FilterToQueryReducer(context.Background(), wm)
+			if (err != nil) != tt.res.wantErr {
+				t.Fatalf("Eventstore.FilterToQueryReducer() error = %v, wantErr %v", err, tt.res.wantErr)
+			}
+			if tt.res.wantErr {
+				return
+			}
+			if wm.Count != tt.res.count {
+				t.Errorf("wrong count: want %d, got %d", tt.res.count, wm.Count)
+			}
+			if tt.res.sequenceFilter != nil {
+				found := false
+				for _, filter := range tt.fields.repo.filtered.Filters[0] {
+					if reflect.DeepEqual(filter, tt.res.sequenceFilter) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("sequence filter %v not found in %v", tt.res.sequenceFilter, tt.fields.repo.filtered.Filters[0])
+				}
+			}
+			if !reflect.DeepEqual(tt.fields.repo.saved, tt.res.saved) {
+				t.Errorf("wrong saved snapshot: want %+v, got %+v", tt.res.saved, tt.fields.repo.saved)
+			}
+		})
+	}
+}
+
+func TestEventstore_FilterToQueryReducer_SnapshotEqualsReplay(t *testing.T) {
+	events := []*repository.Event{
+		{AggregateID: "id", Type: "test.added", Sequence: 1, CreationDate: time.Unix(1, 0)},
+		{AggregateID: "id", Type: "test.changed", Sequence: 2, CreationDate: time.Unix(2, 0)},
+		{AggregateID: "id", Type: "test.changed", Sequence: 3, CreationDate: time.Unix(3, 0)},
+		{AggregateID: "id", Type: "test.removed", Sequence: 4, CreationDate: time.Unix(4, 0)},
+		{AggregateID: "id", Type: "test.added", Sequence: 5, CreationDate: time.Unix(5, 0)},
+	}
+	reduce := func(repo *testSnapshotRepo, useSnapshots bool) *testSnapshotWriteModel {
+		es := &Eventstore{
+			repo:              repo,
+			interceptorMutex:  sync.Mutex{},
+			eventInterceptors: map[EventType]eventTypeInterceptors{},
+		}
+		if useSnapshots {
+			es.UseSnapshots(repo, 1)
+		}
+		wm := &testSnapshotWriteModel{
+			WriteModel: WriteModel{
+				AggregateID:   "id",
+				ResourceOwner: "ro",
+			},
+		}
+		if err := es.FilterToQueryReducer(context.Background(), wm); err != nil {
+			t.Fatalf("Eventstore.FilterToQueryReducer() unexpected error = %v", err)
+		}
+		return wm
+	}
+
+	for i := 1; i < len(events); i++ {
+		snapshotRepo := &testSnapshotRepo{testRepo: testRepo{t: t, events: events[:i]}}
+		reduce(snapshotRepo, true)
+		if snapshotRepo.saved == nil {
+			t.Fatalf("no snapshot saved after %d events", i)
+		}
+
+		restored := reduce(&testSnapshotRepo{testRepo: testRepo{t: t, events: events[i:]}, snapshot: snapshotRepo.saved}, true)
+		replayed := reduce(&testSnapshotRepo{testRepo: testRepo{t: t, events: events}}, false)
+		if !reflect.DeepEqual(restored, replayed) {
+			t.Errorf("snapshot after %d events: restored %+v, replayed %+v", i, restored, replayed)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 	"github.com/caos/logging"
@@ -22,13 +23,20 @@ import (
 
 const (
 	i18nPath = "/i18n"
+	//maxFallbackChains limits the cached chains of translators shared by all requests
+	maxFallbackChains = 100
 )
 
 type Translator struct {
 	bundle             *i18n.Bundle
+	defaultLanguage    language.Tag
 	cookieName         string
 	cookieHandler      *http_util.CookieHandler
 	preferredLanguages []string
+
+	chainMutex     sync.RWMutex
+	matcher        language.Matcher
+	fallbackChains map[string][]language.Tag
 }
 
 type TranslatorConfig struct {
@@ -48,6 +56,7 @@ func NewTranslator(dir http.FileSystem, config TranslatorConfig) (*Translator, e
 	if err != nil {
 		return nil, err
 	}
+	t.defaultLanguage = config.DefaultLanguage
 	t.cookieHandler = http_util.NewCookieHandler()
 	t.cookieName = config.CookieName
 	return t, nil
@@ -109,6 +118,54 @@ func SupportedLanguages(dir http.FileSystem) ([]language.Tag, error) {
 	return languages, nil
 }
 
+//ParseMessages returns the messages of a translation file (yaml or json)
+// nested keys are joined with a dot, which results in the id used to localize the message (e.g. Login.Title)
+func ParseMessages(content []byte) (map[string]string, error) {
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "I18N-Pm2fs", "Errors.TranslationBundle.Invalid")
+	}
+	messages := make(map[string]string)
+	if err := flattenMessages(messages, "", raw); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func flattenMessages(messages map[string]string, prefix string, raw map[string]interface{}) error {
+	for key, value := range raw {
+		id := key
+		if prefix != "" {
+			id = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[id] = v
+		case map[string]interface{}:
+			if err := flattenMessages(messages, id, v); err != nil {
+				return err
+			}
+		default:
+			return errors.ThrowInvalidArgument(nil, "I18N-Fm3gs", "Errors.TranslationBundle.Invalid")
+		}
+	}
+	return nil
+}
+
+//ReadMessages returns the messages of the translation file of the language
+func ReadMessages(dir http.FileSystem, lang language.Tag) (map[string]string, error) {
+	f, err := dir.Open(i18nPath + "/" + lang.String() + ".yaml")
+	if err != nil {
+		return nil, errors.ThrowNotFound(err, "I18N-Rm2fs", "Errors.TranslationFile.NotFound")
+	}
+	defer f.Close()
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "I18N-Rm3fs", "Errors.TranslationFile.ReadError")
+	}
+	return ParseMessages(content)
+}
+
 func (t *Translator) SupportedLanguages() []language.Tag {
 	return t.bundle.LanguageTags()
 }
@@ -124,23 +181,41 @@ func (t *Translator) AddMessages(tag language.Tag, messages ...Message) error {
 			Other: message.Text,
 		}
 	}
-	return t.bundle.AddMessages(tag, i18nMessages...)
+	if err := t.bundle.AddMessages(tag, i18nMessages...); err != nil {
+		return err
+	}
+	//the supported languages might have changed
+	t.resetFallbackChains()
+	return nil
+}
+
+//AddBundle adds the messages of a translation bundle
+// the keys of the map are the ids of the messages
+func (t *Translator) AddBundle(tag language.Tag, messages map[string]string) error {
+	bundleMessages := make([]Message, 0, len(messages))
+	for id, text := range messages {
+		bundleMessages = append(bundleMessages, Message{ID: id, Text: text})
+	}
+	return t.AddMessages(tag, bundleMessages...)
 }
 
 func (t *Translator) LocalizeFromRequest(r *http.Request, id string, args map[string]interface{}) string {
-	return localize(t.localizerFromRequest(r), id, args)
+	return t.localize(id, args, t.langsFromRequest(r)...)
 }
 
 func (t *Translator) LocalizeFromCtx(ctx context.Context, id string, args map[string]interface{}) string {
-	return localize(t.localizerFromCtx(ctx), id, args)
+	return t.localize(id, args, t.langsFromCtx(ctx)...)
 }
 
+//Localize returns the message in the first language of the fallback chain of the langs which contains the message
 func (t *Translator) Localize(id string, args map[string]interface{}, langs ...string) string {
-	return localize(t.localizer(langs...), id, args)
+	return t.localize(id, args, langs...)
 }
 
 func (t *Translator) Lang(r *http.Request) language.Tag {
-	matcher := language.NewMatcher(t.bundle.LanguageTags())
+	t.chainMutex.Lock()
+	matcher := t.languageMatcher()
+	t.chainMutex.Unlock()
 	tag, _ := language.MatchStrings(matcher, t.langsFromRequest(r)...)
 	return tag
 }
@@ -149,16 +224,104 @@ func (t *Translator) SetLangCookie(w http.ResponseWriter, lang language.Tag) {
 	t.cookieHandler.SetCookie(w, t.cookieName, lang.String())
 }
 
-func (t *Translator) localizerFromRequest(r *http.Request) *i18n.Localizer {
-	return t.localizer(t.langsFromRequest(r)...)
+//fallbackChain returns the cached fallback chain of the langs
+// the chains are computed once per combination of langs until messages are added
+func (t *Translator) fallbackChain(langs ...string) []language.Tag {
+	key := strings.Join(langs, ";")
+	t.chainMutex.RLock()
+	chain, ok := t.fallbackChains[key]
+	t.chainMutex.RUnlock()
+	if ok {
+		return chain
+	}
+	t.chainMutex.Lock()
+	defer t.chainMutex.Unlock()
+	if chain, ok = t.fallbackChains[key]; ok {
+		return chain
+	}
+	if t.fallbackChains == nil || len(t.fallbackChains) >= maxFallbackChains {
+		t.fallbackChains = make(map[string][]language.Tag)
+	}
+	chain = t.newFallbackChain(langs...)
+	t.fallbackChains[key] = chain
+	return chain
+}
+
+func (t *Translator) resetFallbackChains() {
+	t.chainMutex.Lock()
+	defer t.chainMutex.Unlock()
+	t.matcher = nil
+	t.fallbackChains = nil
+}
+
+//languageMatcher must be called with the chainMutex locked
+func (t *Translator) languageMatcher() language.Matcher {
+	if t.matcher == nil {
+		t.matcher = language.NewMatcher(t.bundle.LanguageTags())
+	}
+	return t.matcher
+}
+
+//newFallbackChain returns the supported languages in the order they are used to localize a message:
+// the best match of the requested languages followed by its parents (e.g. de-CH, de),
+// the other requested languages and their parents, the default language and english
+// it must be called with the chainMutex locked
+func (t *Translator) newFallbackChain(langs ...string) []language.Tag {
+	supported := t.bundle.LanguageTags()
+	chain := make([]language.Tag, 0, len(supported))
+	add := func(tag language.Tag) {
+		for ; tag != language.Und; tag = tag.Parent() {
+			if containsTag(supported, tag) && !containsTag(chain, tag) {
+				chain = append(chain, tag)
+			}
+		}
+	}
+	requested := parseTags(langs...)
+	if len(requested) > 0 {
+		_, index, _ := t.languageMatcher().Match(requested...)
+		add(supported[index])
+	}
+	for _, tag := range requested {
+		add(tag)
+	}
+	add(t.defaultLanguage)
+	add(language.English)
+	return chain
 }
 
-func (t *Translator) localizerFromCtx(ctx context.Context) *i18n.Localizer {
-	return t.localizer(t.langsFromCtx(ctx)...)
+func (t *Translator) localize(id string, args map[string]interface{}, langs ...string) string {
+	for _, tag := range t.fallbackChain(langs...) {
+		s, err := i18n.NewLocalizer(t.bundle, tag.String()).Localize(&i18n.LocalizeConfig{
+			MessageID:    id,
+			TemplateData: args,
+		})
+		if err == nil {
+			return s
+		}
+	}
+	logging.LogWithFields("I18N-MsF5sx", "id", id, "args", args).Warnf("missing translation")
+	return id
 }
 
-func (t *Translator) localizer(langs ...string) *i18n.Localizer {
-	return i18n.NewLocalizer(t.bundle, langs...)
+func parseTags(langs ...string) []language.Tag {
+	tags := make([]language.Tag, 0, len(langs))
+	for _, lang := range langs {
+		parsed, _, err := language.ParseAcceptLanguage(lang)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, parsed...)
+	}
+	return tags
+}
+
+func containsTag(tags []language.Tag, tag language.Tag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
 
 func (t *Translator) langsFromRequest(r *http.Request) []string {
@@ -192,15 +355,3 @@ func (t *Translator) SetPreferredLanguages(langs ...string) {
 func getAcceptLanguageHeader(ctx context.Context) string {
 	return metautils.ExtractIncoming(ctx).Get("grpcgateway-accept-language")
 }
-
-func localize(localizer *i18n.Localizer, id string, args map[string]interface{}) string {
-	s, err := localizer.Localize(&i18n.LocalizeConfig{
-		MessageID:    id,
-		TemplateData: args,
-	})
-	if err != nil {
-		logging.LogWithFields("I18N-MsF5sx", "id", id, "args", args).WithError(err).Warnf("missing translation")
-		return id
-	}
-	return s
-}
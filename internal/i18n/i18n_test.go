@@ -0,0 +1,189 @@
+package i18n
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func testTranslator(t *testing.T, defaultLanguage language.Tag, files map[string]string) *Translator {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "i18n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, "i18n", name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	translator, err := NewTranslator(http.Dir(dir), TranslatorConfig{DefaultLanguage: defaultLanguage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return translator
+}
+
+func TestTranslator_Localize(t *testing.T) {
+	files := map[string]string{
+		"en.yaml":    "Login:\n  Title: Welcome\n  Description: Login with your account\n  Next: next\n",
+		"de.yaml":    "Login:\n  Title: Willkommen\n  Description: Melde dich an\n",
+		"de-CH.yaml": "Login:\n  Title: Grüezi\n",
+		"it.yaml":    "Login:\n  Title: Benvenuto\n",
+	}
+	type args struct {
+		defaultLanguage language.Tag
+		id              string
+		langs           []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "region variant",
+			args: args{
+				defaultLanguage: language.English,
+				id:              "Login.Title",
+				langs:           []string{"de-CH"},
+			},
+			want: "Grüezi",
+		},
+		{
+			name: "missing in region variant, parent language",
+			args: args{
+				defaultLanguage: language.English,
+				id:              "Login.Description",
+				langs:           []string{"de-CH"},
+			},
+			want: "Melde dich an",
+		},
+		{
+			name: "missing in region variant and parent, next requested language",
+			args: args{
+				defaultLanguage: language.English,
+				id:              "Login.Next",
+				langs:           []string{"de-CH,it;q=0.8"},
+			},
+			want: "next",
+		},
+		{
+			name: "missing in requested language, default language",
+			args: args{
+				defaultLanguage: language.German,
+				id:              "Login.Description",
+				langs:           []string{"it"},
+			},
+			want: "Melde dich an",
+		},
+		{
+			name: "missing in requested and default language, english",
+			args: args{
+				defaultLanguage: language.German,
+				id:              "Login.Next",
+				langs:           []string{"it"},
+			},
+			want: "next",
+		},
+		{
+			name: "unsupported language, default language",
+			args: args{
+				defaultLanguage: language.German,
+				id:              "Login.Title",
+				langs:           []string{"fr"},
+			},
+			want: "Willkommen",
+		},
+		{
+			name: "missing message, id",
+			args: args{
+				defaultLanguage: language.English,
+				id:              "Login.Unknown",
+				langs:           []string{"de"},
+			},
+			want: "Login.Unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator := testTranslator(t, tt.args.defaultLanguage, files)
+			if got := translator.Localize(tt.args.id, nil, tt.args.langs...); got != tt.want {
+				t.Errorf("Localize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslator_Localize_addedLanguage(t *testing.T) {
+	translator := testTranslator(t, language.English, map[string]string{
+		"en.yaml": "Login:\n  Title: Welcome\n",
+	})
+	if got := translator.Localize("Login.Title", nil, "fr"); got != "Welcome" {
+		t.Fatalf("Localize() = %v, want %v", got, "Welcome")
+	}
+	if err := translator.AddMessages(language.French, Message{ID: "Login.Title", Text: "Bienvenue"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := translator.Localize("Login.Title", nil, "fr"); got != "Bienvenue" {
+		t.Errorf("Localize() = %v, want %v", got, "Bienvenue")
+	}
+}
+
+func TestParseMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr func(error) bool
+	}{
+		{
+			name:    "yaml",
+			content: "Login:\n  Title: Welcome\n  Nested:\n    Label: Name\nFooter: footer\n",
+			want: map[string]string{
+				"Login.Title":        "Welcome",
+				"Login.Nested.Label": "Name",
+				"Footer":             "footer",
+			},
+		},
+		{
+			name:    "json",
+			content: `{"Login": {"Title": "Welcome"}}`,
+			want: map[string]string{
+				"Login.Title": "Welcome",
+			},
+		},
+		{
+			name:    "invalid content",
+			content: "Login: [",
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:    "no text",
+			content: "Login:\n  Title: 1\n",
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMessages([]byte(tt.content))
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("ParseMessages() unexpected error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMessages() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMessages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
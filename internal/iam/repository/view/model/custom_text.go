@@ -70,7 +70,8 @@ func (r *CustomTextView) IsMessageTemplate() bool {
 		r.Template == domain.VerifyEmailMessageType ||
 		r.Template == domain.VerifyPhoneMessageType ||
 		r.Template == domain.DomainClaimedMessageType ||
-		r.Template == domain.PasswordlessRegistrationMessageType
+		r.Template == domain.PasswordlessRegistrationMessageType ||
+		domain.IsSecurityAlertMessageType(r.Template)
 }
 
 func CustomTextViewsToLoginDomain(aggregateID, lang string, texts []*CustomTextView) *domain.CustomLoginText {
@@ -13,6 +13,7 @@ import (
 	"github.com/caos/logging"
 	caos_errs "github.com/caos/zitadel/internal/errors"
 	"github.com/caos/zitadel/internal/notification/channels"
+	"github.com/caos/zitadel/internal/notification/messages"
 )
 
 func InitChatChannel(config ChatConfig) (channels.NotificationChannel, error) {
@@ -27,7 +28,7 @@ func InitChatChannel(config ChatConfig) (channels.NotificationChannel, error) {
 	return channels.HandleMessageFunc(func(message channels.Message) error {
 		contentText := message.GetContent()
 		if config.Compact {
-			contentText = html2text.HTML2Text(contentText)
+			contentText = compactContent(message)
 		}
 		for _, splittedMsg := range splitMessage(contentText, config.SplitCount) {
 			if err := sendMessage(splittedMsg, url); err != nil {
@@ -78,3 +79,12 @@ func splitMessage(message string, count int) []string {
 	splits = append(splits, message[l:])
 	return splits
 }
+
+//compactContent returns the plain text of the message
+// emails are reduced to their text part instead of the whole MIME message
+func compactContent(message channels.Message) string {
+	if email, ok := message.(*messages.Email); ok {
+		return email.GetTextContent()
+	}
+	return html2text.HTML2Text(message.GetContent())
+}
@@ -12,9 +12,6 @@ import (
 	"github.com/caos/logging"
 
 	caos_errors "github.com/caos/zitadel/internal/errors"
-
-	"github.com/k3a/html2text"
-
 	"github.com/caos/zitadel/internal/notification/channels"
 	"github.com/caos/zitadel/internal/notification/messages"
 )
@@ -36,10 +33,13 @@ func InitFSChannel(config FSConfig) (channels.NotificationChannel, error) {
 			recipients := make([]string, len(msg.Recipients))
 			copy(recipients, msg.Recipients)
 			sort.Strings(recipients)
-			fileName = fileName + "mail_to_" + strings.Join(recipients, "_") + ".html"
+			fileName = fileName + "mail_to_" + strings.Join(recipients, "_")
 			if config.Compact {
-				content = html2text.HTML2Text(content)
+				fileName = fileName + ".txt"
+				content = msg.GetTextContent()
+				break
 			}
+			fileName = fileName + ".eml"
 		case *messages.SMS:
 			fileName = fileName + "sms_to_" + msg.RecipientPhoneNumber + ".txt"
 		default:
@@ -7,6 +7,7 @@ import (
 
 	"github.com/caos/logging"
 	"github.com/caos/zitadel/internal/notification/channels"
+	"github.com/caos/zitadel/internal/notification/messages"
 )
 
 func InitStdoutChannel(config LogConfig) channels.NotificationChannel {
@@ -17,7 +18,7 @@ func InitStdoutChannel(config LogConfig) channels.NotificationChannel {
 
 		content := message.GetContent()
 		if config.Compact {
-			content = html2text.HTML2Text(content)
+			content = compactContent(message)
 		}
 
 		logging.Log("NOTIF-c73ba").WithFields(map[string]interface{}{
@@ -27,3 +28,12 @@ func InitStdoutChannel(config LogConfig) channels.NotificationChannel {
 		return nil
 	})
 }
+
+//compactContent returns the plain text of the message
+// emails are reduced to their text part instead of the whole MIME message
+func compactContent(message channels.Message) string {
+	if email, ok := message.(*messages.Email); ok {
+		return email.GetTextContent()
+	}
+	return html2text.HTML2Text(message.GetContent())
+}
@@ -5,6 +5,11 @@ type EmailConfig struct {
 	Tls      bool
 	From     string
 	FromName string
+	//Headers are added to every email (e.g. Reply-To, List-Unsubscribe)
+	Headers map[string]string
+	//InlineLogo attaches the logo of the label policy to the email instead of linking it
+	// mail clients often block remote images
+	InlineLogo bool
 }
 
 type SMTP struct {
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/notification/types"
+	"github.com/caos/zitadel/internal/repository/user"
+)
+
+const (
+	mfaTypeOTP          = "OTP"
+	mfaTypeU2F          = "U2F"
+	mfaTypePasswordless = "Passwordless"
+)
+
+//reducePasswordChanged alerts the user if an existing password was changed
+// the initial password of the user is not reported
+func (p *UserNotifier) reducePasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordChangedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Ag4fb", "seq", event.Sequence(), "expectedType", user.HumanPasswordChangedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Wgh3d", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		enabled, err := p.isSecurityAlertEnabled(ctx, e.Aggregate().ResourceOwner, domain.PasswordChangedMessageType)
+		if err != nil || !enabled {
+			return err
+		}
+		events, err := p.getPreviousUserEvents(ctx, e,
+			user.UserV1AddedType, user.UserV1RegisteredType,
+			user.HumanAddedType, user.HumanRegisteredType,
+			user.UserV1PasswordChangedType, user.HumanPasswordChangedType)
+		if err != nil {
+			return err
+		}
+		hadPassword := false
+		for _, previous := range events {
+			switch previous := previous.(type) {
+			case *user.HumanPasswordChangedEvent:
+				hadPassword = true
+			case *user.HumanAddedEvent:
+				hadPassword = previous.Secret != nil
+			case *user.HumanRegisteredEvent:
+				hadPassword = previous.Secret != nil
+			}
+		}
+		if !hadPassword {
+			return nil
+		}
+		return p.sendSecurityAlert(ctx, e, domain.PasswordChangedMessageType, nil, "")
+	}), nil
+}
+
+func (p *UserNotifier) reduceMFAChanged(messageType, mfaType string) handler.Reduce {
+	return func(event eventstore.Event) (*handler.Statement, error) {
+		return crdb.NewStatement(event, func(handler.Executer, string) error {
+			ctx := getSetNotifyContextData(event.Aggregate().ResourceOwner)
+			enabled, err := p.isSecurityAlertEnabled(ctx, event.Aggregate().ResourceOwner, messageType)
+			if err != nil || !enabled {
+				return err
+			}
+			return p.sendSecurityAlert(ctx, event, messageType, map[string]interface{}{"MFAType": mfaType}, "")
+		}), nil
+	}
+}
+
+func (p *UserNotifier) reduceUserLocked(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserLockedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Lo3ck", "seq", event.Sequence(), "expectedType", user.UserLockedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Bg2ed", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		enabled, err := p.isSecurityAlertEnabled(ctx, e.Aggregate().ResourceOwner, domain.UserLockedMessageType)
+		if err != nil || !enabled {
+			return err
+		}
+		return p.sendSecurityAlert(ctx, e, domain.UserLockedMessageType, nil, "")
+	}), nil
+}
+
+//reducePasswordCheckSucceeded alerts the user if the password was checked on a user agent which was never used before
+// the user agents are stored in the user agents table of the notifier, the first login of the user is not reported
+func (p *UserNotifier) reducePasswordCheckSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordCheckSucceededEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Uo2gd", "seq", event.Sequence(), "expectedType", user.HumanPasswordCheckSucceededType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Nh3fs", "reduce.wrong.event.type")
+	}
+	if e.AuthRequestInfo == nil || e.UserAgentID == "" {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return crdb.NewStatement(e, func(ex handler.Executer, _ string) error {
+		isNew, err := addUserAgent(ex, e.Aggregate().ID, e.UserAgentID, e.Sequence())
+		if err != nil || !isNew {
+			return err
+		}
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		enabled, err := p.isSecurityAlertEnabled(ctx, e.Aggregate().ResourceOwner, domain.NewUserAgentMessageType)
+		if err != nil || !enabled {
+			return err
+		}
+		args := map[string]interface{}{
+			"UserAgent": "",
+			"RemoteIP":  "",
+		}
+		if e.BrowserInfo != nil {
+			args["UserAgent"] = e.BrowserInfo.UserAgent
+			if e.BrowserInfo.RemoteIP != nil {
+				args["RemoteIP"] = e.BrowserInfo.RemoteIP.String()
+			}
+		}
+		return p.sendSecurityAlert(ctx, e, domain.NewUserAgentMessageType, args, "")
+	}), nil
+}
+
+//addUserAgent stores the user agent of the user
+// it returns true if the user agent was unknown and the user already used another user agent
+func addUserAgent(ex handler.Executer, userID, userAgentID string, sequence uint64) (bool, error) {
+	result, err := ex.Exec("INSERT INTO "+UserAgentsTable+" (user_id, user_agent_id, sequence)"+
+		" SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM "+UserAgentsTable+" WHERE user_id = $1)"+
+		" ON CONFLICT (user_id, user_agent_id) DO NOTHING",
+		userID, userAgentID, sequence)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "HANDL-Ua2gS", "unable to store user agent")
+	}
+	added, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.ThrowInternal(err, "HANDL-Ua3gR", "unable to store user agent")
+	}
+	if added > 0 {
+		return true, nil
+	}
+	//the first user agent of the user or an already known user agent
+	_, err = ex.Exec("INSERT INTO "+UserAgentsTable+" (user_id, user_agent_id, sequence) VALUES ($1, $2, $3)"+
+		" ON CONFLICT (user_id, user_agent_id) DO NOTHING",
+		userID, userAgentID, sequence)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "HANDL-Ua4gF", "unable to store user agent")
+	}
+	return false, nil
+}
+
+//reduceEmailChanged alerts the user on the previous email address
+func (p *UserNotifier) reduceEmailChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Em3cd", "seq", event.Sequence(), "expectedType", user.HumanEmailChangedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Rf2gs", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		enabled, err := p.isSecurityAlertEnabled(ctx, e.Aggregate().ResourceOwner, domain.EmailChangedMessageType)
+		if err != nil || !enabled {
+			return err
+		}
+		events, err := p.getPreviousUserEvents(ctx, e,
+			user.UserV1AddedType, user.UserV1RegisteredType,
+			user.HumanAddedType, user.HumanRegisteredType,
+			user.UserV1EmailChangedType, user.HumanEmailChangedType)
+		if err != nil {
+			return err
+		}
+		var oldEmail string
+		for _, previous := range events {
+			var email string
+			switch previous := previous.(type) {
+			case *user.HumanAddedEvent:
+				email = previous.EmailAddress
+			case *user.HumanRegisteredEvent:
+				email = previous.EmailAddress
+			case *user.HumanEmailChangedEvent:
+				email = previous.EmailAddress
+			}
+			if email != "" {
+				oldEmail = email
+			}
+		}
+		if oldEmail == "" || oldEmail == e.EmailAddress {
+			return nil
+		}
+		args := map[string]interface{}{
+			"OldEmail": oldEmail,
+			"NewEmail": e.EmailAddress,
+		}
+		return p.sendSecurityAlert(ctx, e, domain.EmailChangedMessageType, args, oldEmail)
+	}), nil
+}
+
+//isSecurityAlertEnabled checks the notification policy of the organisation
+func (p *UserNotifier) isSecurityAlertEnabled(ctx context.Context, orgID, messageType string) (bool, error) {
+	policy, err := p.queries.NotificationPolicyByOrg(ctx, orgID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return policy.ToDomain().IsAlertEnabled(messageType), nil
+}
+
+//sendSecurityAlert sends the alert once for the triggering event
+// if no recipient is passed the alert is sent to the verified email of the user
+func (p *UserNotifier) sendSecurityAlert(ctx context.Context, event eventstore.Event, messageType string, args map[string]interface{}, recipient string) error {
+	alreadySent, err := p.checkIfSecurityAlertAlreadySent(ctx, event)
+	if err != nil || alreadySent {
+		return err
+	}
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, event.Aggregate().ID)
+	if err != nil {
+		return err
+	}
+	if recipient == "" && notifyUser.VerifiedEmail == "" {
+		return nil
+	}
+	colors, err := p.getLabelPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	template, err := p.getMailTemplate(ctx, messageType)
+	if err != nil {
+		return err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return err
+	}
+	err = types.SendSecurityAlert(string(template.Template), translator, notifyUser, messageType, args, p.systemDefaults, colors, p.getLogo(ctx, colors), p.apiDomain, recipient, p.notify(ctx, event, notifyUser, messageType))
+	if err != nil {
+		return err
+	}
+	return p.commands.HumanSecurityAlertSent(ctx, event.Aggregate().ResourceOwner, event.Aggregate().ID, messageType, event.Sequence())
+}
+
+func (p *UserNotifier) checkIfSecurityAlertAlreadySent(ctx context.Context, event eventstore.Event) (bool, error) {
+	events, err := p.getUserEvents(ctx, event.Aggregate().ID, event.Sequence(), user.HumanSecurityAlertSentType)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range events {
+		sentEvent, ok := e.(*user.HumanSecurityAlertSentEvent)
+		if ok && sentEvent.EventSequence == event.Sequence() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//getPreviousUserEvents returns the events of the user with the given types which happened before the event
+func (p *UserNotifier) getPreviousUserEvents(ctx context.Context, event eventstore.Event, eventTypes ...eventstore.EventType) ([]eventstore.Event, error) {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(event.Aggregate().ID).
+		SequenceLess(event.Sequence()).
+		EventTypes(eventTypes...).
+		Builder()
+	return p.Eventstore.Filter(ctx, query)
+}
@@ -0,0 +1,529 @@
+package handlers
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/command"
+	sd "github.com/caos/zitadel/internal/config/systemdefaults"
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/channels"
+	"github.com/caos/zitadel/internal/notification/messages"
+	"github.com/caos/zitadel/internal/notification/outbox"
+	"github.com/caos/zitadel/internal/notification/types"
+	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/repository/user"
+	"github.com/caos/zitadel/internal/static"
+)
+
+const (
+	UserNotifierProjection = "zitadel.projections.user_notifier"
+	UserAgentsTable        = UserNotifierProjection + "_" + userAgentsTableSuffix
+	NotifyUserID           = "NOTIFICATION"
+
+	userAgentsTableSuffix = "user_agents"
+)
+
+//UserNotifier sends the notifications of the user events
+// it runs as projection so the locking and the handling of failed events is shared with the projections
+// the notifications are queued with an idempotency key per event, which allows to run it on multiple instances
+// the queued notifications are delivered by the outbox of the instance holding the outbox lock
+type UserNotifier struct {
+	crdb.StatementHandler
+	commands       *command.Commands
+	queries        *query.Queries
+	outbox         *outbox.Outbox
+	systemDefaults sd.SystemDefaults
+	aesCrypto      crypto.EncryptionAlgorithm
+	statikDir      http.FileSystem
+	staticStorage  static.Storage
+	apiDomain      string
+}
+
+func NewUserNotifier(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *query.Queries,
+	outbox *outbox.Outbox,
+	defaults sd.SystemDefaults,
+	aesCrypto crypto.EncryptionAlgorithm,
+	statikDir http.FileSystem,
+	staticStorage static.Storage,
+	apiDomain string,
+) *UserNotifier {
+	p := &UserNotifier{
+		commands:       commands,
+		queries:        queries,
+		outbox:         outbox,
+		systemDefaults: defaults,
+		aesCrypto:      aesCrypto,
+		statikDir:      statikDir,
+		staticStorage:  staticStorage,
+		apiDomain:      apiDomain,
+	}
+	config.ProjectionName = UserNotifierProjection
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *UserNotifier) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1InitialCodeAddedType,
+					Reduce: p.reduceInitCodeAdded,
+				},
+				{
+					Event:  user.HumanInitialCodeAddedType,
+					Reduce: p.reduceInitCodeAdded,
+				},
+				{
+					Event:  user.UserV1EmailCodeAddedType,
+					Reduce: p.reduceEmailCodeAdded,
+				},
+				{
+					Event:  user.HumanEmailCodeAddedType,
+					Reduce: p.reduceEmailCodeAdded,
+				},
+				{
+					Event:  user.UserV1PasswordCodeAddedType,
+					Reduce: p.reducePasswordCodeAdded,
+				},
+				{
+					Event:  user.HumanPasswordCodeAddedType,
+					Reduce: p.reducePasswordCodeAdded,
+				},
+				{
+					Event:  user.UserV1PhoneCodeAddedType,
+					Reduce: p.reducePhoneCodeAdded,
+				},
+				{
+					Event:  user.HumanPhoneCodeAddedType,
+					Reduce: p.reducePhoneCodeAdded,
+				},
+				{
+					Event:  user.UserDomainClaimedType,
+					Reduce: p.reduceDomainClaimed,
+				},
+				{
+					Event:  user.HumanPasswordlessInitCodeRequestedType,
+					Reduce: p.reducePasswordlessCodeRequested,
+				},
+				{
+					Event:  user.UserV1PasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.HumanPasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.UserV1MFAOTPVerifiedType,
+					Reduce: p.reduceMFAChanged(domain.MFAAddedMessageType, mfaTypeOTP),
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: p.reduceMFAChanged(domain.MFAAddedMessageType, mfaTypeOTP),
+				},
+				{
+					Event:  user.UserV1MFAOTPRemovedType,
+					Reduce: p.reduceMFAChanged(domain.MFARemovedMessageType, mfaTypeOTP),
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: p.reduceMFAChanged(domain.MFARemovedMessageType, mfaTypeOTP),
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: p.reduceMFAChanged(domain.MFAAddedMessageType, mfaTypeU2F),
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: p.reduceMFAChanged(domain.MFARemovedMessageType, mfaTypeU2F),
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: p.reduceMFAChanged(domain.MFAAddedMessageType, mfaTypePasswordless),
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: p.reduceMFAChanged(domain.MFARemovedMessageType, mfaTypePasswordless),
+				},
+				{
+					Event:  user.UserV1PasswordCheckSucceededType,
+					Reduce: p.reducePasswordCheckSucceeded,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: p.reducePasswordCheckSucceeded,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserLocked,
+				},
+				{
+					Event:  user.UserV1EmailChangedType,
+					Reduce: p.reduceEmailChanged,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: p.reduceEmailChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *UserNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanInitialCodeAddedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Eeg3s", "seq", event.Sequence(), "expectedType", user.HumanInitialCodeAddedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-EFe2f", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, e, e.Expiry,
+			user.UserV1InitialCodeAddedType, user.UserV1InitialCodeSentType,
+			user.HumanInitialCodeAddedType, user.HumanInitialCodeSentType)
+		if err != nil || alreadyHandled {
+			return err
+		}
+		colors, err := p.getLabelPolicy(ctx)
+		if err != nil {
+			return err
+		}
+		template, err := p.getMailTemplate(ctx, domain.InitCodeMessageType)
+		if err != nil {
+			return err
+		}
+		notifyUser, err := p.queries.GetNotifyUserByID(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.InitCodeMessageType)
+		if err != nil {
+			return err
+		}
+		err = types.SendUserInitCode(string(template.Template), translator, notifyUser, e, p.systemDefaults, p.aesCrypto, colors, p.getLogo(ctx, colors), p.apiDomain, p.notify(ctx, e, notifyUser, domain.InitCodeMessageType))
+		if err != nil {
+			return err
+		}
+		return p.commands.HumanInitCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (p *UserNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailCodeAddedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Wh1g2", "seq", event.Sequence(), "expectedType", user.HumanEmailCodeAddedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-SWf3g", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, e, e.Expiry,
+			user.UserV1EmailCodeAddedType, user.UserV1EmailCodeSentType,
+			user.HumanEmailCodeAddedType, user.HumanEmailCodeSentType)
+		if err != nil || alreadyHandled {
+			return nil
+		}
+		colors, err := p.getLabelPolicy(ctx)
+		if err != nil {
+			return err
+		}
+		template, err := p.getMailTemplate(ctx, domain.VerifyEmailMessageType)
+		if err != nil {
+			return err
+		}
+		notifyUser, err := p.queries.GetNotifyUserByID(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyEmailMessageType)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmailVerificationCode(string(template.Template), translator, notifyUser, e, p.systemDefaults, p.aesCrypto, colors, p.getLogo(ctx, colors), p.apiDomain, p.notify(ctx, e, notifyUser, domain.VerifyEmailMessageType))
+		if err != nil {
+			return err
+		}
+		return p.commands.HumanEmailVerificationCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (p *UserNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordCodeAddedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Dfb3e", "seq", event.Sequence(), "expectedType", user.HumanPasswordCodeAddedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Hd2vw", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, e, e.Expiry,
+			user.UserV1PasswordCodeAddedType, user.UserV1PasswordCodeSentType,
+			user.HumanPasswordCodeAddedType, user.HumanPasswordCodeSentType)
+		if err != nil || alreadyHandled {
+			return err
+		}
+		colors, err := p.getLabelPolicy(ctx)
+		if err != nil {
+			return err
+		}
+		template, err := p.getMailTemplate(ctx, domain.PasswordResetMessageType)
+		if err != nil {
+			return err
+		}
+		notifyUser, err := p.queries.GetNotifyUserByID(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordResetMessageType)
+		if err != nil {
+			return err
+		}
+		err = types.SendPasswordCode(string(template.Template), translator, notifyUser, e, p.systemDefaults, p.aesCrypto, colors, p.getLogo(ctx, colors), p.apiDomain, p.notify(ctx, e, notifyUser, domain.PasswordResetMessageType))
+		if err != nil {
+			return err
+		}
+		return p.commands.PasswordCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (p *UserNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPhoneCodeAddedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Gb2dg", "seq", event.Sequence(), "expectedType", user.HumanPhoneCodeAddedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-He83g", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, e, e.Expiry,
+			user.UserV1PhoneCodeAddedType, user.UserV1PhoneCodeSentType,
+			user.HumanPhoneCodeAddedType, user.HumanPhoneCodeSentType)
+		if err != nil || alreadyHandled {
+			return nil
+		}
+		notifyUser, err := p.queries.GetNotifyUserByID(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyPhoneMessageType)
+		if err != nil {
+			return err
+		}
+		err = types.SendPhoneVerificationCode(translator, notifyUser, e, p.systemDefaults, p.aesCrypto, p.notify(ctx, e, notifyUser, domain.VerifyPhoneMessageType))
+		if err != nil {
+			return err
+		}
+		return p.commands.HumanPhoneVerificationCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (p *UserNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.DomainClaimedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Drh5w", "seq", event.Sequence(), "expectedType", user.UserDomainClaimedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Gh2ef", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		alreadyHandled, err := p.checkIfAlreadyHandled(ctx, e, user.UserDomainClaimedType, user.UserDomainClaimedSentType)
+		if err != nil || alreadyHandled {
+			return nil
+		}
+		notifyUser, err := p.queries.GetNotifyUserByID(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		if notifyUser.LastEmail == "" {
+			return nil
+		}
+		colors, err := p.getLabelPolicy(ctx)
+		if err != nil {
+			return err
+		}
+		template, err := p.getMailTemplate(ctx, domain.DomainClaimedMessageType)
+		if err != nil {
+			return err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.DomainClaimedMessageType)
+		if err != nil {
+			return err
+		}
+		err = types.SendDomainClaimed(string(template.Template), translator, notifyUser, e.UserName, p.systemDefaults, colors, p.getLogo(ctx, colors), p.apiDomain, p.notify(ctx, e, notifyUser, domain.DomainClaimedMessageType))
+		if err != nil {
+			return err
+		}
+		return p.commands.UserDomainClaimedSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (p *UserNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordlessInitCodeRequestedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Ew2ft", "seq", event.Sequence(), "expectedType", user.HumanPasswordlessInitCodeRequestedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-EDtjd", "reduce.wrong.event.type")
+	}
+	return crdb.NewStatement(e, func(handler.Executer, string) error {
+		ctx := getSetNotifyContextData(e.Aggregate().ResourceOwner)
+		events, err := p.getUserEvents(ctx, e.Aggregate().ID, e.Sequence(), user.HumanPasswordlessInitCodeSentType)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if sentEvent, ok := event.(*user.HumanPasswordlessInitCodeSentEvent); ok && sentEvent.ID == e.ID {
+				return nil
+			}
+		}
+		notifyUser, err := p.queries.GetNotifyUserByID(ctx, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		colors, err := p.getLabelPolicy(ctx)
+		if err != nil {
+			return err
+		}
+		template, err := p.getMailTemplate(ctx, domain.PasswordlessRegistrationMessageType)
+		if err != nil {
+			return err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordlessRegistrationMessageType)
+		if err != nil {
+			return err
+		}
+		err = types.SendPasswordlessRegistrationLink(string(template.Template), translator, notifyUser, e, p.systemDefaults, p.aesCrypto, colors, p.getLogo(ctx, colors), p.apiDomain, p.notify(ctx, e, notifyUser, domain.PasswordlessRegistrationMessageType))
+		if err != nil {
+			return err
+		}
+		return p.commands.HumanPasswordlessInitCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.ID)
+	}), nil
+}
+
+func (p *UserNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, eventTypes ...eventstore.EventType) (bool, error) {
+	if event.CreationDate().Add(expiry).Before(time.Now().UTC()) {
+		return true, nil
+	}
+	return p.checkIfAlreadyHandled(ctx, event, eventTypes...)
+}
+
+//checkIfAlreadyHandled checks if one of the event types was pushed after the event
+func (p *UserNotifier) checkIfAlreadyHandled(ctx context.Context, event eventstore.Event, eventTypes ...eventstore.EventType) (bool, error) {
+	events, err := p.getUserEvents(ctx, event.Aggregate().ID, event.Sequence(), eventTypes...)
+	if err != nil {
+		return false, err
+	}
+	return len(events) > 0, nil
+}
+
+func (p *UserNotifier) getUserEvents(ctx context.Context, userID string, sequence uint64, eventTypes ...eventstore.EventType) ([]eventstore.Event, error) {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		SequenceGreater(sequence).
+		EventTypes(eventTypes...).
+		Builder()
+	return p.Eventstore.Filter(ctx, query)
+}
+
+//notify queues the message of the user in the outbox
+// the event is used as idempotency key, so the message is queued once even if the event is handled multiple times
+func (p *UserNotifier) notify(ctx context.Context, event eventstore.Event, notifyUser *query.NotifyUser, messageType string) types.Notify {
+	idempotencyKey := UserNotifierProjection + ":" + strconv.FormatUint(event.Sequence(), 10)
+	return func(message channels.Message, channel domain.NotificationType, recipient string) error {
+		return p.outbox.Queue(ctx, notifyUser.ResourceOwner, notifyUser.ID, messageType, idempotencyKey, message, channel, recipient)
+	}
+}
+
+func getSetNotifyContextData(orgID string) context.Context {
+	return authz.SetCtxData(context.Background(), authz.CtxData{UserID: NotifyUserID, OrgID: orgID})
+}
+
+// Read organization specific colors
+func (p *UserNotifier) getLabelPolicy(ctx context.Context) (*query.LabelPolicy, error) {
+	return p.queries.ActiveLabelPolicyByOrg(ctx, authz.GetCtxData(ctx).OrgID)
+}
+
+//getLogo returns the logo of the label policy as inline attachment if configured
+// if the logo can't be read the email links the logo
+func (p *UserNotifier) getLogo(ctx context.Context, policy *query.LabelPolicy) *messages.Attachment {
+	if !p.systemDefaults.Notifications.Providers.Email.InlineLogo || p.staticStorage == nil || policy.Light.LogoURL == "" {
+		return nil
+	}
+	reader, getInfo, err := p.staticStorage.GetObject(ctx, policy.ID, policy.Light.LogoURL)
+	if err != nil {
+		logging.LogWithFields("HANDL-Lg2gO", "policy", policy.ID).WithError(err).Warn("unable to get logo")
+		return nil
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		logging.LogWithFields("HANDL-Lg2rC", "policy", policy.ID).WithError(err).Warn("unable to read logo")
+		return nil
+	}
+	info, err := getInfo()
+	if err != nil {
+		logging.LogWithFields("HANDL-Lg2iI", "policy", policy.ID).WithError(err).Warn("unable to get logo info")
+		return nil
+	}
+	return &messages.Attachment{
+		FileName:    path.Base(policy.Light.LogoURL),
+		ContentType: info.ContentType,
+		ContentID:   types.LogoContentID,
+		Content:     content,
+	}
+}
+
+// Read organization specific template of the message type
+func (p *UserNotifier) getMailTemplate(ctx context.Context, messageType string) (*query.MailTemplate, error) {
+	return p.queries.MailTemplateByOrgAndMessageType(ctx, authz.GetCtxData(ctx).OrgID, messageType)
+}
+
+func (p *UserNotifier) getTranslatorWithOrgTexts(ctx context.Context, orgID, textType string) (*i18n.Translator, error) {
+	translator, err := i18n.NewTranslator(p.statikDir, i18n.TranslatorConfig{DefaultLanguage: p.systemDefaults.DefaultLanguage})
+	if err != nil {
+		return nil, err
+	}
+	bundles, err := p.queries.TranslationBundlesByComponent(ctx, domain.TranslationComponentNotification)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles.TranslationBundles {
+		if err := translator.AddBundle(bundle.Language, bundle.Messages); err != nil {
+			return nil, err
+		}
+	}
+	allCustomTexts, err := p.queries.CustomTextListByTemplate(ctx, domain.IAMID, textType)
+	if err != nil {
+		return translator, nil
+	}
+	customTexts, err := p.queries.CustomTextListByTemplate(ctx, orgID, textType)
+	if err != nil {
+		return translator, nil
+	}
+	allCustomTexts.CustomTexts = append(allCustomTexts.CustomTexts, customTexts.CustomTexts...)
+
+	for _, text := range allCustomTexts.CustomTexts {
+		msg := i18n.Message{
+			ID:   text.Template + "." + text.Key,
+			Text: text.Text,
+		}
+		translator.AddMessages(text.Language, msg)
+	}
+	return translator, nil
+}
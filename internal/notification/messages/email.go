@@ -1,16 +1,27 @@
 package messages
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/k3a/html2text"
+
 	"github.com/caos/zitadel/internal/notification/channels"
 )
 
 var (
 	isHTMLRgx = regexp.MustCompile(`.*<html.*>.*`)
 	lineBreak = "\r\n"
+	//base64LineLength is the maximum line length of base64 encoded attachments (RFC 2045)
+	base64LineLength = 76
 )
 
 var _ channels.Message = (*Email)(nil)
@@ -22,30 +33,197 @@ type Email struct {
 	SenderEmail string
 	Subject     string
 	Content     string
+	//TextContent is sent as plain text alternative of html content
+	// it's generated from the content if empty
+	TextContent string
+	//Headers are added to the header of the email (e.g. Reply-To, List-Unsubscribe)
+	Headers     map[string]string
+	Attachments []*Attachment
+}
+
+//Attachment is added to the email
+// inline attachments are referenced in the html content by cid:<ContentID>
+type Attachment struct {
+	FileName    string
+	ContentType string
+	ContentID   string
+	Content     []byte
 }
 
+func (a *Attachment) isInline() bool {
+	return a.ContentID != ""
+}
+
+//GetContent returns the email as MIME message
+// html content is sent as multipart/alternative with a plain text part,
+// inline attachments are related to the html part and other attachments are mixed with the content
 func (msg *Email) GetContent() string {
-	headers := make(map[string]string)
+	buf := new(bytes.Buffer)
+	headers := make(map[string]string, len(msg.Headers)+4)
+	for key, value := range msg.Headers {
+		headers[textproto.CanonicalMIMEHeaderKey(key)] = value
+	}
 	headers["From"] = msg.SenderEmail
 	headers["To"] = strings.Join(msg.Recipients, ", ")
-	headers["Cc"] = strings.Join(msg.CC, ", ")
+	if len(msg.CC) > 0 {
+		headers["Cc"] = strings.Join(msg.CC, ", ")
+	}
+	headers["Subject"] = mime.QEncoding.Encode("UTF-8", msg.Subject)
+	headers["MIME-Version"] = "1.0"
+	writeHeaders(buf, headers)
 
-	message := ""
-	for k, v := range headers {
-		message += fmt.Sprintf("%s: %s"+lineBreak, k, v)
+	inline, attached := msg.splitAttachments()
+	if len(attached) == 0 {
+		msg.writeBody(buf, nil, inline)
+		return buf.String()
+	}
+	mixed := multipart.NewWriter(buf)
+	writeHeaders(buf, map[string]string{"Content-Type": multipartType("mixed", mixed)})
+	buf.WriteString(lineBreak)
+	msg.writeBody(buf, mixed, inline)
+	for _, attachment := range attached {
+		writeAttachment(mixed, attachment)
 	}
+	mixed.Close()
+	return buf.String()
+}
 
-	//default mime-type is html
-	mime := "MIME-version: 1.0;" + lineBreak + "Content-Type: text/html; charset=\"UTF-8\";" + lineBreak + lineBreak
-	if !isHTML(msg.Content) {
-		mime = "MIME-version: 1.0;" + lineBreak + "Content-Type: text/plain; charset=\"UTF-8\";" + lineBreak + lineBreak
+//GetTextContent returns the plain text of the email
+func (msg *Email) GetTextContent() string {
+	if !msg.isHTML() {
+		return msg.Content
 	}
-	subject := "Subject: " + msg.Subject + lineBreak
-	message += subject + mime + lineBreak + msg.Content
+	if msg.TextContent != "" {
+		return msg.TextContent
+	}
+	return html2text.HTML2Text(msg.Content)
+}
 
-	return message
+//writeBody writes the content of the email as part of the parent
+// if parent is nil the body is written directly to buf including its content type header
+func (msg *Email) writeBody(buf *bytes.Buffer, parent *multipart.Writer, inline []*Attachment) {
+	if !msg.isHTML() {
+		writePart(buf, parent, "text/plain; charset=\"UTF-8\"", msg.Content)
+		return
+	}
+	alternative := newPart(buf, parent, "alternative")
+	writeTextPart(alternative, "text/plain; charset=\"UTF-8\"", msg.GetTextContent())
+	if len(inline) == 0 {
+		writeTextPart(alternative, "text/html; charset=\"UTF-8\"", msg.Content)
+		alternative.Close()
+		return
+	}
+	related := newPart(buf, alternative, "related")
+	writeTextPart(related, "text/html; charset=\"UTF-8\"", msg.Content)
+	for _, attachment := range inline {
+		writeAttachment(related, attachment)
+	}
+	related.Close()
+	alternative.Close()
+}
+
+func (msg *Email) splitAttachments() (inline, attached []*Attachment) {
+	for _, attachment := range msg.Attachments {
+		if attachment.isInline() && msg.isHTML() {
+			inline = append(inline, attachment)
+			continue
+		}
+		attached = append(attached, attachment)
+	}
+	return inline, attached
+}
+
+func (msg *Email) isHTML() bool {
+	return isHTML(msg.Content)
 }
 
 func isHTML(input string) bool {
 	return isHTMLRgx.MatchString(input)
 }
+
+//newPart creates a multipart writer for a part of the parent
+// if parent is nil the content type header is written directly to buf
+func newPart(buf *bytes.Buffer, parent *multipart.Writer, subtype string) *multipart.Writer {
+	if parent == nil {
+		w := multipart.NewWriter(buf)
+		writeHeaders(buf, map[string]string{"Content-Type": multipartType(subtype, w)})
+		buf.WriteString(lineBreak)
+		return w
+	}
+	//the boundary is part of the header of the part, so it's generated before the writer of the part exists
+	boundary := multipart.NewWriter(nil).Boundary()
+	part, _ := parent.CreatePart(textproto.MIMEHeader{"Content-Type": {"multipart/" + subtype + "; boundary=" + boundary}})
+	w := multipart.NewWriter(part)
+	w.SetBoundary(boundary)
+	return w
+}
+
+func writePart(buf *bytes.Buffer, parent *multipart.Writer, contentType, content string) {
+	if parent != nil {
+		writeTextPart(parent, contentType, content)
+		return
+	}
+	writeHeaders(buf, map[string]string{
+		"Content-Type":              contentType,
+		"Content-Transfer-Encoding": "quoted-printable",
+	})
+	buf.WriteString(lineBreak)
+	writeQuotedPrintable(buf, content)
+}
+
+func writeTextPart(parent *multipart.Writer, contentType, content string) {
+	part, _ := parent.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	buf := new(bytes.Buffer)
+	writeQuotedPrintable(buf, content)
+	part.Write(buf.Bytes())
+}
+
+func writeAttachment(parent *multipart.Writer, attachment *Attachment) {
+	header := textproto.MIMEHeader{
+		"Content-Type":              {attachment.ContentType},
+		"Content-Transfer-Encoding": {"base64"},
+	}
+	disposition := "attachment"
+	if attachment.isInline() {
+		disposition = "inline"
+		header.Set("Content-Id", "<"+attachment.ContentID+">")
+	}
+	if attachment.FileName != "" {
+		disposition = mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName})
+	}
+	header.Set("Content-Disposition", disposition)
+	part, _ := parent.CreatePart(header)
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+	for len(encoded) > base64LineLength {
+		part.Write([]byte(encoded[:base64LineLength] + lineBreak))
+		encoded = encoded[base64LineLength:]
+	}
+	part.Write([]byte(encoded + lineBreak))
+}
+
+func writeQuotedPrintable(buf *bytes.Buffer, content string) {
+	w := quotedprintable.NewWriter(buf)
+	w.Write([]byte(content))
+	w.Close()
+}
+
+func multipartType(subtype string, w *multipart.Writer) string {
+	return "multipart/" + subtype + "; boundary=" + w.Boundary()
+}
+
+//writeHeaders writes the headers sorted by key
+// line breaks in values are removed to prevent header injection
+func writeHeaders(buf *bytes.Buffer, headers map[string]string) {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := strings.NewReplacer("\r", "", "\n", "").Replace(headers[key])
+		buf.WriteString(fmt.Sprintf("%s: %s"+lineBreak, key, value))
+	}
+}
@@ -0,0 +1,182 @@
+package messages
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+type mimePart struct {
+	contentType string
+	contentID   string
+	content     string
+	parts       []*mimePart
+}
+
+func parseMIME(t *testing.T, contentType string, contentTransferEncoding string, body io.Reader) *mimePart {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("invalid content type %q: %v", contentType, err)
+	}
+	part := &mimePart{contentType: mediaType}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		if contentTransferEncoding == "quoted-printable" {
+			body = quotedprintable.NewReader(body)
+		}
+		content, err := ioutil.ReadAll(body)
+		if err != nil {
+			t.Fatalf("unable to read part: %v", err)
+		}
+		part.content = string(content)
+		return part
+	}
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		p, err := reader.NextRawPart()
+		if err == io.EOF {
+			return part
+		}
+		if err != nil {
+			t.Fatalf("unable to read multipart: %v", err)
+		}
+		child := parseMIME(t, p.Header.Get("Content-Type"), p.Header.Get("Content-Transfer-Encoding"), p)
+		child.contentID = p.Header.Get("Content-Id")
+		part.parts = append(part.parts, child)
+	}
+}
+
+func TestEmail_GetContent(t *testing.T) {
+	html := "<html><body><p>Hello Gigi</p><img src=\"cid:logo\"></body></html>"
+	type want struct {
+		headers map[string]string
+		part    func(t *testing.T, part *mimePart)
+	}
+	tests := []struct {
+		name string
+		msg  *Email
+		want want
+	}{
+		{
+			name: "plain text",
+			msg: &Email{
+				Recipients:  []string{"gigi@zitadel.ch"},
+				SenderEmail: "noreply@zitadel.ch",
+				Subject:     "Hello",
+				Content:     "Hello Gigi",
+			},
+			want: want{
+				headers: map[string]string{
+					"From":         "noreply@zitadel.ch",
+					"To":           "gigi@zitadel.ch",
+					"Subject":      "Hello",
+					"Mime-Version": "1.0",
+				},
+				part: func(t *testing.T, part *mimePart) {
+					if part.contentType != "text/plain" || part.content != "Hello Gigi" {
+						t.Errorf("unexpected part %+v", part)
+					}
+				},
+			},
+		},
+		{
+			name: "html with text alternative and custom headers",
+			msg: &Email{
+				Recipients:  []string{"gigi@zitadel.ch"},
+				CC:          []string{"support@zitadel.ch"},
+				SenderEmail: "noreply@zitadel.ch",
+				Subject:     "Grüezi",
+				Content:     html,
+				Headers: map[string]string{
+					"reply-to":         "support@zitadel.ch",
+					"List-Unsubscribe": "<https://zitadel.ch/unsubscribe>\r\nBcc: evil@zitadel.ch",
+				},
+			},
+			want: want{
+				headers: map[string]string{
+					"Cc":               "support@zitadel.ch",
+					"Subject":          "Grüezi",
+					"Reply-To":         "support@zitadel.ch",
+					"List-Unsubscribe": "<https://zitadel.ch/unsubscribe>Bcc: evil@zitadel.ch",
+					"Bcc":              "",
+				},
+				part: func(t *testing.T, part *mimePart) {
+					if part.contentType != "multipart/alternative" || len(part.parts) != 2 {
+						t.Fatalf("expected alternative with 2 parts got %+v", part)
+					}
+					if part.parts[0].contentType != "text/plain" || strings.TrimSpace(part.parts[0].content) != "Hello Gigi" {
+						t.Errorf("unexpected text part %+v", part.parts[0])
+					}
+					if part.parts[1].contentType != "text/html" || part.parts[1].content != html {
+						t.Errorf("unexpected html part %+v", part.parts[1])
+					}
+				},
+			},
+		},
+		{
+			name: "html with inline logo and attachment",
+			msg: &Email{
+				Recipients:  []string{"gigi@zitadel.ch"},
+				SenderEmail: "noreply@zitadel.ch",
+				Subject:     "Hello",
+				Content:     html,
+				TextContent: "Hello Gigi as text",
+				Attachments: []*Attachment{
+					{FileName: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte("png")},
+					{FileName: "terms.txt", ContentType: "text/plain", Content: []byte(strings.Repeat("terms ", 50))},
+				},
+			},
+			want: want{
+				part: func(t *testing.T, part *mimePart) {
+					if part.contentType != "multipart/mixed" || len(part.parts) != 2 {
+						t.Fatalf("expected mixed with 2 parts got %+v", part)
+					}
+					alternative := part.parts[0]
+					if alternative.contentType != "multipart/alternative" || len(alternative.parts) != 2 {
+						t.Fatalf("expected alternative with 2 parts got %+v", alternative)
+					}
+					if alternative.parts[0].content != "Hello Gigi as text" {
+						t.Errorf("unexpected text part %+v", alternative.parts[0])
+					}
+					related := alternative.parts[1]
+					if related.contentType != "multipart/related" || len(related.parts) != 2 {
+						t.Fatalf("expected related with 2 parts got %+v", related)
+					}
+					if related.parts[0].content != html {
+						t.Errorf("unexpected html part %+v", related.parts[0])
+					}
+					if related.parts[1].contentID != "<logo>" || related.parts[1].contentType != "image/png" {
+						t.Errorf("unexpected inline part %+v", related.parts[1])
+					}
+					if part.parts[1].contentType != "text/plain" || part.parts[1].contentID != "" {
+						t.Errorf("unexpected attachment %+v", part.parts[1])
+					}
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := mail.ReadMessage(strings.NewReader(tt.msg.GetContent()))
+			if err != nil {
+				t.Fatalf("unable to parse message: %v", err)
+			}
+			decoder := new(mime.WordDecoder)
+			for key, value := range tt.want.headers {
+				got, err := decoder.DecodeHeader(msg.Header.Get(key))
+				if err != nil {
+					t.Fatalf("unable to decode header %s: %v", key, err)
+				}
+				if got != value {
+					t.Errorf("header %s: expected %q got %q", key, value, got)
+				}
+			}
+			tt.want.part(t, parseMIME(t, msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body))
+		})
+	}
+}
@@ -6,26 +6,37 @@ import (
 	"github.com/caos/logging"
 	"github.com/caos/zitadel/internal/command"
 	sd "github.com/caos/zitadel/internal/config/systemdefaults"
-	"github.com/caos/zitadel/internal/notification/repository/eventsourcing"
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/notification/handlers"
+	"github.com/caos/zitadel/internal/notification/outbox"
 	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/query/projection"
+	"github.com/caos/zitadel/internal/static"
 	"github.com/rakyll/statik/fs"
 
 	_ "github.com/caos/zitadel/internal/notification/statik"
 )
 
 type Config struct {
-	APIDomain  string
-	Repository eventsourcing.Config
+	APIDomain string
+	Outbox    outbox.Config
 }
 
-func Start(ctx context.Context, config Config, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, hasStatics bool) {
+func Start(ctx context.Context, config Config, handlerConfig projection.CustomConfig, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, staticStorage static.Storage) {
 	statikFS, err := fs.NewWithNamespace("notification")
 	logging.Log("CONFI-7usEW").OnError(err).Panic("unable to start listener")
 
 	apiDomain := config.APIDomain
-	if !hasStatics {
+	if staticStorage == nil {
 		apiDomain = ""
 	}
-	_, err = eventsourcing.Start(config.Repository, statikFS, systemDefaults, command, queries, apiDomain)
-	logging.Log("MAIN-9uBxp").OnError(err).Panic("unable to start app")
+	aesCrypto, err := crypto.NewAESCrypto(systemDefaults.UserVerificationKey)
+	logging.Log("MAIN-Sdk2o").OnError(err).Panic("unable to create notification crypto")
+	notifierConfig := projection.ApplyCustomConfig(handlerConfig)
+	outboxLocker := crdb.NewLocker(notifierConfig.Client, notifierConfig.LockTable, outbox.LockName)
+	notificationOutbox := outbox.New(config.Outbox, outboxLocker, command, queries, aesCrypto, systemDefaults.Notifications)
+	notificationOutbox.Start(ctx)
+
+	handlers.NewUserNotifier(ctx, notifierConfig, command, queries, notificationOutbox, systemDefaults, aesCrypto, statikFS, staticStorage, apiDomain)
 }
@@ -0,0 +1,244 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/command"
+	"github.com/caos/zitadel/internal/config/systemdefaults"
+	"github.com/caos/zitadel/internal/config/types"
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/notification/channels"
+	"github.com/caos/zitadel/internal/notification/messages"
+	"github.com/caos/zitadel/internal/notification/senders"
+	"github.com/caos/zitadel/internal/query"
+)
+
+const (
+	outboxUserID = "NOTIFICATION"
+	//LockName is the name of the lock which ensures only one instance delivers the notifications
+	LockName = "notification_outbox"
+)
+
+type Config struct {
+	Interval       types.Duration
+	BulkLimit      uint64
+	MaxAttempts    uint64
+	InitialBackoff types.Duration
+	MaxBackoff     types.Duration
+}
+
+//Outbox persists the messages of the notification handler
+// and delivers them to the channels, failed deliveries are retried with backoff
+// only the instance holding the lock delivers, so messages aren't sent once per instance
+type Outbox struct {
+	config        Config
+	locker        crdb.Locker
+	command       *command.Commands
+	queries       *query.Queries
+	alg           crypto.EncryptionAlgorithm
+	notifications systemdefaults.Notifications
+
+	//handled contains the sequence of each notification at the time it was handled
+	// to prevent a second delivery until the projection contains the result
+	handled      map[string]uint64
+	handledMutex sync.Mutex
+}
+
+func New(config Config, locker crdb.Locker, command *command.Commands, queries *query.Queries, alg crypto.EncryptionAlgorithm, notifications systemdefaults.Notifications) *Outbox {
+	return &Outbox{
+		config:        config,
+		locker:        locker,
+		command:       command,
+		queries:       queries,
+		alg:           alg,
+		notifications: notifications,
+		handled:       make(map[string]uint64),
+	}
+}
+
+//Queue stores the encrypted message in the outbox, it will be delivered by the worker
+// a message with an idempotency key which was already queued is ignored
+func (o *Outbox) Queue(ctx context.Context, resourceOwner, userID, messageType, idempotencyKey string, message channels.Message, channel domain.NotificationType, recipient string) error {
+	encrypted, err := encodeMessage(message, o.alg)
+	if err != nil {
+		return err
+	}
+	_, _, err = o.command.AddNotification(ctx, resourceOwner, &domain.Notification{
+		UserID:         userID,
+		MessageType:    messageType,
+		Channel:        channel,
+		Recipient:      recipient,
+		Message:        encrypted,
+		IdempotencyKey: idempotencyKey,
+	})
+	if errors.IsErrorAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+//Start delivers the due notifications of the outbox in the configured interval
+func (o *Outbox) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(o.config.Interval.Duration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				o.deliverDueLocked(ctx)
+			}
+		}
+	}()
+}
+
+//deliverDueLocked delivers the due notifications if this instance holds the lock
+// the lock isn't released after the delivery, it's valid for two intervals
+// so the instance keeps it as long as it's running and other instances don't take over
+// while the projection doesn't contain the results of the deliveries yet
+func (o *Outbox) deliverDueLocked(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := o.locker.Lock(ctx, 2*o.config.Interval.Duration)
+	if err, ok := <-errs; err != nil || !ok {
+		logging.Log("OUTBO-Lk2sa").OnError(err).Debug("outbox is locked by another instance")
+		go drainLockErrs(errs, cancel)
+		return
+	}
+	go drainLockErrs(errs, cancel)
+	o.deliverDue(ctx)
+}
+
+//drainLockErrs cancels the delivery as soon as the lock couldn't be renewed
+// it reads until the locker closes the channel so the locker never blocks
+func drainLockErrs(errs <-chan error, cancel context.CancelFunc) {
+	for err := range errs {
+		if err != nil {
+			cancel()
+		}
+	}
+}
+
+func (o *Outbox) deliverDue(ctx context.Context) {
+	due, err := o.queries.DueNotifications(ctx, time.Now(), o.config.BulkLimit)
+	if err != nil {
+		logging.Log("OUTBO-Ek2pf").WithError(err).Warn("unable to query due notifications")
+		return
+	}
+	o.handledMutex.Lock()
+	defer o.handledMutex.Unlock()
+
+	stillDue := make(map[string]bool, len(due.Notifications))
+	for _, notification := range due.Notifications {
+		stillDue[notification.ID] = true
+		if ctx.Err() != nil {
+			continue
+		}
+		if sequence, ok := o.handled[notification.ID]; ok && notification.Sequence <= sequence {
+			continue
+		}
+		o.handled[notification.ID] = notification.Sequence
+		o.deliver(notification)
+	}
+	for id := range o.handled {
+		if !stillDue[id] {
+			delete(o.handled, id)
+		}
+	}
+}
+
+func (o *Outbox) deliver(notification *query.Notification) {
+	ctx := authz.SetCtxData(context.Background(), authz.CtxData{UserID: outboxUserID, OrgID: notification.ResourceOwner})
+	message, err := decodeMessage(notification.Message, notification.Channel, o.alg)
+	if err != nil {
+		o.failed(ctx, notification, err)
+		return
+	}
+	err = o.send(message, notification.Channel)
+	if err == nil {
+		_, err = o.command.NotificationSent(ctx, notification.ResourceOwner, notification.ID)
+		logging.LogWithFields("OUTBO-Kd82n", "notification", notification.ID).OnError(err).Warn("unable to set notification sent")
+		return
+	}
+	if notification.Attempts+1 >= o.config.MaxAttempts {
+		o.failed(ctx, notification, err)
+		return
+	}
+	nextAttempt := time.Now().Add(backoff(notification.Attempts, o.config.InitialBackoff.Duration, o.config.MaxBackoff.Duration))
+	_, err = o.command.NotificationRetryScheduled(ctx, notification.ResourceOwner, notification.ID, err.Error(), nextAttempt)
+	logging.LogWithFields("OUTBO-s9Mfe", "notification", notification.ID).OnError(err).Warn("unable to schedule notification retry")
+}
+
+func (o *Outbox) failed(ctx context.Context, notification *query.Notification, deliveryErr error) {
+	_, err := o.command.NotificationFailed(ctx, notification.ResourceOwner, notification.ID, deliveryErr.Error())
+	logging.LogWithFields("OUTBO-Pq0sw", "notification", notification.ID).OnError(err).Warn("unable to set notification failed")
+}
+
+func (o *Outbox) send(message channels.Message, channel domain.NotificationType) error {
+	var notificationChannels channels.NotificationChannel
+	var err error
+	switch channel {
+	case domain.NotificationTypeEmail:
+		notificationChannels, err = senders.EmailChannels(o.notifications)
+	case domain.NotificationTypeSms:
+		notificationChannels, err = senders.SMSChannels(o.notifications)
+	default:
+		return errors.ThrowInvalidArgument(nil, "OUTBO-Wm2od", "Errors.Notification.Invalid")
+	}
+	if err != nil {
+		return err
+	}
+	return notificationChannels.HandleMessage(message)
+}
+
+//backoff returns the delay until the next delivery attempt,
+// it doubles with every attempt and is capped at maxBackoff
+func backoff(attempts uint64, initialBackoff, maxBackoff time.Duration) time.Duration {
+	delay := initialBackoff
+	for i := uint64(0); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+func encodeMessage(message channels.Message, alg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OUTBO-Lp3nd", "unable to marshal message")
+	}
+	return crypto.Encrypt(data, alg)
+}
+
+func decodeMessage(encrypted *crypto.CryptoValue, channel domain.NotificationType, alg crypto.EncryptionAlgorithm) (channels.Message, error) {
+	data, err := crypto.Decrypt(encrypted, alg)
+	if err != nil {
+		return nil, err
+	}
+	var message channels.Message
+	switch channel {
+	case domain.NotificationTypeEmail:
+		message = new(messages.Email)
+	case domain.NotificationTypeSms:
+		message = new(messages.SMS)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "OUTBO-Sk2mf", "Errors.Notification.Invalid")
+	}
+	if err = json.Unmarshal(data, message); err != nil {
+		return nil, errors.ThrowInternal(err, "OUTBO-Xo9wq", "unable to unmarshal message")
+	}
+	return message, nil
+}
@@ -0,0 +1,186 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/config/types"
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/notification/channels"
+	"github.com/caos/zitadel/internal/notification/messages"
+)
+
+func Test_backoff(t *testing.T) {
+	type args struct {
+		attempts       uint64
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Duration
+	}{
+		{
+			name: "first attempt",
+			args: args{
+				attempts:       0,
+				initialBackoff: time.Second,
+				maxBackoff:     time.Minute,
+			},
+			want: time.Second,
+		},
+		{
+			name: "third attempt",
+			args: args{
+				attempts:       2,
+				initialBackoff: time.Second,
+				maxBackoff:     time.Minute,
+			},
+			want: 4 * time.Second,
+		},
+		{
+			name: "capped",
+			args: args{
+				attempts:       10,
+				initialBackoff: time.Second,
+				maxBackoff:     time.Minute,
+			},
+			want: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, backoff(tt.args.attempts, tt.args.initialBackoff, tt.args.maxBackoff))
+		})
+	}
+}
+
+func Test_encodeDecodeMessage(t *testing.T) {
+	type args struct {
+		message channels.Message
+		channel domain.NotificationType
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "email",
+			args: args{
+				message: &messages.Email{
+					Recipients:  []string{"user@caos.ch"},
+					SenderEmail: "noreply@caos.ch",
+					Subject:     "subject",
+					Content:     "<html><body>content</body></html>",
+					Headers:     map[string]string{"Reply-To": "support@caos.ch"},
+					Attachments: []*messages.Attachment{
+						{
+							FileName:    "logo.png",
+							ContentType: "image/png",
+							ContentID:   "logo",
+							Content:     []byte("logo"),
+						},
+					},
+				},
+				channel: domain.NotificationTypeEmail,
+			},
+		},
+		{
+			name: "sms",
+			args: args{
+				message: &messages.SMS{
+					SenderPhoneNumber:    "+41000000000",
+					RecipientPhoneNumber: "+41000000001",
+					Content:              "content",
+				},
+				channel: domain.NotificationTypeSms,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+			encrypted, err := encodeMessage(tt.args.message, alg)
+			assert.NoError(t, err)
+			decoded, err := decodeMessage(encrypted, tt.args.channel, alg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.args.message, decoded)
+		})
+	}
+}
+
+type testLocker struct {
+	errs []error
+}
+
+func (l *testLocker) Lock(ctx context.Context, _ time.Duration) <-chan error {
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+		for _, err := range l.errs {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return errs
+}
+
+func (l *testLocker) Unlock() error {
+	return nil
+}
+
+func TestOutbox_deliverDueLocked(t *testing.T) {
+	tests := []struct {
+		name   string
+		locker *testLocker
+	}{
+		{
+			name:   "locked by other instance, no delivery",
+			locker: &testLocker{errs: []error{errors.ThrowAlreadyExists(nil, "CRDB-mmi4J", "projection already locked")}},
+		},
+		{
+			name:   "lock failed, no delivery",
+			locker: &testLocker{errs: []error{errors.ThrowInternal(nil, "CRDB-uaDoR", "unable to execute lock")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//queries is nil, a delivery would panic
+			o := &Outbox{
+				config:  Config{Interval: types.Duration{Duration: time.Second}},
+				locker:  tt.locker,
+				handled: make(map[string]uint64),
+			}
+			o.deliverDueLocked(context.Background())
+		})
+	}
+}
+
+func Test_drainLockErrs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	done := make(chan struct{})
+	go func() {
+		drainLockErrs(errs, cancel)
+		close(done)
+	}()
+
+	errs <- nil
+	assert.NoError(t, ctx.Err(), "renewed lock must not cancel the delivery")
+	errs <- errors.ThrowAlreadyExists(nil, "CRDB-mmi4J", "projection already locked")
+	<-ctx.Done()
+	errs <- nil
+	close(errs)
+	<-done
+}
@@ -1,5 +0,0 @@
-package repository
-
-type Repository interface {
-	Health() error
-}
@@ -6,9 +6,9 @@ import (
 	"github.com/caos/zitadel/internal/config/systemdefaults"
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/messages"
 	"github.com/caos/zitadel/internal/notification/templates"
 	"github.com/caos/zitadel/internal/query"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
 )
 
 type DomainClaimedData struct {
@@ -16,7 +16,7 @@ type DomainClaimedData struct {
 	URL string
 }
 
-func SendDomainClaimed(mailhtml string, translator *i18n.Translator, user *view_model.NotifyUser, username string, systemDefaults systemdefaults.SystemDefaults, colors *query.LabelPolicy, apiDomain string) error {
+func SendDomainClaimed(mailhtml string, translator *i18n.Translator, user *query.NotifyUser, username string, systemDefaults systemdefaults.SystemDefaults, colors *query.LabelPolicy, logo *messages.Attachment, apiDomain string, notify Notify) error {
 	url, err := templates.ParseTemplateText(systemDefaults.Notifications.Endpoints.DomainClaimed, &UrlData{UserID: user.ID})
 	if err != nil {
 		return err
@@ -26,12 +26,12 @@ func SendDomainClaimed(mailhtml string, translator *i18n.Translator, user *view_
 	args["Domain"] = strings.Split(user.LastEmail, "@")[1]
 
 	domainClaimedData := &DomainClaimedData{
-		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.DomainClaimedMessageType, user.PreferredLanguage, colors),
+		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.DomainClaimedMessageType, user.PreferredLanguage, colors, logo),
 		URL:          url,
 	}
 	template, err := templates.GetParsedTemplate(mailhtml, domainClaimedData)
 	if err != nil {
 		return err
 	}
-	return generateEmail(user, domainClaimedData.Subject, template, systemDefaults.Notifications, true)
+	return generateEmail(user, domainClaimedData.Subject, template, systemDefaults.Notifications, true, logo, notify)
 }
@@ -5,10 +5,10 @@ import (
 	"github.com/caos/zitadel/internal/crypto"
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/messages"
 	"github.com/caos/zitadel/internal/notification/templates"
 	"github.com/caos/zitadel/internal/query"
-	es_model "github.com/caos/zitadel/internal/user/repository/eventsourcing/model"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
+	"github.com/caos/zitadel/internal/repository/user"
 )
 
 type EmailVerificationCodeData struct {
@@ -16,7 +16,7 @@ type EmailVerificationCodeData struct {
 	URL string
 }
 
-func SendEmailVerificationCode(mailhtml string, translator *i18n.Translator, user *view_model.NotifyUser, code *es_model.EmailCode, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, apiDomain string) error {
+func SendEmailVerificationCode(mailhtml string, translator *i18n.Translator, user *query.NotifyUser, code *user.HumanEmailCodeAddedEvent, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, logo *messages.Attachment, apiDomain string, notify Notify) error {
 	codeString, err := crypto.DecryptString(code.Code, alg)
 	if err != nil {
 		return err
@@ -30,7 +30,7 @@ func SendEmailVerificationCode(mailhtml string, translator *i18n.Translator, use
 	args["Code"] = codeString
 
 	emailCodeData := &EmailVerificationCodeData{
-		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.VerifyEmailMessageType, user.PreferredLanguage, colors),
+		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.VerifyEmailMessageType, user.PreferredLanguage, colors, logo),
 		URL:          url,
 	}
 
@@ -38,5 +38,5 @@ func SendEmailVerificationCode(mailhtml string, translator *i18n.Translator, use
 	if err != nil {
 		return err
 	}
-	return generateEmail(user, emailCodeData.Subject, template, systemDefaults.Notifications, true)
+	return generateEmail(user, emailCodeData.Subject, template, systemDefaults.Notifications, true, logo, notify)
 }
@@ -5,10 +5,10 @@ import (
 	"github.com/caos/zitadel/internal/crypto"
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/messages"
 	"github.com/caos/zitadel/internal/notification/templates"
 	"github.com/caos/zitadel/internal/query"
-	es_model "github.com/caos/zitadel/internal/user/repository/eventsourcing/model"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
+	"github.com/caos/zitadel/internal/repository/user"
 )
 
 type InitCodeEmailData struct {
@@ -22,7 +22,7 @@ type UrlData struct {
 	PasswordSet bool
 }
 
-func SendUserInitCode(mailhtml string, translator *i18n.Translator, user *view_model.NotifyUser, code *es_model.InitUserCode, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, apiDomain string) error {
+func SendUserInitCode(mailhtml string, translator *i18n.Translator, user *query.NotifyUser, code *user.HumanInitialCodeAddedEvent, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, logo *messages.Attachment, apiDomain string, notify Notify) error {
 	codeString, err := crypto.DecryptString(code.Code, alg)
 	if err != nil {
 		return err
@@ -35,12 +35,12 @@ func SendUserInitCode(mailhtml string, translator *i18n.Translator, user *view_m
 	args["Code"] = codeString
 
 	initCodeData := &InitCodeEmailData{
-		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.InitCodeMessageType, user.PreferredLanguage, colors),
+		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.InitCodeMessageType, user.PreferredLanguage, colors, logo),
 		URL:          url,
 	}
 	template, err := templates.GetParsedTemplate(mailhtml, initCodeData)
 	if err != nil {
 		return err
 	}
-	return generateEmail(user, initCodeData.Subject, template, systemDefaults.Notifications, true)
+	return generateEmail(user, initCodeData.Subject, template, systemDefaults.Notifications, true, logo, notify)
 }
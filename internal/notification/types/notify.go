@@ -0,0 +1,9 @@
+package types
+
+import (
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/notification/channels"
+)
+
+//Notify hands the generated message over for delivery to the recipient on the channel
+type Notify func(message channels.Message, channel domain.NotificationType, recipient string) error
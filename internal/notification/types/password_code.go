@@ -5,10 +5,10 @@ import (
 	"github.com/caos/zitadel/internal/crypto"
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/messages"
 	"github.com/caos/zitadel/internal/notification/templates"
 	"github.com/caos/zitadel/internal/query"
-	es_model "github.com/caos/zitadel/internal/user/repository/eventsourcing/model"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
+	"github.com/caos/zitadel/internal/repository/user"
 )
 
 type PasswordCodeData struct {
@@ -18,7 +18,7 @@ type PasswordCodeData struct {
 	URL       string
 }
 
-func SendPasswordCode(mailhtml string, translator *i18n.Translator, user *view_model.NotifyUser, code *es_model.PasswordCode, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, apiDomain string) error {
+func SendPasswordCode(mailhtml string, translator *i18n.Translator, user *query.NotifyUser, code *user.HumanPasswordCodeAddedEvent, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, logo *messages.Attachment, apiDomain string, notify Notify) error {
 	codeString, err := crypto.DecryptString(code.Code, alg)
 	if err != nil {
 		return err
@@ -31,7 +31,7 @@ func SendPasswordCode(mailhtml string, translator *i18n.Translator, user *view_m
 	args["Code"] = codeString
 
 	passwordResetData := &PasswordCodeData{
-		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.PasswordResetMessageType, user.PreferredLanguage, colors),
+		TemplateData: GetTemplateData(translator, args, apiDomain, url, domain.PasswordResetMessageType, user.PreferredLanguage, colors, logo),
 		FirstName:    user.FirstName,
 		LastName:     user.LastName,
 		URL:          url,
@@ -40,9 +40,9 @@ func SendPasswordCode(mailhtml string, translator *i18n.Translator, user *view_m
 	if err != nil {
 		return err
 	}
-	if code.NotificationType == int32(domain.NotificationTypeSms) {
-		return generateSms(user, passwordResetData.Text, systemDefaults.Notifications, false)
+	if code.NotificationType == domain.NotificationTypeSms {
+		return generateSms(user, passwordResetData.Text, systemDefaults.Notifications, false, notify)
 	}
-	return generateEmail(user, passwordResetData.Subject, template, systemDefaults.Notifications, true)
+	return generateEmail(user, passwordResetData.Subject, template, systemDefaults.Notifications, true, logo, notify)
 
 }
@@ -8,15 +8,15 @@ import (
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/i18n"
 	"github.com/caos/zitadel/internal/notification/templates"
-	es_model "github.com/caos/zitadel/internal/user/repository/eventsourcing/model"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
+	"github.com/caos/zitadel/internal/query"
+	"github.com/caos/zitadel/internal/repository/user"
 )
 
 type PhoneVerificationCodeData struct {
 	UserID string
 }
 
-func SendPhoneVerificationCode(translator *i18n.Translator, user *view_model.NotifyUser, code *es_model.PhoneCode, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm) error {
+func SendPhoneVerificationCode(translator *i18n.Translator, user *query.NotifyUser, code *user.HumanPhoneCodeAddedEvent, systemDefaults systemdefaults.SystemDefaults, alg crypto.EncryptionAlgorithm, notify Notify) error {
 	codeString, err := crypto.DecryptString(code.Code, alg)
 	if err != nil {
 		return err
@@ -31,5 +31,5 @@ func SendPhoneVerificationCode(translator *i18n.Translator, user *view_model.Not
 	if err != nil {
 		return err
 	}
-	return generateSms(user, template, systemDefaults.Notifications, true)
+	return generateSms(user, template, systemDefaults.Notifications, true, notify)
 }
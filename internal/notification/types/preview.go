@@ -0,0 +1,86 @@
+package types
+
+import (
+	"html"
+
+	"github.com/caos/zitadel/internal/notification/templates"
+	"github.com/caos/zitadel/internal/query"
+)
+
+const previewURL = "https://example.com"
+
+//previewArgs are the sample user data the message texts are filled with
+var previewArgs = map[string]interface{}{
+	"UserName":           "john.doe",
+	"FirstName":          "John",
+	"LastName":           "Doe",
+	"NickName":           "Johnny",
+	"DisplayName":        "John Doe",
+	"LastEmail":          "john.doe@example.com",
+	"VerifiedEmail":      "john.doe@example.com",
+	"LastPhone":          "+41 79 123 45 67",
+	"VerifiedPhone":      "+41 79 123 45 67",
+	"PreferredLoginName": "john.doe@example.com",
+	"LoginNames":         []string{"john.doe@example.com"},
+	"ChangeDate":         "2021-11-11 11:11:11",
+	"Code":               "ABC123",
+	"TempUsername":       "john.doe@example.com",
+	"Domain":             "example.com",
+	"MFAType":            "OTP",
+	"UserAgent":          "Mozilla/5.0 (X11; Linux x86_64)",
+	"RemoteIP":           "192.0.2.1",
+	"OldEmail":           "john.doe@example.com",
+	"NewEmail":           "johnny.doe@example.com",
+}
+
+type PreviewEmailData struct {
+	templates.TemplateData
+	URL string
+}
+
+//RenderMailTemplatePreview renders the mail template with the message text filled with sample data
+// and the colors, logo and font of the label policy
+func RenderMailTemplatePreview(mailhtml string, text *query.MessageText, policy *query.LabelPolicy, apiDomain string) (string, error) {
+	data, err := previewTemplateData(text)
+	if err != nil {
+		return "", err
+	}
+	applyLabelPolicy(&data.TemplateData, apiDomain, policy, nil)
+	return templates.GetParsedTemplate(mailhtml, data)
+}
+
+func previewTemplateData(text *query.MessageText) (*PreviewEmailData, error) {
+	data := &PreviewEmailData{
+		TemplateData: templates.TemplateData{
+			Href:            previewURL,
+			PrimaryColor:    templates.DefaultPrimaryColor,
+			BackgroundColor: templates.DefaultBackgroundColor,
+			FontColor:       templates.DefaultFontColor,
+			LogoURL:         templates.DefaultLogo,
+			FontURL:         templates.DefaultFont,
+			FontFamily:      templates.DefaultFontFamily,
+			IncludeFooter:   false,
+		},
+		URL: previewURL,
+	}
+	fields := []struct {
+		text   string
+		target *string
+	}{
+		{text.Title, &data.Title},
+		{text.PreHeader, &data.PreHeader},
+		{text.Subject, &data.Subject},
+		{text.Greeting, &data.Greeting},
+		{text.Text, &data.Text},
+		{text.ButtonText, &data.ButtonText},
+		{text.Footer, &data.FooterText},
+	}
+	for _, field := range fields {
+		value, err := templates.ParseTemplateText(field.text, previewArgs)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = html.UnescapeString(value)
+	}
+	return data, nil
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/query"
+)
+
+func TestRenderMailTemplatePreview(t *testing.T) {
+	type args struct {
+		mailhtml  string
+		text      *query.MessageText
+		policy    *query.LabelPolicy
+		apiDomain string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "texts filled with sample data",
+			args: args{
+				mailhtml: `<p>{{.Greeting}}</p><p>{{.Text}}</p><a href="{{.URL}}">{{.ButtonText}}</a>`,
+				text: &query.MessageText{
+					Greeting:   "Hello {{.FirstName}} {{.LastName}},",
+					Text:       "your code is {{.Code}}",
+					ButtonText: "Verify",
+				},
+				policy: &query.LabelPolicy{},
+			},
+			want: `<p>Hello John Doe,</p><p>your code is ABC123</p><a href="https://example.com">Verify</a>`,
+		},
+		{
+			name: "colors and logo of label policy",
+			args: args{
+				mailhtml: `<div style="color:{{.PrimaryColor}}"><img src="{{.LogoURL}}"></div>`,
+				text:     &query.MessageText{},
+				policy: &query.LabelPolicy{
+					ID: "org1",
+					Light: query.Theme{
+						PrimaryColor: "#ff0000",
+						LogoURL:      "logo.png",
+					},
+				},
+				apiDomain: "https://api.example.com",
+			},
+			want: `<div style="color:#ff0000"><img src="https://api.example.com/assets/v1/org1/logo.png"></div>`,
+		},
+		{
+			name: "invalid text, error",
+			args: args{
+				mailhtml: `<p>{{.Text}}</p>`,
+				text: &query.MessageText{
+					Text: "{{.Code",
+				},
+				policy: &query.LabelPolicy{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMailTemplatePreview(tt.args.mailhtml, tt.args.text, tt.args.policy, tt.args.apiDomain)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RenderMailTemplatePreview() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RenderMailTemplatePreview() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
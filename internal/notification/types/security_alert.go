@@ -0,0 +1,41 @@
+package types
+
+import (
+	"github.com/caos/zitadel/internal/config/systemdefaults"
+	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/messages"
+	"github.com/caos/zitadel/internal/notification/templates"
+	"github.com/caos/zitadel/internal/query"
+)
+
+type SecurityAlertData struct {
+	templates.TemplateData
+	URL string
+}
+
+//SendSecurityAlert informs the user about a security relevant change of the account
+// the additional args (e.g. MFAType, UserAgent, OldEmail) can be used in the message texts
+// if no recipient is passed the alert is sent to the verified email of the user
+func SendSecurityAlert(mailhtml string, translator *i18n.Translator, user *query.NotifyUser, messageType string, alertArgs map[string]interface{}, systemDefaults systemdefaults.SystemDefaults, colors *query.LabelPolicy, logo *messages.Attachment, apiDomain, recipient string, notify Notify) error {
+	url, err := templates.ParseTemplateText(systemDefaults.Notifications.Endpoints.SecurityAlert, &UrlData{UserID: user.ID})
+	if err != nil {
+		return err
+	}
+	var args = mapNotifyUserToArgs(user)
+	for key, value := range alertArgs {
+		args[key] = value
+	}
+
+	securityAlertData := &SecurityAlertData{
+		TemplateData: GetTemplateData(translator, args, apiDomain, url, messageType, user.PreferredLanguage, colors, logo),
+		URL:          url,
+	}
+	template, err := templates.GetParsedTemplate(mailhtml, securityAlertData)
+	if err != nil {
+		return err
+	}
+	if recipient == "" {
+		recipient = user.VerifiedEmail
+	}
+	return generateEmailTo(recipient, securityAlertData.Subject, template, systemDefaults.Notifications, logo, notify)
+}
@@ -5,11 +5,17 @@ import (
 	"strings"
 
 	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/notification/messages"
 	"github.com/caos/zitadel/internal/notification/templates"
 	"github.com/caos/zitadel/internal/query"
 )
 
-func GetTemplateData(translator *i18n.Translator, translateArgs map[string]interface{}, apiDomain, href, msgType, lang string, policy *query.LabelPolicy) templates.TemplateData {
+//LogoContentID references the inline logo in the email template
+const LogoContentID = "logo"
+
+//GetTemplateData returns the data of the email template
+// if the logo is attached to the email it's referenced instead of linked
+func GetTemplateData(translator *i18n.Translator, translateArgs map[string]interface{}, apiDomain, href, msgType, lang string, policy *query.LabelPolicy, logo *messages.Attachment) templates.TemplateData {
 	templateData := templates.TemplateData{
 		Href:            href,
 		PrimaryColor:    templates.DefaultPrimaryColor,
@@ -21,6 +27,12 @@ func GetTemplateData(translator *i18n.Translator, translateArgs map[string]inter
 		IncludeFooter:   false,
 	}
 	templateData.Translate(translator, msgType, translateArgs, lang)
+	applyLabelPolicy(&templateData, apiDomain, policy, logo)
+	return templateData
+}
+
+//applyLabelPolicy sets the colors, logo and font of the label policy
+func applyLabelPolicy(templateData *templates.TemplateData, apiDomain string, policy *query.LabelPolicy, logo *messages.Attachment) {
 	if policy.Light.PrimaryColor != "" {
 		templateData.PrimaryColor = policy.Light.PrimaryColor
 	}
@@ -30,17 +42,21 @@ func GetTemplateData(translator *i18n.Translator, translateArgs map[string]inter
 	if policy.Light.FontColor != "" {
 		templateData.FontColor = policy.Light.FontColor
 	}
+	if logo != nil {
+		templateData.LogoURL = "cid:" + logo.ContentID
+	}
 	if apiDomain == "" {
-		return templateData
+		return
 	}
-	templateData.LogoURL = ""
-	if policy.Light.LogoURL != "" {
-		templateData.LogoURL = fmt.Sprintf("%s/assets/v1/%s/%s", apiDomain, policy.ID, policy.Light.LogoURL)
+	if logo == nil {
+		templateData.LogoURL = ""
+		if policy.Light.LogoURL != "" {
+			templateData.LogoURL = fmt.Sprintf("%s/assets/v1/%s/%s", apiDomain, policy.ID, policy.Light.LogoURL)
+		}
 	}
 	if policy.FontURL != "" {
 		split := strings.Split(policy.FontURL, "/")
 		templateData.FontFamily = split[len(split)-1] + "," + templates.DefaultFontFamily
 		templateData.FontURL = fmt.Sprintf("%s/assets/v1/%s/%s", apiDomain, policy.ID, policy.FontURL)
 	}
-	return templateData
 }
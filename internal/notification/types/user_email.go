@@ -3,34 +3,38 @@ package types
 import (
 	"html"
 
+	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/notification/messages"
-	"github.com/caos/zitadel/internal/notification/senders"
 
 	"github.com/caos/zitadel/internal/config/systemdefaults"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
+	"github.com/caos/zitadel/internal/query"
 )
 
-func generateEmail(user *view_model.NotifyUser, subject, content string, config systemdefaults.Notifications, lastEmail bool) error {
+func generateEmail(user *query.NotifyUser, subject, content string, config systemdefaults.Notifications, lastEmail bool, logo *messages.Attachment, notify Notify) error {
+	recipient := user.VerifiedEmail
+	if lastEmail {
+		recipient = user.LastEmail
+	}
+	return generateEmailTo(recipient, subject, content, config, logo, notify)
+}
+
+//generateEmailTo sends the email to the given address instead of the one of the user
+func generateEmailTo(recipient, subject, content string, config systemdefaults.Notifications, logo *messages.Attachment, notify Notify) error {
 	content = html.UnescapeString(content)
 	message := &messages.Email{
 		SenderEmail: config.Providers.Email.From,
-		Recipients:  []string{user.VerifiedEmail},
+		Recipients:  []string{recipient},
 		Subject:     subject,
 		Content:     content,
+		Headers:     config.Providers.Email.Headers,
 	}
-	if lastEmail {
-		message.Recipients = []string{user.LastEmail}
-	}
-
-	channels, err := senders.EmailChannels(config)
-	if err != nil {
-		return err
+	if logo != nil {
+		message.Attachments = []*messages.Attachment{logo}
 	}
-
-	return channels.HandleMessage(message)
+	return notify(message, domain.NotificationTypeEmail, recipient)
 }
 
-func mapNotifyUserToArgs(user *view_model.NotifyUser) map[string]interface{} {
+func mapNotifyUserToArgs(user *query.NotifyUser) map[string]interface{} {
 	return map[string]interface{}{
 		"UserName":           user.UserName,
 		"FirstName":          user.FirstName,
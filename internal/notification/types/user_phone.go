@@ -2,12 +2,12 @@ package types
 
 import (
 	"github.com/caos/zitadel/internal/config/systemdefaults"
+	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/notification/messages"
-	"github.com/caos/zitadel/internal/notification/senders"
-	view_model "github.com/caos/zitadel/internal/user/repository/view/model"
+	"github.com/caos/zitadel/internal/query"
 )
 
-func generateSms(user *view_model.NotifyUser, content string, config systemdefaults.Notifications, lastPhone bool) error {
+func generateSms(user *query.NotifyUser, content string, config systemdefaults.Notifications, lastPhone bool, notify Notify) error {
 	message := &messages.SMS{
 		SenderPhoneNumber:    config.Providers.Twilio.From,
 		RecipientPhoneNumber: user.VerifiedPhone,
@@ -16,10 +16,5 @@ func generateSms(user *view_model.NotifyUser, content string, config systemdefau
 	if lastPhone {
 		message.RecipientPhoneNumber = user.LastPhone
 	}
-
-	channels, err := senders.SMSChannels(config)
-	if err != nil {
-		return err
-	}
-	return channels.HandleMessage(message)
+	return notify(message, domain.NotificationTypeSms, message.RecipientPhoneNumber)
 }
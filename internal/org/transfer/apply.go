@@ -0,0 +1,494 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/command"
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore/v1/models"
+	"github.com/caos/zitadel/internal/query"
+)
+
+//Import creates and updates the objects of the document in the organisation
+// the permissions must allow to write every section which is changed (e.g. identity providers require org.idp.write)
+// if dryRun is set the changes are only computed and returned
+func Import(ctx context.Context, queries *query.Queries, commands *command.Commands, orgID, ownerUserID string, permissions []string, doc *Document, dryRun bool) ([]*Change, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "TRANS-Im2oR", "Errors.ResourceOwnerMissing")
+	}
+	current, err := export(ctx, queries, orgID, allPermissions{})
+	if err != nil {
+		return nil, err
+	}
+	plan, err := NewPlan(current, doc)
+	if err != nil {
+		return nil, err
+	}
+	if err = plan.checkPermissions(userPermissions(permissions)); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return plan.Changes, nil
+	}
+	return plan.Changes, plan.Apply(ctx, commands, orgID, ownerUserID)
+}
+
+//Apply executes the changes of the plan in their order
+// it stops at the first failing change, applied changes are not reverted
+// but importing the same document again continues with the missing changes
+func (p *Plan) Apply(ctx context.Context, commands *command.Commands, orgID, ownerUserID string) error {
+	a := &applier{
+		commands:    commands,
+		orgID:       orgID,
+		ownerUserID: ownerUserID,
+		ids:         p.ids,
+		clients:     make(map[string]*client),
+	}
+	for _, change := range p.Changes {
+		if change.apply == nil {
+			continue
+		}
+		targetID, err := change.apply(ctx, a)
+		if err != nil {
+			return err
+		}
+		if targetID == "" {
+			continue
+		}
+		change.TargetID = targetID
+		if change.SourceID != "" {
+			a.ids[objectID{change.ObjectType, change.SourceID}] = targetID
+		}
+		if client, ok := a.clients[targetID]; ok {
+			change.ClientID = client.id
+			change.ClientSecret = client.secret
+		}
+	}
+	return nil
+}
+
+//checkPermissions returns an error if the permissions don't allow to apply a change of the plan
+// changes of an existing project, its roles and apps are also allowed by the permission on the project
+func (p *Plan) checkPermissions(permissions userPermissions) error {
+	for _, change := range p.Changes {
+		if change.apply == nil {
+			continue
+		}
+		permission := writePermissions[change.ObjectType]
+		if !permissions.canWrite(permission, change.projectID) {
+			return caos_errs.ThrowPermissionDeniedf(nil, "TRANS-Ap2Pm", "Errors.Org.Transfer.PermissionDenied: %s %q requires %s", change.ObjectType, change.Key, permission)
+		}
+	}
+	return nil
+}
+
+//applier executes the changes using the ids of the target organisation
+type applier struct {
+	commands    *command.Commands
+	orgID       string
+	ownerUserID string
+	ids         map[objectID]string
+	//clients are the credentials of the created apps by their id
+	clients map[string]*client
+}
+
+type client struct {
+	id     string
+	secret string
+}
+
+//targetID returns the id in the target organisation of the object with the id of the document
+func (a *applier) targetID(objectType ObjectType, sourceID string) string {
+	return a.ids[objectID{objectType, sourceID}]
+}
+
+type idpChange struct {
+	id            string
+	idp           *IDP
+	configChanged bool
+	oidcChanged   bool
+	jwtChanged    bool
+}
+
+type loginPolicyChange struct {
+	policy              *LoginPolicy
+	exists              bool
+	settingsChanged     bool
+	addSecondFactors    []domain.SecondFactorType
+	removeSecondFactors []domain.SecondFactorType
+	addMultiFactors     []domain.MultiFactorType
+	removeMultiFactors  []domain.MultiFactorType
+	//addIDPIDs are the ids of the document
+	addIDPIDs []string
+}
+
+func (a *applier) addIDP(ctx context.Context, idp *IDP) (string, error) {
+	config := &domain.IDPConfig{
+		Name:         idp.Name,
+		StylingType:  idp.StylingType,
+		AutoRegister: idp.AutoRegister,
+	}
+	if idp.OIDC != nil {
+		config.Type = domain.IDPConfigTypeOIDC
+		config.OIDCConfig = oidcIDPToDomain("", idp.OIDC)
+	}
+	if idp.JWT != nil {
+		config.Type = domain.IDPConfigTypeJWT
+		config.JWTConfig = jwtIDPToDomain("", idp.JWT)
+	}
+	added, err := a.commands.AddIDPConfig(ctx, config, a.orgID)
+	if err != nil {
+		return "", err
+	}
+	return added.IDPConfigID, nil
+}
+
+func (a *applier) changeIDP(ctx context.Context, change *idpChange) (string, error) {
+	if change.configChanged {
+		_, err := a.commands.ChangeIDPConfig(ctx, &domain.IDPConfig{
+			IDPConfigID:  change.id,
+			Name:         change.idp.Name,
+			StylingType:  change.idp.StylingType,
+			AutoRegister: change.idp.AutoRegister,
+		}, a.orgID)
+		if err != nil {
+			return "", err
+		}
+	}
+	if change.oidcChanged {
+		if _, err := a.commands.ChangeIDPOIDCConfig(ctx, oidcIDPToDomain(change.id, change.idp.OIDC), a.orgID); err != nil {
+			return "", err
+		}
+	}
+	if change.jwtChanged {
+		if _, err := a.commands.ChangeIDPJWTConfig(ctx, jwtIDPToDomain(change.id, change.idp.JWT), a.orgID); err != nil {
+			return "", err
+		}
+	}
+	return change.id, nil
+}
+
+func oidcIDPToDomain(idpID string, idp *OIDCIDP) *domain.OIDCIDPConfig {
+	return &domain.OIDCIDPConfig{
+		IDPConfigID:           idpID,
+		ClientID:              idp.ClientID,
+		ClientSecretString:    idp.ClientSecret,
+		Issuer:                idp.Issuer,
+		AuthorizationEndpoint: idp.AuthorizationEndpoint,
+		TokenEndpoint:         idp.TokenEndpoint,
+		Scopes:                idp.Scopes,
+		IDPDisplayNameMapping: idp.DisplayNameMapping,
+		UsernameMapping:       idp.UsernameMapping,
+	}
+}
+
+func jwtIDPToDomain(idpID string, idp *JWTIDP) *domain.JWTIDPConfig {
+	return &domain.JWTIDPConfig{
+		IDPConfigID:  idpID,
+		JWTEndpoint:  idp.JWTEndpoint,
+		Issuer:       idp.Issuer,
+		KeysEndpoint: idp.KeysEndpoint,
+		HeaderName:   idp.HeaderName,
+	}
+}
+
+func (a *applier) addAction(ctx context.Context, action *Action) (string, error) {
+	id, _, err := a.commands.AddAction(ctx, actionToDomain("", action), a.orgID)
+	return id, err
+}
+
+func (a *applier) changeAction(ctx context.Context, actionID string, action *Action) (string, error) {
+	_, err := a.commands.ChangeAction(ctx, actionToDomain(actionID, action), a.orgID)
+	return actionID, err
+}
+
+func actionToDomain(actionID string, action *Action) *domain.Action {
+	return &domain.Action{
+		ObjectRoot:    models.ObjectRoot{AggregateID: actionID},
+		Name:          action.Name,
+		Script:        action.Script,
+		Timeout:       time.Duration(action.Timeout),
+		AllowedToFail: action.AllowedToFail,
+	}
+}
+
+func (a *applier) addProject(ctx context.Context, project *Project) (string, error) {
+	added, err := a.commands.AddProject(ctx, projectToDomain("", project), a.orgID, a.ownerUserID)
+	if err != nil {
+		return "", err
+	}
+	return added.AggregateID, nil
+}
+
+func (a *applier) changeProject(ctx context.Context, projectID string, project *Project) (string, error) {
+	_, err := a.commands.ChangeProject(ctx, projectToDomain(projectID, project), a.orgID)
+	return projectID, err
+}
+
+func projectToDomain(projectID string, project *Project) *domain.Project {
+	return &domain.Project{
+		ObjectRoot:             models.ObjectRoot{AggregateID: projectID},
+		Name:                   project.Name,
+		ProjectRoleAssertion:   project.ProjectRoleAssertion,
+		ProjectRoleCheck:       project.ProjectRoleCheck,
+		HasProjectCheck:        project.HasProjectCheck,
+		PrivateLabelingSetting: project.PrivateLabelingSetting,
+	}
+}
+
+func (a *applier) addProjectRole(ctx context.Context, projectSourceID string, role *Role) error {
+	_, err := a.commands.AddProjectRole(ctx, a.projectRoleToDomain(projectSourceID, role), a.orgID)
+	return err
+}
+
+func (a *applier) changeProjectRole(ctx context.Context, projectSourceID string, role *Role) error {
+	_, err := a.commands.ChangeProjectRole(ctx, a.projectRoleToDomain(projectSourceID, role), a.orgID)
+	return err
+}
+
+func (a *applier) projectRoleToDomain(projectSourceID string, role *Role) *domain.ProjectRole {
+	return &domain.ProjectRole{
+		ObjectRoot:  models.ObjectRoot{AggregateID: a.targetID(ObjectTypeProject, projectSourceID)},
+		Key:         role.Key,
+		DisplayName: role.DisplayName,
+		Group:       role.Group,
+	}
+}
+
+func (a *applier) addOIDCApp(ctx context.Context, projectSourceID string, app *OIDCApp) (string, error) {
+	added, err := a.commands.AddOIDCApplication(ctx, oidcAppToDomain(a.targetID(ObjectTypeProject, projectSourceID), "", app), a.orgID)
+	if err != nil {
+		return "", err
+	}
+	a.clients[added.AppID] = &client{id: added.ClientID, secret: added.ClientSecretString}
+	return added.AppID, nil
+}
+
+func (a *applier) changeOIDCApp(ctx context.Context, projectSourceID, appID string, app *OIDCApp) (string, error) {
+	_, err := a.commands.ChangeOIDCApplication(ctx, oidcAppToDomain(a.targetID(ObjectTypeProject, projectSourceID), appID, app), a.orgID)
+	return appID, err
+}
+
+func oidcAppToDomain(projectID, appID string, app *OIDCApp) *domain.OIDCApp {
+	return &domain.OIDCApp{
+		ObjectRoot:               models.ObjectRoot{AggregateID: projectID},
+		AppID:                    appID,
+		AppName:                  app.Name,
+		RedirectUris:             app.RedirectURIs,
+		ResponseTypes:            app.ResponseTypes,
+		GrantTypes:               app.GrantTypes,
+		ApplicationType:          app.AppType,
+		AuthMethodType:           app.AuthMethodType,
+		PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
+		OIDCVersion:              app.Version,
+		DevMode:                  app.DevMode,
+		AccessTokenType:          app.AccessTokenType,
+		AccessTokenRoleAssertion: app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:     app.IDTokenRoleAssertion,
+		IDTokenUserinfoAssertion: app.IDTokenUserinfoAssertion,
+		ClockSkew:                time.Duration(app.ClockSkew),
+		AdditionalOrigins:        app.AdditionalOrigins,
+	}
+}
+
+func (a *applier) addAPIApp(ctx context.Context, projectSourceID string, app *APIApp) (string, error) {
+	added, err := a.commands.AddAPIApplication(ctx, apiAppToDomain(a.targetID(ObjectTypeProject, projectSourceID), "", app), a.orgID)
+	if err != nil {
+		return "", err
+	}
+	a.clients[added.AppID] = &client{id: added.ClientID, secret: added.ClientSecretString}
+	return added.AppID, nil
+}
+
+func (a *applier) changeAPIApp(ctx context.Context, projectSourceID, appID string, app *APIApp) (string, error) {
+	_, err := a.commands.ChangeAPIApplication(ctx, apiAppToDomain(a.targetID(ObjectTypeProject, projectSourceID), appID, app), a.orgID)
+	return appID, err
+}
+
+func apiAppToDomain(projectID, appID string, app *APIApp) *domain.APIApp {
+	return &domain.APIApp{
+		ObjectRoot:     models.ObjectRoot{AggregateID: projectID},
+		AppID:          appID,
+		AppName:        app.Name,
+		AuthMethodType: app.AuthMethodType,
+	}
+}
+
+func (a *applier) setLoginPolicy(ctx context.Context, change *loginPolicyChange) (err error) {
+	policy := &domain.LoginPolicy{
+		AllowUsernamePassword: change.policy.AllowUsernamePassword,
+		AllowRegister:         change.policy.AllowRegister,
+		AllowExternalIDP:      change.policy.AllowExternalIDP,
+		ForceMFA:              change.policy.ForceMFA,
+		HidePasswordReset:     change.policy.HidePasswordReset,
+		PasswordlessType:      change.policy.PasswordlessType,
+	}
+	switch {
+	case !change.exists:
+		_, err = a.commands.AddLoginPolicy(ctx, a.orgID, policy)
+	case change.settingsChanged:
+		_, err = a.commands.ChangeLoginPolicy(ctx, a.orgID, policy)
+	}
+	if err != nil {
+		return err
+	}
+	for _, factor := range change.addSecondFactors {
+		if _, _, err = a.commands.AddSecondFactorToLoginPolicy(ctx, factor, a.orgID); err != nil {
+			return err
+		}
+	}
+	for _, factor := range change.removeSecondFactors {
+		if _, err = a.commands.RemoveSecondFactorFromLoginPolicy(ctx, factor, a.orgID); err != nil {
+			return err
+		}
+	}
+	for _, factor := range change.addMultiFactors {
+		if _, _, err = a.commands.AddMultiFactorToLoginPolicy(ctx, factor, a.orgID); err != nil {
+			return err
+		}
+	}
+	for _, factor := range change.removeMultiFactors {
+		if _, err = a.commands.RemoveMultiFactorFromLoginPolicy(ctx, factor, a.orgID); err != nil {
+			return err
+		}
+	}
+	for _, idpID := range change.addIDPIDs {
+		_, err = a.commands.AddIDPProviderToLoginPolicy(ctx, a.orgID, &domain.IDPProvider{
+			IDPConfigID: a.targetID(ObjectTypeIDP, idpID),
+			Type:        domain.IdentityProviderTypeOrg,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *applier) setPasswordComplexityPolicy(ctx context.Context, policy *PasswordComplexityPolicy, exists bool) (err error) {
+	complexity := &domain.PasswordComplexityPolicy{
+		MinLength:    policy.MinLength,
+		HasLowercase: policy.HasLowercase,
+		HasUppercase: policy.HasUppercase,
+		HasNumber:    policy.HasNumber,
+		HasSymbol:    policy.HasSymbol,
+	}
+	if exists {
+		_, err = a.commands.ChangePasswordComplexityPolicy(ctx, a.orgID, complexity)
+		return err
+	}
+	_, err = a.commands.AddPasswordComplexityPolicy(ctx, a.orgID, complexity)
+	return err
+}
+
+func (a *applier) setPasswordAgePolicy(ctx context.Context, policy *PasswordAgePolicy, exists bool) (err error) {
+	age := &domain.PasswordAgePolicy{
+		MaxAgeDays:     policy.MaxAgeDays,
+		ExpireWarnDays: policy.ExpireWarnDays,
+	}
+	if exists {
+		_, err = a.commands.ChangePasswordAgePolicy(ctx, a.orgID, age)
+		return err
+	}
+	_, err = a.commands.AddPasswordAgePolicy(ctx, a.orgID, age)
+	return err
+}
+
+func (a *applier) setLockoutPolicy(ctx context.Context, policy *LockoutPolicy, exists bool) (err error) {
+	lockout := &domain.LockoutPolicy{
+		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOTPAttempts:      policy.MaxOTPAttempts,
+		LockoutDuration:     time.Duration(policy.LockoutDuration),
+		MaxIPAttempts:       policy.MaxIPAttempts,
+		IPThrottleDuration:  time.Duration(policy.IPThrottleDuration),
+		ShowLockOutFailures: policy.ShowLockOutFailures,
+	}
+	if exists {
+		_, err = a.commands.ChangeLockoutPolicy(ctx, a.orgID, lockout)
+		return err
+	}
+	_, err = a.commands.AddLockoutPolicy(ctx, a.orgID, lockout)
+	return err
+}
+
+func (a *applier) setPrivacyPolicy(ctx context.Context, policy *PrivacyPolicy, exists bool) (err error) {
+	privacy := &domain.PrivacyPolicy{
+		TOSLink:     policy.TOSLink,
+		PrivacyLink: policy.PrivacyLink,
+		HelpLink:    policy.HelpLink,
+	}
+	if exists {
+		_, err = a.commands.ChangePrivacyPolicy(ctx, a.orgID, privacy)
+		return err
+	}
+	_, err = a.commands.AddPrivacyPolicy(ctx, a.orgID, privacy)
+	return err
+}
+
+//setLabelPolicy changes the preview of the label policy and activates it
+func (a *applier) setLabelPolicy(ctx context.Context, policy *LabelPolicy, exists bool) (err error) {
+	label := &domain.LabelPolicy{
+		PrimaryColor:        policy.PrimaryColor,
+		BackgroundColor:     policy.BackgroundColor,
+		WarnColor:           policy.WarnColor,
+		FontColor:           policy.FontColor,
+		PrimaryColorDark:    policy.PrimaryColorDark,
+		BackgroundColorDark: policy.BackgroundColorDark,
+		WarnColorDark:       policy.WarnColorDark,
+		FontColorDark:       policy.FontColorDark,
+		HideLoginNameSuffix: policy.HideLoginNameSuffix,
+		ErrorMsgPopup:       policy.ErrorMsgPopup,
+		DisableWatermark:    policy.DisableWatermark,
+	}
+	if exists {
+		_, err = a.commands.ChangeLabelPolicy(ctx, a.orgID, label)
+	} else {
+		_, err = a.commands.AddLabelPolicy(ctx, a.orgID, label)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = a.commands.ActivateLabelPolicy(ctx, a.orgID)
+	return err
+}
+
+func (a *applier) setTriggerActions(ctx context.Context, flowType domain.FlowType, trigger *Trigger) error {
+	actionIDs := make([]string, len(trigger.ActionIDs))
+	for i, id := range trigger.ActionIDs {
+		actionIDs[i] = a.targetID(ObjectTypeAction, id)
+	}
+	_, err := a.commands.SetTriggerActions(ctx, flowType, trigger.Type, actionIDs, a.orgID)
+	return err
+}
+
+func (a *applier) setCustomTexts(ctx context.Context, texts *CustomTexts) error {
+	if texts.Template == domain.LoginCustomText {
+		customTexts := &query.CustomTexts{CustomTexts: make([]*query.CustomText, 0, len(texts.Texts))}
+		for key, text := range texts.Texts {
+			customTexts.CustomTexts = append(customTexts.CustomTexts, &query.CustomText{
+				AggregateID: a.orgID,
+				Template:    texts.Template,
+				Language:    language.Make(texts.Language),
+				Key:         key,
+				Text:        text,
+			})
+		}
+		_, err := a.commands.SetOrgLoginText(ctx, a.orgID, query.CustomTextsToLoginDomain(a.orgID, texts.Language, customTexts))
+		return err
+	}
+	_, err := a.commands.SetOrgMessageText(ctx, a.orgID, &domain.CustomMessageText{
+		MessageTextType: texts.Template,
+		Language:        language.Make(texts.Language),
+		Title:           texts.Texts[domain.MessageTitle],
+		PreHeader:       texts.Texts[domain.MessagePreHeader],
+		Subject:         texts.Texts[domain.MessageSubject],
+		Greeting:        texts.Texts[domain.MessageGreeting],
+		Text:            texts.Texts[domain.MessageText],
+		ButtonText:      texts.Texts[domain.MessageButtonText],
+		FooterText:      texts.Texts[domain.MessageFooterText],
+	})
+	return err
+}
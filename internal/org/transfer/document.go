@@ -0,0 +1,257 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+//Version of the document written by Export
+// documents of other versions are rejected by Read
+const Version = "v1"
+
+//Document describes the configuration of an organisation
+// the ids are the ids of the source organisation and are only used to resolve references inside the document,
+// objects are matched with the existing objects of the target organisation by their names
+type Document struct {
+	Version     string         `json:"version"`
+	OrgID       string         `json:"orgId,omitempty"`
+	IDPs        []*IDP         `json:"idps,omitempty"`
+	Actions     []*Action      `json:"actions,omitempty"`
+	Projects    []*Project     `json:"projects,omitempty"`
+	Policies    *Policies      `json:"policies,omitempty"`
+	Flows       []*Flow        `json:"flows,omitempty"`
+	CustomTexts []*CustomTexts `json:"customTexts,omitempty"`
+}
+
+type IDP struct {
+	ID           string                      `json:"id"`
+	Name         string                      `json:"name"`
+	StylingType  domain.IDPConfigStylingType `json:"stylingType,omitempty"`
+	AutoRegister bool                        `json:"autoRegister,omitempty"`
+	OIDC         *OIDCIDP                    `json:"oidc,omitempty"`
+	JWT          *JWTIDP                     `json:"jwt,omitempty"`
+}
+
+//OIDCIDP is the configuration of an oidc identity provider
+// ClientSecret is never exported and must be added to the document before an import
+type OIDCIDP struct {
+	ClientID              string                  `json:"clientId"`
+	ClientSecret          string                  `json:"clientSecret,omitempty"`
+	Issuer                string                  `json:"issuer"`
+	AuthorizationEndpoint string                  `json:"authorizationEndpoint,omitempty"`
+	TokenEndpoint         string                  `json:"tokenEndpoint,omitempty"`
+	Scopes                []string                `json:"scopes,omitempty"`
+	DisplayNameMapping    domain.OIDCMappingField `json:"displayNameMapping,omitempty"`
+	UsernameMapping       domain.OIDCMappingField `json:"usernameMapping,omitempty"`
+}
+
+type JWTIDP struct {
+	JWTEndpoint  string `json:"jwtEndpoint"`
+	Issuer       string `json:"issuer"`
+	KeysEndpoint string `json:"keysEndpoint"`
+	HeaderName   string `json:"headerName,omitempty"`
+}
+
+type Action struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Script        string   `json:"script"`
+	Timeout       Duration `json:"timeout,omitempty"`
+	AllowedToFail bool     `json:"allowedToFail,omitempty"`
+}
+
+type Project struct {
+	ID                     string                        `json:"id"`
+	Name                   string                        `json:"name"`
+	ProjectRoleAssertion   bool                          `json:"projectRoleAssertion,omitempty"`
+	ProjectRoleCheck       bool                          `json:"projectRoleCheck,omitempty"`
+	HasProjectCheck        bool                          `json:"hasProjectCheck,omitempty"`
+	PrivateLabelingSetting domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
+	Roles                  []*Role                       `json:"roles,omitempty"`
+	OIDCApps               []*OIDCApp                    `json:"oidcApps,omitempty"`
+	APIApps                []*APIApp                     `json:"apiApps,omitempty"`
+}
+
+type Role struct {
+	Key         string `json:"key"`
+	DisplayName string `json:"displayName,omitempty"`
+	Group       string `json:"group,omitempty"`
+}
+
+//OIDCApp is the configuration of an oidc application
+// client id and secret are generated by the target organisation
+type OIDCApp struct {
+	ID                       string                     `json:"id"`
+	Name                     string                     `json:"name"`
+	RedirectURIs             []string                   `json:"redirectUris,omitempty"`
+	ResponseTypes            []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
+	GrantTypes               []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
+	AppType                  domain.OIDCApplicationType `json:"appType,omitempty"`
+	AuthMethodType           domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
+	PostLogoutRedirectURIs   []string                   `json:"postLogoutRedirectUris,omitempty"`
+	Version                  domain.OIDCVersion         `json:"version,omitempty"`
+	DevMode                  bool                       `json:"devMode,omitempty"`
+	AccessTokenType          domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion bool                       `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion     bool                       `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                Duration                   `json:"clockSkew,omitempty"`
+	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
+}
+
+type APIApp struct {
+	ID             string                   `json:"id"`
+	Name           string                   `json:"name"`
+	AuthMethodType domain.APIAuthMethodType `json:"authMethodType,omitempty"`
+}
+
+//Policies contains the policies customised by the organisation
+// policies which are not set use the default policies of the instance
+type Policies struct {
+	Login              *LoginPolicy              `json:"login,omitempty"`
+	PasswordComplexity *PasswordComplexityPolicy `json:"passwordComplexity,omitempty"`
+	PasswordAge        *PasswordAgePolicy        `json:"passwordAge,omitempty"`
+	Lockout            *LockoutPolicy            `json:"lockout,omitempty"`
+	Privacy            *PrivacyPolicy            `json:"privacy,omitempty"`
+	Label              *LabelPolicy              `json:"label,omitempty"`
+}
+
+//LoginPolicy references the allowed identity providers by the ids of the document
+type LoginPolicy struct {
+	AllowUsernamePassword bool                      `json:"allowUsernamePassword,omitempty"`
+	AllowRegister         bool                      `json:"allowRegister,omitempty"`
+	AllowExternalIDP      bool                      `json:"allowExternalIdp,omitempty"`
+	ForceMFA              bool                      `json:"forceMfa,omitempty"`
+	HidePasswordReset     bool                      `json:"hidePasswordReset,omitempty"`
+	PasswordlessType      domain.PasswordlessType   `json:"passwordlessType,omitempty"`
+	SecondFactors         []domain.SecondFactorType `json:"secondFactors,omitempty"`
+	MultiFactors          []domain.MultiFactorType  `json:"multiFactors,omitempty"`
+	IDPIDs                []string                  `json:"idpIds,omitempty"`
+}
+
+type PasswordComplexityPolicy struct {
+	MinLength    uint64 `json:"minLength"`
+	HasLowercase bool   `json:"hasLowercase,omitempty"`
+	HasUppercase bool   `json:"hasUppercase,omitempty"`
+	HasNumber    bool   `json:"hasNumber,omitempty"`
+	HasSymbol    bool   `json:"hasSymbol,omitempty"`
+}
+
+type PasswordAgePolicy struct {
+	MaxAgeDays     uint64 `json:"maxAgeDays"`
+	ExpireWarnDays uint64 `json:"expireWarnDays"`
+}
+
+type LockoutPolicy struct {
+	MaxPasswordAttempts uint64   `json:"maxPasswordAttempts"`
+	MaxOTPAttempts      uint64   `json:"maxOtpAttempts,omitempty"`
+	LockoutDuration     Duration `json:"lockoutDuration,omitempty"`
+	MaxIPAttempts       uint64   `json:"maxIpAttempts,omitempty"`
+	IPThrottleDuration  Duration `json:"ipThrottleDuration,omitempty"`
+	ShowLockOutFailures bool     `json:"showLockOutFailures,omitempty"`
+}
+
+type PrivacyPolicy struct {
+	TOSLink     string `json:"tosLink,omitempty"`
+	PrivacyLink string `json:"privacyLink,omitempty"`
+	HelpLink    string `json:"helpLink,omitempty"`
+}
+
+//LabelPolicy contains the colors and settings of the private labeling
+// uploaded assets (logos, icons and fonts) are not part of the document
+type LabelPolicy struct {
+	PrimaryColor        string `json:"primaryColor,omitempty"`
+	BackgroundColor     string `json:"backgroundColor,omitempty"`
+	WarnColor           string `json:"warnColor,omitempty"`
+	FontColor           string `json:"fontColor,omitempty"`
+	PrimaryColorDark    string `json:"primaryColorDark,omitempty"`
+	BackgroundColorDark string `json:"backgroundColorDark,omitempty"`
+	WarnColorDark       string `json:"warnColorDark,omitempty"`
+	FontColorDark       string `json:"fontColorDark,omitempty"`
+	HideLoginNameSuffix bool   `json:"hideLoginNameSuffix,omitempty"`
+	ErrorMsgPopup       bool   `json:"errorMsgPopup,omitempty"`
+	DisableWatermark    bool   `json:"disableWatermark,omitempty"`
+}
+
+//Flow references the actions of each trigger by the ids of the document
+type Flow struct {
+	Type     domain.FlowType `json:"type"`
+	Triggers []*Trigger      `json:"triggers"`
+}
+
+type Trigger struct {
+	Type      domain.TriggerType `json:"type"`
+	ActionIDs []string           `json:"actionIds"`
+}
+
+//CustomTexts are the customised texts of a template (login or a message type) in a language
+type CustomTexts struct {
+	Template string            `json:"template"`
+	Language string            `json:"language"`
+	Texts    map[string]string `json:"texts"`
+}
+
+//Duration is marshalled as string (e.g. 10s) to keep the document readable
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+//Read parses a document written by Write
+func Read(r io.Reader) (*Document, error) {
+	doc := new(Document)
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(doc); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "TRANS-Rd2oC", "Errors.Org.Transfer.Invalid")
+	}
+	if doc.Version != Version {
+		return nil, caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Rd3vE", "Errors.Org.Transfer.VersionUnsupported: %s", doc.Version)
+	}
+	return doc, nil
+}
+
+//ReadYAML parses a document in yaml format
+// the fields are named like the json fields
+func ReadYAML(r io.Reader) (*Document, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "TRANS-Ym2oR", "Errors.Org.Transfer.Invalid")
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "TRANS-Ym3oC", "Errors.Org.Transfer.Invalid")
+	}
+	return Read(bytes.NewReader(data))
+}
+
+func Write(w io.Writer, doc *Document) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return caos_errs.ThrowInternal(err, "TRANS-Wr2oC", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package transfer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func TestRead(t *testing.T) {
+	type res struct {
+		doc *Document
+		err func(error) bool
+	}
+	tests := []struct {
+		name string
+		data string
+		res  res
+	}{
+		{
+			name: "valid document",
+			data: `{"version":"v1","actions":[{"id":"action1","name":"log","script":"function log() {}","timeout":"10s"}]}`,
+			res: res{
+				doc: &Document{
+					Version: Version,
+					Actions: []*Action{{ID: "action1", Name: "log", Script: "function log() {}", Timeout: Duration(10 * time.Second)}},
+				},
+			},
+		},
+		{
+			name: "unsupported version, invalid argument error",
+			data: `{"version":"v2"}`,
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown field, invalid argument error",
+			data: `{"version":"v1","users":[]}`,
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid duration, invalid argument error",
+			data: `{"version":"v1","actions":[{"name":"log","timeout":"ten seconds"}]}`,
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.data))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.res.doc, got)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestReadYAML(t *testing.T) {
+	type res struct {
+		doc *Document
+		err func(error) bool
+	}
+	tests := []struct {
+		name string
+		data string
+		res  res
+	}{
+		{
+			name: "valid document",
+			data: `
+version: v1
+actions:
+  - name: log
+    script: function log() {}
+    timeout: 10s
+`,
+			res: res{
+				doc: &Document{
+					Version: Version,
+					Actions: []*Action{{Name: "log", Script: "function log() {}", Timeout: Duration(10 * time.Second)}},
+				},
+			},
+		},
+		{
+			name: "invalid yaml, invalid argument error",
+			data: "version: [v1",
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown field, invalid argument error",
+			data: "version: v1\nusers: []",
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadYAML(strings.NewReader(tt.data))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.res.doc, got)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	doc := &Document{
+		Version: Version,
+		OrgID:   "org1",
+		Policies: &Policies{
+			Lockout: &LockoutPolicy{MaxPasswordAttempts: 5, LockoutDuration: Duration(time.Hour)},
+		},
+	}
+	data := new(bytes.Buffer)
+	err := Write(data, doc)
+	assert.NoError(t, err)
+	assert.Contains(t, data.String(), `"lockoutDuration": "1h0m0s"`)
+
+	got, err := Read(data)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, got)
+}
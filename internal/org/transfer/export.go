@@ -0,0 +1,474 @@
+package transfer
+
+import (
+	"context"
+	"sort"
+
+	"github.com/caos/zitadel/internal/api/authz"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/query"
+)
+
+const (
+	permissionIDPRead     = "org.idp.read"
+	permissionActionRead  = "org.action.read"
+	permissionFlowRead    = "org.flow.read"
+	permissionPolicyRead  = "policy.read"
+	permissionProjectRead = "project.read"
+	permissionRoleRead    = "project.role.read"
+	permissionAppRead     = "project.app.read"
+)
+
+//writePermissions are the permissions needed to import the objects
+var writePermissions = map[ObjectType]string{
+	ObjectTypeIDP:                      "org.idp.write",
+	ObjectTypeAction:                   "org.action.write",
+	ObjectTypeProject:                  "project.write",
+	ObjectTypeProjectRole:              "project.role.write",
+	ObjectTypeOIDCApp:                  "project.app.write",
+	ObjectTypeAPIApp:                   "project.app.write",
+	ObjectTypeLoginPolicy:              "policy.write",
+	ObjectTypePasswordComplexityPolicy: "policy.write",
+	ObjectTypePasswordAgePolicy:        "policy.write",
+	ObjectTypeLockoutPolicy:            "policy.write",
+	ObjectTypePrivacyPolicy:            "policy.write",
+	ObjectTypeLabelPolicy:              "policy.write",
+	ObjectTypeFlow:                     "org.flow.write",
+	ObjectTypeCustomTexts:              "policy.write",
+}
+
+//textTemplates are the templates of the custom texts which are exported
+var textTemplates = []string{
+	domain.LoginCustomText,
+	domain.InitCodeMessageType,
+	domain.PasswordResetMessageType,
+	domain.VerifyEmailMessageType,
+	domain.VerifyPhoneMessageType,
+	domain.DomainClaimedMessageType,
+	domain.PasswordlessRegistrationMessageType,
+	domain.PasswordChangedMessageType,
+	domain.MFAAddedMessageType,
+	domain.MFARemovedMessageType,
+	domain.NewUserAgentMessageType,
+	domain.UserLockedMessageType,
+	domain.EmailChangedMessageType,
+}
+
+//readPermissions decide which sections of the organisation are exported
+type readPermissions interface {
+	//canRead checks the permission on the organisation
+	canRead(permission string) bool
+	//canReadProject checks the permission on the organisation or the project
+	canReadProject(permission, projectID string) bool
+}
+
+//userPermissions are the permissions of the user which requested the export or import
+type userPermissions []string
+
+func (p userPermissions) canRead(permission string) bool {
+	return authz.HasGlobalExplicitPermission(p, permission)
+}
+
+func (p userPermissions) canReadProject(permission, projectID string) bool {
+	if p.canRead(permission) {
+		return true
+	}
+	for _, id := range authz.GetExplicitPermissionCtxIDs(p, permission) {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+//canWrite checks the permission on the organisation or, if the project id is set, on the project
+func (p userPermissions) canWrite(permission, projectID string) bool {
+	if projectID == "" {
+		return p.canRead(permission)
+	}
+	return p.canReadProject(permission, projectID)
+}
+
+//allPermissions is used to read the current configuration on import
+type allPermissions struct{}
+
+func (allPermissions) canRead(string) bool { return true }
+
+func (allPermissions) canReadProject(string, string) bool { return true }
+
+//Export reads the configuration of the organisation
+// only the sections the permissions allow to read are exported (e.g. identity providers require org.idp.read),
+// secrets, users, members and grants are not exported
+func Export(ctx context.Context, queries *query.Queries, orgID string, permissions []string) (*Document, error) {
+	return export(ctx, queries, orgID, userPermissions(permissions))
+}
+
+func export(ctx context.Context, queries *query.Queries, orgID string, permissions readPermissions) (_ *Document, err error) {
+	doc := &Document{
+		Version: Version,
+		OrgID:   orgID,
+	}
+	if permissions.canRead(permissionIDPRead) {
+		if doc.IDPs, err = exportIDPs(ctx, queries, orgID); err != nil {
+			return nil, err
+		}
+	}
+	if permissions.canRead(permissionActionRead) {
+		if doc.Actions, err = exportActions(ctx, queries, orgID); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Projects, err = exportProjects(ctx, queries, orgID, permissions); err != nil {
+		return nil, err
+	}
+	if permissions.canRead(permissionPolicyRead) {
+		if doc.Policies, err = exportPolicies(ctx, queries, orgID, permissions.canRead(permissionIDPRead)); err != nil {
+			return nil, err
+		}
+		if doc.CustomTexts, err = exportCustomTexts(ctx, queries, orgID); err != nil {
+			return nil, err
+		}
+	}
+	//flows reference the actions by their ids
+	if permissions.canRead(permissionFlowRead) && permissions.canRead(permissionActionRead) {
+		if doc.Flows, err = exportFlows(ctx, queries, orgID); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func exportIDPs(ctx context.Context, queries *query.Queries, orgID string) ([]*IDP, error) {
+	ownerQuery, err := query.NewIDPResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	typeQuery, err := query.NewIDPOwnerTypeSearchQuery(domain.IdentityProviderTypeOrg)
+	if err != nil {
+		return nil, err
+	}
+	idps, err := queries.IDPs(ctx, &query.IDPSearchQueries{Queries: []query.SearchQuery{ownerQuery, typeQuery}})
+	if err != nil {
+		return nil, err
+	}
+	exports := make([]*IDP, 0, len(idps.IDPs))
+	for _, idp := range idps.IDPs {
+		export := &IDP{
+			ID:           idp.ID,
+			Name:         idp.Name,
+			StylingType:  idp.StylingType,
+			AutoRegister: idp.AutoRegister,
+		}
+		if idp.OIDCIDP != nil {
+			export.OIDC = &OIDCIDP{
+				ClientID:              idp.OIDCIDP.ClientID,
+				Issuer:                idp.OIDCIDP.Issuer,
+				AuthorizationEndpoint: idp.OIDCIDP.AuthorizationEndpoint,
+				TokenEndpoint:         idp.OIDCIDP.TokenEndpoint,
+				Scopes:                idp.OIDCIDP.Scopes,
+				DisplayNameMapping:    idp.OIDCIDP.DisplayNameMapping,
+				UsernameMapping:       idp.OIDCIDP.UsernameMapping,
+			}
+		}
+		if idp.JWTIDP != nil {
+			export.JWT = &JWTIDP{
+				JWTEndpoint:  idp.JWTIDP.Endpoint,
+				Issuer:       idp.JWTIDP.Issuer,
+				KeysEndpoint: idp.JWTIDP.KeysEndpoint,
+				HeaderName:   idp.JWTIDP.HeaderName,
+			}
+		}
+		exports = append(exports, export)
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Name < exports[j].Name })
+	return exports, nil
+}
+
+func exportActions(ctx context.Context, queries *query.Queries, orgID string) ([]*Action, error) {
+	ownerQuery, err := query.NewActionResourceOwnerQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := queries.SearchActions(ctx, &query.ActionSearchQueries{Queries: []query.SearchQuery{ownerQuery}})
+	if err != nil {
+		return nil, err
+	}
+	exports := make([]*Action, len(actions.Actions))
+	for i, action := range actions.Actions {
+		exports[i] = &Action{
+			ID:            action.ID,
+			Name:          action.Name,
+			Script:        action.Script,
+			Timeout:       Duration(action.Timeout),
+			AllowedToFail: action.AllowedToFail,
+		}
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Name < exports[j].Name })
+	return exports, nil
+}
+
+func exportProjects(ctx context.Context, queries *query.Queries, orgID string, permissions readPermissions) ([]*Project, error) {
+	ownerQuery, err := query.NewProjectResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := queries.SearchProjects(ctx, &query.ProjectSearchQueries{Queries: []query.SearchQuery{ownerQuery}})
+	if err != nil {
+		return nil, err
+	}
+	exports := make([]*Project, 0, len(projects.Projects))
+	for _, project := range projects.Projects {
+		if !permissions.canReadProject(permissionProjectRead, project.ID) {
+			continue
+		}
+		export := &Project{
+			ID:                     project.ID,
+			Name:                   project.Name,
+			ProjectRoleAssertion:   project.ProjectRoleAssertion,
+			ProjectRoleCheck:       project.ProjectRoleCheck,
+			HasProjectCheck:        project.HasProjectCheck,
+			PrivateLabelingSetting: project.PrivateLabelingSetting,
+		}
+		if permissions.canReadProject(permissionRoleRead, project.ID) {
+			if export.Roles, err = exportRoles(ctx, queries, project.ID); err != nil {
+				return nil, err
+			}
+		}
+		if permissions.canReadProject(permissionAppRead, project.ID) {
+			if export.OIDCApps, export.APIApps, err = exportApps(ctx, queries, project.ID); err != nil {
+				return nil, err
+			}
+		}
+		exports = append(exports, export)
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Name < exports[j].Name })
+	return exports, nil
+}
+
+func exportRoles(ctx context.Context, queries *query.Queries, projectID string) ([]*Role, error) {
+	projectQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := queries.SearchProjectRoles(ctx, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectQuery}})
+	if err != nil {
+		return nil, err
+	}
+	exports := make([]*Role, len(roles.ProjectRoles))
+	for i, role := range roles.ProjectRoles {
+		exports[i] = &Role{
+			Key:         role.Key,
+			DisplayName: role.DisplayName,
+			Group:       role.Group,
+		}
+	}
+	sort.Slice(exports, func(i, j int) bool { return exports[i].Key < exports[j].Key })
+	return exports, nil
+}
+
+func exportApps(ctx context.Context, queries *query.Queries, projectID string) ([]*OIDCApp, []*APIApp, error) {
+	projectQuery, err := query.NewAppProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	apps, err := queries.SearchApps(ctx, &query.AppSearchQueries{Queries: []query.SearchQuery{projectQuery}})
+	if err != nil {
+		return nil, nil, err
+	}
+	oidcApps := make([]*OIDCApp, 0)
+	apiApps := make([]*APIApp, 0)
+	for _, app := range apps.Apps {
+		if app.OIDCConfig != nil {
+			oidcApps = append(oidcApps, &OIDCApp{
+				ID:                       app.ID,
+				Name:                     app.Name,
+				RedirectURIs:             app.OIDCConfig.RedirectURIs,
+				ResponseTypes:            app.OIDCConfig.ResponseTypes,
+				GrantTypes:               app.OIDCConfig.GrantTypes,
+				AppType:                  app.OIDCConfig.AppType,
+				AuthMethodType:           app.OIDCConfig.AuthMethodType,
+				PostLogoutRedirectURIs:   app.OIDCConfig.PostLogoutRedirectURIs,
+				Version:                  app.OIDCConfig.Version,
+				DevMode:                  app.OIDCConfig.IsDevMode,
+				AccessTokenType:          app.OIDCConfig.AccessTokenType,
+				AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
+				IDTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
+				IDTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
+				ClockSkew:                Duration(app.OIDCConfig.ClockSkew),
+				AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
+			})
+		}
+		if app.APIConfig != nil {
+			apiApps = append(apiApps, &APIApp{
+				ID:             app.ID,
+				Name:           app.Name,
+				AuthMethodType: app.APIConfig.AuthMethodType,
+			})
+		}
+	}
+	sort.Slice(oidcApps, func(i, j int) bool { return oidcApps[i].Name < oidcApps[j].Name })
+	sort.Slice(apiApps, func(i, j int) bool { return apiApps[i].Name < apiApps[j].Name })
+	return oidcApps, apiApps, nil
+}
+
+//exportPolicies only exports the policies of the organisation and not the default policies
+// the identity providers of the login policy are only exported if the identity providers are exported
+func exportPolicies(ctx context.Context, queries *query.Queries, orgID string, withIDPs bool) (*Policies, error) {
+	policies := new(Policies)
+	login, err := queries.LoginPolicyByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !login.IsDefault {
+		policies.Login = &LoginPolicy{
+			AllowUsernamePassword: login.AllowUsernamePassword,
+			AllowRegister:         login.AllowRegister,
+			AllowExternalIDP:      login.AllowExternalIDPs,
+			ForceMFA:              login.ForceMFA,
+			HidePasswordReset:     login.HidePasswordReset,
+			PasswordlessType:      login.PasswordlessType,
+			SecondFactors:         login.SecondFactors,
+			MultiFactors:          login.MultiFactors,
+		}
+		if withIDPs {
+			links, err := queries.IDPLoginPolicyLinks(ctx, orgID, &query.IDPLoginPolicyLinksSearchQuery{})
+			if err != nil {
+				return nil, err
+			}
+			policies.Login.IDPIDs = make([]string, len(links.Links))
+			for i, link := range links.Links {
+				policies.Login.IDPIDs[i] = link.IDPID
+			}
+		}
+	}
+	complexity, err := queries.PasswordComplexityPolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !complexity.IsDefault {
+		policies.PasswordComplexity = &PasswordComplexityPolicy{
+			MinLength:    complexity.MinLength,
+			HasLowercase: complexity.HasLowercase,
+			HasUppercase: complexity.HasUppercase,
+			HasNumber:    complexity.HasNumber,
+			HasSymbol:    complexity.HasSymbol,
+		}
+	}
+	age, err := queries.PasswordAgePolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !age.IsDefault {
+		policies.PasswordAge = &PasswordAgePolicy{
+			MaxAgeDays:     age.MaxAgeDays,
+			ExpireWarnDays: age.ExpireWarnDays,
+		}
+	}
+	lockout, err := queries.LockoutPolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !lockout.IsDefault {
+		policies.Lockout = &LockoutPolicy{
+			MaxPasswordAttempts: lockout.MaxPasswordAttempts,
+			MaxOTPAttempts:      lockout.MaxOTPAttempts,
+			LockoutDuration:     Duration(lockout.LockoutDuration),
+			MaxIPAttempts:       lockout.MaxIPAttempts,
+			IPThrottleDuration:  Duration(lockout.IPThrottleDuration),
+			ShowLockOutFailures: lockout.ShowFailures,
+		}
+	}
+	privacy, err := queries.PrivacyPolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !privacy.IsDefault {
+		policies.Privacy = &PrivacyPolicy{
+			TOSLink:     privacy.TOSLink,
+			PrivacyLink: privacy.PrivacyLink,
+			HelpLink:    privacy.HelpLink,
+		}
+	}
+	label, err := queries.ActiveLabelPolicyByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !label.IsDefault {
+		policies.Label = &LabelPolicy{
+			PrimaryColor:        label.Light.PrimaryColor,
+			BackgroundColor:     label.Light.BackgroundColor,
+			WarnColor:           label.Light.WarnColor,
+			FontColor:           label.Light.FontColor,
+			PrimaryColorDark:    label.Dark.PrimaryColor,
+			BackgroundColorDark: label.Dark.BackgroundColor,
+			WarnColorDark:       label.Dark.WarnColor,
+			FontColorDark:       label.Dark.FontColor,
+			HideLoginNameSuffix: label.HideLoginNameSuffix,
+			ErrorMsgPopup:       label.ShouldErrorPopup,
+			DisableWatermark:    label.WatermarkDisabled,
+		}
+	}
+	return policies, nil
+}
+
+func exportFlows(ctx context.Context, queries *query.Queries, orgID string) ([]*Flow, error) {
+	flows := make([]*Flow, 0)
+	for _, flowType := range []domain.FlowType{domain.FlowTypeExternalAuthentication} {
+		flow, err := queries.GetFlow(ctx, flowType, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if len(flow.TriggerActions) == 0 {
+			continue
+		}
+		export := &Flow{
+			Type:     flowType,
+			Triggers: make([]*Trigger, 0, len(flow.TriggerActions)),
+		}
+		for triggerType, actions := range flow.TriggerActions {
+			trigger := &Trigger{
+				Type:      triggerType,
+				ActionIDs: make([]string, len(actions)),
+			}
+			for i, action := range actions {
+				trigger.ActionIDs[i] = action.ID
+			}
+			export.Triggers = append(export.Triggers, trigger)
+		}
+		sort.Slice(export.Triggers, func(i, j int) bool { return export.Triggers[i].Type < export.Triggers[j].Type })
+		flows = append(flows, export)
+	}
+	return flows, nil
+}
+
+func exportCustomTexts(ctx context.Context, queries *query.Queries, orgID string) ([]*CustomTexts, error) {
+	exports := make([]*CustomTexts, 0)
+	for _, template := range textTemplates {
+		texts, err := queries.CustomTextListByTemplate(ctx, orgID, template)
+		if err != nil {
+			return nil, err
+		}
+		byLanguage := make(map[string]*CustomTexts)
+		for _, text := range texts.CustomTexts {
+			lang := text.Language.String()
+			export, ok := byLanguage[lang]
+			if !ok {
+				export = &CustomTexts{
+					Template: template,
+					Language: lang,
+					Texts:    make(map[string]string),
+				}
+				byLanguage[lang] = export
+				exports = append(exports, export)
+			}
+			export.Texts[text.Key] = text.Text
+		}
+	}
+	sort.SliceStable(exports, func(i, j int) bool {
+		if exports[i].Template != exports[j].Template {
+			return exports[i].Template < exports[j].Template
+		}
+		return exports[i].Language < exports[j].Language
+	})
+	return exports, nil
+}
@@ -0,0 +1,83 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userPermissions(t *testing.T) {
+	type args struct {
+		permissions []string
+		permission  string
+		projectID   string
+	}
+	type res struct {
+		canRead        bool
+		canReadProject bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "no permissions",
+			args: args{
+				permissions: []string{},
+				permission:  permissionIDPRead,
+				projectID:   "project1",
+			},
+			res: res{},
+		},
+		{
+			name: "org permission",
+			args: args{
+				permissions: []string{"org.read", permissionIDPRead},
+				permission:  permissionIDPRead,
+				projectID:   "project1",
+			},
+			res: res{
+				canRead:        true,
+				canReadProject: true,
+			},
+		},
+		{
+			name: "other permission",
+			args: args{
+				permissions: []string{"org.read", "org.member.read"},
+				permission:  permissionIDPRead,
+				projectID:   "project1",
+			},
+			res: res{},
+		},
+		{
+			name: "project permission",
+			args: args{
+				permissions: []string{"org.read", permissionProjectRead + ":project1"},
+				permission:  permissionProjectRead,
+				projectID:   "project1",
+			},
+			res: res{
+				canRead:        false,
+				canReadProject: true,
+			},
+		},
+		{
+			name: "permission of other project",
+			args: args{
+				permissions: []string{"org.read", permissionProjectRead + ":project2"},
+				permission:  permissionProjectRead,
+				projectID:   "project1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions := userPermissions(tt.args.permissions)
+			assert.Equal(t, tt.res.canRead, permissions.canRead(tt.args.permission))
+			assert.Equal(t, tt.res.canReadProject, permissions.canReadProject(tt.args.permission, tt.args.projectID))
+		})
+	}
+}
@@ -0,0 +1,621 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+//ChangeType describes what the import does with an object
+type ChangeType string
+
+const (
+	ChangeTypeCreate    ChangeType = "create"
+	ChangeTypeUpdate    ChangeType = "update"
+	ChangeTypeUnchanged ChangeType = "unchanged"
+)
+
+type ObjectType string
+
+const (
+	ObjectTypeIDP                      ObjectType = "idp"
+	ObjectTypeAction                   ObjectType = "action"
+	ObjectTypeProject                  ObjectType = "project"
+	ObjectTypeProjectRole              ObjectType = "project_role"
+	ObjectTypeOIDCApp                  ObjectType = "oidc_app"
+	ObjectTypeAPIApp                   ObjectType = "api_app"
+	ObjectTypeLoginPolicy              ObjectType = "login_policy"
+	ObjectTypePasswordComplexityPolicy ObjectType = "password_complexity_policy"
+	ObjectTypePasswordAgePolicy        ObjectType = "password_age_policy"
+	ObjectTypeLockoutPolicy            ObjectType = "lockout_policy"
+	ObjectTypePrivacyPolicy            ObjectType = "privacy_policy"
+	ObjectTypeLabelPolicy              ObjectType = "label_policy"
+	ObjectTypeFlow                     ObjectType = "flow"
+	ObjectTypeCustomTexts              ObjectType = "custom_texts"
+)
+
+//Change is a single step of an import
+// Key is the natural key the object is matched with (e.g. the name of the project),
+// SourceID is the id of the object in the document and TargetID the id in the target organisation
+// which is set after the object is created
+type Change struct {
+	ObjectType ObjectType
+	Key        string
+	Type       ChangeType
+	SourceID   string
+	TargetID   string
+	//ClientID and ClientSecret are set for created apps, the secret can't be read afterwards
+	ClientID     string
+	ClientSecret string
+
+	//projectID is the id of the existing project in the target organisation the change belongs to
+	projectID string
+	apply     func(ctx context.Context, a *applier) (string, error)
+}
+
+//objectID identifies an object of the document
+// the ids are only unique per object type (e.g. a project and an idp both named after the organisation)
+type objectID struct {
+	objectType ObjectType
+	id         string
+}
+
+//Plan contains the changes needed to bring an organisation to the state of a document
+// the changes are ordered so that referenced objects are created first
+type Plan struct {
+	Changes []*Change
+	//ids maps the ids of the document to the ids of the target organisation
+	ids map[objectID]string
+}
+
+//NewPlan compares the current state of the target organisation with the desired document
+// objects of the target organisation which are not part of the document are left untouched
+func NewPlan(current, desired *Document) (*Plan, error) {
+	p := &Plan{
+		Changes: make([]*Change, 0),
+		ids:     make(map[objectID]string),
+	}
+	defaultIDs(desired)
+	if err := p.planIDPs(current.IDPs, desired.IDPs); err != nil {
+		return nil, err
+	}
+	if err := p.planActions(current.Actions, desired.Actions); err != nil {
+		return nil, err
+	}
+	if err := p.planProjects(current.Projects, desired.Projects); err != nil {
+		return nil, err
+	}
+	if err := p.planPolicies(current, desired); err != nil {
+		return nil, err
+	}
+	if err := p.planFlows(current, desired); err != nil {
+		return nil, err
+	}
+	if err := p.planCustomTexts(current.CustomTexts, desired.CustomTexts); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+//defaultIDs uses the names as ids of the objects without id
+// so that handwritten documents can reference the objects by their names
+func defaultIDs(doc *Document) {
+	for _, idp := range doc.IDPs {
+		if idp.ID == "" {
+			idp.ID = idp.Name
+		}
+	}
+	for _, action := range doc.Actions {
+		if action.ID == "" {
+			action.ID = action.Name
+		}
+	}
+	for _, project := range doc.Projects {
+		if project.ID == "" {
+			project.ID = project.Name
+		}
+	}
+}
+
+func (p *Plan) add(objectType ObjectType, key string, changeType ChangeType, sourceID, targetID string, apply func(context.Context, *applier) (string, error)) {
+	change := &Change{
+		ObjectType: objectType,
+		Key:        key,
+		Type:       changeType,
+		SourceID:   sourceID,
+		TargetID:   targetID,
+	}
+	if changeType != ChangeTypeUnchanged {
+		change.apply = apply
+	}
+	p.Changes = append(p.Changes, change)
+}
+
+func (p *Plan) planIDPs(current, desired []*IDP) error {
+	existing := make(map[string]*IDP, len(current))
+	for _, idp := range current {
+		existing[idp.Name] = idp
+	}
+	names := make(map[string]bool, len(desired))
+	for _, idp := range desired {
+		idp := idp
+		if idp.Name == "" || names[idp.Name] || (idp.OIDC == nil) == (idp.JWT == nil) {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Id", "Errors.Org.Transfer.Invalid: idp %q", idp.Name)
+		}
+		names[idp.Name] = true
+		target, ok := existing[idp.Name]
+		if !ok {
+			p.add(ObjectTypeIDP, idp.Name, ChangeTypeCreate, idp.ID, "", func(ctx context.Context, a *applier) (string, error) {
+				return a.addIDP(ctx, idp)
+			})
+			continue
+		}
+		if (idp.OIDC == nil) != (target.OIDC == nil) {
+			return caos_errs.ThrowPreconditionFailedf(nil, "TRANS-Pl3Id", "Errors.Org.Transfer.TypeChanged: idp %q", idp.Name)
+		}
+		p.ids[objectID{ObjectTypeIDP, idp.ID}] = target.ID
+		change := &idpChange{
+			id:            target.ID,
+			idp:           idp,
+			configChanged: idp.StylingType != target.StylingType || idp.AutoRegister != target.AutoRegister,
+		}
+		if idp.OIDC != nil {
+			oidc := *idp.OIDC
+			oidc.ClientSecret = ""
+			change.oidcChanged = !sameJSON(&oidc, target.OIDC)
+		}
+		if idp.JWT != nil {
+			change.jwtChanged = !sameJSON(idp.JWT, target.JWT)
+		}
+		if !change.configChanged && !change.oidcChanged && !change.jwtChanged {
+			p.add(ObjectTypeIDP, idp.Name, ChangeTypeUnchanged, idp.ID, target.ID, nil)
+			continue
+		}
+		p.add(ObjectTypeIDP, idp.Name, ChangeTypeUpdate, idp.ID, target.ID, func(ctx context.Context, a *applier) (string, error) {
+			return a.changeIDP(ctx, change)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planActions(current, desired []*Action) error {
+	existing := make(map[string]*Action, len(current))
+	for _, action := range current {
+		existing[action.Name] = action
+	}
+	names := make(map[string]bool, len(desired))
+	for _, action := range desired {
+		action := action
+		if action.Name == "" || names[action.Name] {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Ac", "Errors.Org.Transfer.Invalid: action %q", action.Name)
+		}
+		names[action.Name] = true
+		target, ok := existing[action.Name]
+		if !ok {
+			p.add(ObjectTypeAction, action.Name, ChangeTypeCreate, action.ID, "", func(ctx context.Context, a *applier) (string, error) {
+				return a.addAction(ctx, action)
+			})
+			continue
+		}
+		p.ids[objectID{ObjectTypeAction, action.ID}] = target.ID
+		if action.Script == target.Script && action.Timeout == target.Timeout && action.AllowedToFail == target.AllowedToFail {
+			p.add(ObjectTypeAction, action.Name, ChangeTypeUnchanged, action.ID, target.ID, nil)
+			continue
+		}
+		p.add(ObjectTypeAction, action.Name, ChangeTypeUpdate, action.ID, target.ID, func(ctx context.Context, a *applier) (string, error) {
+			return a.changeAction(ctx, target.ID, action)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planProjects(current, desired []*Project) error {
+	existing := make(map[string]*Project, len(current))
+	for _, project := range current {
+		existing[project.Name] = project
+	}
+	names := make(map[string]bool, len(desired))
+	for _, project := range desired {
+		project := project
+		if project.Name == "" || names[project.Name] {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Pr", "Errors.Org.Transfer.Invalid: project %q", project.Name)
+		}
+		names[project.Name] = true
+		//the changes of the project, its roles and apps
+		first := len(p.Changes)
+		target, ok := existing[project.Name]
+		switch {
+		case !ok:
+			target = new(Project)
+			p.add(ObjectTypeProject, project.Name, ChangeTypeCreate, project.ID, "", func(ctx context.Context, a *applier) (string, error) {
+				return a.addProject(ctx, project)
+			})
+		case sameJSON(projectSettings(project), projectSettings(target)):
+			p.ids[objectID{ObjectTypeProject, project.ID}] = target.ID
+			p.add(ObjectTypeProject, project.Name, ChangeTypeUnchanged, project.ID, target.ID, nil)
+		default:
+			p.ids[objectID{ObjectTypeProject, project.ID}] = target.ID
+			p.add(ObjectTypeProject, project.Name, ChangeTypeUpdate, project.ID, target.ID, func(ctx context.Context, a *applier) (string, error) {
+				return a.changeProject(ctx, target.ID, project)
+			})
+		}
+		if err := p.planRoles(project, target); err != nil {
+			return err
+		}
+		if err := p.planApps(project, target); err != nil {
+			return err
+		}
+		for _, change := range p.Changes[first:] {
+			change.projectID = target.ID
+		}
+	}
+	return nil
+}
+
+//projectSettings returns the project without ids, roles and apps
+func projectSettings(project *Project) *Project {
+	settings := *project
+	settings.ID = ""
+	settings.Roles = nil
+	settings.OIDCApps = nil
+	settings.APIApps = nil
+	return &settings
+}
+
+func (p *Plan) planRoles(project, target *Project) error {
+	existing := make(map[string]*Role, len(target.Roles))
+	for _, role := range target.Roles {
+		existing[role.Key] = role
+	}
+	keys := make(map[string]bool, len(project.Roles))
+	for _, role := range project.Roles {
+		role := role
+		if role.Key == "" || keys[role.Key] {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Ro", "Errors.Org.Transfer.Invalid: role %q of project %q", role.Key, project.Name)
+		}
+		keys[role.Key] = true
+		key := project.Name + "/" + role.Key
+		targetRole, ok := existing[role.Key]
+		switch {
+		case !ok:
+			p.add(ObjectTypeProjectRole, key, ChangeTypeCreate, "", "", func(ctx context.Context, a *applier) (string, error) {
+				return "", a.addProjectRole(ctx, project.ID, role)
+			})
+		case *role == *targetRole:
+			p.add(ObjectTypeProjectRole, key, ChangeTypeUnchanged, "", "", nil)
+		default:
+			p.add(ObjectTypeProjectRole, key, ChangeTypeUpdate, "", "", func(ctx context.Context, a *applier) (string, error) {
+				return "", a.changeProjectRole(ctx, project.ID, role)
+			})
+		}
+	}
+	return nil
+}
+
+func (p *Plan) planApps(project, target *Project) error {
+	existingOIDC := make(map[string]*OIDCApp, len(target.OIDCApps))
+	for _, app := range target.OIDCApps {
+		existingOIDC[app.Name] = app
+	}
+	existingAPI := make(map[string]*APIApp, len(target.APIApps))
+	for _, app := range target.APIApps {
+		existingAPI[app.Name] = app
+	}
+	names := make(map[string]bool, len(project.OIDCApps)+len(project.APIApps))
+	for _, app := range project.OIDCApps {
+		app := app
+		if app.Name == "" || names[app.Name] {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Ap", "Errors.Org.Transfer.Invalid: app %q of project %q", app.Name, project.Name)
+		}
+		names[app.Name] = true
+		key := project.Name + "/" + app.Name
+		targetApp, ok := existingOIDC[app.Name]
+		if !ok {
+			p.add(ObjectTypeOIDCApp, key, ChangeTypeCreate, app.ID, "", func(ctx context.Context, a *applier) (string, error) {
+				return a.addOIDCApp(ctx, project.ID, app)
+			})
+			continue
+		}
+		settings, targetSettings := *app, *targetApp
+		settings.ID, targetSettings.ID = "", ""
+		if sameJSON(&settings, &targetSettings) {
+			p.add(ObjectTypeOIDCApp, key, ChangeTypeUnchanged, app.ID, targetApp.ID, nil)
+			continue
+		}
+		p.add(ObjectTypeOIDCApp, key, ChangeTypeUpdate, app.ID, targetApp.ID, func(ctx context.Context, a *applier) (string, error) {
+			return a.changeOIDCApp(ctx, project.ID, targetApp.ID, app)
+		})
+	}
+	for _, app := range project.APIApps {
+		app := app
+		if app.Name == "" || names[app.Name] {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl3Ap", "Errors.Org.Transfer.Invalid: app %q of project %q", app.Name, project.Name)
+		}
+		names[app.Name] = true
+		key := project.Name + "/" + app.Name
+		targetApp, ok := existingAPI[app.Name]
+		if !ok {
+			p.add(ObjectTypeAPIApp, key, ChangeTypeCreate, app.ID, "", func(ctx context.Context, a *applier) (string, error) {
+				return a.addAPIApp(ctx, project.ID, app)
+			})
+			continue
+		}
+		if app.AuthMethodType == targetApp.AuthMethodType {
+			p.add(ObjectTypeAPIApp, key, ChangeTypeUnchanged, app.ID, targetApp.ID, nil)
+			continue
+		}
+		p.add(ObjectTypeAPIApp, key, ChangeTypeUpdate, app.ID, targetApp.ID, func(ctx context.Context, a *applier) (string, error) {
+			return a.changeAPIApp(ctx, project.ID, targetApp.ID, app)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) planPolicies(current, desired *Document) error {
+	if desired.Policies == nil {
+		return nil
+	}
+	existing := current.Policies
+	if existing == nil {
+		existing = new(Policies)
+	}
+	if err := p.planLoginPolicy(current, desired); err != nil {
+		return err
+	}
+	if policy := desired.Policies.PasswordComplexity; policy != nil {
+		exists := existing.PasswordComplexity != nil
+		p.addPolicy(ObjectTypePasswordComplexityPolicy, exists, sameJSON(policy, existing.PasswordComplexity), func(ctx context.Context, a *applier) (string, error) {
+			return "", a.setPasswordComplexityPolicy(ctx, policy, exists)
+		})
+	}
+	if policy := desired.Policies.PasswordAge; policy != nil {
+		exists := existing.PasswordAge != nil
+		p.addPolicy(ObjectTypePasswordAgePolicy, exists, sameJSON(policy, existing.PasswordAge), func(ctx context.Context, a *applier) (string, error) {
+			return "", a.setPasswordAgePolicy(ctx, policy, exists)
+		})
+	}
+	if policy := desired.Policies.Lockout; policy != nil {
+		exists := existing.Lockout != nil
+		p.addPolicy(ObjectTypeLockoutPolicy, exists, sameJSON(policy, existing.Lockout), func(ctx context.Context, a *applier) (string, error) {
+			return "", a.setLockoutPolicy(ctx, policy, exists)
+		})
+	}
+	if policy := desired.Policies.Privacy; policy != nil {
+		exists := existing.Privacy != nil
+		p.addPolicy(ObjectTypePrivacyPolicy, exists, sameJSON(policy, existing.Privacy), func(ctx context.Context, a *applier) (string, error) {
+			return "", a.setPrivacyPolicy(ctx, policy, exists)
+		})
+	}
+	if policy := desired.Policies.Label; policy != nil {
+		exists := existing.Label != nil
+		p.addPolicy(ObjectTypeLabelPolicy, exists, sameJSON(policy, existing.Label), func(ctx context.Context, a *applier) (string, error) {
+			return "", a.setLabelPolicy(ctx, policy, exists)
+		})
+	}
+	return nil
+}
+
+func (p *Plan) addPolicy(objectType ObjectType, exists, unchanged bool, apply func(context.Context, *applier) (string, error)) {
+	switch {
+	case !exists:
+		p.add(objectType, "", ChangeTypeCreate, "", "", apply)
+	case unchanged:
+		p.add(objectType, "", ChangeTypeUnchanged, "", "", nil)
+	default:
+		p.add(objectType, "", ChangeTypeUpdate, "", "", apply)
+	}
+}
+
+//planLoginPolicy compares the settings, the factors and the identity providers of the login policy
+// identity providers are compared by name and only added, links of other identity providers are kept
+func (p *Plan) planLoginPolicy(current, desired *Document) error {
+	policy := desired.Policies.Login
+	if policy == nil {
+		return nil
+	}
+	var existing *LoginPolicy
+	if current.Policies != nil {
+		existing = current.Policies.Login
+	}
+	change := &loginPolicyChange{
+		policy: policy,
+		exists: existing != nil,
+	}
+	if existing == nil {
+		existing = new(LoginPolicy)
+	}
+	change.settingsChanged = !sameJSON(loginPolicySettings(policy), loginPolicySettings(existing))
+	change.addSecondFactors, change.removeSecondFactors = diffSecondFactors(existing.SecondFactors, policy.SecondFactors)
+	change.addMultiFactors, change.removeMultiFactors = diffMultiFactors(existing.MultiFactors, policy.MultiFactors)
+
+	currentIDPs := idpNamesByID(current.IDPs)
+	linked := make(map[string]bool, len(existing.IDPIDs))
+	for _, id := range existing.IDPIDs {
+		linked[currentIDPs[id]] = true
+	}
+	desiredIDPs := idpNamesByID(desired.IDPs)
+	for _, id := range policy.IDPIDs {
+		name, ok := desiredIDPs[id]
+		if !ok {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Lo", "Errors.Org.Transfer.ReferenceNotFound: idp %s", id)
+		}
+		if !linked[name] {
+			change.addIDPIDs = append(change.addIDPIDs, id)
+		}
+	}
+
+	unchanged := !change.settingsChanged &&
+		len(change.addSecondFactors)+len(change.removeSecondFactors)+len(change.addMultiFactors)+len(change.removeMultiFactors)+len(change.addIDPIDs) == 0
+	p.addPolicy(ObjectTypeLoginPolicy, change.exists, unchanged, func(ctx context.Context, a *applier) (string, error) {
+		return "", a.setLoginPolicy(ctx, change)
+	})
+	return nil
+}
+
+//loginPolicySettings returns the login policy without factors and identity providers
+func loginPolicySettings(policy *LoginPolicy) *LoginPolicy {
+	settings := *policy
+	settings.SecondFactors = nil
+	settings.MultiFactors = nil
+	settings.IDPIDs = nil
+	return &settings
+}
+
+func diffSecondFactors(current, desired []domain.SecondFactorType) (add, remove []domain.SecondFactorType) {
+	existing := make(map[domain.SecondFactorType]bool, len(current))
+	for _, factor := range current {
+		existing[factor] = true
+	}
+	for _, factor := range desired {
+		if !existing[factor] {
+			add = append(add, factor)
+		}
+		delete(existing, factor)
+	}
+	for factor := range existing {
+		remove = append(remove, factor)
+	}
+	sort.Slice(remove, func(i, j int) bool { return remove[i] < remove[j] })
+	return add, remove
+}
+
+func diffMultiFactors(current, desired []domain.MultiFactorType) (add, remove []domain.MultiFactorType) {
+	existing := make(map[domain.MultiFactorType]bool, len(current))
+	for _, factor := range current {
+		existing[factor] = true
+	}
+	for _, factor := range desired {
+		if !existing[factor] {
+			add = append(add, factor)
+		}
+		delete(existing, factor)
+	}
+	for factor := range existing {
+		remove = append(remove, factor)
+	}
+	sort.Slice(remove, func(i, j int) bool { return remove[i] < remove[j] })
+	return add, remove
+}
+
+func idpNamesByID(idps []*IDP) map[string]string {
+	names := make(map[string]string, len(idps))
+	for _, idp := range idps {
+		names[idp.ID] = idp.Name
+	}
+	return names
+}
+
+//planFlows compares the actions of each trigger by their names
+func (p *Plan) planFlows(current, desired *Document) error {
+	currentActions := actionNamesByID(current.Actions)
+	existing := make(map[string][]string)
+	for _, flow := range current.Flows {
+		for _, trigger := range flow.Triggers {
+			names := make([]string, len(trigger.ActionIDs))
+			for i, id := range trigger.ActionIDs {
+				names[i] = currentActions[id]
+			}
+			existing[triggerKey(flow.Type, trigger.Type)] = names
+		}
+	}
+	desiredActions := actionNamesByID(desired.Actions)
+	for _, flow := range desired.Flows {
+		for _, trigger := range flow.Triggers {
+			trigger := trigger
+			flowType := flow.Type
+			key := triggerKey(flowType, trigger.Type)
+			if !flowType.Valid() || !flowType.HasTrigger(trigger.Type) {
+				return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Fl", "Errors.Org.Transfer.Invalid: flow %s", key)
+			}
+			names := make([]string, len(trigger.ActionIDs))
+			for i, id := range trigger.ActionIDs {
+				name, ok := desiredActions[id]
+				if !ok {
+					return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl3Fl", "Errors.Org.Transfer.ReferenceNotFound: action %s", id)
+				}
+				names[i] = name
+			}
+			current, ok := existing[key]
+			switch {
+			case !ok:
+				p.add(ObjectTypeFlow, key, ChangeTypeCreate, "", "", func(ctx context.Context, a *applier) (string, error) {
+					return "", a.setTriggerActions(ctx, flowType, trigger)
+				})
+			case sameJSON(names, current):
+				p.add(ObjectTypeFlow, key, ChangeTypeUnchanged, "", "", nil)
+			default:
+				p.add(ObjectTypeFlow, key, ChangeTypeUpdate, "", "", func(ctx context.Context, a *applier) (string, error) {
+					return "", a.setTriggerActions(ctx, flowType, trigger)
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func actionNamesByID(actions []*Action) map[string]string {
+	names := make(map[string]string, len(actions))
+	for _, action := range actions {
+		names[action.ID] = action.Name
+	}
+	return names
+}
+
+func triggerKey(flowType domain.FlowType, triggerType domain.TriggerType) string {
+	return fmt.Sprintf("%d/%d", flowType, triggerType)
+}
+
+func (p *Plan) planCustomTexts(current, desired []*CustomTexts) error {
+	existing := make(map[string]*CustomTexts, len(current))
+	for _, texts := range current {
+		existing[texts.Template+"/"+texts.Language] = texts
+	}
+	for _, texts := range desired {
+		texts := texts
+		key := texts.Template + "/" + texts.Language
+		if !isTextTemplate(texts.Template) || texts.Language == "" || len(texts.Texts) == 0 {
+			return caos_errs.ThrowInvalidArgumentf(nil, "TRANS-Pl2Tx", "Errors.Org.Transfer.Invalid: custom texts %s", key)
+		}
+		target, ok := existing[key]
+		switch {
+		case !ok:
+			p.add(ObjectTypeCustomTexts, key, ChangeTypeCreate, "", "", func(ctx context.Context, a *applier) (string, error) {
+				return "", a.setCustomTexts(ctx, texts)
+			})
+		case sameJSON(texts.Texts, target.Texts):
+			p.add(ObjectTypeCustomTexts, key, ChangeTypeUnchanged, "", "", nil)
+		default:
+			p.add(ObjectTypeCustomTexts, key, ChangeTypeUpdate, "", "", func(ctx context.Context, a *applier) (string, error) {
+				return "", a.setCustomTexts(ctx, texts)
+			})
+		}
+	}
+	return nil
+}
+
+func isTextTemplate(template string) bool {
+	for _, textTemplate := range textTemplates {
+		if template == textTemplate {
+			return true
+		}
+	}
+	return false
+}
+
+//sameJSON compares the json representation of the objects
+// so that empty and missing lists are treated equally
+func sameJSON(a, b interface{}) bool {
+	first, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	second, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(first) == string(second)
+}
@@ -0,0 +1,371 @@
+package transfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+func TestNewPlan(t *testing.T) {
+	type args struct {
+		current *Document
+		desired *Document
+	}
+	type res struct {
+		changes []*Change
+		ids     map[objectID]string
+		err     func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "empty organisation, everything created",
+			args: args{
+				current: &Document{Policies: &Policies{}},
+				desired: &Document{
+					IDPs:    []*IDP{{ID: "idp1", Name: "google", OIDC: &OIDCIDP{ClientID: "client", Issuer: "https://accounts.google.com"}}},
+					Actions: []*Action{{ID: "action1", Name: "log", Script: "function log() {}"}},
+					Projects: []*Project{
+						{
+							ID:       "project1",
+							Name:     "shop",
+							Roles:    []*Role{{Key: "admin"}},
+							OIDCApps: []*OIDCApp{{ID: "app1", Name: "web"}},
+							APIApps:  []*APIApp{{ID: "app2", Name: "backend"}},
+						},
+					},
+					Policies: &Policies{
+						Login:       &LoginPolicy{AllowUsernamePassword: true, IDPIDs: []string{"idp1"}},
+						PasswordAge: &PasswordAgePolicy{MaxAgeDays: 90},
+					},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePostAuthentication, ActionIDs: []string{"action1"}}},
+						},
+					},
+					CustomTexts: []*CustomTexts{
+						{Template: domain.InitCodeMessageType, Language: "de", Texts: map[string]string{domain.MessageTitle: "Willkommen"}},
+					},
+				},
+			},
+			res: res{
+				changes: []*Change{
+					{ObjectType: ObjectTypeIDP, Key: "google", Type: ChangeTypeCreate, SourceID: "idp1"},
+					{ObjectType: ObjectTypeAction, Key: "log", Type: ChangeTypeCreate, SourceID: "action1"},
+					{ObjectType: ObjectTypeProject, Key: "shop", Type: ChangeTypeCreate, SourceID: "project1"},
+					{ObjectType: ObjectTypeProjectRole, Key: "shop/admin", Type: ChangeTypeCreate},
+					{ObjectType: ObjectTypeOIDCApp, Key: "shop/web", Type: ChangeTypeCreate, SourceID: "app1"},
+					{ObjectType: ObjectTypeAPIApp, Key: "shop/backend", Type: ChangeTypeCreate, SourceID: "app2"},
+					{ObjectType: ObjectTypeLoginPolicy, Type: ChangeTypeCreate},
+					{ObjectType: ObjectTypePasswordAgePolicy, Type: ChangeTypeCreate},
+					{ObjectType: ObjectTypeFlow, Key: "1/1", Type: ChangeTypeCreate},
+					{ObjectType: ObjectTypeCustomTexts, Key: "InitCode/de", Type: ChangeTypeCreate},
+				},
+				ids: map[objectID]string{},
+			},
+		},
+		{
+			name: "same configuration, nothing changed and ids remapped",
+			args: args{
+				current: &Document{
+					IDPs:     []*IDP{{ID: "target-idp", Name: "google", OIDC: &OIDCIDP{ClientID: "client", Scopes: []string{}}}},
+					Actions:  []*Action{{ID: "target-action", Name: "log", Timeout: Duration(time.Second)}},
+					Projects: []*Project{{ID: "target-project", Name: "shop", Roles: []*Role{{Key: "admin"}}, OIDCApps: []*OIDCApp{{ID: "target-app", Name: "web"}}}},
+					Policies: &Policies{Login: &LoginPolicy{IDPIDs: []string{"target-idp"}, SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP}}},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePreCreation, ActionIDs: []string{"target-action"}}},
+						},
+					},
+				},
+				desired: &Document{
+					IDPs:     []*IDP{{ID: "idp1", Name: "google", OIDC: &OIDCIDP{ClientID: "client", ClientSecret: "secret"}}},
+					Actions:  []*Action{{ID: "action1", Name: "log", Timeout: Duration(time.Second)}},
+					Projects: []*Project{{ID: "project1", Name: "shop", Roles: []*Role{{Key: "admin"}}, OIDCApps: []*OIDCApp{{ID: "app1", Name: "web"}}}},
+					Policies: &Policies{Login: &LoginPolicy{IDPIDs: []string{"idp1"}, SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP}}},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePreCreation, ActionIDs: []string{"action1"}}},
+						},
+					},
+				},
+			},
+			res: res{
+				changes: []*Change{
+					{ObjectType: ObjectTypeIDP, Key: "google", Type: ChangeTypeUnchanged, SourceID: "idp1", TargetID: "target-idp"},
+					{ObjectType: ObjectTypeAction, Key: "log", Type: ChangeTypeUnchanged, SourceID: "action1", TargetID: "target-action"},
+					{ObjectType: ObjectTypeProject, Key: "shop", Type: ChangeTypeUnchanged, SourceID: "project1", TargetID: "target-project", projectID: "target-project"},
+					{ObjectType: ObjectTypeProjectRole, Key: "shop/admin", Type: ChangeTypeUnchanged, projectID: "target-project"},
+					{ObjectType: ObjectTypeOIDCApp, Key: "shop/web", Type: ChangeTypeUnchanged, SourceID: "app1", TargetID: "target-app", projectID: "target-project"},
+					{ObjectType: ObjectTypeLoginPolicy, Type: ChangeTypeUnchanged},
+					{ObjectType: ObjectTypeFlow, Key: "1/2", Type: ChangeTypeUnchanged},
+				},
+				ids: map[objectID]string{
+					{ObjectTypeIDP, "idp1"}:         "target-idp",
+					{ObjectTypeAction, "action1"}:   "target-action",
+					{ObjectTypeProject, "project1"}: "target-project",
+				},
+			},
+		},
+		{
+			name: "changed configuration, updated",
+			args: args{
+				current: &Document{
+					Actions:  []*Action{{ID: "target-action", Name: "log", Script: "old"}, {ID: "target-other", Name: "other"}},
+					Projects: []*Project{{ID: "target-project", Name: "shop", Roles: []*Role{{Key: "admin", DisplayName: "Admin"}}}},
+					Policies: &Policies{Login: &LoginPolicy{SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP}}},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePreCreation, ActionIDs: []string{"target-other"}}},
+						},
+					},
+					CustomTexts: []*CustomTexts{
+						{Template: domain.LoginCustomText, Language: "en", Texts: map[string]string{"Login.Title": "Hello"}},
+					},
+				},
+				desired: &Document{
+					Actions:  []*Action{{ID: "action1", Name: "log", Script: "new"}},
+					Projects: []*Project{{ID: "project1", Name: "shop", ProjectRoleCheck: true, Roles: []*Role{{Key: "admin", DisplayName: "Administrator"}}}},
+					Policies: &Policies{Login: &LoginPolicy{}},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePreCreation, ActionIDs: []string{"action1"}}},
+						},
+					},
+					CustomTexts: []*CustomTexts{
+						{Template: domain.LoginCustomText, Language: "en", Texts: map[string]string{"Login.Title": "Welcome"}},
+					},
+				},
+			},
+			res: res{
+				changes: []*Change{
+					{ObjectType: ObjectTypeAction, Key: "log", Type: ChangeTypeUpdate, SourceID: "action1", TargetID: "target-action"},
+					{ObjectType: ObjectTypeProject, Key: "shop", Type: ChangeTypeUpdate, SourceID: "project1", TargetID: "target-project", projectID: "target-project"},
+					{ObjectType: ObjectTypeProjectRole, Key: "shop/admin", Type: ChangeTypeUpdate, projectID: "target-project"},
+					{ObjectType: ObjectTypeLoginPolicy, Type: ChangeTypeUpdate},
+					{ObjectType: ObjectTypeFlow, Key: "1/2", Type: ChangeTypeUpdate},
+					{ObjectType: ObjectTypeCustomTexts, Key: "Login/en", Type: ChangeTypeUpdate},
+				},
+				ids: map[objectID]string{
+					{ObjectTypeAction, "action1"}:   "target-action",
+					{ObjectTypeProject, "project1"}: "target-project",
+				},
+			},
+		},
+		{
+			name: "document without ids, names used as ids",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					Actions: []*Action{{Name: "log"}},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePostCreation, ActionIDs: []string{"log"}}},
+						},
+					},
+				},
+			},
+			res: res{
+				changes: []*Change{
+					{ObjectType: ObjectTypeAction, Key: "log", Type: ChangeTypeCreate, SourceID: "log"},
+					{ObjectType: ObjectTypeFlow, Key: "1/3", Type: ChangeTypeCreate},
+				},
+				ids: map[objectID]string{},
+			},
+		},
+		{
+			name: "same ids of different object types, ids remapped per type",
+			args: args{
+				current: &Document{
+					IDPs:     []*IDP{{ID: "target-idp", Name: "shop", OIDC: &OIDCIDP{ClientID: "client"}}},
+					Projects: []*Project{{ID: "target-project", Name: "shop"}},
+				},
+				desired: &Document{
+					IDPs:     []*IDP{{Name: "shop", OIDC: &OIDCIDP{ClientID: "client"}}},
+					Projects: []*Project{{Name: "shop"}},
+				},
+			},
+			res: res{
+				changes: []*Change{
+					{ObjectType: ObjectTypeIDP, Key: "shop", Type: ChangeTypeUnchanged, SourceID: "shop", TargetID: "target-idp"},
+					{ObjectType: ObjectTypeProject, Key: "shop", Type: ChangeTypeUnchanged, SourceID: "shop", TargetID: "target-project", projectID: "target-project"},
+				},
+				ids: map[objectID]string{
+					{ObjectTypeIDP, "shop"}:     "target-idp",
+					{ObjectTypeProject, "shop"}: "target-project",
+				},
+			},
+		},
+		{
+			name: "unknown idp referenced, invalid argument error",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					Policies: &Policies{Login: &LoginPolicy{IDPIDs: []string{"idp1"}}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown action referenced, invalid argument error",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePostCreation, ActionIDs: []string{"action1"}}},
+						},
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "duplicate project, invalid argument error",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					Projects: []*Project{{ID: "project1", Name: "shop"}, {ID: "project2", Name: "shop"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp type changed, precondition failed error",
+			args: args{
+				current: &Document{
+					IDPs: []*IDP{{ID: "target-idp", Name: "google", JWT: &JWTIDP{Issuer: "issuer"}}},
+				},
+				desired: &Document{
+					IDPs: []*IDP{{ID: "idp1", Name: "google", OIDC: &OIDCIDP{Issuer: "issuer"}}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPlan(tt.args.current, tt.args.desired)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				for _, change := range got.Changes {
+					change.apply = nil
+				}
+				assert.Equal(t, tt.res.changes, got.Changes)
+				assert.Equal(t, tt.res.ids, got.ids)
+			}
+		})
+	}
+}
+
+func TestPlan_checkPermissions(t *testing.T) {
+	type args struct {
+		current     *Document
+		desired     *Document
+		permissions []string
+	}
+	tests := []struct {
+		name string
+		args args
+		err  func(error) bool
+	}{
+		{
+			name: "all sections allowed",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					IDPs:     []*IDP{{Name: "google", OIDC: &OIDCIDP{ClientID: "client"}}},
+					Projects: []*Project{{Name: "shop", Roles: []*Role{{Key: "admin"}}}},
+					Policies: &Policies{PasswordAge: &PasswordAgePolicy{MaxAgeDays: 90}},
+				},
+				permissions: []string{"org.idp.write", "project.write", "project.role.write", "policy.write"},
+			},
+		},
+		{
+			name: "section not allowed, permission denied error",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					IDPs:     []*IDP{{Name: "google", OIDC: &OIDCIDP{ClientID: "client"}}},
+					Projects: []*Project{{Name: "shop"}},
+				},
+				permissions: []string{"project.write"},
+			},
+			err: caos_errs.IsPermissionDenied,
+		},
+		{
+			name: "unchanged section, no permission needed",
+			args: args{
+				current: &Document{IDPs: []*IDP{{ID: "target-idp", Name: "google", OIDC: &OIDCIDP{ClientID: "client"}}}},
+				desired: &Document{
+					IDPs:     []*IDP{{Name: "google", OIDC: &OIDCIDP{ClientID: "client"}}},
+					Projects: []*Project{{Name: "shop"}},
+				},
+				permissions: []string{"project.write"},
+			},
+		},
+		{
+			name: "existing project, permission of project",
+			args: args{
+				current: &Document{Projects: []*Project{{ID: "target-project", Name: "shop"}}},
+				desired: &Document{
+					Projects: []*Project{{Name: "shop", Roles: []*Role{{Key: "admin"}}}},
+				},
+				permissions: []string{"project.role.write:target-project"},
+			},
+		},
+		{
+			name: "new project, permission of other project, permission denied error",
+			args: args{
+				current: &Document{},
+				desired: &Document{
+					Projects: []*Project{{Name: "shop"}},
+				},
+				permissions: []string{"project.write:target-project"},
+			},
+			err: caos_errs.IsPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := NewPlan(tt.args.current, tt.args.desired)
+			if err != nil {
+				t.Fatalf("unable to create plan: %v", err)
+			}
+			err = plan.checkPermissions(userPermissions(tt.args.permissions))
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package transfer
+
+import (
+	"strconv"
+
+	"github.com/caos/zitadel/internal/domain"
+)
+
+//ObjectTypeLoginPolicyIDP is the link of an identity provider to the login policy
+// it's only used for removals, links are added with the login policy
+const ObjectTypeLoginPolicyIDP ObjectType = "login_policy_idp"
+
+//Removal is an object of the organisation which is not part of the desired document
+// only the fields needed to remove the object are set
+type Removal struct {
+	ObjectType ObjectType
+	Key        string
+	//ID of the object in the organisation, for project roles the role key
+	ID          string
+	ProjectID   string
+	FlowType    domain.FlowType
+	TriggerType domain.TriggerType
+	Template    string
+	Language    string
+}
+
+//Prune returns the objects of the current configuration which are not part of the desired document
+// objects are matched by the same names as on import,
+// dependent objects are removed first (e.g. flows before actions, roles before projects)
+// and children of removed objects are not listed (e.g. roles of removed projects)
+// the project of zitadel (iamProjectID) and its apps (e.g. console) are never removed
+func Prune(current, desired *Document, iamProjectID string) []*Removal {
+	removals := make([]*Removal, 0)
+	removals = append(removals, pruneLoginPolicyIDPs(current, desired)...)
+	removals = append(removals, pruneFlows(current.Flows, desired.Flows)...)
+	removals = append(removals, pruneCustomTexts(current.CustomTexts, desired.CustomTexts)...)
+	removals = append(removals, prunePolicies(current.Policies, desired.Policies)...)
+	removals = append(removals, pruneProjects(current.Projects, desired.Projects, iamProjectID)...)
+
+	desiredActions := make(map[string]bool, len(desired.Actions))
+	for _, action := range desired.Actions {
+		desiredActions[action.Name] = true
+	}
+	for _, action := range current.Actions {
+		if !desiredActions[action.Name] {
+			removals = append(removals, &Removal{ObjectType: ObjectTypeAction, Key: action.Name, ID: action.ID})
+		}
+	}
+	desiredIDPs := make(map[string]bool, len(desired.IDPs))
+	for _, idp := range desired.IDPs {
+		desiredIDPs[idp.Name] = true
+	}
+	for _, idp := range current.IDPs {
+		if !desiredIDPs[idp.Name] {
+			removals = append(removals, &Removal{ObjectType: ObjectTypeIDP, Key: idp.Name, ID: idp.ID})
+		}
+	}
+	return removals
+}
+
+//pruneLoginPolicyIDPs returns the links of identity providers which are kept but not linked in the desired login policy
+// links of removed identity providers are removed with the identity provider
+func pruneLoginPolicyIDPs(current, desired *Document) []*Removal {
+	if current.Policies == nil || current.Policies.Login == nil || desired.Policies == nil || desired.Policies.Login == nil {
+		return nil
+	}
+	desiredIDPs := make(map[string]bool, len(desired.IDPs))
+	for _, idp := range desired.IDPs {
+		desiredIDPs[idp.Name] = true
+	}
+	desiredNames := idpNamesByID(desired.IDPs)
+	linked := make(map[string]bool, len(desired.Policies.Login.IDPIDs))
+	for _, id := range desired.Policies.Login.IDPIDs {
+		name, ok := desiredNames[id]
+		if !ok {
+			name = id
+		}
+		linked[name] = true
+	}
+	currentNames := idpNamesByID(current.IDPs)
+	removals := make([]*Removal, 0)
+	for _, id := range current.Policies.Login.IDPIDs {
+		name := currentNames[id]
+		if desiredIDPs[name] && !linked[name] {
+			removals = append(removals, &Removal{ObjectType: ObjectTypeLoginPolicyIDP, Key: name, ID: id})
+		}
+	}
+	return removals
+}
+
+//pruneFlows clears flows which are not part of the document and the triggers which are not part of a desired flow
+func pruneFlows(current, desired []*Flow) []*Removal {
+	desiredTriggers := make(map[domain.FlowType]map[domain.TriggerType]bool, len(desired))
+	for _, flow := range desired {
+		desiredTriggers[flow.Type] = make(map[domain.TriggerType]bool, len(flow.Triggers))
+		for _, trigger := range flow.Triggers {
+			desiredTriggers[flow.Type][trigger.Type] = true
+		}
+	}
+	removals := make([]*Removal, 0)
+	for _, flow := range current {
+		triggers, ok := desiredTriggers[flow.Type]
+		if !ok {
+			removals = append(removals, &Removal{ObjectType: ObjectTypeFlow, Key: strconv.Itoa(int(flow.Type)), FlowType: flow.Type})
+			continue
+		}
+		for _, trigger := range flow.Triggers {
+			if !triggers[trigger.Type] {
+				removals = append(removals, &Removal{ObjectType: ObjectTypeFlow, Key: triggerKey(flow.Type, trigger.Type), FlowType: flow.Type, TriggerType: trigger.Type})
+			}
+		}
+	}
+	return removals
+}
+
+func pruneCustomTexts(current, desired []*CustomTexts) []*Removal {
+	desiredTexts := make(map[string]bool, len(desired))
+	for _, texts := range desired {
+		desiredTexts[texts.Template+"/"+texts.Language] = true
+	}
+	removals := make([]*Removal, 0)
+	for _, texts := range current {
+		key := texts.Template + "/" + texts.Language
+		if !desiredTexts[key] {
+			removals = append(removals, &Removal{ObjectType: ObjectTypeCustomTexts, Key: key, Template: texts.Template, Language: texts.Language})
+		}
+	}
+	return removals
+}
+
+//prunePolicies resets the policies which are not part of the document to the default policies
+func prunePolicies(current, desired *Policies) []*Removal {
+	if current == nil {
+		return nil
+	}
+	if desired == nil {
+		desired = new(Policies)
+	}
+	removals := make([]*Removal, 0)
+	if current.Login != nil && desired.Login == nil {
+		removals = append(removals, &Removal{ObjectType: ObjectTypeLoginPolicy})
+	}
+	if current.PasswordComplexity != nil && desired.PasswordComplexity == nil {
+		removals = append(removals, &Removal{ObjectType: ObjectTypePasswordComplexityPolicy})
+	}
+	if current.PasswordAge != nil && desired.PasswordAge == nil {
+		removals = append(removals, &Removal{ObjectType: ObjectTypePasswordAgePolicy})
+	}
+	if current.Lockout != nil && desired.Lockout == nil {
+		removals = append(removals, &Removal{ObjectType: ObjectTypeLockoutPolicy})
+	}
+	if current.Privacy != nil && desired.Privacy == nil {
+		removals = append(removals, &Removal{ObjectType: ObjectTypePrivacyPolicy})
+	}
+	if current.Label != nil && desired.Label == nil {
+		removals = append(removals, &Removal{ObjectType: ObjectTypeLabelPolicy})
+	}
+	return removals
+}
+
+//pruneProjects returns the projects, roles and apps which are not part of the document
+// the iam project is skipped, zitadel can't be used without it and its console app
+func pruneProjects(current, desired []*Project, iamProjectID string) []*Removal {
+	desiredProjects := make(map[string]*Project, len(desired))
+	for _, project := range desired {
+		desiredProjects[project.Name] = project
+	}
+	removals := make([]*Removal, 0)
+	projects := make([]*Removal, 0)
+	for _, project := range current {
+		if project.ID == iamProjectID {
+			continue
+		}
+		desiredProject, ok := desiredProjects[project.Name]
+		if !ok {
+			projects = append(projects, &Removal{ObjectType: ObjectTypeProject, Key: project.Name, ID: project.ID})
+			continue
+		}
+		apps := make(map[string]bool, len(desiredProject.OIDCApps)+len(desiredProject.APIApps))
+		for _, app := range desiredProject.OIDCApps {
+			apps[app.Name] = true
+		}
+		for _, app := range desiredProject.APIApps {
+			apps[app.Name] = true
+		}
+		for _, app := range project.OIDCApps {
+			if !apps[app.Name] {
+				removals = append(removals, &Removal{ObjectType: ObjectTypeOIDCApp, Key: project.Name + "/" + app.Name, ID: app.ID, ProjectID: project.ID})
+			}
+		}
+		for _, app := range project.APIApps {
+			if !apps[app.Name] {
+				removals = append(removals, &Removal{ObjectType: ObjectTypeAPIApp, Key: project.Name + "/" + app.Name, ID: app.ID, ProjectID: project.ID})
+			}
+		}
+		roles := make(map[string]bool, len(desiredProject.Roles))
+		for _, role := range desiredProject.Roles {
+			roles[role.Key] = true
+		}
+		for _, role := range project.Roles {
+			if !roles[role.Key] {
+				removals = append(removals, &Removal{ObjectType: ObjectTypeProjectRole, Key: project.Name + "/" + role.Key, ID: role.Key, ProjectID: project.ID})
+			}
+		}
+	}
+	return append(removals, projects...)
+}
@@ -0,0 +1,167 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caos/zitadel/internal/domain"
+)
+
+func TestPrune(t *testing.T) {
+	type args struct {
+		current      *Document
+		desired      *Document
+		iamProjectID string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*Removal
+	}{
+		{
+			name: "same configuration, nothing removed",
+			args: args{
+				current: &Document{
+					IDPs:     []*IDP{{ID: "target-idp", Name: "google"}},
+					Projects: []*Project{{ID: "target-project", Name: "shop", Roles: []*Role{{Key: "admin"}}}},
+					Policies: &Policies{Login: &LoginPolicy{IDPIDs: []string{"target-idp"}}},
+				},
+				desired: &Document{
+					IDPs:     []*IDP{{ID: "idp1", Name: "google"}},
+					Projects: []*Project{{ID: "project1", Name: "shop", Roles: []*Role{{Key: "admin"}}}},
+					Policies: &Policies{Login: &LoginPolicy{IDPIDs: []string{"idp1"}}},
+				},
+			},
+			want: []*Removal{},
+		},
+		{
+			name: "empty document, everything removed",
+			args: args{
+				current: &Document{
+					IDPs:     []*IDP{{ID: "target-idp", Name: "google"}},
+					Actions:  []*Action{{ID: "target-action", Name: "log"}},
+					Projects: []*Project{{ID: "target-project", Name: "shop", Roles: []*Role{{Key: "admin"}}}},
+					Policies: &Policies{
+						Login:   &LoginPolicy{IDPIDs: []string{"target-idp"}},
+						Privacy: &PrivacyPolicy{TOSLink: "https://zitadel.ch/tos"},
+					},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePreCreation, ActionIDs: []string{"target-action"}}},
+						},
+					},
+					CustomTexts: []*CustomTexts{{Template: domain.LoginCustomText, Language: "de"}},
+				},
+				desired: &Document{},
+			},
+			want: []*Removal{
+				{ObjectType: ObjectTypeFlow, Key: "1", FlowType: domain.FlowTypeExternalAuthentication},
+				{ObjectType: ObjectTypeCustomTexts, Key: "Login/de", Template: domain.LoginCustomText, Language: "de"},
+				{ObjectType: ObjectTypeLoginPolicy},
+				{ObjectType: ObjectTypePrivacyPolicy},
+				{ObjectType: ObjectTypeProject, Key: "shop", ID: "target-project"},
+				{ObjectType: ObjectTypeAction, Key: "log", ID: "target-action"},
+				{ObjectType: ObjectTypeIDP, Key: "google", ID: "target-idp"},
+			},
+		},
+		{
+			name: "children of kept objects removed",
+			args: args{
+				current: &Document{
+					IDPs: []*IDP{{ID: "target-idp", Name: "google"}},
+					Projects: []*Project{
+						{
+							ID:       "target-project",
+							Name:     "shop",
+							Roles:    []*Role{{Key: "admin"}, {Key: "viewer"}},
+							OIDCApps: []*OIDCApp{{ID: "target-web", Name: "web"}},
+							APIApps:  []*APIApp{{ID: "target-backend", Name: "backend"}},
+						},
+					},
+					Policies: &Policies{Login: &LoginPolicy{IDPIDs: []string{"target-idp"}}},
+					Flows: []*Flow{
+						{
+							Type: domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{
+								{Type: domain.TriggerTypePreCreation},
+								{Type: domain.TriggerTypePostCreation},
+							},
+						},
+					},
+				},
+				desired: &Document{
+					IDPs: []*IDP{{ID: "idp1", Name: "google"}},
+					Projects: []*Project{
+						{
+							ID:      "project1",
+							Name:    "shop",
+							Roles:   []*Role{{Key: "admin"}},
+							APIApps: []*APIApp{{ID: "backend", Name: "backend"}},
+						},
+					},
+					Policies: &Policies{Login: &LoginPolicy{}},
+					Flows: []*Flow{
+						{
+							Type:     domain.FlowTypeExternalAuthentication,
+							Triggers: []*Trigger{{Type: domain.TriggerTypePreCreation}},
+						},
+					},
+				},
+			},
+			want: []*Removal{
+				{ObjectType: ObjectTypeLoginPolicyIDP, Key: "google", ID: "target-idp"},
+				{ObjectType: ObjectTypeFlow, Key: "1/3", FlowType: domain.FlowTypeExternalAuthentication, TriggerType: domain.TriggerTypePostCreation},
+				{ObjectType: ObjectTypeOIDCApp, Key: "shop/web", ID: "target-web", ProjectID: "target-project"},
+				{ObjectType: ObjectTypeProjectRole, Key: "shop/viewer", ID: "viewer", ProjectID: "target-project"},
+			},
+		},
+		{
+			name: "iam project and console app kept",
+			args: args{
+				current: &Document{
+					Projects: []*Project{
+						{
+							ID:       "target-iam-project",
+							Name:     "Zitadel",
+							OIDCApps: []*OIDCApp{{ID: "target-console", Name: "Management-Console"}},
+						},
+						{ID: "target-project", Name: "shop"},
+					},
+				},
+				desired:      &Document{},
+				iamProjectID: "target-iam-project",
+			},
+			want: []*Removal{
+				{ObjectType: ObjectTypeProject, Key: "shop", ID: "target-project"},
+			},
+		},
+		{
+			name: "apps of iam project not part of the document kept",
+			args: args{
+				current: &Document{
+					Projects: []*Project{
+						{
+							ID:       "target-iam-project",
+							Name:     "Zitadel",
+							Roles:    []*Role{{Key: "admin"}},
+							OIDCApps: []*OIDCApp{{ID: "target-console", Name: "Management-Console"}},
+						},
+					},
+				},
+				desired: &Document{
+					Projects: []*Project{{Name: "Zitadel"}},
+				},
+				iamProjectID: "target-iam-project",
+			},
+			want: []*Removal{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Prune(tt.args.current, tt.args.desired, tt.args.iamProjectID)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package query
+
+import (
+	"context"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+)
+
+//AuthorizationCheck asks if the user may perform the permission on the resource
+// the permission is either a role key of the project granted by user grants
+// or a zitadel permission granted by the roles of the memberships of the user
+type AuthorizationCheck struct {
+	UserID     string
+	Permission string
+	Resource   AuthorizationResource
+}
+
+//AuthorizationResource is the object the permission is checked on
+// exactly one of the ids must be set
+type AuthorizationResource struct {
+	OrgID          string
+	ProjectID      string
+	ProjectGrantID string
+}
+
+type AuthorizationCheckResult struct {
+	Allowed bool
+	//Matches are the grants and memberships which allow the permission
+	// they are only set if the check is explained
+	Matches []*AuthorizationMatch
+}
+
+type AuthorizationMatch struct {
+	Source AuthorizationMatchSource
+	//ID is the id of the user grant or of the object the user is member of
+	ID string
+	//Role is the role key of the user grant or the member role which grants the permission
+	Role string
+}
+
+type AuthorizationMatchSource int32
+
+const (
+	AuthorizationMatchSourceUnspecified AuthorizationMatchSource = iota
+	AuthorizationMatchSourceUserGrant
+	AuthorizationMatchSourceIAMMember
+	AuthorizationMatchSourceOrgMember
+	AuthorizationMatchSourceProjectMember
+	AuthorizationMatchSourceProjectGrantMember
+)
+
+//authorizationTarget is the resolved resource of a check
+type authorizationTarget struct {
+	//callerOrgID is the organisation which checks the authorization
+	callerOrgID  string
+	orgIDs       []string
+	project      *Project
+	projectGrant *ProjectGrant
+}
+
+//authorizationSubject are the state, the grants and the memberships of the user of a check
+type authorizationSubject struct {
+	userState   domain.UserState
+	userGrants  []*UserGrant
+	memberships []*Membership
+}
+
+//CheckAuthorizations answers the checks on behalf of the organisation
+// only resources owned by or granted to the organisation can be checked
+// and only the memberships of the organisation, its projects and project grants are evaluated
+// the results are in the order of the checks
+func (q *Queries) CheckAuthorizations(ctx context.Context, orgID string, checks []*AuthorizationCheck, explain bool) ([]*AuthorizationCheckResult, error) {
+	targets := make(map[AuthorizationResource]*authorizationTarget)
+	subjects := make(map[string]*authorizationSubject)
+	projectGrants := make(map[string]*ProjectGrant)
+	rolePermissions := q.zitadelRolePermissions()
+	customRolesLoaded := false
+
+	results := make([]*AuthorizationCheckResult, len(checks))
+	for i, check := range checks {
+		if check.UserID == "" || check.Permission == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "QUERY-Az2cI", "Errors.AuthorizationCheck.Invalid")
+		}
+		target, ok := targets[check.Resource]
+		if !ok {
+			var err error
+			target, err = q.authorizationTarget(ctx, orgID, check.Resource)
+			if err != nil {
+				return nil, err
+			}
+			target.callerOrgID = orgID
+			targets[check.Resource] = target
+		}
+		subject, ok := subjects[check.UserID]
+		if !ok {
+			var err error
+			subject, err = q.authorizationSubject(ctx, orgID, check.UserID)
+			if err != nil {
+				return nil, err
+			}
+			subjects[check.UserID] = subject
+		}
+		for _, grant := range subject.userGrants {
+			if grant.GrantID == "" || !target.containsUserGrant(grant) {
+				continue
+			}
+			if _, ok := projectGrants[grant.GrantID]; ok {
+				continue
+			}
+			projectGrant, err := q.ProjectGrantByID(ctx, grant.GrantID)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			projectGrants[grant.GrantID] = projectGrant
+		}
+		if !customRolesLoaded && hasUnknownMemberRoles(rolePermissions, subject.memberships) {
+			if err := q.addCustomRolePermissions(ctx, rolePermissions); err != nil {
+				return nil, err
+			}
+			customRolesLoaded = true
+		}
+		matches := authorizationMatches(check.Permission, target, subject, projectGrants, rolePermissions)
+		results[i] = &AuthorizationCheckResult{Allowed: len(matches) > 0}
+		if explain {
+			results[i].Matches = matches
+		}
+	}
+	return results, nil
+}
+
+func (q *Queries) authorizationTarget(ctx context.Context, orgID string, resource AuthorizationResource) (*authorizationTarget, error) {
+	switch {
+	case resource.OrgID != "" && resource.ProjectID == "" && resource.ProjectGrantID == "":
+		if resource.OrgID != orgID {
+			return nil, errors.ThrowNotFound(nil, "QUERY-Az2oN", "Errors.AuthorizationCheck.ResourceNotFound")
+		}
+		return &authorizationTarget{orgIDs: []string{resource.OrgID}}, nil
+	case resource.ProjectID != "" && resource.OrgID == "" && resource.ProjectGrantID == "":
+		project, err := q.ProjectByID(ctx, resource.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if project.ResourceOwner != orgID {
+			return nil, errors.ThrowNotFound(nil, "QUERY-Az2pN", "Errors.AuthorizationCheck.ResourceNotFound")
+		}
+		return &authorizationTarget{orgIDs: []string{project.ResourceOwner}, project: project}, nil
+	case resource.ProjectGrantID != "" && resource.OrgID == "" && resource.ProjectID == "":
+		grant, err := q.ProjectGrantByID(ctx, resource.ProjectGrantID)
+		if err != nil {
+			return nil, err
+		}
+		if grant.ResourceOwner != orgID && grant.GrantedOrgID != orgID {
+			return nil, errors.ThrowNotFound(nil, "QUERY-Az2gN", "Errors.AuthorizationCheck.ResourceNotFound")
+		}
+		project, err := q.ProjectByID(ctx, grant.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		return &authorizationTarget{
+			orgIDs:       []string{grant.ResourceOwner, grant.GrantedOrgID},
+			project:      project,
+			projectGrant: grant,
+		}, nil
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Az2rI", "Errors.AuthorizationCheck.ResourceInvalid")
+	}
+}
+
+//authorizationSubject loads the state, the user grants and the memberships of the user in the organisation
+// removed users are returned with the deleted state
+func (q *Queries) authorizationSubject(ctx context.Context, orgID, userID string) (*authorizationSubject, error) {
+	user, err := q.GetUserByID(ctx, userID)
+	if errors.IsNotFound(err) {
+		return &authorizationSubject{userState: domain.UserStateDeleted}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	grantUserQuery, err := NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := q.UserGrants(ctx, &UserGrantsQueries{Queries: []SearchQuery{grantUserQuery}})
+	if err != nil {
+		return nil, err
+	}
+	membershipUserQuery, err := NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := q.Memberships(ctx, &MembershipSearchQuery{Queries: []SearchQuery{membershipUserQuery}})
+	if err != nil {
+		return nil, err
+	}
+	return &authorizationSubject{
+		userState:   user.State,
+		userGrants:  grants.UserGrants,
+		memberships: orgMemberships(orgID, memberships.Memberships),
+	}, nil
+}
+
+//orgMemberships omits the memberships of the iam and of other organisations
+// memberships of project grants are kept because the project grant is checked against the target
+func orgMemberships(orgID string, memberships []*Membership) []*Membership {
+	filtered := make([]*Membership, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership.IAM != nil || (membership.ProjectGrant == nil && membership.ResourceOwner != orgID) {
+			continue
+		}
+		filtered = append(filtered, membership)
+	}
+	return filtered
+}
+
+//addCustomRolePermissions adds the permissions of the custom roles
+// the roles of the authz config take precedence
+func (q *Queries) addCustomRolePermissions(ctx context.Context, rolePermissions map[string][]string) error {
+	customRoles, err := q.SearchCustomRoles(ctx, &CustomRoleSearchQueries{})
+	if err != nil {
+		return err
+	}
+	for _, role := range customRoles.CustomRoles {
+		if _, ok := rolePermissions[role.Role]; !ok {
+			rolePermissions[role.Role] = role.Permissions
+		}
+	}
+	return nil
+}
+
+func (q *Queries) zitadelRolePermissions() map[string][]string {
+	permissions := make(map[string][]string, len(q.zitadelRoles))
+	for _, mapping := range q.zitadelRoles {
+		permissions[mapping.Role] = append(permissions[mapping.Role], mapping.Permissions...)
+	}
+	return permissions
+}
+
+func hasUnknownMemberRoles(rolePermissions map[string][]string, memberships []*Membership) bool {
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			if _, ok := rolePermissions[role]; !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//containsUserGrant checks if the user grant applies to the target
+// user grants of a project apply regardless of the project grant they were given through
+func (t *authorizationTarget) containsUserGrant(grant *UserGrant) bool {
+	if t.project == nil || grant.ProjectID != t.project.ID {
+		return false
+	}
+	return t.projectGrant == nil || grant.GrantID == t.projectGrant.GrantID
+}
+
+//containsMembership checks if the membership applies to the target
+// only memberships of the organisation which checks, its projects and project grants apply
+func (t *authorizationTarget) containsMembership(membership *Membership) (AuthorizationMatchSource, string, bool) {
+	switch {
+	case membership.Org != nil:
+		if membership.Org.OrgID == t.callerOrgID && containsString(t.orgIDs, t.callerOrgID) {
+			return AuthorizationMatchSourceOrgMember, t.callerOrgID, true
+		}
+	case membership.Project != nil:
+		if t.project != nil && t.project.ResourceOwner == t.callerOrgID && membership.Project.ProjectID == t.project.ID {
+			return AuthorizationMatchSourceProjectMember, t.project.ID, true
+		}
+	case membership.ProjectGrant != nil:
+		if t.projectGrant != nil && membership.ProjectGrant.GrantID == t.projectGrant.GrantID {
+			return AuthorizationMatchSourceProjectGrantMember, t.projectGrant.GrantID, true
+		}
+	}
+	return AuthorizationMatchSourceUnspecified, "", false
+}
+
+//authorizationMatches returns the user grants and memberships of the subject which allow the permission on the target
+// nothing matches if the user isn't active (e.g. locked, deactivated or removed),
+// user grants only match if the project and the project grant they were given through are active
+// and the project grant still contains the role
+func authorizationMatches(permission string, target *authorizationTarget, subject *authorizationSubject, projectGrants map[string]*ProjectGrant, rolePermissions map[string][]string) []*AuthorizationMatch {
+	matches := make([]*AuthorizationMatch, 0)
+	if !isAuthorizableUserState(subject.userState) {
+		return matches
+	}
+	if target.project != nil && target.project.State == domain.ProjectStateActive {
+		for _, grant := range subject.userGrants {
+			if grant.State != domain.UserGrantStateActive || !target.containsUserGrant(grant) || !containsString(grant.Roles, permission) {
+				continue
+			}
+			if grant.GrantID != "" {
+				projectGrant := projectGrants[grant.GrantID]
+				if projectGrant == nil ||
+					projectGrant.State != domain.ProjectGrantStateActive ||
+					!containsString(projectGrant.GrantedRoleKeys, permission) {
+					continue
+				}
+			}
+			matches = append(matches, &AuthorizationMatch{
+				Source: AuthorizationMatchSourceUserGrant,
+				ID:     grant.ID,
+				Role:   permission,
+			})
+		}
+	}
+	for _, membership := range subject.memberships {
+		source, id, ok := target.containsMembership(membership)
+		if !ok {
+			continue
+		}
+		for _, role := range membership.Roles {
+			if !containsString(rolePermissions[role], permission) {
+				continue
+			}
+			matches = append(matches, &AuthorizationMatch{
+				Source: source,
+				ID:     id,
+				Role:   role,
+			})
+		}
+	}
+	return matches
+}
+
+//isAuthorizableUserState checks if the user can use its grants and memberships
+// initial users are active users which haven't finished their initialisation yet
+func isAuthorizableUserState(state domain.UserState) bool {
+	return state == domain.UserStateActive || state == domain.UserStateInitial
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
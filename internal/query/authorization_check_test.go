@@ -0,0 +1,371 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/caos/zitadel/internal/domain"
+)
+
+func Test_authorizationMatches(t *testing.T) {
+	project := &Project{ID: "project1", ResourceOwner: "org1", State: domain.ProjectStateActive}
+	projectGrant := &ProjectGrant{
+		GrantID:         "grant1",
+		ProjectID:       "project1",
+		ResourceOwner:   "org1",
+		GrantedOrgID:    "org2",
+		State:           domain.ProjectGrantStateActive,
+		GrantedRoleKeys: []string{"reader"},
+	}
+	rolePermissions := map[string][]string{
+		"IAM_OWNER":             {"project.read", "project.write"},
+		"ORG_OWNER":             {"project.read"},
+		"ORG_PROJECT_CREATOR":   {"project.create"},
+		"PROJECT_OWNER":         {"project.read"},
+		"PROJECT_GRANT_OWNER":   {"project.grant.read"},
+		"PROJECT_GRANT_AUDITOR": {"project.grant.read"},
+	}
+	type args struct {
+		permission    string
+		target        *authorizationTarget
+		subject       *authorizationSubject
+		projectGrants map[string]*ProjectGrant
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*AuthorizationMatch
+	}{
+		{
+			name: "user grant with role, match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{
+				{Source: AuthorizationMatchSourceUserGrant, ID: "usergrant1", Role: "reader"},
+			},
+		},
+		{
+			name: "user grant without role, no match",
+			args: args{
+				permission: "writer",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "inactive user grant, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateInactive},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "user grant of inactive project, no match",
+			args: args{
+				permission: "reader",
+				target: &authorizationTarget{
+					orgIDs:  []string{"org1"},
+					project: &Project{ID: "project1", ResourceOwner: "org1", State: domain.ProjectStateInactive},
+				},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "user grant of other project, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project2", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "user grant through project grant, match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1", "org2"}, project: project, projectGrant: projectGrant},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", GrantID: "grant1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+				projectGrants: map[string]*ProjectGrant{"grant1": projectGrant},
+			},
+			want: []*AuthorizationMatch{
+				{Source: AuthorizationMatchSourceUserGrant, ID: "usergrant1", Role: "reader"},
+			},
+		},
+		{
+			name: "user grant through project grant without role, no match",
+			args: args{
+				permission: "writer",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", GrantID: "grant1", Roles: []string{"writer"}, State: domain.UserGrantStateActive},
+					},
+				},
+				projectGrants: map[string]*ProjectGrant{"grant1": projectGrant},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "user grant through inactive project grant, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", GrantID: "grant1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+				projectGrants: map[string]*ProjectGrant{
+					"grant1": {GrantID: "grant1", State: domain.ProjectGrantStateInactive, GrantedRoleKeys: []string{"reader"}},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "user grant of other project grant, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1", "org2"}, project: project, projectGrant: projectGrant},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "memberships, match",
+			args: args{
+				permission: "project.read",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					memberships: []*Membership{
+						{Roles: []string{"ORG_OWNER", "ORG_PROJECT_CREATOR"}, Org: &OrgMembership{OrgID: "org1"}},
+						{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{
+				{Source: AuthorizationMatchSourceOrgMember, ID: "org1", Role: "ORG_OWNER"},
+				{Source: AuthorizationMatchSourceProjectMember, ID: "project1", Role: "PROJECT_OWNER"},
+			},
+		},
+		{
+			name: "iam membership, no match",
+			args: args{
+				permission: "project.read",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					memberships: []*Membership{
+						{Roles: []string{"IAM_OWNER"}, IAM: &IAMMembership{IAMID: "iam"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "memberships of owner organisation checked by granted organisation, no match",
+			args: args{
+				permission: "project.read",
+				target:     &authorizationTarget{callerOrgID: "org2", orgIDs: []string{"org1", "org2"}, project: project, projectGrant: projectGrant},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					memberships: []*Membership{
+						{Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org1"}},
+						{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "memberships of other resources, no match",
+			args: args{
+				permission: "project.read",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					memberships: []*Membership{
+						{Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org2"}},
+						{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project2"}},
+						{Roles: []string{"PROJECT_GRANT_OWNER"}, ProjectGrant: &ProjectGrantMembership{ProjectID: "project1", GrantID: "grant1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "project grant membership, match",
+			args: args{
+				permission: "project.grant.read",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1", "org2"}, project: project, projectGrant: projectGrant},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					memberships: []*Membership{
+						{Roles: []string{"PROJECT_GRANT_AUDITOR"}, ProjectGrant: &ProjectGrantMembership{ProjectID: "project1", GrantID: "grant1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{
+				{Source: AuthorizationMatchSourceProjectGrantMember, ID: "grant1", Role: "PROJECT_GRANT_AUDITOR"},
+			},
+		},
+		{
+			name: "initial user, match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateInitial,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+					memberships: []*Membership{
+						{Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{
+				{Source: AuthorizationMatchSourceUserGrant, ID: "usergrant1", Role: "reader"},
+			},
+		},
+		{
+			name: "locked user, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateLocked,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+					memberships: []*Membership{
+						{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "inactive user, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateInactive,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+					memberships: []*Membership{
+						{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "removed user, no match",
+			args: args{
+				permission: "reader",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}, project: project},
+				subject: &authorizationSubject{
+					userState: domain.UserStateDeleted,
+					userGrants: []*UserGrant{
+						{ID: "usergrant1", ProjectID: "project1", Roles: []string{"reader"}, State: domain.UserGrantStateActive},
+					},
+					memberships: []*Membership{
+						{Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+		{
+			name: "membership with unknown role, no match",
+			args: args{
+				permission: "project.read",
+				target:     &authorizationTarget{callerOrgID: "org1", orgIDs: []string{"org1"}},
+				subject: &authorizationSubject{
+					userState: domain.UserStateActive,
+					memberships: []*Membership{
+						{Roles: []string{"ORG_REMOVED"}, Org: &OrgMembership{OrgID: "org1"}},
+					},
+				},
+			},
+			want: []*AuthorizationMatch{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := authorizationMatches(tt.args.permission, tt.args.target, tt.args.subject, tt.args.projectGrants, rolePermissions)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("authorizationMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_orgMemberships(t *testing.T) {
+	iamMembership := &Membership{ResourceOwner: "iam", Roles: []string{"IAM_OWNER"}, IAM: &IAMMembership{IAMID: "iam"}}
+	orgMembership := &Membership{ResourceOwner: "org1", Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org1"}}
+	otherOrgMembership := &Membership{ResourceOwner: "org2", Roles: []string{"ORG_OWNER"}, Org: &OrgMembership{OrgID: "org2"}}
+	projectMembership := &Membership{ResourceOwner: "org1", Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project1"}}
+	otherProjectMembership := &Membership{ResourceOwner: "org2", Roles: []string{"PROJECT_OWNER"}, Project: &ProjectMembership{ProjectID: "project2"}}
+	grantMembership := &Membership{ResourceOwner: "org2", Roles: []string{"PROJECT_GRANT_OWNER"}, ProjectGrant: &ProjectGrantMembership{ProjectID: "project2", GrantID: "grant1"}}
+
+	got := orgMemberships("org1", []*Membership{
+		iamMembership,
+		orgMembership,
+		otherOrgMembership,
+		projectMembership,
+		otherProjectMembership,
+		grantMembership,
+	})
+	want := []*Membership{orgMembership, projectMembership, grantMembership}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orgMemberships() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query/projection"
+)
+
+var (
+	customRolesTable = table{
+		name: projection.CustomRoleProjectionTable,
+	}
+	CustomRoleColumnRole = Column{
+		name:  projection.CustomRoleColumnRole,
+		table: customRolesTable,
+	}
+	CustomRoleColumnCreationDate = Column{
+		name:  projection.CustomRoleColumnCreationDate,
+		table: customRolesTable,
+	}
+	CustomRoleColumnChangeDate = Column{
+		name:  projection.CustomRoleColumnChangeDate,
+		table: customRolesTable,
+	}
+	CustomRoleColumnResourceOwner = Column{
+		name:  projection.CustomRoleColumnResourceOwner,
+		table: customRolesTable,
+	}
+	CustomRoleColumnSequence = Column{
+		name:  projection.CustomRoleColumnSequence,
+		table: customRolesTable,
+	}
+	CustomRoleColumnScope = Column{
+		name:  projection.CustomRoleColumnScope,
+		table: customRolesTable,
+	}
+	CustomRoleColumnPermissions = Column{
+		name:  projection.CustomRoleColumnPermissions,
+		table: customRolesTable,
+	}
+)
+
+type CustomRoles struct {
+	SearchResponse
+	CustomRoles []*CustomRole
+}
+
+type CustomRole struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Role        string
+	Scope       domain.CustomRoleScope
+	Permissions []string
+}
+
+type CustomRoleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+//SearchCustomRoles returns the roles defined at runtime
+// the roles of the authz config aren't part of the result
+func (q *Queries) SearchCustomRoles(ctx context.Context, queries *CustomRoleSearchQueries) (roles *CustomRoles, err error) {
+	query, scan := prepareCustomRolesQuery()
+	stmt, args, err := queries.toQuery(query).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Cr2sI", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Cr3sI", "Errors.Internal")
+	}
+	roles, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	roles.LatestSequence, err = q.latestSequence(ctx, customRolesTable)
+	return roles, err
+}
+
+func NewCustomRoleRoleSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnRole, value, method)
+}
+
+func NewCustomRoleScopeSearchQuery(value domain.CustomRoleScope) (SearchQuery, error) {
+	return NewNumberQuery(CustomRoleColumnScope, value, NumberEquals)
+}
+
+func (q *CustomRoleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareCustomRolesQuery() (sq.SelectBuilder, func(*sql.Rows) (*CustomRoles, error)) {
+	return sq.Select(
+			CustomRoleColumnCreationDate.identifier(),
+			CustomRoleColumnChangeDate.identifier(),
+			CustomRoleColumnResourceOwner.identifier(),
+			CustomRoleColumnSequence.identifier(),
+			CustomRoleColumnRole.identifier(),
+			CustomRoleColumnScope.identifier(),
+			CustomRoleColumnPermissions.identifier(),
+			countColumn.identifier()).
+			From(customRolesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*CustomRoles, error) {
+			roles := make([]*CustomRole, 0)
+			var count uint64
+			for rows.Next() {
+				role := new(CustomRole)
+				permissions := pq.StringArray{}
+				err := rows.Scan(
+					&role.CreationDate,
+					&role.ChangeDate,
+					&role.ResourceOwner,
+					&role.Sequence,
+					&role.Role,
+					&role.Scope,
+					&permissions,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				role.Permissions = permissions
+				roles = append(roles, role)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Cr2cR", "Errors.Query.CloseRows")
+			}
+
+			return &CustomRoles{
+				CustomRoles: roles,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
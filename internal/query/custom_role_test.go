@@ -0,0 +1,139 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/caos/zitadel/internal/domain"
+)
+
+var (
+	customRolesQuery = regexp.QuoteMeta(`SELECT zitadel.projections.custom_roles.creation_date,` +
+		` zitadel.projections.custom_roles.change_date,` +
+		` zitadel.projections.custom_roles.resource_owner,` +
+		` zitadel.projections.custom_roles.sequence,` +
+		` zitadel.projections.custom_roles.role,` +
+		` zitadel.projections.custom_roles.scope,` +
+		` zitadel.projections.custom_roles.permissions,` +
+		` COUNT(*) OVER ()` +
+		` FROM zitadel.projections.custom_roles`)
+	customRolesColumns = []string{
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"role",
+		"scope",
+		"permissions",
+		"count",
+	}
+)
+
+func Test_CustomRolePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareCustomRolesQuery no result",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					customRolesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &CustomRoles{CustomRoles: []*CustomRole{}},
+		},
+		{
+			name:    "prepareCustomRolesQuery multiple result",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					customRolesQuery,
+					customRolesColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211111),
+							"IAM_AUDITOR",
+							domain.CustomRoleScopeIAM,
+							pq.StringArray{"iam.read", "org.read"},
+						},
+						{
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211111),
+							"ORG_SUPPORT",
+							domain.CustomRoleScopeOrg,
+							pq.StringArray{"user.read"},
+						},
+					},
+				),
+			},
+			object: &CustomRoles{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				CustomRoles: []*CustomRole{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211111,
+						Role:          "IAM_AUDITOR",
+						Scope:         domain.CustomRoleScopeIAM,
+						Permissions:   []string{"iam.read", "org.read"},
+					},
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211111,
+						Role:          "ORG_SUPPORT",
+						Scope:         domain.CustomRoleScopeOrg,
+						Permissions:   []string{"user.read"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareCustomRolesQuery sql err",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					customRolesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
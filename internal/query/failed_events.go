@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	errs "errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -16,6 +17,10 @@ const (
 	failedEventsColumnFailedSequence = "failed_sequence"
 	failedEventsColumnFailureCount   = "failure_count"
 	failedEventsColumnError          = "error"
+	failedEventsColumnLastFailed     = "last_failed"
+	failedEventsColumnSkipped        = "skipped"
+	failedEventsColumnSkipReason     = "skip_reason"
+	failedEventsColumnRetryRequested = "retry_requested"
 )
 
 var (
@@ -38,6 +43,78 @@ var (
 		name:  failedEventsColumnError,
 		table: failedEventsTable,
 	}
+	FailedEventsColumnLastFailed = Column{
+		name:  failedEventsColumnLastFailed,
+		table: failedEventsTable,
+	}
+	FailedEventsColumnSkipped = Column{
+		name:  failedEventsColumnSkipped,
+		table: failedEventsTable,
+	}
+	FailedEventsColumnSkipReason = Column{
+		name:  failedEventsColumnSkipReason,
+		table: failedEventsTable,
+	}
+)
+
+var (
+	failedEventErrorsTable = table{
+		name: projection.FailedEventErrorsTable,
+	}
+	FailedEventErrorsColumnProjectionName = Column{
+		name:  "projection_name",
+		table: failedEventErrorsTable,
+	}
+	FailedEventErrorsColumnFailedSequence = Column{
+		name:  "failed_sequence",
+		table: failedEventErrorsTable,
+	}
+	FailedEventErrorsColumnFailureCount = Column{
+		name:  "failure_count",
+		table: failedEventErrorsTable,
+	}
+	FailedEventErrorsColumnError = Column{
+		name:  "error",
+		table: failedEventErrorsTable,
+	}
+	FailedEventErrorsColumnCreationDate = Column{
+		name:  "creation_date",
+		table: failedEventErrorsTable,
+	}
+)
+
+var (
+	eventsTable = table{
+		name: "eventstore.events",
+	}
+	EventsColumnSequence = Column{
+		name:  "event_sequence",
+		table: eventsTable,
+	}
+	EventsColumnType = Column{
+		name:  "event_type",
+		table: eventsTable,
+	}
+	EventsColumnAggregateType = Column{
+		name:  "aggregate_type",
+		table: eventsTable,
+	}
+	EventsColumnAggregateID = Column{
+		name:  "aggregate_id",
+		table: eventsTable,
+	}
+	EventsColumnResourceOwner = Column{
+		name:  "resource_owner",
+		table: eventsTable,
+	}
+	EventsColumnCreationDate = Column{
+		name:  "creation_date",
+		table: eventsTable,
+	}
+	EventsColumnData = Column{
+		name:  "event_data",
+		table: eventsTable,
+	}
 )
 
 type FailedEvents struct {
@@ -50,6 +127,30 @@ type FailedEvent struct {
 	FailedSequence uint64
 	FailureCount   uint64
 	Error          string
+	LastFailed     time.Time
+	Skipped        bool
+	SkipReason     string
+
+	//Event and Errors are only set by FailedEventByID
+	Event  *FailedEventPayload
+	Errors []*FailedEventError
+}
+
+//FailedEventPayload represents the event the projection failed on
+type FailedEventPayload struct {
+	Type          string
+	AggregateType string
+	AggregateID   string
+	ResourceOwner string
+	CreationDate  time.Time
+	Data          []byte
+}
+
+//FailedEventError is an entry in the error history of a failed event
+type FailedEventError struct {
+	FailureCount uint64
+	Error        string
+	CreationDate time.Time
 }
 
 type FailedEventSearchQueries struct {
@@ -71,8 +172,94 @@ func (q *Queries) SearchFailedEvents(ctx context.Context, queries *FailedEventSe
 	return scan(rows)
 }
 
+func (q *Queries) FailedEventByID(ctx context.Context, projectionName string, sequence uint64) (failedEvent *FailedEvent, err error) {
+	query, scan := prepareFailedEventQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		FailedEventsColumnProjectionName.identifier(): projectionName,
+		FailedEventsColumnFailedSequence.identifier(): sequence,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Vf3gQ", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	failedEvent, err = scan(row)
+	if err != nil {
+		return nil, err
+	}
+
+	errorsQuery, scanErrors := prepareFailedEventErrorsQuery()
+	stmt, args, err = errorsQuery.Where(sq.Eq{
+		FailedEventErrorsColumnProjectionName.identifier(): projectionName,
+		FailedEventErrorsColumnFailedSequence.identifier(): sequence,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-2Hb0p", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-L0eXm", "Errors.Internal")
+	}
+	failedEvent.Errors, err = scanErrors(rows)
+	if err != nil {
+		return nil, err
+	}
+	return failedEvent, nil
+}
+
 func (q *Queries) RemoveFailedEvent(ctx context.Context, projectionName string, sequence uint64) (err error) {
-	stmt, args, err := sq.Delete(projection.FailedEventsTable).
+	tx, err := q.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-m3Xbn", "Errors.RemoveFailed")
+	}
+	for _, table := range []string{projection.FailedEventsTable, projection.FailedEventErrorsTable} {
+		stmt, args, err := sq.Delete(table).
+			Where(sq.Eq{
+				failedEventsColumnProjectionName: projectionName,
+				failedEventsColumnFailedSequence: sequence,
+			}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "QUERY-DGgh3", "Errors.RemoveFailed")
+		}
+		_, err = tx.Exec(stmt, args...)
+		if err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "QUERY-0kbFF", "Errors.RemoveFailed")
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.ThrowInternal(err, "QUERY-1sFmA", "Errors.RemoveFailed")
+	}
+	return nil
+}
+
+//SkipFailedEvent marks the failed event as permanently skipped
+// the projection continues with the next event as soon as it fails again
+func (q *Queries) SkipFailedEvent(ctx context.Context, projectionName string, sequence uint64, reason string) (err error) {
+	return q.updateFailedEvent(ctx, projectionName, sequence, map[string]interface{}{
+		failedEventsColumnSkipped:    true,
+		failedEventsColumnSkipReason: reason,
+	})
+}
+
+//RetryFailedEvent resets the failure count of the failed event
+// skipped events are executed again on the next run of the projection
+func (q *Queries) RetryFailedEvent(ctx context.Context, projectionName string, sequence uint64) (err error) {
+	return q.updateFailedEvent(ctx, projectionName, sequence, map[string]interface{}{
+		failedEventsColumnFailureCount:   0,
+		failedEventsColumnRetryRequested: sq.Expr(failedEventsColumnSkipped),
+		failedEventsColumnSkipped:        false,
+		failedEventsColumnSkipReason:     nil,
+	})
+}
+
+func (q *Queries) updateFailedEvent(ctx context.Context, projectionName string, sequence uint64, values map[string]interface{}) error {
+	stmt, args, err := sq.Update(projection.FailedEventsTable).
+		SetMap(values).
 		Where(sq.Eq{
 			failedEventsColumnProjectionName: projectionName,
 			failedEventsColumnFailedSequence: sequence,
@@ -80,11 +267,14 @@ func (q *Queries) RemoveFailedEvent(ctx context.Context, projectionName string,
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
-		return errors.ThrowInternal(err, "QUERY-DGgh3", "Errors.RemoveFailed")
+		return errors.ThrowInternal(err, "QUERY-Tq2Ks", "Errors.Query.SQLStatement")
 	}
-	_, err = q.client.Exec(stmt, args...)
+	res, err := q.client.ExecContext(ctx, stmt, args...)
 	if err != nil {
-		return errors.ThrowInternal(err, "QUERY-0kbFF", "Errors.RemoveFailed")
+		return errors.ThrowInternal(err, "QUERY-Gk2Rr", "Errors.Internal")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return errors.ThrowNotFound(nil, "QUERY-7WmPa", "Errors.FailedEvents.NotFound")
 	}
 	return nil
 }
@@ -115,15 +305,45 @@ func prepareFailedEventQuery() (sq.SelectBuilder, func(*sql.Row) (*FailedEvent,
 			FailedEventsColumnProjectionName.identifier(),
 			FailedEventsColumnFailedSequence.identifier(),
 			FailedEventsColumnFailureCount.identifier(),
-			FailedEventsColumnError.identifier()).
-			From(failedEventsTable.identifier()).PlaceholderFormat(sq.Dollar),
+			FailedEventsColumnError.identifier(),
+			FailedEventsColumnLastFailed.identifier(),
+			FailedEventsColumnSkipped.identifier(),
+			FailedEventsColumnSkipReason.identifier(),
+			EventsColumnType.identifier(),
+			EventsColumnAggregateType.identifier(),
+			EventsColumnAggregateID.identifier(),
+			EventsColumnResourceOwner.identifier(),
+			EventsColumnCreationDate.identifier(),
+			EventsColumnData.identifier()).
+			From(failedEventsTable.identifier()).
+			LeftJoin(join(EventsColumnSequence, FailedEventsColumnFailedSequence)).
+			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*FailedEvent, error) {
 			p := new(FailedEvent)
+			var (
+				lastFailed        sql.NullTime
+				skipReason        sql.NullString
+				eventType         sql.NullString
+				aggregateType     sql.NullString
+				aggregateID       sql.NullString
+				resourceOwner     sql.NullString
+				eventCreationDate sql.NullTime
+				eventData         []byte
+			)
 			err := row.Scan(
 				&p.ProjectionName,
 				&p.FailedSequence,
 				&p.FailureCount,
 				&p.Error,
+				&lastFailed,
+				&p.Skipped,
+				&skipReason,
+				&eventType,
+				&aggregateType,
+				&aggregateID,
+				&resourceOwner,
+				&eventCreationDate,
+				&eventData,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -131,6 +351,18 @@ func prepareFailedEventQuery() (sq.SelectBuilder, func(*sql.Row) (*FailedEvent,
 				}
 				return nil, errors.ThrowInternal(err, "QUERY-0oJf3", "Errors.Internal")
 			}
+			p.LastFailed = lastFailed.Time
+			p.SkipReason = skipReason.String
+			if eventType.Valid {
+				p.Event = &FailedEventPayload{
+					Type:          eventType.String,
+					AggregateType: aggregateType.String,
+					AggregateID:   aggregateID.String,
+					ResourceOwner: resourceOwner.String,
+					CreationDate:  eventCreationDate.Time,
+					Data:          eventData,
+				}
+			}
 			return p, nil
 		}
 }
@@ -141,6 +373,9 @@ func prepareFailedEventsQuery() (sq.SelectBuilder, func(*sql.Rows) (*FailedEvent
 			FailedEventsColumnFailedSequence.identifier(),
 			FailedEventsColumnFailureCount.identifier(),
 			FailedEventsColumnError.identifier(),
+			FailedEventsColumnLastFailed.identifier(),
+			FailedEventsColumnSkipped.identifier(),
+			FailedEventsColumnSkipReason.identifier(),
 			countColumn.identifier()).
 			From(failedEventsTable.identifier()).PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*FailedEvents, error) {
@@ -148,16 +383,25 @@ func prepareFailedEventsQuery() (sq.SelectBuilder, func(*sql.Rows) (*FailedEvent
 			var count uint64
 			for rows.Next() {
 				failedEvent := new(FailedEvent)
+				var (
+					lastFailed sql.NullTime
+					skipReason sql.NullString
+				)
 				err := rows.Scan(
 					&failedEvent.ProjectionName,
 					&failedEvent.FailedSequence,
 					&failedEvent.FailureCount,
 					&failedEvent.Error,
+					&lastFailed,
+					&failedEvent.Skipped,
+					&skipReason,
 					&count,
 				)
 				if err != nil {
 					return nil, err
 				}
+				failedEvent.LastFailed = lastFailed.Time
+				failedEvent.SkipReason = skipReason.String
 				failedEvents = append(failedEvents, failedEvent)
 			}
 
@@ -173,3 +417,34 @@ func prepareFailedEventsQuery() (sq.SelectBuilder, func(*sql.Rows) (*FailedEvent
 			}, nil
 		}
 }
+
+func prepareFailedEventErrorsQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*FailedEventError, error)) {
+	return sq.Select(
+			FailedEventErrorsColumnFailureCount.identifier(),
+			FailedEventErrorsColumnError.identifier(),
+			FailedEventErrorsColumnCreationDate.identifier()).
+			From(failedEventErrorsTable.identifier()).
+			OrderBy(FailedEventErrorsColumnCreationDate.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*FailedEventError, error) {
+			failures := make([]*FailedEventError, 0)
+			for rows.Next() {
+				failure := new(FailedEventError)
+				err := rows.Scan(
+					&failure.FailureCount,
+					&failure.Error,
+					&failure.CreationDate,
+				)
+				if err != nil {
+					return nil, err
+				}
+				failures = append(failures, failure)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Rj4ds", "Errors.Query.CloseRows")
+			}
+
+			return failures, nil
+		}
+}
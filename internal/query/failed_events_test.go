@@ -7,6 +7,41 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+
+	errs "github.com/caos/zitadel/internal/errors"
+)
+
+var (
+	prepareFailedEventStmt = regexp.QuoteMeta(`SELECT projections.failed_events.projection_name,` +
+		` projections.failed_events.failed_sequence,` +
+		` projections.failed_events.failure_count,` +
+		` projections.failed_events.error,` +
+		` projections.failed_events.last_failed,` +
+		` projections.failed_events.skipped,` +
+		` projections.failed_events.skip_reason,` +
+		` eventstore.events.event_type,` +
+		` eventstore.events.aggregate_type,` +
+		` eventstore.events.aggregate_id,` +
+		` eventstore.events.resource_owner,` +
+		` eventstore.events.creation_date,` +
+		` eventstore.events.event_data` +
+		` FROM projections.failed_events` +
+		` LEFT JOIN eventstore.events ON projections.failed_events.failed_sequence = eventstore.events.event_sequence`)
+	prepareFailedEventCols = []string{
+		"projection_name",
+		"failed_sequence",
+		"failure_count",
+		"error",
+		"last_failed",
+		"skipped",
+		"skip_reason",
+		"event_type",
+		"aggregate_type",
+		"aggregate_id",
+		"resource_owner",
+		"creation_date",
+		"event_data",
+	}
 )
 
 func Test_FailedEventsPrepares(t *testing.T) {
@@ -29,6 +64,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						` projections.failed_events.failed_sequence,`+
 						` projections.failed_events.failure_count,`+
 						` projections.failed_events.error,`+
+						` projections.failed_events.last_failed,`+
+						` projections.failed_events.skipped,`+
+						` projections.failed_events.skip_reason,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.failed_events`),
 					nil,
@@ -46,6 +84,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						` projections.failed_events.failed_sequence,`+
 						` projections.failed_events.failure_count,`+
 						` projections.failed_events.error,`+
+						` projections.failed_events.last_failed,`+
+						` projections.failed_events.skipped,`+
+						` projections.failed_events.skip_reason,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.failed_events`),
 					[]string{
@@ -53,6 +94,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						"failed_sequence",
 						"failure_count",
 						"error",
+						"last_failed",
+						"skipped",
+						"skip_reason",
 						"count",
 					},
 					[][]driver.Value{
@@ -61,6 +105,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 							uint64(20211108),
 							uint64(2),
 							"error",
+							testNow,
+							true,
+							"max failure count reached",
 						},
 					},
 				),
@@ -75,6 +122,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						FailedSequence: 20211108,
 						FailureCount:   2,
 						Error:          "error",
+						LastFailed:     testNow,
+						Skipped:        true,
+						SkipReason:     "max failure count reached",
 					},
 				},
 			},
@@ -88,6 +138,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						` projections.failed_events.failed_sequence,`+
 						` projections.failed_events.failure_count,`+
 						` projections.failed_events.error,`+
+						` projections.failed_events.last_failed,`+
+						` projections.failed_events.skipped,`+
+						` projections.failed_events.skip_reason,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.failed_events`),
 					[]string{
@@ -95,6 +148,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						"failed_sequence",
 						"failure_count",
 						"error",
+						"last_failed",
+						"skipped",
+						"skip_reason",
 						"count",
 					},
 					[][]driver.Value{
@@ -103,12 +159,18 @@ func Test_FailedEventsPrepares(t *testing.T) {
 							uint64(20211108),
 							2,
 							"error",
+							testNow,
+							false,
+							nil,
 						},
 						{
 							"projection-name-2",
 							uint64(20211108),
 							2,
 							"error",
+							testNow,
+							false,
+							nil,
 						},
 					},
 				),
@@ -123,12 +185,14 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						FailedSequence: 20211108,
 						FailureCount:   2,
 						Error:          "error",
+						LastFailed:     testNow,
 					},
 					{
 						ProjectionName: "projection-name-2",
 						FailedSequence: 20211108,
 						FailureCount:   2,
 						Error:          "error",
+						LastFailed:     testNow,
 					},
 				},
 			},
@@ -142,6 +206,9 @@ func Test_FailedEventsPrepares(t *testing.T) {
 						` projections.failed_events.failed_sequence,`+
 						` projections.failed_events.failure_count,`+
 						` projections.failed_events.error,`+
+						` projections.failed_events.last_failed,`+
+						` projections.failed_events.skipped,`+
+						` projections.failed_events.skip_reason,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.failed_events`),
 					sql.ErrConnDone,
@@ -155,6 +222,108 @@ func Test_FailedEventsPrepares(t *testing.T) {
 			},
 			object: nil,
 		},
+		{
+			name:    "prepareFailedEventQuery no result",
+			prepare: prepareFailedEventQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					prepareFailedEventStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*FailedEvent)(nil),
+		},
+		{
+			name:    "prepareFailedEventQuery found",
+			prepare: prepareFailedEventQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					prepareFailedEventStmt,
+					prepareFailedEventCols,
+					[]driver.Value{
+						"projection-name",
+						uint64(20211108),
+						uint64(5),
+						"error",
+						testNow,
+						true,
+						"max failure count reached",
+						"user.added",
+						"user",
+						"agg-id",
+						"ro",
+						testNow,
+						[]byte(`{"userName": "name"}`),
+					},
+				),
+			},
+			object: &FailedEvent{
+				ProjectionName: "projection-name",
+				FailedSequence: 20211108,
+				FailureCount:   5,
+				Error:          "error",
+				LastFailed:     testNow,
+				Skipped:        true,
+				SkipReason:     "max failure count reached",
+				Event: &FailedEventPayload{
+					Type:          "user.added",
+					AggregateType: "user",
+					AggregateID:   "agg-id",
+					ResourceOwner: "ro",
+					CreationDate:  testNow,
+					Data:          []byte(`{"userName": "name"}`),
+				},
+			},
+		},
+		{
+			name:    "prepareFailedEventErrorsQuery found",
+			prepare: prepareFailedEventErrorsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.failed_event_errors.failure_count,`+
+						` projections.failed_event_errors.error,`+
+						` projections.failed_event_errors.creation_date`+
+						` FROM projections.failed_event_errors`+
+						` ORDER BY projections.failed_event_errors.creation_date`),
+					[]string{
+						"failure_count",
+						"error",
+						"creation_date",
+					},
+					[][]driver.Value{
+						{
+							uint64(1),
+							"error 1",
+							testNow,
+						},
+						{
+							uint64(2),
+							"error 2",
+							testNow,
+						},
+					},
+				),
+			},
+			object: []*FailedEventError{
+				{
+					FailureCount: 1,
+					Error:        "error 1",
+					CreationDate: testNow,
+				},
+				{
+					FailureCount: 2,
+					Error:        "error 2",
+					CreationDate: testNow,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/caos/logging"
+	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/i18n"
 	"golang.org/x/text/language"
 )
 
+//Languages returns the languages of the login ui
+// including the languages of the uploaded translation bundles
 func (q *Queries) Languages(ctx context.Context) ([]language.Tag, error) {
 	if len(q.supportedLangs) == 0 {
 		langs, err := i18n.SupportedLanguages(q.LoginDir)
@@ -17,5 +20,25 @@ func (q *Queries) Languages(ctx context.Context) ([]language.Tag, error) {
 		}
 		q.supportedLangs = langs
 	}
-	return q.supportedLangs, nil
+	bundles, err := q.TranslationBundlesByComponent(ctx, domain.TranslationComponentLogin)
+	if err != nil {
+		return nil, err
+	}
+	langs := make([]language.Tag, len(q.supportedLangs), len(q.supportedLangs)+len(bundles.TranslationBundles))
+	copy(langs, q.supportedLangs)
+	for _, bundle := range bundles.TranslationBundles {
+		if !containsLanguage(langs, bundle.Language) {
+			langs = append(langs, bundle.Language)
+		}
+	}
+	return langs, nil
+}
+
+func containsLanguage(langs []language.Tag, lang language.Tag) bool {
+	for _, l := range langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
 }
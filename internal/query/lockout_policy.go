@@ -21,6 +21,10 @@ type LockoutPolicy struct {
 	State         domain.PolicyState
 
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
+	LockoutDuration     time.Duration
+	MaxIPAttempts       uint64
+	IPThrottleDuration  time.Duration
 	ShowFailures        bool
 
 	IsDefault bool
@@ -58,6 +62,22 @@ var (
 		name:  projection.LockoutPolicyMaxPasswordAttemptsCol,
 		table: lockoutTable,
 	}
+	LockoutColMaxOTPAttempts = Column{
+		name:  projection.LockoutPolicyMaxOTPAttemptsCol,
+		table: lockoutTable,
+	}
+	LockoutColLockoutDuration = Column{
+		name:  projection.LockoutPolicyLockoutDurationCol,
+		table: lockoutTable,
+	}
+	LockoutColMaxIPAttempts = Column{
+		name:  projection.LockoutPolicyMaxIPAttemptsCol,
+		table: lockoutTable,
+	}
+	LockoutColIPThrottleDuration = Column{
+		name:  projection.LockoutPolicyIPThrottleDurationCol,
+		table: lockoutTable,
+	}
 	LockoutColIsDefault = Column{
 		name:  projection.LockoutPolicyIsDefaultCol,
 		table: lockoutTable,
@@ -113,6 +133,10 @@ func prepareLockoutPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*LockoutPoli
 			LockoutColResourceOwner.identifier(),
 			LockoutColShowFailures.identifier(),
 			LockoutColMaxPasswordAttempts.identifier(),
+			LockoutColMaxOTPAttempts.identifier(),
+			LockoutColLockoutDuration.identifier(),
+			LockoutColMaxIPAttempts.identifier(),
+			LockoutColIPThrottleDuration.identifier(),
 			LockoutColIsDefault.identifier(),
 			LockoutColState.identifier(),
 		).
@@ -127,6 +151,10 @@ func prepareLockoutPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*LockoutPoli
 				&policy.ResourceOwner,
 				&policy.ShowFailures,
 				&policy.MaxPasswordAttempts,
+				&policy.MaxOTPAttempts,
+				&policy.LockoutDuration,
+				&policy.MaxIPAttempts,
+				&policy.IPThrottleDuration,
 				&policy.IsDefault,
 				&policy.State,
 			)
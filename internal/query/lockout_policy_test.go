@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/caos/zitadel/internal/domain"
 	errs "github.com/caos/zitadel/internal/errors"
@@ -35,6 +36,10 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						` zitadel.projections.lockout_policies.resource_owner,`+
 						` zitadel.projections.lockout_policies.show_failure,`+
 						` zitadel.projections.lockout_policies.max_password_attempts,`+
+						` zitadel.projections.lockout_policies.max_otp_attempts,`+
+						` zitadel.projections.lockout_policies.lockout_duration,`+
+						` zitadel.projections.lockout_policies.max_ip_attempts,`+
+						` zitadel.projections.lockout_policies.ip_throttle_duration,`+
 						` zitadel.projections.lockout_policies.is_default,`+
 						` zitadel.projections.lockout_policies.state`+
 						` FROM zitadel.projections.lockout_policies`),
@@ -62,6 +67,10 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						` zitadel.projections.lockout_policies.resource_owner,`+
 						` zitadel.projections.lockout_policies.show_failure,`+
 						` zitadel.projections.lockout_policies.max_password_attempts,`+
+						` zitadel.projections.lockout_policies.max_otp_attempts,`+
+						` zitadel.projections.lockout_policies.lockout_duration,`+
+						` zitadel.projections.lockout_policies.max_ip_attempts,`+
+						` zitadel.projections.lockout_policies.ip_throttle_duration,`+
 						` zitadel.projections.lockout_policies.is_default,`+
 						` zitadel.projections.lockout_policies.state`+
 						` FROM zitadel.projections.lockout_policies`),
@@ -73,6 +82,10 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"resource_owner",
 						"show_failure",
 						"max_password_attempts",
+						"max_otp_attempts",
+						"lockout_duration",
+						"max_ip_attempts",
+						"ip_throttle_duration",
 						"is_default",
 						"state",
 					},
@@ -84,6 +97,10 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						20,
+						5,
+						int64(time.Hour),
+						100,
+						int64(time.Minute),
 						true,
 						domain.PolicyStateActive,
 					},
@@ -98,6 +115,10 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 				State:               domain.PolicyStateActive,
 				ShowFailures:        true,
 				MaxPasswordAttempts: 20,
+				MaxOTPAttempts:      5,
+				LockoutDuration:     time.Hour,
+				MaxIPAttempts:       100,
+				IPThrottleDuration:  time.Minute,
 				IsDefault:           true,
 			},
 		},
@@ -113,6 +134,10 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						` zitadel.projections.lockout_policies.resource_owner,`+
 						` zitadel.projections.lockout_policies.show_failure,`+
 						` zitadel.projections.lockout_policies.max_password_attempts,`+
+						` zitadel.projections.lockout_policies.max_otp_attempts,`+
+						` zitadel.projections.lockout_policies.lockout_duration,`+
+						` zitadel.projections.lockout_policies.max_ip_attempts,`+
+						` zitadel.projections.lockout_policies.ip_throttle_duration,`+
 						` zitadel.projections.lockout_policies.is_default,`+
 						` zitadel.projections.lockout_policies.state`+
 						` FROM zitadel.projections.lockout_policies`),
@@ -0,0 +1,157 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query/projection"
+)
+
+var (
+	loginTemplatesTable = table{
+		name: projection.LoginTemplateTable,
+	}
+	LoginTemplateColumnAggregateID = Column{
+		name:  projection.LoginTemplateAggregateIDCol,
+		table: loginTemplatesTable,
+	}
+	LoginTemplateColumnTemplate = Column{
+		name:  projection.LoginTemplateTemplateCol,
+		table: loginTemplatesTable,
+	}
+	LoginTemplateColumnCreationDate = Column{
+		name:  projection.LoginTemplateCreationDateCol,
+		table: loginTemplatesTable,
+	}
+	LoginTemplateColumnChangeDate = Column{
+		name:  projection.LoginTemplateChangeDateCol,
+		table: loginTemplatesTable,
+	}
+	LoginTemplateColumnSequence = Column{
+		name:  projection.LoginTemplateSequenceCol,
+		table: loginTemplatesTable,
+	}
+	LoginTemplateColumnAssetKey = Column{
+		name:  projection.LoginTemplateAssetKeyCol,
+		table: loginTemplatesTable,
+	}
+)
+
+type LoginTemplates struct {
+	SearchResponse
+	LoginTemplates []*LoginTemplate
+}
+
+//LoginTemplate is a template of the login uploaded by an organisation
+// the content is stored as asset under the asset key
+type LoginTemplate struct {
+	AggregateID  string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+
+	Template string
+	AssetKey string
+}
+
+//LoginTemplatesByOrg returns all login templates uploaded by the organisation
+func (q *Queries) LoginTemplatesByOrg(ctx context.Context, orgID string) (*LoginTemplates, error) {
+	query, scan := prepareLoginTemplatesQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		LoginTemplateColumnAggregateID.identifier(): orgID,
+	}).OrderBy(LoginTemplateColumnTemplate.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tp1sI", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tp1qE", "Errors.Internal")
+	}
+	templates, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	templates.LatestSequence, err = q.latestSequence(ctx, loginTemplatesTable)
+	return templates, err
+}
+
+//LoginTemplateByOrg returns the login template uploaded by the organisation
+func (q *Queries) LoginTemplateByOrg(ctx context.Context, orgID, template string) (*LoginTemplate, error) {
+	templates, err := q.LoginTemplatesByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, loginTemplate := range templates.LoginTemplates {
+		if loginTemplate.Template == template {
+			return loginTemplate, nil
+		}
+	}
+	return nil, errors.ThrowNotFound(nil, "QUERY-Tp1nF", "Errors.Org.LoginTemplate.NotFound")
+}
+
+//DefaultLoginTemplate returns the template of the login shipped with zitadel
+func (q *Queries) DefaultLoginTemplate(template string) ([]byte, error) {
+	if !domain.IsLoginTemplate(template) {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Tp1iV", "Errors.Org.LoginTemplate.Invalid")
+	}
+	file, err := q.LoginDir.Open("/templates/" + template)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tp1oF", "Errors.Internal")
+	}
+	defer file.Close()
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tp1rF", "Errors.Internal")
+	}
+	return content, nil
+}
+
+func prepareLoginTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*LoginTemplates, error)) {
+	return sq.Select(
+			LoginTemplateColumnAggregateID.identifier(),
+			LoginTemplateColumnCreationDate.identifier(),
+			LoginTemplateColumnChangeDate.identifier(),
+			LoginTemplateColumnSequence.identifier(),
+			LoginTemplateColumnTemplate.identifier(),
+			LoginTemplateColumnAssetKey.identifier(),
+			countColumn.identifier()).
+			From(loginTemplatesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*LoginTemplates, error) {
+			templates := make([]*LoginTemplate, 0)
+			var count uint64
+			for rows.Next() {
+				template := new(LoginTemplate)
+				err := rows.Scan(
+					&template.AggregateID,
+					&template.CreationDate,
+					&template.ChangeDate,
+					&template.Sequence,
+					&template.Template,
+					&template.AssetKey,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				templates = append(templates, template)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Tp1cR", "Errors.Query.CloseRows")
+			}
+
+			return &LoginTemplates{
+				LoginTemplates: templates,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
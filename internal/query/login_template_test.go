@@ -0,0 +1,129 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	loginTemplatesQuery = regexp.QuoteMeta(`SELECT zitadel.projections.login_templates.aggregate_id,` +
+		` zitadel.projections.login_templates.creation_date,` +
+		` zitadel.projections.login_templates.change_date,` +
+		` zitadel.projections.login_templates.sequence,` +
+		` zitadel.projections.login_templates.template,` +
+		` zitadel.projections.login_templates.asset_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM zitadel.projections.login_templates`)
+	loginTemplatesColumns = []string{
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"template",
+		"asset_key",
+		"count",
+	}
+)
+
+func Test_LoginTemplatePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareLoginTemplatesQuery no result",
+			prepare: prepareLoginTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					loginTemplatesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &LoginTemplates{LoginTemplates: []*LoginTemplate{}},
+		},
+		{
+			name:    "prepareLoginTemplatesQuery multiple result",
+			prepare: prepareLoginTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					loginTemplatesQuery,
+					loginTemplatesColumns,
+					[][]driver.Value{
+						{
+							"org1",
+							testNow,
+							testNow,
+							uint64(20211111),
+							"login.html",
+							"login/templates/login.html-id1",
+						},
+						{
+							"org1",
+							testNow,
+							testNow,
+							uint64(20211111),
+							"main.html",
+							"login/templates/main.html-id2",
+						},
+					},
+				),
+			},
+			object: &LoginTemplates{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				LoginTemplates: []*LoginTemplate{
+					{
+						AggregateID:  "org1",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211111,
+						Template:     "login.html",
+						AssetKey:     "login/templates/login.html-id1",
+					},
+					{
+						AggregateID:  "org1",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211111,
+						Template:     "main.html",
+						AssetKey:     "login/templates/main.html-id2",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLoginTemplatesQuery sql err",
+			prepare: prepareLoginTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					loginTemplatesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
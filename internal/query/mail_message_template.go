@@ -0,0 +1,169 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query/projection"
+)
+
+//MailMessageTemplate replaces the mail template for the emails of the message type
+type MailMessageTemplate struct {
+	AggregateID  string
+	Sequence     uint64
+	CreationDate time.Time
+	ChangeDate   time.Time
+
+	MessageType string
+	Template    []byte
+	IsDefault   bool
+}
+
+var (
+	mailMessageTemplateTable = table{
+		name: projection.MailMessageTemplateTable,
+	}
+	MailMessageTemplateColAggregateID = Column{
+		name:  projection.MailMessageTemplateAggregateIDCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColSequence = Column{
+		name:  projection.MailMessageTemplateSequenceCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColCreationDate = Column{
+		name:  projection.MailMessageTemplateCreationDateCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColChangeDate = Column{
+		name:  projection.MailMessageTemplateChangeDateCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColMessageType = Column{
+		name:  projection.MailMessageTemplateMessageTypeCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColTemplate = Column{
+		name:  projection.MailMessageTemplateTemplateCol,
+		table: mailMessageTemplateTable,
+	}
+	MailMessageTemplateColIsDefault = Column{
+		name:  projection.MailMessageTemplateIsDefaultCol,
+		table: mailMessageTemplateTable,
+	}
+)
+
+//MailMessageTemplateByOrg returns the template of the message type of the organisation
+// or the default template of the message type if the organisation has none
+func (q *Queries) MailMessageTemplateByOrg(ctx context.Context, orgID, messageType string) (*MailMessageTemplate, error) {
+	stmt, scan := prepareMailMessageTemplateQuery()
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{
+				MailMessageTemplateColMessageType.identifier(): messageType,
+			},
+			sq.Or{
+				sq.Eq{
+					MailMessageTemplateColAggregateID.identifier(): orgID,
+				},
+				sq.Eq{
+					MailMessageTemplateColAggregateID.identifier(): q.iamID,
+				},
+			},
+		}).
+		OrderBy(MailMessageTemplateColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mt7oS", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultMailMessageTemplate(ctx context.Context, messageType string) (*MailMessageTemplate, error) {
+	stmt, scan := prepareMailMessageTemplateQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		MailMessageTemplateColMessageType.identifier(): messageType,
+		MailMessageTemplateColAggregateID.identifier(): q.iamID,
+	}).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mt7dS", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+//MailTemplateByOrgAndMessageType returns the template used to send the emails of the message type
+// the templates of the organisation take precedence over the default ones:
+// template of the message type of the organisation, mail template of the organisation,
+// default template of the message type, default mail template
+func (q *Queries) MailTemplateByOrgAndMessageType(ctx context.Context, orgID, messageType string) (*MailTemplate, error) {
+	messageTemplate, err := q.MailMessageTemplateByOrg(ctx, orgID, messageType)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if messageTemplate != nil && !messageTemplate.IsDefault {
+		return messageTemplate.mailTemplate(), nil
+	}
+	mailTemplate, err := q.MailTemplateByOrg(ctx, orgID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if messageTemplate != nil && (mailTemplate == nil || mailTemplate.IsDefault) {
+		return messageTemplate.mailTemplate(), nil
+	}
+	return mailTemplate, err
+}
+
+func (t *MailMessageTemplate) mailTemplate() *MailTemplate {
+	return &MailTemplate{
+		AggregateID:  t.AggregateID,
+		Sequence:     t.Sequence,
+		CreationDate: t.CreationDate,
+		ChangeDate:   t.ChangeDate,
+		State:        domain.PolicyStateActive,
+		Template:     t.Template,
+		IsDefault:    t.IsDefault,
+	}
+}
+
+func prepareMailMessageTemplateQuery() (sq.SelectBuilder, func(*sql.Row) (*MailMessageTemplate, error)) {
+	return sq.Select(
+			MailMessageTemplateColAggregateID.identifier(),
+			MailMessageTemplateColSequence.identifier(),
+			MailMessageTemplateColCreationDate.identifier(),
+			MailMessageTemplateColChangeDate.identifier(),
+			MailMessageTemplateColMessageType.identifier(),
+			MailMessageTemplateColTemplate.identifier(),
+			MailMessageTemplateColIsDefault.identifier(),
+		).
+			From(mailMessageTemplateTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*MailMessageTemplate, error) {
+			template := new(MailMessageTemplate)
+			err := row.Scan(
+				&template.AggregateID,
+				&template.Sequence,
+				&template.CreationDate,
+				&template.ChangeDate,
+				&template.MessageType,
+				&template.Template,
+				&template.IsDefault,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Mt7nF", "Errors.MailMessageTemplate.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Mt7iE", "Errors.Internal")
+			}
+			return template, nil
+		}
+}
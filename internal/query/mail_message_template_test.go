@@ -0,0 +1,115 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/caos/zitadel/internal/domain"
+	errs "github.com/caos/zitadel/internal/errors"
+)
+
+var (
+	mailMessageTemplateQuery = regexp.QuoteMeta(`SELECT zitadel.projections.mail_message_templates.aggregate_id,` +
+		` zitadel.projections.mail_message_templates.sequence,` +
+		` zitadel.projections.mail_message_templates.creation_date,` +
+		` zitadel.projections.mail_message_templates.change_date,` +
+		` zitadel.projections.mail_message_templates.message_type,` +
+		` zitadel.projections.mail_message_templates.template,` +
+		` zitadel.projections.mail_message_templates.is_default` +
+		` FROM zitadel.projections.mail_message_templates`)
+	mailMessageTemplateColumns = []string{
+		"aggregate_id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"message_type",
+		"template",
+		"is_default",
+	}
+)
+
+func Test_MailMessageTemplatePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareMailMessageTemplateQuery no result",
+			prepare: prepareMailMessageTemplateQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					mailMessageTemplateQuery,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*MailMessageTemplate)(nil),
+		},
+		{
+			name:    "prepareMailMessageTemplateQuery found",
+			prepare: prepareMailMessageTemplateQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					mailMessageTemplateQuery,
+					mailMessageTemplateColumns,
+					[]driver.Value{
+						"agg-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						domain.InitCodeMessageType,
+						[]byte("<html></html>"),
+						true,
+					},
+				),
+			},
+			object: &MailMessageTemplate{
+				AggregateID:  "agg-id",
+				Sequence:     20211109,
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				MessageType:  domain.InitCodeMessageType,
+				Template:     []byte("<html></html>"),
+				IsDefault:    true,
+			},
+		},
+		{
+			name:    "prepareMailMessageTemplateQuery sql err",
+			prepare: prepareMailMessageTemplateQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					mailMessageTemplateQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
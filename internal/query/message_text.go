@@ -27,6 +27,12 @@ type MessageTexts struct {
 	VerifyPhone              MessageText
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
+	PasswordChanged          MessageText
+	MFAAdded                 MessageText
+	MFARemoved               MessageText
+	NewUserAgent             MessageText
+	UserLocked               MessageText
+	EmailChanged             MessageText
 }
 
 type MessageText struct {
@@ -331,6 +337,18 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.DomainClaimed
 	case domain.PasswordlessRegistrationMessageType:
 		return &m.PasswordlessRegistration
+	case domain.PasswordChangedMessageType:
+		return &m.PasswordChanged
+	case domain.MFAAddedMessageType:
+		return &m.MFAAdded
+	case domain.MFARemovedMessageType:
+		return &m.MFARemoved
+	case domain.NewUserAgentMessageType:
+		return &m.NewUserAgent
+	case domain.UserLockedMessageType:
+		return &m.UserLocked
+	case domain.EmailChangedMessageType:
+		return &m.EmailChanged
 	}
 	return nil
 }
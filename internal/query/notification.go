@@ -0,0 +1,321 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query/projection"
+)
+
+var (
+	notificationTable = table{
+		name: projection.NotificationTable,
+	}
+	NotificationColumnID = Column{
+		name:  projection.NotificationIDCol,
+		table: notificationTable,
+	}
+	NotificationColumnCreationDate = Column{
+		name:  projection.NotificationCreationDateCol,
+		table: notificationTable,
+	}
+	NotificationColumnChangeDate = Column{
+		name:  projection.NotificationChangeDateCol,
+		table: notificationTable,
+	}
+	NotificationColumnSequence = Column{
+		name:  projection.NotificationSequenceCol,
+		table: notificationTable,
+	}
+	NotificationColumnResourceOwner = Column{
+		name:  projection.NotificationResourceOwnerCol,
+		table: notificationTable,
+	}
+	NotificationColumnUserID = Column{
+		name:  projection.NotificationUserIDCol,
+		table: notificationTable,
+	}
+	NotificationColumnMessageType = Column{
+		name:  projection.NotificationMessageTypeCol,
+		table: notificationTable,
+	}
+	NotificationColumnChannel = Column{
+		name:  projection.NotificationChannelCol,
+		table: notificationTable,
+	}
+	NotificationColumnRecipient = Column{
+		name:  projection.NotificationRecipientCol,
+		table: notificationTable,
+	}
+	NotificationColumnMessage = Column{
+		name:  projection.NotificationMessageCol,
+		table: notificationTable,
+	}
+	NotificationColumnState = Column{
+		name:  projection.NotificationStateCol,
+		table: notificationTable,
+	}
+	NotificationColumnAttempts = Column{
+		name:  projection.NotificationAttemptsCol,
+		table: notificationTable,
+	}
+	NotificationColumnLastError = Column{
+		name:  projection.NotificationLastErrorCol,
+		table: notificationTable,
+	}
+	NotificationColumnNextAttempt = Column{
+		name:  projection.NotificationNextAttemptCol,
+		table: notificationTable,
+	}
+)
+
+type Notifications struct {
+	SearchResponse
+	Notifications []*Notification
+}
+
+type Notification struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+
+	UserID      string
+	MessageType string
+	Channel     domain.NotificationType
+	Recipient   string
+	Message     *crypto.CryptoValue
+	State       domain.NotificationState
+	Attempts    uint64
+	LastError   string
+	NextAttempt time.Time
+}
+
+type NotificationSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *NotificationSearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
+	query, err := NewNotificationResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return err
+	}
+	q.Queries = append(q.Queries, query)
+	return nil
+}
+
+func (q *NotificationSearchQueries) AppendUserIDQuery(userID string) error {
+	query, err := NewNotificationUserIDSearchQuery(userID)
+	if err != nil {
+		return err
+	}
+	q.Queries = append(q.Queries, query)
+	return nil
+}
+
+func (q *Queries) SearchNotifications(ctx context.Context, queries *NotificationSearchQueries) (*Notifications, error) {
+	query, scan := prepareNotificationsQuery()
+	stmt, args, err := queries.toQuery(query).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Nt9sQ", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt9sE", "Errors.Internal")
+	}
+	notifications, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	notifications.LatestSequence, err = q.latestSequence(ctx, notificationTable)
+	return notifications, err
+}
+
+//DueNotifications returns the queued notifications and the ones to retry
+// whose next attempt is before the given time, the oldest first
+func (q *Queries) DueNotifications(ctx context.Context, dueBefore time.Time, limit uint64) (*Notifications, error) {
+	query, scan := prepareNotificationsQuery()
+	stmt, args, err := query.Where(
+		sq.And{
+			sq.Eq{
+				NotificationColumnState.identifier(): []domain.NotificationState{
+					domain.NotificationStateQueued,
+					domain.NotificationStateRetrying,
+				},
+			},
+			sq.LtOrEq{
+				NotificationColumnNextAttempt.identifier(): dueBefore,
+			},
+		}).
+		OrderBy(NotificationColumnNextAttempt.identifier()).
+		Limit(limit).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt9dQ", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt9dE", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func (q *Queries) NotificationByID(ctx context.Context, id string) (*Notification, error) {
+	stmt, scan := prepareNotificationQuery()
+	query, args, err := stmt.Where(
+		sq.Eq{
+			NotificationColumnID.identifier(): id,
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Nt9iQ", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func NewNotificationResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationColumnResourceOwner, value, TextEquals)
+}
+
+func NewNotificationUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationColumnUserID, value, TextEquals)
+}
+
+func NewNotificationStateSearchQuery(value domain.NotificationState) (SearchQuery, error) {
+	return NewNumberQuery(NotificationColumnState, int(value), NumberEquals)
+}
+
+func prepareNotificationsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Notifications, error)) {
+	return sq.Select(
+			NotificationColumnID.identifier(),
+			NotificationColumnCreationDate.identifier(),
+			NotificationColumnChangeDate.identifier(),
+			NotificationColumnSequence.identifier(),
+			NotificationColumnResourceOwner.identifier(),
+			NotificationColumnUserID.identifier(),
+			NotificationColumnMessageType.identifier(),
+			NotificationColumnChannel.identifier(),
+			NotificationColumnRecipient.identifier(),
+			NotificationColumnMessage.identifier(),
+			NotificationColumnState.identifier(),
+			NotificationColumnAttempts.identifier(),
+			NotificationColumnLastError.identifier(),
+			NotificationColumnNextAttempt.identifier(),
+			countColumn.identifier(),
+		).From(notificationTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Notifications, error) {
+			notifications := make([]*Notification, 0)
+			var count uint64
+			for rows.Next() {
+				notification := new(Notification)
+				message := new(crypto.CryptoValue)
+				lastError := sql.NullString{}
+				nextAttempt := sql.NullTime{}
+				err := rows.Scan(
+					&notification.ID,
+					&notification.CreationDate,
+					&notification.ChangeDate,
+					&notification.Sequence,
+					&notification.ResourceOwner,
+					&notification.UserID,
+					&notification.MessageType,
+					&notification.Channel,
+					&notification.Recipient,
+					message,
+					&notification.State,
+					&notification.Attempts,
+					&lastError,
+					&nextAttempt,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				notification.Message = message
+				notification.LastError = lastError.String
+				notification.NextAttempt = nextAttempt.Time
+				notifications = append(notifications, notification)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Nt9cR", "Errors.Query.CloseRows")
+			}
+
+			return &Notifications{
+				Notifications: notifications,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareNotificationQuery() (sq.SelectBuilder, func(*sql.Row) (*Notification, error)) {
+	return sq.Select(
+			NotificationColumnID.identifier(),
+			NotificationColumnCreationDate.identifier(),
+			NotificationColumnChangeDate.identifier(),
+			NotificationColumnSequence.identifier(),
+			NotificationColumnResourceOwner.identifier(),
+			NotificationColumnUserID.identifier(),
+			NotificationColumnMessageType.identifier(),
+			NotificationColumnChannel.identifier(),
+			NotificationColumnRecipient.identifier(),
+			NotificationColumnMessage.identifier(),
+			NotificationColumnState.identifier(),
+			NotificationColumnAttempts.identifier(),
+			NotificationColumnLastError.identifier(),
+			NotificationColumnNextAttempt.identifier(),
+		).From(notificationTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Notification, error) {
+			notification := new(Notification)
+			message := new(crypto.CryptoValue)
+			lastError := sql.NullString{}
+			nextAttempt := sql.NullTime{}
+			err := row.Scan(
+				&notification.ID,
+				&notification.CreationDate,
+				&notification.ChangeDate,
+				&notification.Sequence,
+				&notification.ResourceOwner,
+				&notification.UserID,
+				&notification.MessageType,
+				&notification.Channel,
+				&notification.Recipient,
+				message,
+				&notification.State,
+				&notification.Attempts,
+				&lastError,
+				&nextAttempt,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Nt9nF", "Errors.Notification.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Nt9nE", "Errors.Internal")
+			}
+			notification.Message = message
+			notification.LastError = lastError.String
+			notification.NextAttempt = nextAttempt.Time
+			return notification, nil
+		}
+}
@@ -0,0 +1,182 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query/projection"
+)
+
+type NotificationPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	PasswordChanged bool
+	MFAAdded        bool
+	MFARemoved      bool
+	NewUserAgent    bool
+	UserLocked      bool
+	EmailChanged    bool
+
+	IsDefault bool
+}
+
+var (
+	notificationPolicyTable = table{
+		name: projection.NotificationPolicyTable,
+	}
+	NotificationPolicyColID = Column{
+		name:  projection.NotificationPolicyIDCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColSequence = Column{
+		name:  projection.NotificationPolicySequenceCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColCreationDate = Column{
+		name:  projection.NotificationPolicyCreationDateCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColChangeDate = Column{
+		name:  projection.NotificationPolicyChangeDateCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColResourceOwner = Column{
+		name:  projection.NotificationPolicyResourceOwnerCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColPasswordChanged = Column{
+		name:  projection.NotificationPolicyPasswordChangedCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFAAdded = Column{
+		name:  projection.NotificationPolicyMFAAddedCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFARemoved = Column{
+		name:  projection.NotificationPolicyMFARemovedCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColNewUserAgent = Column{
+		name:  projection.NotificationPolicyNewUserAgentCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColUserLocked = Column{
+		name:  projection.NotificationPolicyUserLockedCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColEmailChanged = Column{
+		name:  projection.NotificationPolicyEmailChangedCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColIsDefault = Column{
+		name:  projection.NotificationPolicyIsDefaultCol,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColState = Column{
+		name:  projection.NotificationPolicyStateCol,
+		table: notificationPolicyTable,
+	}
+)
+
+func (q *Queries) NotificationPolicyByOrg(ctx context.Context, orgID string) (*NotificationPolicy, error) {
+	stmt, scan := prepareNotificationPolicyQuery()
+	query, args, err := stmt.Where(
+		sq.Or{
+			sq.Eq{
+				NotificationPolicyColID.identifier(): orgID,
+			},
+			sq.Eq{
+				NotificationPolicyColID.identifier(): q.iamID,
+			},
+		}).
+		OrderBy(NotificationPolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Np6oS", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultNotificationPolicy(ctx context.Context) (*NotificationPolicy, error) {
+	stmt, scan := prepareNotificationPolicyQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		NotificationPolicyColID.identifier(): q.iamID,
+	}).
+		OrderBy(NotificationPolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Np6dS", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareNotificationPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*NotificationPolicy, error)) {
+	return sq.Select(
+			NotificationPolicyColID.identifier(),
+			NotificationPolicyColSequence.identifier(),
+			NotificationPolicyColCreationDate.identifier(),
+			NotificationPolicyColChangeDate.identifier(),
+			NotificationPolicyColResourceOwner.identifier(),
+			NotificationPolicyColPasswordChanged.identifier(),
+			NotificationPolicyColMFAAdded.identifier(),
+			NotificationPolicyColMFARemoved.identifier(),
+			NotificationPolicyColNewUserAgent.identifier(),
+			NotificationPolicyColUserLocked.identifier(),
+			NotificationPolicyColEmailChanged.identifier(),
+			NotificationPolicyColIsDefault.identifier(),
+			NotificationPolicyColState.identifier(),
+		).
+			From(notificationPolicyTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*NotificationPolicy, error) {
+			policy := new(NotificationPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.PasswordChanged,
+				&policy.MFAAdded,
+				&policy.MFARemoved,
+				&policy.NewUserAgent,
+				&policy.UserLocked,
+				&policy.EmailChanged,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Np6nF", "Errors.Org.NotificationPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Np6iE", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
+
+func (p *NotificationPolicy) ToDomain() *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		State:           p.State,
+		Default:         p.IsDefault,
+		PasswordChanged: p.PasswordChanged,
+		MFAAdded:        p.MFAAdded,
+		MFARemoved:      p.MFARemoved,
+		NewUserAgent:    p.NewUserAgent,
+		UserLocked:      p.UserLocked,
+		EmailChanged:    p.EmailChanged,
+	}
+}
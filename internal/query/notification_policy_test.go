@@ -0,0 +1,160 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/caos/zitadel/internal/domain"
+	errs "github.com/caos/zitadel/internal/errors"
+)
+
+func Test_NotificationPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationPolicyQuery no result",
+			prepare: prepareNotificationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notification_policies.id,`+
+						` zitadel.projections.notification_policies.sequence,`+
+						` zitadel.projections.notification_policies.creation_date,`+
+						` zitadel.projections.notification_policies.change_date,`+
+						` zitadel.projections.notification_policies.resource_owner,`+
+						` zitadel.projections.notification_policies.password_changed,`+
+						` zitadel.projections.notification_policies.mfa_added,`+
+						` zitadel.projections.notification_policies.mfa_removed,`+
+						` zitadel.projections.notification_policies.new_user_agent,`+
+						` zitadel.projections.notification_policies.user_locked,`+
+						` zitadel.projections.notification_policies.email_changed,`+
+						` zitadel.projections.notification_policies.is_default,`+
+						` zitadel.projections.notification_policies.state`+
+						` FROM zitadel.projections.notification_policies`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationPolicy)(nil),
+		},
+		{
+			name:    "prepareNotificationPolicyQuery found",
+			prepare: prepareNotificationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notification_policies.id,`+
+						` zitadel.projections.notification_policies.sequence,`+
+						` zitadel.projections.notification_policies.creation_date,`+
+						` zitadel.projections.notification_policies.change_date,`+
+						` zitadel.projections.notification_policies.resource_owner,`+
+						` zitadel.projections.notification_policies.password_changed,`+
+						` zitadel.projections.notification_policies.mfa_added,`+
+						` zitadel.projections.notification_policies.mfa_removed,`+
+						` zitadel.projections.notification_policies.new_user_agent,`+
+						` zitadel.projections.notification_policies.user_locked,`+
+						` zitadel.projections.notification_policies.email_changed,`+
+						` zitadel.projections.notification_policies.is_default,`+
+						` zitadel.projections.notification_policies.state`+
+						` FROM zitadel.projections.notification_policies`),
+					[]string{
+						"id",
+						"sequence",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"password_changed",
+						"mfa_added",
+						"mfa_removed",
+						"new_user_agent",
+						"user_locked",
+						"email_changed",
+						"is_default",
+						"state",
+					},
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						true,
+						true,
+						true,
+						false,
+						true,
+						true,
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &NotificationPolicy{
+				ID:              "pol-id",
+				CreationDate:    testNow,
+				ChangeDate:      testNow,
+				Sequence:        20211109,
+				ResourceOwner:   "ro",
+				State:           domain.PolicyStateActive,
+				PasswordChanged: true,
+				MFAAdded:        true,
+				MFARemoved:      true,
+				NewUserAgent:    false,
+				UserLocked:      true,
+				EmailChanged:    true,
+				IsDefault:       true,
+			},
+		},
+		{
+			name:    "prepareNotificationPolicyQuery sql err",
+			prepare: prepareNotificationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notification_policies.id,`+
+						` zitadel.projections.notification_policies.sequence,`+
+						` zitadel.projections.notification_policies.creation_date,`+
+						` zitadel.projections.notification_policies.change_date,`+
+						` zitadel.projections.notification_policies.resource_owner,`+
+						` zitadel.projections.notification_policies.password_changed,`+
+						` zitadel.projections.notification_policies.mfa_added,`+
+						` zitadel.projections.notification_policies.mfa_removed,`+
+						` zitadel.projections.notification_policies.new_user_agent,`+
+						` zitadel.projections.notification_policies.user_locked,`+
+						` zitadel.projections.notification_policies.email_changed,`+
+						` zitadel.projections.notification_policies.is_default,`+
+						` zitadel.projections.notification_policies.state`+
+						` FROM zitadel.projections.notification_policies`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	errs "github.com/caos/zitadel/internal/errors"
+)
+
+func Test_NotificationPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationsQuery no result",
+			prepare: prepareNotificationsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notifications.id,`+
+						` zitadel.projections.notifications.creation_date,`+
+						` zitadel.projections.notifications.change_date,`+
+						` zitadel.projections.notifications.sequence,`+
+						` zitadel.projections.notifications.resource_owner,`+
+						` zitadel.projections.notifications.user_id,`+
+						` zitadel.projections.notifications.message_type,`+
+						` zitadel.projections.notifications.channel,`+
+						` zitadel.projections.notifications.recipient,`+
+						` zitadel.projections.notifications.message,`+
+						` zitadel.projections.notifications.state,`+
+						` zitadel.projections.notifications.attempts,`+
+						` zitadel.projections.notifications.last_error,`+
+						` zitadel.projections.notifications.next_attempt,`+
+						` COUNT(*) OVER ()`+
+						` FROM zitadel.projections.notifications`),
+					nil,
+					nil,
+				),
+			},
+			object: &Notifications{Notifications: []*Notification{}},
+		},
+		{
+			name:    "prepareNotificationsQuery one result",
+			prepare: prepareNotificationsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notifications.id,`+
+						` zitadel.projections.notifications.creation_date,`+
+						` zitadel.projections.notifications.change_date,`+
+						` zitadel.projections.notifications.sequence,`+
+						` zitadel.projections.notifications.resource_owner,`+
+						` zitadel.projections.notifications.user_id,`+
+						` zitadel.projections.notifications.message_type,`+
+						` zitadel.projections.notifications.channel,`+
+						` zitadel.projections.notifications.recipient,`+
+						` zitadel.projections.notifications.message,`+
+						` zitadel.projections.notifications.state,`+
+						` zitadel.projections.notifications.attempts,`+
+						` zitadel.projections.notifications.last_error,`+
+						` zitadel.projections.notifications.next_attempt,`+
+						` COUNT(*) OVER ()`+
+						` FROM zitadel.projections.notifications`),
+					[]string{
+						"id",
+						"creation_date",
+						"change_date",
+						"sequence",
+						"resource_owner",
+						"user_id",
+						"message_type",
+						"channel",
+						"recipient",
+						"message",
+						"state",
+						"attempts",
+						"last_error",
+						"next_attempt",
+						"count",
+					},
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							uint64(20211019),
+							"ro",
+							"user-id",
+							"InitCode",
+							domain.NotificationTypeEmail,
+							"user@caos.ch",
+							[]byte(`{"algorithm":"enc","keyID":"id","crypted":"bWVzc2FnZQ=="}`),
+							domain.NotificationStateRetrying,
+							uint64(1),
+							"smtp unavailable",
+							testNow,
+						},
+					},
+				),
+			},
+			object: &Notifications{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Notifications: []*Notification{
+					{
+						ID:            "id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211019,
+						ResourceOwner: "ro",
+						UserID:        "user-id",
+						MessageType:   "InitCode",
+						Channel:       domain.NotificationTypeEmail,
+						Recipient:     "user@caos.ch",
+						Message:       &crypto.CryptoValue{Algorithm: "enc", KeyID: "id", Crypted: []byte("message")},
+						State:         domain.NotificationStateRetrying,
+						Attempts:      1,
+						LastError:     "smtp unavailable",
+						NextAttempt:   testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationsQuery sql err",
+			prepare: prepareNotificationsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notifications.id,`+
+						` zitadel.projections.notifications.creation_date,`+
+						` zitadel.projections.notifications.change_date,`+
+						` zitadel.projections.notifications.sequence,`+
+						` zitadel.projections.notifications.resource_owner,`+
+						` zitadel.projections.notifications.user_id,`+
+						` zitadel.projections.notifications.message_type,`+
+						` zitadel.projections.notifications.channel,`+
+						` zitadel.projections.notifications.recipient,`+
+						` zitadel.projections.notifications.message,`+
+						` zitadel.projections.notifications.state,`+
+						` zitadel.projections.notifications.attempts,`+
+						` zitadel.projections.notifications.last_error,`+
+						` zitadel.projections.notifications.next_attempt,`+
+						` COUNT(*) OVER ()`+
+						` FROM zitadel.projections.notifications`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareNotificationQuery no result",
+			prepare: prepareNotificationQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notifications.id,`+
+						` zitadel.projections.notifications.creation_date,`+
+						` zitadel.projections.notifications.change_date,`+
+						` zitadel.projections.notifications.sequence,`+
+						` zitadel.projections.notifications.resource_owner,`+
+						` zitadel.projections.notifications.user_id,`+
+						` zitadel.projections.notifications.message_type,`+
+						` zitadel.projections.notifications.channel,`+
+						` zitadel.projections.notifications.recipient,`+
+						` zitadel.projections.notifications.message,`+
+						` zitadel.projections.notifications.state,`+
+						` zitadel.projections.notifications.attempts,`+
+						` zitadel.projections.notifications.last_error,`+
+						` zitadel.projections.notifications.next_attempt`+
+						` FROM zitadel.projections.notifications`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Notification)(nil),
+		},
+		{
+			name:    "prepareNotificationQuery found",
+			prepare: prepareNotificationQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notifications.id,`+
+						` zitadel.projections.notifications.creation_date,`+
+						` zitadel.projections.notifications.change_date,`+
+						` zitadel.projections.notifications.sequence,`+
+						` zitadel.projections.notifications.resource_owner,`+
+						` zitadel.projections.notifications.user_id,`+
+						` zitadel.projections.notifications.message_type,`+
+						` zitadel.projections.notifications.channel,`+
+						` zitadel.projections.notifications.recipient,`+
+						` zitadel.projections.notifications.message,`+
+						` zitadel.projections.notifications.state,`+
+						` zitadel.projections.notifications.attempts,`+
+						` zitadel.projections.notifications.last_error,`+
+						` zitadel.projections.notifications.next_attempt`+
+						` FROM zitadel.projections.notifications`),
+					[]string{
+						"id",
+						"creation_date",
+						"change_date",
+						"sequence",
+						"resource_owner",
+						"user_id",
+						"message_type",
+						"channel",
+						"recipient",
+						"message",
+						"state",
+						"attempts",
+						"last_error",
+						"next_attempt",
+					},
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211019),
+						"ro",
+						"user-id",
+						"InitCode",
+						domain.NotificationTypeEmail,
+						"user@caos.ch",
+						[]byte(`{"algorithm":"enc","keyID":"id","crypted":"bWVzc2FnZQ=="}`),
+						domain.NotificationStateRetrying,
+						uint64(1),
+						"smtp unavailable",
+						testNow,
+					},
+				),
+			},
+			object: &Notification{
+				ID:            "id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211019,
+				ResourceOwner: "ro",
+				UserID:        "user-id",
+				MessageType:   "InitCode",
+				Channel:       domain.NotificationTypeEmail,
+				Recipient:     "user@caos.ch",
+				Message:       &crypto.CryptoValue{Algorithm: "enc", KeyID: "id", Crypted: []byte("message")},
+				State:         domain.NotificationStateRetrying,
+				Attempts:      1,
+				LastError:     "smtp unavailable",
+				NextAttempt:   testNow,
+			},
+		},
+		{
+			name:    "prepareNotificationQuery sql err",
+			prepare: prepareNotificationQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT zitadel.projections.notifications.id,`+
+						` zitadel.projections.notifications.creation_date,`+
+						` zitadel.projections.notifications.change_date,`+
+						` zitadel.projections.notifications.sequence,`+
+						` zitadel.projections.notifications.resource_owner,`+
+						` zitadel.projections.notifications.user_id,`+
+						` zitadel.projections.notifications.message_type,`+
+						` zitadel.projections.notifications.channel,`+
+						` zitadel.projections.notifications.recipient,`+
+						` zitadel.projections.notifications.message,`+
+						` zitadel.projections.notifications.state,`+
+						` zitadel.projections.notifications.attempts,`+
+						` zitadel.projections.notifications.last_error,`+
+						` zitadel.projections.notifications.next_attempt`+
+						` FROM zitadel.projections.notifications`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -106,6 +106,23 @@ func (q *Queries) OrgByDomainGlobal(ctx context.Context, domain string) (*Org, e
 	return scan(row)
 }
 
+func (q *Queries) OrgByLoginDomain(ctx context.Context, domain string) (*Org, error) {
+	stmt, scan := prepareOrgQuery()
+	query, args, err := stmt.
+		LeftJoin(join(OrgDomainOrgIDCol, OrgColumnID)).
+		Where(sq.Eq{
+			OrgDomainDomainCol.identifier():        domain,
+			OrgDomainIsVerifiedCol.identifier():    true,
+			OrgDomainIsLoginDomainCol.identifier(): true,
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lg4sQ", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
 func (q *Queries) IsOrgUnique(ctx context.Context, name, domain string) (isUnique bool, err error) {
 	query, scan := prepareOrgUniqueQuery()
 	stmt, args, err := query.Where(
@@ -19,6 +19,7 @@ type Domain struct {
 	OrgID          string
 	IsVerified     bool
 	IsPrimary      bool
+	IsLoginDomain  bool
 	ValidationType domain.OrgDomainValidationType
 }
 
@@ -52,6 +53,10 @@ func NewOrgDomainVerifiedSearchQuery(verified bool) (SearchQuery, error) {
 	return NewBoolQuery(OrgDomainIsVerifiedCol, verified)
 }
 
+func NewOrgDomainLoginDomainSearchQuery(loginDomain bool) (SearchQuery, error) {
+	return NewBoolQuery(OrgDomainIsLoginDomainCol, loginDomain)
+}
+
 func (q *Queries) SearchOrgDomains(ctx context.Context, queries *OrgDomainSearchQueries) (domains *Domains, err error) {
 	query, scan := prepareDomainsQuery()
 	stmt, args, err := queries.toQuery(query).ToSql()
@@ -80,6 +85,7 @@ func prepareDomainsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Domains, error))
 			OrgDomainOrgIDCol.identifier(),
 			OrgDomainIsVerifiedCol.identifier(),
 			OrgDomainIsPrimaryCol.identifier(),
+			OrgDomainIsLoginDomainCol.identifier(),
 			OrgDomainValidationTypeCol.identifier(),
 			countColumn.identifier(),
 		).From(orgDomainsTable.identifier()).PlaceholderFormat(sq.Dollar),
@@ -96,6 +102,7 @@ func prepareDomainsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Domains, error))
 					&domain.OrgID,
 					&domain.IsVerified,
 					&domain.IsPrimary,
+					&domain.IsLoginDomain,
 					&domain.ValidationType,
 					&count,
 				)
@@ -151,6 +158,10 @@ var (
 		name:  projection.OrgDomainIsPrimaryCol,
 		table: orgDomainsTable,
 	}
+	OrgDomainIsLoginDomainCol = Column{
+		name:  projection.OrgDomainIsLoginDomainCol,
+		table: orgDomainsTable,
+	}
 	OrgDomainValidationTypeCol = Column{
 		name:  projection.OrgDomainValidationTypeCol,
 		table: orgDomainsTable,
@@ -34,6 +34,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						` zitadel.projections.org_domains.org_id,`+
 						` zitadel.projections.org_domains.is_verified,`+
 						` zitadel.projections.org_domains.is_primary,`+
+						` zitadel.projections.org_domains.is_login_domain,`+
 						` zitadel.projections.org_domains.validation_type,`+
 						` COUNT(*) OVER ()`+
 						` FROM zitadel.projections.org_domains`),
@@ -55,18 +56,20 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						` zitadel.projections.org_domains.org_id,`+
 						` zitadel.projections.org_domains.is_verified,`+
 						` zitadel.projections.org_domains.is_primary,`+
+						` zitadel.projections.org_domains.is_login_domain,`+
 						` zitadel.projections.org_domains.validation_type,`+
 						` COUNT(*) OVER ()`+
 						` FROM zitadel.projections.org_domains`),
 					[]string{
-						"id",
 						"creation_date",
 						"change_date",
-						"resource_owner",
-						"org_state",
 						"sequence",
-						"name",
-						"primary_domain",
+						"domain",
+						"org_id",
+						"is_verified",
+						"is_primary",
+						"is_login_domain",
+						"validation_type",
 						"count",
 					},
 					[][]driver.Value{
@@ -78,6 +81,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 							"ro",
 							true,
 							true,
+							true,
 							domain.OrgDomainValidationTypeHTTP,
 						},
 					},
@@ -96,6 +100,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						OrgID:          "ro",
 						IsVerified:     true,
 						IsPrimary:      true,
+						IsLoginDomain:  true,
 						ValidationType: domain.OrgDomainValidationTypeHTTP,
 					},
 				},
@@ -113,18 +118,20 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						` zitadel.projections.org_domains.org_id,`+
 						` zitadel.projections.org_domains.is_verified,`+
 						` zitadel.projections.org_domains.is_primary,`+
+						` zitadel.projections.org_domains.is_login_domain,`+
 						` zitadel.projections.org_domains.validation_type,`+
 						` COUNT(*) OVER ()`+
 						` FROM zitadel.projections.org_domains`),
 					[]string{
-						"id",
 						"creation_date",
 						"change_date",
-						"resource_owner",
-						"org_state",
 						"sequence",
-						"name",
-						"primary_domain",
+						"domain",
+						"org_id",
+						"is_verified",
+						"is_primary",
+						"is_login_domain",
+						"validation_type",
 						"count",
 					},
 					[][]driver.Value{
@@ -136,6 +143,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 							"ro",
 							true,
 							true,
+							true,
 							domain.OrgDomainValidationTypeHTTP,
 						},
 						{
@@ -146,6 +154,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 							"ro",
 							false,
 							false,
+							false,
 							domain.OrgDomainValidationTypeDNS,
 						},
 					},
@@ -164,6 +173,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						OrgID:          "ro",
 						IsVerified:     true,
 						IsPrimary:      true,
+						IsLoginDomain:  true,
 						ValidationType: domain.OrgDomainValidationTypeHTTP,
 					},
 					{
@@ -174,6 +184,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						OrgID:          "ro",
 						IsVerified:     false,
 						IsPrimary:      false,
+						IsLoginDomain:  false,
 						ValidationType: domain.OrgDomainValidationTypeDNS,
 					},
 				},
@@ -191,6 +202,7 @@ func Test_OrgDomainPrepares(t *testing.T) {
 						` zitadel.projections.org_domains.org_id,`+
 						` zitadel.projections.org_domains.is_verified,`+
 						` zitadel.projections.org_domains.is_primary,`+
+						` zitadel.projections.org_domains.is_login_domain,`+
 						` zitadel.projections.org_domains.validation_type,`+
 						` COUNT(*) OVER ()`+
 						` FROM zitadel.projections.org_domains`),
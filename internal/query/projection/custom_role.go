@@ -0,0 +1,113 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+	"github.com/lib/pq"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type CustomRoleProjection struct {
+	crdb.StatementHandler
+}
+
+const CustomRoleProjectionTable = "zitadel.projections.custom_roles"
+
+func NewCustomRoleProjection(ctx context.Context, config crdb.StatementHandlerConfig) *CustomRoleProjection {
+	p := &CustomRoleProjection{}
+	config.ProjectionName = CustomRoleProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *CustomRoleProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: iam.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  iam.CustomRoleAddedEventType,
+					Reduce: p.reduceCustomRoleAdded,
+				},
+				{
+					Event:  iam.CustomRoleChangedEventType,
+					Reduce: p.reduceCustomRoleChanged,
+				},
+				{
+					Event:  iam.CustomRoleRemovedEventType,
+					Reduce: p.reduceCustomRoleRemoved,
+				},
+			},
+		},
+	}
+}
+
+const (
+	CustomRoleColumnRole          = "role"
+	CustomRoleColumnCreationDate  = "creation_date"
+	CustomRoleColumnChangeDate    = "change_date"
+	CustomRoleColumnResourceOwner = "resource_owner"
+	CustomRoleColumnSequence      = "sequence"
+	CustomRoleColumnScope         = "scope"
+	CustomRoleColumnPermissions   = "permissions"
+)
+
+func (p *CustomRoleProjection) reduceCustomRoleAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.CustomRoleAddedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Cr2aW", "seq", event.Sequence(), "expectedType", iam.CustomRoleAddedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Cr3aW", "reduce.wrong.event.type")
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(CustomRoleColumnRole, e.Role),
+			handler.NewCol(CustomRoleColumnCreationDate, e.CreationDate()),
+			handler.NewCol(CustomRoleColumnChangeDate, e.CreationDate()),
+			handler.NewCol(CustomRoleColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(CustomRoleColumnSequence, e.Sequence()),
+			handler.NewCol(CustomRoleColumnScope, e.Scope),
+			handler.NewCol(CustomRoleColumnPermissions, pq.StringArray(e.Permissions)),
+		},
+	), nil
+}
+
+func (p *CustomRoleProjection) reduceCustomRoleChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.CustomRoleChangedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Cr2cW", "seq", event.Sequence(), "expectedType", iam.CustomRoleChangedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Cr3cW", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(CustomRoleColumnChangeDate, e.CreationDate()),
+			handler.NewCol(CustomRoleColumnSequence, e.Sequence()),
+			handler.NewCol(CustomRoleColumnPermissions, pq.StringArray(e.Permissions)),
+		},
+		[]handler.Condition{
+			handler.NewCond(CustomRoleColumnRole, e.Role),
+		},
+	), nil
+}
+
+func (p *CustomRoleProjection) reduceCustomRoleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.CustomRoleRemovedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Cr2rW", "seq", event.Sequence(), "expectedType", iam.CustomRoleRemovedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Cr3rW", "reduce.wrong.event.type")
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(CustomRoleColumnRole, e.Role),
+		},
+	), nil
+}
@@ -0,0 +1,130 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+func TestCustomRoleProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceCustomRoleAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.CustomRoleAddedEventType),
+					iam.AggregateType,
+					[]byte(`{"role": "IAM_AUDITOR", "scope": 1, "permissions": ["iam.read", "org.read"]}`),
+				), iam.CustomRoleAddedEventMapper),
+			},
+			reduce: (&CustomRoleProjection{}).reduceCustomRoleAdded,
+			want: wantReduce{
+				projection:       CustomRoleProjectionTable,
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO zitadel.projections.custom_roles (role, creation_date, change_date, resource_owner, sequence, scope, permissions) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"IAM_AUDITOR",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								uint64(15),
+								domain.CustomRoleScopeIAM,
+								pq.StringArray{"iam.read", "org.read"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCustomRoleChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.CustomRoleChangedEventType),
+					iam.AggregateType,
+					[]byte(`{"role": "IAM_AUDITOR", "permissions": ["iam.read"]}`),
+				), iam.CustomRoleChangedEventMapper),
+			},
+			reduce: (&CustomRoleProjection{}).reduceCustomRoleChanged,
+			want: wantReduce{
+				projection:       CustomRoleProjectionTable,
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.custom_roles SET (change_date, sequence, permissions) = ($1, $2, $3) WHERE (role = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								pq.StringArray{"iam.read"},
+								"IAM_AUDITOR",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCustomRoleRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.CustomRoleRemovedEventType),
+					iam.AggregateType,
+					[]byte(`{"role": "IAM_AUDITOR"}`),
+				), iam.CustomRoleRemovedEventMapper),
+			},
+			reduce: (&CustomRoleProjection{}).reduceCustomRoleRemoved,
+			want: wantReduce{
+				projection:       CustomRoleProjectionTable,
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM zitadel.projections.custom_roles WHERE (role = $1)",
+							expectedArgs: []interface{}{
+								"IAM_AUDITOR",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+//pushEventSkipped pushes an event to the iam
+// as soon as a projection gave up on an event and the skip is committed
+func pushEventSkipped(es *eventstore.Eventstore) crdb.EventSkipped {
+	return func(ctx context.Context, projectionName string, sequence uint64, failureCount uint, err error) {
+		_, pushErr := es.Push(ctx, iam.NewProjectionEventSkippedEvent(
+			ctx,
+			&iam.NewAggregate().Aggregate,
+			projectionName,
+			sequence,
+			failureCount,
+			err.Error(),
+		))
+		logging.LogWithFields("PROJE-sJ2mW", "projection", projectionName, "seq", sequence).OnError(pushErr).Warn("unable to push event skipped")
+	}
+}
@@ -27,6 +27,10 @@ const (
 	LockoutPolicyIDCol                  = "id"
 	LockoutPolicyStateCol               = "state"
 	LockoutPolicyMaxPasswordAttemptsCol = "max_password_attempts"
+	LockoutPolicyMaxOTPAttemptsCol      = "max_otp_attempts"
+	LockoutPolicyLockoutDurationCol     = "lockout_duration"
+	LockoutPolicyMaxIPAttemptsCol       = "max_ip_attempts"
+	LockoutPolicyIPThrottleDurationCol  = "ip_throttle_duration"
 	LockoutPolicyShowLockOutFailuresCol = "show_failure"
 	LockoutPolicyIsDefaultCol           = "is_default"
 	LockoutPolicyResourceOwnerCol       = "resource_owner"
@@ -98,6 +102,10 @@ func (p *LockoutPolicyProjection) reduceAdded(event eventstore.Event) (*handler.
 			handler.NewCol(LockoutPolicyIDCol, policyEvent.Aggregate().ID),
 			handler.NewCol(LockoutPolicyStateCol, domain.PolicyStateActive),
 			handler.NewCol(LockoutPolicyMaxPasswordAttemptsCol, policyEvent.MaxPasswordAttempts),
+			handler.NewCol(LockoutPolicyMaxOTPAttemptsCol, policyEvent.MaxOTPAttempts),
+			handler.NewCol(LockoutPolicyLockoutDurationCol, policyEvent.LockoutDuration),
+			handler.NewCol(LockoutPolicyMaxIPAttemptsCol, policyEvent.MaxIPAttempts),
+			handler.NewCol(LockoutPolicyIPThrottleDurationCol, policyEvent.IPThrottleDuration),
 			handler.NewCol(LockoutPolicyShowLockOutFailuresCol, policyEvent.ShowLockOutFailures),
 			handler.NewCol(LockoutPolicyIsDefaultCol, isDefault),
 			handler.NewCol(LockoutPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
@@ -122,6 +130,18 @@ func (p *LockoutPolicyProjection) reduceChanged(event eventstore.Event) (*handle
 	if policyEvent.MaxPasswordAttempts != nil {
 		cols = append(cols, handler.NewCol(LockoutPolicyMaxPasswordAttemptsCol, *policyEvent.MaxPasswordAttempts))
 	}
+	if policyEvent.MaxOTPAttempts != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyMaxOTPAttemptsCol, *policyEvent.MaxOTPAttempts))
+	}
+	if policyEvent.LockoutDuration != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyLockoutDurationCol, *policyEvent.LockoutDuration))
+	}
+	if policyEvent.MaxIPAttempts != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyMaxIPAttemptsCol, *policyEvent.MaxIPAttempts))
+	}
+	if policyEvent.IPThrottleDuration != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyIPThrottleDurationCol, *policyEvent.IPThrottleDuration))
+	}
 	if policyEvent.ShowLockOutFailures != nil {
 		cols = append(cols, handler.NewCol(LockoutPolicyShowLockOutFailuresCol, *policyEvent.ShowLockOutFailures))
 	}
@@ -2,6 +2,7 @@ package projection
 
 import (
 	"testing"
+	"time"
 
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/errors"
@@ -30,6 +31,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					org.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
+						"maxOTPAttempts": 5,
+						"lockoutDuration": 3600000000000,
+						"maxIPAttempts": 100,
+						"ipThrottleDuration": 60000000000,
 						"showLockOutFailures": true
 }`),
 				), org.LockoutPolicyAddedEventMapper),
@@ -43,7 +48,7 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, show_failure, is_default, resource_owner) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, max_otp_attempts, lockout_duration, max_ip_attempts, ip_throttle_duration, show_failure, is_default, resource_owner) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -51,6 +56,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								uint64(10),
+								uint64(5),
+								time.Hour,
+								uint64(100),
+								time.Minute,
 								true,
 								false,
 								"ro-id",
@@ -69,6 +78,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					org.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
+						"maxOTPAttempts": 5,
+						"lockoutDuration": 3600000000000,
+						"maxIPAttempts": 100,
+						"ipThrottleDuration": 60000000000,
 						"showLockOutFailures": true
 		}`),
 				), org.LockoutPolicyChangedEventMapper),
@@ -81,11 +94,15 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE zitadel.projections.lockout_policies SET (change_date, sequence, max_password_attempts, show_failure) = ($1, $2, $3, $4) WHERE (id = $5)",
+							expectedStmt: "UPDATE zitadel.projections.lockout_policies SET (change_date, sequence, max_password_attempts, max_otp_attempts, lockout_duration, max_ip_attempts, ip_throttle_duration, show_failure) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (id = $9)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								uint64(10),
+								uint64(5),
+								time.Hour,
+								uint64(100),
+								time.Minute,
 								true,
 								"agg-id",
 							},
@@ -130,6 +147,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					iam.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
+						"maxOTPAttempts": 5,
+						"lockoutDuration": 3600000000000,
+						"maxIPAttempts": 100,
+						"ipThrottleDuration": 60000000000,
 						"showLockOutFailures": true
 					}`),
 				), iam.LockoutPolicyAddedEventMapper),
@@ -142,7 +163,7 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, show_failure, is_default, resource_owner) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, max_otp_attempts, lockout_duration, max_ip_attempts, ip_throttle_duration, show_failure, is_default, resource_owner) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -150,6 +171,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								uint64(10),
+								uint64(5),
+								time.Hour,
+								uint64(100),
+								time.Minute,
 								true,
 								true,
 								"ro-id",
@@ -168,6 +193,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					iam.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
+						"maxOTPAttempts": 5,
+						"lockoutDuration": 3600000000000,
+						"maxIPAttempts": 100,
+						"ipThrottleDuration": 60000000000,
 						"showLockOutFailures": true
 					}`),
 				), iam.LockoutPolicyChangedEventMapper),
@@ -180,11 +209,15 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE zitadel.projections.lockout_policies SET (change_date, sequence, max_password_attempts, show_failure) = ($1, $2, $3, $4) WHERE (id = $5)",
+							expectedStmt: "UPDATE zitadel.projections.lockout_policies SET (change_date, sequence, max_password_attempts, max_otp_attempts, lockout_duration, max_ip_attempts, ip_throttle_duration, show_failure) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (id = $9)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								uint64(10),
+								uint64(5),
+								time.Hour,
+								uint64(100),
+								time.Minute,
 								true,
 								"agg-id",
 							},
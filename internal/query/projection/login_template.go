@@ -0,0 +1,85 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+type LoginTemplateProjection struct {
+	crdb.StatementHandler
+}
+
+const (
+	LoginTemplateTable = "zitadel.projections.login_templates"
+
+	LoginTemplateAggregateIDCol  = "aggregate_id"
+	LoginTemplateTemplateCol     = "template"
+	LoginTemplateCreationDateCol = "creation_date"
+	LoginTemplateChangeDateCol   = "change_date"
+	LoginTemplateSequenceCol     = "sequence"
+	LoginTemplateAssetKeyCol     = "asset_key"
+)
+
+func NewLoginTemplateProjection(ctx context.Context, config crdb.StatementHandlerConfig) *LoginTemplateProjection {
+	p := &LoginTemplateProjection{}
+	config.ProjectionName = LoginTemplateTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *LoginTemplateProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.LoginTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.LoginTemplateRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *LoginTemplateProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.LoginTemplateSetEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Tp9sW", "seq", event.Sequence(), "expectedType", org.LoginTemplateSetEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Tp9sR", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(LoginTemplateAggregateIDCol, e.Aggregate().ID),
+			handler.NewCol(LoginTemplateTemplateCol, e.Template),
+			handler.NewCol(LoginTemplateCreationDateCol, e.CreationDate()),
+			handler.NewCol(LoginTemplateChangeDateCol, e.CreationDate()),
+			handler.NewCol(LoginTemplateSequenceCol, e.Sequence()),
+			handler.NewCol(LoginTemplateAssetKeyCol, e.AssetKey),
+		}), nil
+}
+
+func (p *LoginTemplateProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.LoginTemplateRemovedEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Tp9rW", "seq", event.Sequence(), "expectedType", org.LoginTemplateRemovedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Tp9rR", "reduce.wrong.event.type")
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(LoginTemplateAggregateIDCol, e.Aggregate().ID),
+			handler.NewCond(LoginTemplateTemplateCol, e.Template),
+		}), nil
+}
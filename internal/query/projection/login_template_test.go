@@ -0,0 +1,97 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+func TestLoginTemplateProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org.reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.LoginTemplateSetEventType),
+					org.AggregateType,
+					[]byte(`{"template": "login.html", "assetKey": "login/templates/login.html-id1"}`),
+				), org.LoginTemplateSetEventMapper),
+			},
+			reduce: (&LoginTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       LoginTemplateTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO zitadel.projections.login_templates (aggregate_id, template, creation_date, change_date, sequence, asset_key) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"login.html",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"login/templates/login.html-id1",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.LoginTemplateRemovedEventType),
+					org.AggregateType,
+					[]byte(`{"template": "login.html"}`),
+				), org.LoginTemplateRemovedEventMapper),
+			},
+			reduce: (&LoginTemplateProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       LoginTemplateTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM zitadel.projections.login_templates WHERE (aggregate_id = $1) AND (template = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"login.html",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/iam"
+	"github.com/caos/zitadel/internal/repository/org"
+	"github.com/caos/zitadel/internal/repository/policy"
+)
+
+type MailMessageTemplateProjection struct {
+	crdb.StatementHandler
+}
+
+const (
+	MailMessageTemplateTable = "zitadel.projections.mail_message_templates"
+
+	MailMessageTemplateAggregateIDCol  = "aggregate_id"
+	MailMessageTemplateMessageTypeCol  = "message_type"
+	MailMessageTemplateCreationDateCol = "creation_date"
+	MailMessageTemplateChangeDateCol   = "change_date"
+	MailMessageTemplateSequenceCol     = "sequence"
+	MailMessageTemplateIsDefaultCol    = "is_default"
+	MailMessageTemplateTemplateCol     = "template"
+)
+
+func NewMailMessageTemplateProjection(ctx context.Context, config crdb.StatementHandlerConfig) *MailMessageTemplateProjection {
+	p := &MailMessageTemplateProjection{}
+	config.ProjectionName = MailMessageTemplateTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *MailMessageTemplateProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.MailMessageTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.MailMessageTemplateResetEventType,
+					Reduce: p.reduceReset,
+				},
+			},
+		},
+		{
+			Aggregate: iam.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  iam.MailMessageTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  iam.MailMessageTemplateResetEventType,
+					Reduce: p.reduceReset,
+				},
+			},
+		},
+	}
+}
+
+func (p *MailMessageTemplateProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	var templateEvent policy.MailMessageTemplateSetEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.MailMessageTemplateSetEvent:
+		templateEvent = e.MailMessageTemplateSetEvent
+		isDefault = false
+	case *iam.MailMessageTemplateSetEvent:
+		templateEvent = e.MailMessageTemplateSetEvent
+		isDefault = true
+	default:
+		logging.LogWithFields("PROJE-Mt6sW", "seq", event.Sequence(), "expectedTypes", []eventstore.EventType{org.MailMessageTemplateSetEventType, iam.MailMessageTemplateSetEventType}).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Mt6sR", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpsertStatement(
+		&templateEvent,
+		[]handler.Column{
+			handler.NewCol(MailMessageTemplateAggregateIDCol, templateEvent.Aggregate().ID),
+			handler.NewCol(MailMessageTemplateMessageTypeCol, templateEvent.MessageType),
+			handler.NewCol(MailMessageTemplateCreationDateCol, templateEvent.CreationDate()),
+			handler.NewCol(MailMessageTemplateChangeDateCol, templateEvent.CreationDate()),
+			handler.NewCol(MailMessageTemplateSequenceCol, templateEvent.Sequence()),
+			handler.NewCol(MailMessageTemplateIsDefaultCol, isDefault),
+			handler.NewCol(MailMessageTemplateTemplateCol, templateEvent.Template),
+		}), nil
+}
+
+func (p *MailMessageTemplateProjection) reduceReset(event eventstore.Event) (*handler.Statement, error) {
+	var templateEvent policy.MailMessageTemplateResetEvent
+	switch e := event.(type) {
+	case *org.MailMessageTemplateResetEvent:
+		templateEvent = e.MailMessageTemplateResetEvent
+	case *iam.MailMessageTemplateResetEvent:
+		templateEvent = e.MailMessageTemplateResetEvent
+	default:
+		logging.LogWithFields("PROJE-Mt6rW", "seq", event.Sequence(), "expectedTypes", []eventstore.EventType{org.MailMessageTemplateResetEventType, iam.MailMessageTemplateResetEventType}).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Mt6rR", "reduce.wrong.event.type")
+	}
+	return crdb.NewDeleteStatement(
+		&templateEvent,
+		[]handler.Condition{
+			handler.NewCond(MailMessageTemplateAggregateIDCol, templateEvent.Aggregate().ID),
+			handler.NewCond(MailMessageTemplateMessageTypeCol, templateEvent.MessageType),
+		}), nil
+}
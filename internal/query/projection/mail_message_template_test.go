@@ -0,0 +1,161 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/iam"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+func TestMailMessageTemplateProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org.reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MailMessageTemplateSetEventType),
+					org.AggregateType,
+					[]byte(`{"messageType": "InitCode", "template": "PHRhYmxlPjwvdGFibGU+"}`),
+				), org.MailMessageTemplateSetEventMapper),
+			},
+			reduce: (&MailMessageTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       MailMessageTemplateTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO zitadel.projections.mail_message_templates (aggregate_id, message_type, creation_date, change_date, sequence, is_default, template) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.InitCodeMessageType,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								false,
+								[]byte("<table></table>"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceReset",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MailMessageTemplateResetEventType),
+					org.AggregateType,
+					[]byte(`{"messageType": "InitCode"}`),
+				), org.MailMessageTemplateResetEventMapper),
+			},
+			reduce: (&MailMessageTemplateProjection{}).reduceReset,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       MailMessageTemplateTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM zitadel.projections.mail_message_templates WHERE (aggregate_id = $1) AND (message_type = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.InitCodeMessageType,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "iam.reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.MailMessageTemplateSetEventType),
+					iam.AggregateType,
+					[]byte(`{"messageType": "InitCode", "template": "PHRhYmxlPjwvdGFibGU+"}`),
+				), iam.MailMessageTemplateSetEventMapper),
+			},
+			reduce: (&MailMessageTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       MailMessageTemplateTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO zitadel.projections.mail_message_templates (aggregate_id, message_type, creation_date, change_date, sequence, is_default, template) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.InitCodeMessageType,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+								[]byte("<table></table>"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "iam.reduceReset",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.MailMessageTemplateResetEventType),
+					iam.AggregateType,
+					[]byte(`{"messageType": "InitCode"}`),
+				), iam.MailMessageTemplateResetEventMapper),
+			},
+			reduce: (&MailMessageTemplateProjection{}).reduceReset,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       MailMessageTemplateTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM zitadel.projections.mail_message_templates WHERE (aggregate_id = $1) AND (message_type = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.InitCodeMessageType,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
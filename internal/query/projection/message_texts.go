@@ -215,7 +215,8 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyEmailMessageType ||
 		template == domain.VerifyPhoneMessageType ||
 		template == domain.DomainClaimedMessageType ||
-		template == domain.PasswordlessRegistrationMessageType
+		template == domain.PasswordlessRegistrationMessageType ||
+		domain.IsSecurityAlertMessageType(template)
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,186 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/notification"
+)
+
+type NotificationProjection struct {
+	crdb.StatementHandler
+}
+
+const (
+	NotificationTable = "zitadel.projections.notifications"
+
+	NotificationIDCol            = "id"
+	NotificationCreationDateCol  = "creation_date"
+	NotificationChangeDateCol    = "change_date"
+	NotificationSequenceCol      = "sequence"
+	NotificationResourceOwnerCol = "resource_owner"
+	NotificationUserIDCol        = "user_id"
+	NotificationMessageTypeCol   = "message_type"
+	NotificationChannelCol       = "channel"
+	NotificationRecipientCol     = "recipient"
+	NotificationMessageCol       = "message"
+	NotificationStateCol         = "state"
+	NotificationAttemptsCol      = "attempts"
+	NotificationLastErrorCol     = "last_error"
+	NotificationNextAttemptCol   = "next_attempt"
+)
+
+func NewNotificationProjection(ctx context.Context, config crdb.StatementHandlerConfig) *NotificationProjection {
+	p := &NotificationProjection{}
+	config.ProjectionName = NotificationTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *NotificationProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  notification.QueuedEventType,
+					Reduce: p.reduceQueued,
+				},
+				{
+					Event:  notification.SentEventType,
+					Reduce: p.reduceSent,
+				},
+				{
+					Event:  notification.RetryScheduledEventType,
+					Reduce: p.reduceRetryScheduled,
+				},
+				{
+					Event:  notification.FailedEventType,
+					Reduce: p.reduceFailed,
+				},
+				{
+					Event:  notification.ResendRequestedEventType,
+					Reduce: p.reduceResendRequested,
+				},
+			},
+		},
+	}
+}
+
+func (p *NotificationProjection) reduceQueued(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.QueuedEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Nt8qL", "seq", event.Sequence(), "expectedType", notification.QueuedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Nt8qE", "reduce.wrong.event.type")
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationIDCol, e.Aggregate().ID),
+			handler.NewCol(NotificationCreationDateCol, e.CreationDate()),
+			handler.NewCol(NotificationChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationSequenceCol, e.Sequence()),
+			handler.NewCol(NotificationResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationUserIDCol, e.UserID),
+			handler.NewCol(NotificationMessageTypeCol, e.MessageType),
+			handler.NewCol(NotificationChannelCol, e.Channel),
+			handler.NewCol(NotificationRecipientCol, e.Recipient),
+			handler.NewCol(NotificationMessageCol, e.Message),
+			handler.NewCol(NotificationStateCol, domain.NotificationStateQueued),
+			handler.NewCol(NotificationAttemptsCol, 0),
+			handler.NewCol(NotificationNextAttemptCol, e.CreationDate()),
+		},
+	), nil
+}
+
+func (p *NotificationProjection) reduceSent(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.SentEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Nt8sL", "seq", event.Sequence(), "expectedType", notification.SentEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Nt8sE", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationSequenceCol, e.Sequence()),
+			handler.NewCol(NotificationStateCol, domain.NotificationStateSent),
+			crdb.NewIncrementCol(NotificationAttemptsCol, 1),
+			handler.NewCol(NotificationLastErrorCol, ""),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *NotificationProjection) reduceRetryScheduled(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.RetryScheduledEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Nt8rL", "seq", event.Sequence(), "expectedType", notification.RetryScheduledEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Nt8rE", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationSequenceCol, e.Sequence()),
+			handler.NewCol(NotificationStateCol, domain.NotificationStateRetrying),
+			crdb.NewIncrementCol(NotificationAttemptsCol, 1),
+			handler.NewCol(NotificationLastErrorCol, e.Error),
+			handler.NewCol(NotificationNextAttemptCol, e.NextAttempt),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *NotificationProjection) reduceFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.FailedEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Nt8fL", "seq", event.Sequence(), "expectedType", notification.FailedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Nt8fE", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationSequenceCol, e.Sequence()),
+			handler.NewCol(NotificationStateCol, domain.NotificationStateFailed),
+			crdb.NewIncrementCol(NotificationAttemptsCol, 1),
+			handler.NewCol(NotificationLastErrorCol, e.Error),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *NotificationProjection) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*notification.ResendRequestedEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Nt8aL", "seq", event.Sequence(), "expectedType", notification.ResendRequestedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Nt8aE", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationChangeDateCol, e.CreationDate()),
+			handler.NewCol(NotificationSequenceCol, e.Sequence()),
+			handler.NewCol(NotificationStateCol, domain.NotificationStateQueued),
+			handler.NewCol(NotificationAttemptsCol, 0),
+			handler.NewCol(NotificationLastErrorCol, ""),
+			handler.NewCol(NotificationNextAttemptCol, e.CreationDate()),
+		},
+		[]handler.Condition{
+			handler.NewCond(NotificationIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
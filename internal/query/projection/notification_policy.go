@@ -0,0 +1,167 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/caos/logging"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/iam"
+	"github.com/caos/zitadel/internal/repository/org"
+	"github.com/caos/zitadel/internal/repository/policy"
+)
+
+type NotificationPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+const (
+	NotificationPolicyTable = "zitadel.projections.notification_policies"
+
+	NotificationPolicyCreationDateCol    = "creation_date"
+	NotificationPolicyChangeDateCol      = "change_date"
+	NotificationPolicySequenceCol        = "sequence"
+	NotificationPolicyIDCol              = "id"
+	NotificationPolicyStateCol           = "state"
+	NotificationPolicyPasswordChangedCol = "password_changed"
+	NotificationPolicyMFAAddedCol        = "mfa_added"
+	NotificationPolicyMFARemovedCol      = "mfa_removed"
+	NotificationPolicyNewUserAgentCol    = "new_user_agent"
+	NotificationPolicyUserLockedCol      = "user_locked"
+	NotificationPolicyEmailChangedCol    = "email_changed"
+	NotificationPolicyIsDefaultCol       = "is_default"
+	NotificationPolicyResourceOwnerCol   = "resource_owner"
+)
+
+func NewNotificationPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *NotificationPolicyProjection {
+	p := &NotificationPolicyProjection{}
+	config.ProjectionName = NotificationPolicyTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *NotificationPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.NotificationPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.NotificationPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.NotificationPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: iam.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  iam.NotificationPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  iam.NotificationPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *NotificationPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.NotificationPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.NotificationPolicyAddedEvent:
+		policyEvent = e.NotificationPolicyAddedEvent
+		isDefault = false
+	case *iam.NotificationPolicyAddedEvent:
+		policyEvent = e.NotificationPolicyAddedEvent
+		isDefault = true
+	default:
+		logging.LogWithFields("PROJE-Np5aL", "seq", event.Sequence(), "expectedTypes", []eventstore.EventType{org.NotificationPolicyAddedEventType, iam.NotificationPolicyAddedEventType}).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Np5aE", "reduce.wrong.event.type")
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(NotificationPolicyCreationDateCol, policyEvent.CreationDate()),
+			handler.NewCol(NotificationPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(NotificationPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(NotificationPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCol(NotificationPolicyStateCol, domain.PolicyStateActive),
+			handler.NewCol(NotificationPolicyPasswordChangedCol, policyEvent.PasswordChanged),
+			handler.NewCol(NotificationPolicyMFAAddedCol, policyEvent.MFAAdded),
+			handler.NewCol(NotificationPolicyMFARemovedCol, policyEvent.MFARemoved),
+			handler.NewCol(NotificationPolicyNewUserAgentCol, policyEvent.NewUserAgent),
+			handler.NewCol(NotificationPolicyUserLockedCol, policyEvent.UserLocked),
+			handler.NewCol(NotificationPolicyEmailChangedCol, policyEvent.EmailChanged),
+			handler.NewCol(NotificationPolicyIsDefaultCol, isDefault),
+			handler.NewCol(NotificationPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
+		}), nil
+}
+
+func (p *NotificationPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.NotificationPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.NotificationPolicyChangedEvent:
+		policyEvent = e.NotificationPolicyChangedEvent
+	case *iam.NotificationPolicyChangedEvent:
+		policyEvent = e.NotificationPolicyChangedEvent
+	default:
+		logging.LogWithFields("PROJE-Np5cL", "seq", event.Sequence(), "expectedTypes", []eventstore.EventType{org.NotificationPolicyChangedEventType, iam.NotificationPolicyChangedEventType}).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Np5cE", "reduce.wrong.event.type")
+	}
+	cols := []handler.Column{
+		handler.NewCol(NotificationPolicyChangeDateCol, policyEvent.CreationDate()),
+		handler.NewCol(NotificationPolicySequenceCol, policyEvent.Sequence()),
+	}
+	if policyEvent.PasswordChanged != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyPasswordChangedCol, *policyEvent.PasswordChanged))
+	}
+	if policyEvent.MFAAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyMFAAddedCol, *policyEvent.MFAAdded))
+	}
+	if policyEvent.MFARemoved != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyMFARemovedCol, *policyEvent.MFARemoved))
+	}
+	if policyEvent.NewUserAgent != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyNewUserAgentCol, *policyEvent.NewUserAgent))
+	}
+	if policyEvent.UserLocked != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyUserLockedCol, *policyEvent.UserLocked))
+	}
+	if policyEvent.EmailChanged != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyEmailChangedCol, *policyEvent.EmailChanged))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(NotificationPolicyIDCol, policyEvent.Aggregate().ID),
+		}), nil
+}
+
+func (p *NotificationPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.NotificationPolicyRemovedEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Np5rL", "seq", event.Sequence(), "expectedType", org.NotificationPolicyRemovedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Np5rE", "reduce.wrong.event.type")
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(NotificationPolicyIDCol, policyEvent.Aggregate().ID),
+		}), nil
+}
@@ -0,0 +1,223 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/notification"
+)
+
+func TestNotificationProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceQueued",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.QueuedEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"userId": "user-id",
+						"messageType": "InitCode",
+						"channel": 0,
+						"recipient": "user@caos.ch",
+						"message": {
+							"cryptoType": 1,
+							"algorithm": "enc",
+							"keyID": "id",
+							"crypted": "bWVzc2FnZQ=="
+						}
+}`),
+				), notification.QueuedEventMapper),
+			},
+			reduce: (&NotificationProjection{}).reduceQueued,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       NotificationTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO zitadel.projections.notifications (id, creation_date, change_date, sequence, resource_owner, user_id, message_type, channel, recipient, message, state, attempts, next_attempt) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"user-id",
+								"InitCode",
+								domain.NotificationTypeEmail,
+								"user@caos.ch",
+								anyArg{},
+								domain.NotificationStateQueued,
+								0,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSent",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.SentEventType),
+					notification.AggregateType,
+					nil,
+				), notification.SentEventMapper),
+			},
+			reduce: (&NotificationProjection{}).reduceSent,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       NotificationTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.notifications SET (change_date, sequence, state, attempts, last_error) = ($1, $2, $3, attempts + $4, $5) WHERE (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateSent,
+								1,
+								"",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRetryScheduled",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.RetryScheduledEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"error": "smtp unavailable",
+						"nextAttempt": "2021-10-01T12:00:00Z"
+}`),
+				), notification.RetryScheduledEventMapper),
+			},
+			reduce: (&NotificationProjection{}).reduceRetryScheduled,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       NotificationTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.notifications SET (change_date, sequence, state, attempts, last_error, next_attempt) = ($1, $2, $3, attempts + $4, $5, $6) WHERE (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateRetrying,
+								1,
+								"smtp unavailable",
+								anyArg{},
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFailed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.FailedEventType),
+					notification.AggregateType,
+					[]byte(`{
+						"error": "smtp unavailable"
+}`),
+				), notification.FailedEventMapper),
+			},
+			reduce: (&NotificationProjection{}).reduceFailed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       NotificationTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.notifications SET (change_date, sequence, state, attempts, last_error) = ($1, $2, $3, attempts + $4, $5) WHERE (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateFailed,
+								1,
+								"smtp unavailable",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceResendRequested",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(notification.ResendRequestedEventType),
+					notification.AggregateType,
+					nil,
+				), notification.ResendRequestedEventMapper),
+			},
+			reduce: (&NotificationProjection{}).reduceResendRequested,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("notification"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       NotificationTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.notifications SET (change_date, sequence, state, attempts, last_error, next_attempt) = ($1, $2, $3, $4, $5, $6) WHERE (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateQueued,
+								0,
+								"",
+								anyArg{},
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
@@ -50,6 +50,14 @@ func (p *OrgDomainProjection) reducers() []handler.AggregateReducer {
 					Event:  org.OrgDomainPrimarySetEventType,
 					Reduce: p.reducePrimaryDomainSet,
 				},
+				{
+					Event:  org.OrgDomainLoginSetEventType,
+					Reduce: p.reduceLoginDomainSet,
+				},
+				{
+					Event:  org.OrgDomainLoginRemovedEventType,
+					Reduce: p.reduceLoginDomainRemoved,
+				},
 				{
 					Event:  org.OrgDomainRemovedEventType,
 					Reduce: p.reduceDomainRemoved,
@@ -67,6 +75,7 @@ const (
 	OrgDomainOrgIDCol          = "org_id"
 	OrgDomainIsVerifiedCol     = "is_verified"
 	OrgDomainIsPrimaryCol      = "is_primary"
+	OrgDomainIsLoginDomainCol  = "is_login_domain"
 	OrgDomainValidationTypeCol = "validation_type"
 )
 
@@ -86,6 +95,7 @@ func (p *OrgDomainProjection) reduceDomainAdded(event eventstore.Event) (*handle
 			handler.NewCol(OrgDomainOrgIDCol, e.Aggregate().ID),
 			handler.NewCol(OrgDomainIsVerifiedCol, false),
 			handler.NewCol(OrgDomainIsPrimaryCol, false),
+			handler.NewCol(OrgDomainIsLoginDomainCol, false),
 			handler.NewCol(OrgDomainValidationTypeCol, domain.OrgDomainValidationTypeUnspecified),
 		},
 	), nil
@@ -164,6 +174,46 @@ func (p *OrgDomainProjection) reducePrimaryDomainSet(event eventstore.Event) (*h
 	), nil
 }
 
+func (p *OrgDomainProjection) reduceLoginDomainSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainLoginSetEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Lg2sE", "seq", event.Sequence(), "expectedType", org.OrgDomainLoginSetEventType, "gottenType", fmt.Sprintf("%T", event)).Error("unexpected event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Lg2sW", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgDomainChangeDateCol, e.CreationDate()),
+			handler.NewCol(OrgDomainSequenceCol, e.Sequence()),
+			handler.NewCol(OrgDomainIsLoginDomainCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(OrgDomainDomainCol, e.Domain),
+			handler.NewCond(OrgDomainOrgIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *OrgDomainProjection) reduceLoginDomainRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.DomainLoginRemovedEvent)
+	if !ok {
+		logging.LogWithFields("PROJE-Lg3rE", "seq", event.Sequence(), "expectedType", org.OrgDomainLoginRemovedEventType, "gottenType", fmt.Sprintf("%T", event)).Error("unexpected event type")
+		return nil, errors.ThrowInvalidArgument(nil, "PROJE-Lg3rW", "reduce.wrong.event.type")
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgDomainChangeDateCol, e.CreationDate()),
+			handler.NewCol(OrgDomainSequenceCol, e.Sequence()),
+			handler.NewCol(OrgDomainIsLoginDomainCol, false),
+		},
+		[]handler.Condition{
+			handler.NewCond(OrgDomainDomainCol, e.Domain),
+			handler.NewCond(OrgDomainOrgIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
 func (p *OrgDomainProjection) reduceDomainRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*org.DomainRemovedEvent)
 	if !ok {
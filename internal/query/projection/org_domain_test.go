@@ -39,7 +39,7 @@ func TestOrgDomainProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.org_domains (creation_date, change_date, sequence, domain, org_id, is_verified, is_primary, validation_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedStmt: "INSERT INTO zitadel.projections.org_domains (creation_date, change_date, sequence, domain, org_id, is_verified, is_primary, is_login_domain, validation_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -48,6 +48,7 @@ func TestOrgDomainProjection_reduces(t *testing.T) {
 								"agg-id",
 								false,
 								false,
+								false,
 								domain.OrgDomainValidationTypeUnspecified,
 							},
 						},
@@ -158,6 +159,68 @@ func TestOrgDomainProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceLoginDomainSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgDomainLoginSetEventType),
+					org.AggregateType,
+					[]byte(`{"domain": "domain.new"}`),
+				), org.DomainLoginSetEventMapper),
+			},
+			reduce: (&OrgDomainProjection{}).reduceLoginDomainSet,
+			want: wantReduce{
+				projection:       OrgDomainTable,
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.org_domains SET (change_date, sequence, is_login_domain) = ($1, $2, $3) WHERE (domain = $4) AND (org_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"domain.new",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceLoginDomainRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgDomainLoginRemovedEventType),
+					org.AggregateType,
+					[]byte(`{"domain": "domain.new"}`),
+				), org.DomainLoginRemovedEventMapper),
+			},
+			reduce: (&OrgDomainProjection{}).reduceLoginDomainRemoved,
+			want: wantReduce{
+				projection:       OrgDomainTable,
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.org_domains SET (change_date, sequence, is_login_domain) = ($1, $2, $3) WHERE (domain = $4) AND (org_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								false,
+								"domain.new",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceDomainRemoved",
 			args: args{
@@ -12,13 +12,18 @@ import (
 )
 
 const (
-	CurrentSeqTable   = "projections.current_sequences"
-	LocksTable        = "projections.locks"
-	FailedEventsTable = "projections.failed_events"
+	CurrentSeqTable        = "projections.current_sequences"
+	LocksTable             = "projections.locks"
+	FailedEventsTable      = "projections.failed_events"
+	FailedEventErrorsTable = "projections.failed_event_errors"
+)
+
+var (
+	projectionConfig crdb.StatementHandlerConfig
 )
 
 func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, config Config, defaults systemdefaults.SystemDefaults, keyChan chan<- interface{}) error {
-	projectionConfig := crdb.StatementHandlerConfig{
+	projectionConfig = crdb.StatementHandlerConfig{
 		ProjectionHandlerConfig: handler.ProjectionHandlerConfig{
 			HandlerConfig: handler.HandlerConfig{
 				Eventstore: es,
@@ -26,12 +31,17 @@ func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, co
 			RequeueEvery:     config.RequeueEvery.Duration,
 			RetryFailedAfter: config.RetryFailedAfter.Duration,
 		},
-		Client:            sqlClient,
-		SequenceTable:     CurrentSeqTable,
-		LockTable:         LocksTable,
-		FailedEventsTable: FailedEventsTable,
-		MaxFailureCount:   config.MaxFailureCount,
-		BulkLimit:         config.BulkLimit,
+		Client:                 sqlClient,
+		SequenceTable:          CurrentSeqTable,
+		LockTable:              LocksTable,
+		FailedEventsTable:      FailedEventsTable,
+		FailedEventErrorsTable: FailedEventErrorsTable,
+		MaxFailureCount:        config.MaxFailureCount,
+		BulkLimit:              config.BulkLimit,
+		EventSkipped:           pushEventSkipped(es),
+	}
+	if config.CRDB.IsPostgres() {
+		projectionConfig.Dialect = crdb.DialectPostgres
 	}
 
 	NewOrgProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["orgs"]))
@@ -42,6 +52,8 @@ func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, co
 	NewPasswordAgeProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_age_policy"]))
 	NewLockoutPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["lockout_policy"]))
 	NewPrivacyPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["privacy_policy"]))
+	NewNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policy"]))
+	NewNotificationProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notifications"]))
 	NewOrgIAMPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_iam_policy"]))
 	NewLabelPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["label_policy"]))
 	NewProjectGrantProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["project_grants"]))
@@ -54,6 +66,7 @@ func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, co
 	NewIDPUserLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_user_links"]))
 	NewIDPLoginPolicyLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_login_policy_links"]))
 	NewMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_templates"]))
+	NewMailMessageTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_message_templates"]))
 	NewMessageTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_texts"]))
 	NewCustomTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_texts"]))
 	NewFeatureProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["features"]))
@@ -69,11 +82,20 @@ func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, co
 	NewUserMetadataProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_metadata"]))
 	NewUserAuthMethodProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_auth_method"]))
 	NewIAMProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["iam"]))
+	NewCustomRoleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_roles"]))
+	NewTranslationBundleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["translation_bundles"]))
+	NewLoginTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["login_templates"]))
 	_, err := NewKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), defaults.KeyConfig, keyChan)
 
 	return err
 }
 
+//ApplyCustomConfig returns the config of the projections with the customizations applied
+// it's used by handlers which are started outside of this package
+func ApplyCustomConfig(customConfig CustomConfig) crdb.StatementHandlerConfig {
+	return applyCustomConfig(projectionConfig, customConfig)
+}
+
 func applyCustomConfig(config crdb.StatementHandlerConfig, customConfig CustomConfig) crdb.StatementHandlerConfig {
 	if customConfig.BulkLimit != nil {
 		config.BulkLimit = *customConfig.BulkLimit
@@ -0,0 +1,93 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+type TranslationBundleProjection struct {
+	crdb.StatementHandler
+}
+
+const TranslationBundleProjectionTable = "zitadel.projections.translation_bundles"
+
+func NewTranslationBundleProjection(ctx context.Context, config crdb.StatementHandlerConfig) *TranslationBundleProjection {
+	p := &TranslationBundleProjection{}
+	config.ProjectionName = TranslationBundleProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *TranslationBundleProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: iam.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  iam.TranslationBundleSetEventType,
+					Reduce: p.reduceTranslationBundleSet,
+				},
+				{
+					Event:  iam.TranslationBundleRemovedEventType,
+					Reduce: p.reduceTranslationBundleRemoved,
+				},
+			},
+		},
+	}
+}
+
+const (
+	TranslationBundleColumnComponent    = "component"
+	TranslationBundleColumnLanguage     = "language"
+	TranslationBundleColumnCreationDate = "creation_date"
+	TranslationBundleColumnChangeDate   = "change_date"
+	TranslationBundleColumnSequence     = "sequence"
+	TranslationBundleColumnMessages     = "messages"
+)
+
+func (p *TranslationBundleProjection) reduceTranslationBundleSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.TranslationBundleSetEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Tb2sW", "seq", event.Sequence(), "expectedType", iam.TranslationBundleSetEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Tb3sW", "reduce.wrong.event.type")
+	}
+	messages, err := json.Marshal(e.Messages)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "HANDL-Tb3mJ", "unable to marshal messages")
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TranslationBundleColumnComponent, e.Component),
+			handler.NewCol(TranslationBundleColumnLanguage, e.Language.String()),
+			handler.NewCol(TranslationBundleColumnCreationDate, e.CreationDate()),
+			handler.NewCol(TranslationBundleColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TranslationBundleColumnSequence, e.Sequence()),
+			handler.NewCol(TranslationBundleColumnMessages, messages),
+		},
+	), nil
+}
+
+func (p *TranslationBundleProjection) reduceTranslationBundleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.TranslationBundleRemovedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Tb2rW", "seq", event.Sequence(), "expectedType", iam.TranslationBundleRemovedEventType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-Tb3rW", "reduce.wrong.event.type")
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TranslationBundleColumnComponent, e.Component),
+			handler.NewCond(TranslationBundleColumnLanguage, e.Language.String()),
+		},
+	), nil
+}
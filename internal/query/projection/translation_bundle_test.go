@@ -0,0 +1,98 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+	"github.com/caos/zitadel/internal/repository/iam"
+)
+
+func TestTranslationBundleProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceTranslationBundleSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.TranslationBundleSetEventType),
+					iam.AggregateType,
+					[]byte(`{"component": 1, "language": "fr-CH", "messages": {"Login.Title": "Bienvenue"}}`),
+				), iam.TranslationBundleSetEventMapper),
+			},
+			reduce: (&TranslationBundleProjection{}).reduceTranslationBundleSet,
+			want: wantReduce{
+				projection:       TranslationBundleProjectionTable,
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO zitadel.projections.translation_bundles (component, language, creation_date, change_date, sequence, messages) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedArgs: []interface{}{
+								domain.TranslationComponentLogin,
+								"fr-CH",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								[]byte(`{"Login.Title":"Bienvenue"}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTranslationBundleRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.TranslationBundleRemovedEventType),
+					iam.AggregateType,
+					[]byte(`{"component": 2, "language": "fr"}`),
+				), iam.TranslationBundleRemovedEventMapper),
+			},
+			reduce: (&TranslationBundleProjection{}).reduceTranslationBundleRemoved,
+			want: wantReduce{
+				projection:       TranslationBundleProjectionTable,
+				aggregateType:    eventstore.AggregateType("iam"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM zitadel.projections.translation_bundles WHERE (component = $1) AND (language = $2)",
+							expectedArgs: []interface{}{
+								domain.TranslationComponentNotification,
+								"fr",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
@@ -63,6 +63,13 @@ const (
 	// phone
 	HumanPhoneCol           = "phone"
 	HumanIsPhoneVerifiedCol = "is_phone_verified"
+
+	// password
+	HumanPasswordChangedCol = "password_changed"
+
+	// lock
+	HumanLockReasonCol  = "lock_reason"
+	HumanLockedUntilCol = "locked_until"
 )
 
 const (
@@ -166,6 +173,14 @@ func (p *UserProjection) reducers() []handler.AggregateReducer {
 					Event:  user.UserV1PhoneVerifiedType,
 					Reduce: p.reduceHumanPhoneVerified,
 				},
+				{
+					Event:  user.HumanPasswordChangedType,
+					Reduce: p.reduceHumanPasswordChanged,
+				},
+				{
+					Event:  user.UserV1PasswordChangedType,
+					Reduce: p.reduceHumanPasswordChanged,
+				},
 				{
 					Event:  user.HumanEmailChangedType,
 					Reduce: p.reduceHumanEmailChanged,
@@ -234,6 +249,7 @@ func (p *UserProjection) reduceHumanAdded(event eventstore.Event) (*handler.Stat
 				handler.NewCol(HumanGenderCol, &sql.NullInt16{Int16: int16(e.Gender), Valid: e.Gender.Specified()}),
 				handler.NewCol(HumanEmailCol, e.EmailAddress),
 				handler.NewCol(HumanPhoneCol, &sql.NullString{String: e.PhoneNumber, Valid: e.PhoneNumber != ""}),
+				handler.NewCol(HumanPasswordChangedCol, &sql.NullTime{Time: e.CreationDate(), Valid: e.Secret != nil}),
 			},
 			crdb.WithTableSuffix(UserHumanSuffix),
 		),
@@ -271,6 +287,7 @@ func (p *UserProjection) reduceHumanRegistered(event eventstore.Event) (*handler
 				handler.NewCol(HumanGenderCol, &sql.NullInt16{Int16: int16(e.Gender), Valid: e.Gender.Specified()}),
 				handler.NewCol(HumanEmailCol, e.EmailAddress),
 				handler.NewCol(HumanPhoneCol, &sql.NullString{String: e.PhoneNumber, Valid: e.PhoneNumber != ""}),
+				handler.NewCol(HumanPasswordChangedCol, &sql.NullTime{Time: e.CreationDate(), Valid: e.Secret != nil}),
 			},
 			crdb.WithTableSuffix(UserHumanSuffix),
 		),
@@ -318,16 +335,32 @@ func (p *UserProjection) reduceUserLocked(event eventstore.Event) (*handler.Stat
 		return nil, errors.ThrowInvalidArgument(nil, "HANDL-exyBF", "reduce.wrong.event.type")
 	}
 
-	return crdb.NewUpdateStatement(
+	lockedUntil := sql.NullTime{}
+	if e.LockedUntil != nil {
+		lockedUntil = sql.NullTime{Time: *e.LockedUntil, Valid: true}
+	}
+	return crdb.NewMultiStatement(
 		e,
-		[]handler.Column{
-			handler.NewCol(UserChangeDateCol, e.CreationDate()),
-			handler.NewCol(UserStateCol, domain.UserStateLocked),
-			handler.NewCol(UserSequenceCol, e.Sequence()),
-		},
-		[]handler.Condition{
-			handler.NewCond(UserIDCol, e.Aggregate().ID),
-		},
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(UserChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserStateCol, domain.UserStateLocked),
+				handler.NewCol(UserSequenceCol, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(UserIDCol, e.Aggregate().ID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(HumanLockReasonCol, e.Reason),
+				handler.NewCol(HumanLockedUntilCol, &lockedUntil),
+			},
+			[]handler.Condition{
+				handler.NewCond(HumanUserIDCol, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(UserHumanSuffix),
+		),
 	), nil
 }
 
@@ -338,16 +371,28 @@ func (p *UserProjection) reduceUserUnlocked(event eventstore.Event) (*handler.St
 		return nil, errors.ThrowInvalidArgument(nil, "HANDL-JIyRl", "reduce.wrong.event.type")
 	}
 
-	return crdb.NewUpdateStatement(
+	return crdb.NewMultiStatement(
 		e,
-		[]handler.Column{
-			handler.NewCol(UserChangeDateCol, e.CreationDate()),
-			handler.NewCol(UserStateCol, domain.UserStateActive),
-			handler.NewCol(UserSequenceCol, e.Sequence()),
-		},
-		[]handler.Condition{
-			handler.NewCond(UserIDCol, e.Aggregate().ID),
-		},
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(UserChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserStateCol, domain.UserStateActive),
+				handler.NewCol(UserSequenceCol, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(UserIDCol, e.Aggregate().ID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(HumanLockReasonCol, nil),
+				handler.NewCol(HumanLockedUntilCol, nil),
+			},
+			[]handler.Condition{
+				handler.NewCond(HumanUserIDCol, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(UserHumanSuffix),
+		),
 	), nil
 }
 
@@ -570,6 +615,36 @@ func (p *UserProjection) reduceHumanPhoneVerified(event eventstore.Event) (*hand
 	), nil
 }
 
+func (p *UserProjection) reduceHumanPasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordChangedEvent)
+	if !ok {
+		logging.LogWithFields("HANDL-Pw3Ch", "seq", event.Sequence(), "expectedType", user.HumanPasswordChangedType).Error("wrong event type")
+		return nil, errors.ThrowInvalidArgument(nil, "HANDL-nB8Rt", "reduce.wrong.event.type")
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(UserChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserSequenceCol, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(UserIDCol, e.Aggregate().ID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(HumanPasswordChangedCol, e.CreationDate()),
+			},
+			[]handler.Condition{
+				handler.NewCond(HumanUserIDCol, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(UserHumanSuffix),
+		),
+	), nil
+}
+
 func (p *UserProjection) reduceHumanEmailChanged(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanEmailChangedEvent)
 	if !ok {
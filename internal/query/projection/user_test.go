@@ -3,6 +3,7 @@ package projection
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/errors"
@@ -63,7 +64,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"first-name",
@@ -74,6 +75,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.ch",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -121,7 +123,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"first-name",
@@ -132,6 +134,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.ch",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -174,7 +177,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"first-name",
@@ -185,6 +188,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{},
 								"email@zitadel.ch",
 								&sql.NullString{},
+								anyArg{},
 							},
 						},
 					},
@@ -232,7 +236,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"first-name",
@@ -243,6 +247,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.ch",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -290,7 +295,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"first-name",
@@ -301,6 +306,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.ch",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -343,7 +349,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO zitadel.projections.users_humans (user_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"first-name",
@@ -354,6 +360,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{},
 								"email@zitadel.ch",
 								&sql.NullString{},
+								anyArg{},
 							},
 						},
 					},
@@ -478,7 +485,10 @@ func TestUserProjection_reduces(t *testing.T) {
 				event: getEvent(testEvent(
 					repository.EventType(user.UserLockedType),
 					user.AggregateType,
-					[]byte(`{}`),
+					[]byte(`{
+						"reason": 2,
+						"lockedUntil": "2021-11-20T12:00:00Z"
+					}`),
 				), user.UserLockedEventMapper),
 			},
 			reduce: (&UserProjection{}).reduceUserLocked,
@@ -498,6 +508,14 @@ func TestUserProjection_reduces(t *testing.T) {
 								"agg-id",
 							},
 						},
+						{
+							expectedStmt: "UPDATE zitadel.projections.users_humans SET (lock_reason, locked_until) = ($1, $2) WHERE (user_id = $3)",
+							expectedArgs: []interface{}{
+								domain.UserLockReasonOTPAttempts,
+								&sql.NullTime{Time: time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC), Valid: true},
+								"agg-id",
+							},
+						},
 					},
 				},
 			},
@@ -528,6 +546,14 @@ func TestUserProjection_reduces(t *testing.T) {
 								"agg-id",
 							},
 						},
+						{
+							expectedStmt: "UPDATE zitadel.projections.users_humans SET (lock_reason, locked_until) = ($1, $2) WHERE (user_id = $3)",
+							expectedArgs: []interface{}{
+								nil,
+								nil,
+								"agg-id",
+							},
+						},
 					},
 				},
 			},
@@ -899,6 +925,78 @@ func TestUserProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceHumanPasswordChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordChangedType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.HumanPasswordChangedEventMapper),
+			},
+			reduce: (&UserProjection{}).reduceHumanPasswordChanged,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.users SET (change_date, sequence) = ($1, $2) WHERE (id = $3)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE zitadel.projections.users_humans SET (password_changed) = ($1) WHERE (user_id = $2)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserV1PasswordChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserV1PasswordChangedType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.HumanPasswordChangedEventMapper),
+			},
+			reduce: (&UserProjection{}).reduceHumanPasswordChanged,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE zitadel.projections.users SET (change_date, sequence) = ($1, $2) WHERE (id = $3)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE zitadel.projections.users_humans SET (password_changed) = ($1) WHERE (user_id = $2)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceHumanPhoneVerified",
 			args: args{
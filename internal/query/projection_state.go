@@ -0,0 +1,194 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/handler/crdb"
+)
+
+const (
+	//reducedEventTypesJoin selects the event types the projection reduces of the aggregate type
+	// the event types are null if the projection is not started by this instance
+	reducedEventTypesJoin = "CROSS JOIN LATERAL (" +
+		"SELECT ?::JSONB -> projections.current_sequences.projection_name -> projections.current_sequences.aggregate_type AS event_types" +
+		") AS reduced"
+	//pendingEventsJoin selects the events of the aggregate type
+	// which were not processed by the projection yet
+	pendingEventsJoin = "CROSS JOIN LATERAL (" +
+		"SELECT max(event_sequence) AS latest_sequence, count(*) AS pending_events, min(creation_date) AS oldest_pending" +
+		" FROM eventstore.events" +
+		" WHERE aggregate_type = projections.current_sequences.aggregate_type" +
+		" AND event_sequence > projections.current_sequences.current_sequence" +
+		" AND (reduced.event_types IS NULL OR event_type IN (SELECT jsonb_array_elements_text(reduced.event_types)))" +
+		") AS pending"
+)
+
+//reducedEventTypes are the event types per aggregate type of the projections
+type reducedEventTypes map[string]map[eventstore.AggregateType][]eventstore.EventType
+
+func (types reducedEventTypes) Value() (driver.Value, error) {
+	return json.Marshal(types)
+}
+
+type ProjectionStates struct {
+	SearchResponse
+	ProjectionStates []*ProjectionState
+}
+
+type ProjectionState struct {
+	ProjectionName string
+	//CurrentSequence is the highest sequence processed by the projection
+	CurrentSequence uint64
+	//LatestSequence is the highest sequence of the eventstore
+	// relevant for the projection
+	LatestSequence uint64
+	//EventsBehind is the count of events the projection has not processed yet
+	EventsBehind uint64
+	//OldestPendingEvent is the creation date of the oldest event
+	// the projection has not processed yet
+	OldestPendingEvent time.Time
+	//LastSuccessfulRun is the timestamp the current sequence was updated the last time
+	LastSuccessfulRun time.Time
+	LockerID          string
+	LockedUntil       time.Time
+}
+
+//TimeBehind returns the duration since the oldest unprocessed event was created
+// if the projection is up to date 0 is returned
+func (s *ProjectionState) TimeBehind() time.Duration {
+	if s.OldestPendingEvent.IsZero() {
+		return 0
+	}
+	return time.Since(s.OldestPendingEvent)
+}
+
+//IsLocked returns if a worker currently holds the lock of the projection
+func (s *ProjectionState) IsLocked() bool {
+	return s.LockerID != "" && s.LockedUntil.After(time.Now())
+}
+
+type ProjectionStateSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *ProjectionStateSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewProjectionStateProjectionNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CurrentSequenceColProjectionName, value, method)
+}
+
+func (q *Queries) SearchProjectionStates(ctx context.Context, queries *ProjectionStateSearchQueries) (states *ProjectionStates, err error) {
+	query, scan := prepareProjectionStatesQuery()
+	stmt, args, err := queries.toQuery(query).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Wx9qP", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-o5TcB", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareProjectionStatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*ProjectionStates, error)) {
+	return sq.Select(
+			CurrentSequenceColProjectionName.identifier(),
+			CurrentSequenceColCurrentSequence.identifier(),
+			CurrentSequenceColTimestamp.identifier(),
+			LocksColLockerID.identifier(),
+			LocksColUntil.identifier(),
+			"pending.latest_sequence",
+			"pending.pending_events",
+			"pending.oldest_pending").
+			From(currentSequencesTable.identifier()).
+			LeftJoin(join(LocksColProjectionName, CurrentSequenceColProjectionName)).
+			JoinClause(reducedEventTypesJoin, reducedEventTypes(crdb.ReducedEventTypes())).
+			JoinClause(pendingEventsJoin).
+			OrderBy(CurrentSequenceColProjectionName.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ProjectionStates, error) {
+			states := make([]*ProjectionState, 0)
+			//current sequences are stored per aggregate type
+			// so the rows are combined per projection
+			var state *ProjectionState
+			for rows.Next() {
+				var (
+					projectionName  string
+					currentSequence uint64
+					timestamp       time.Time
+					lockerID        sql.NullString
+					lockedUntil     sql.NullTime
+					latestSequence  sql.NullInt64
+					pendingEvents   uint64
+					oldestPending   sql.NullTime
+				)
+				err := rows.Scan(
+					&projectionName,
+					&currentSequence,
+					&timestamp,
+					&lockerID,
+					&lockedUntil,
+					&latestSequence,
+					&pendingEvents,
+					&oldestPending,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if state == nil || state.ProjectionName != projectionName {
+					state = &ProjectionState{
+						ProjectionName: projectionName,
+						LockerID:       lockerID.String,
+						LockedUntil:    lockedUntil.Time,
+					}
+					states = append(states, state)
+				}
+				if currentSequence > state.CurrentSequence {
+					state.CurrentSequence = currentSequence
+				}
+				if uint64(latestSequence.Int64) > state.LatestSequence {
+					state.LatestSequence = uint64(latestSequence.Int64)
+				}
+				if timestamp.After(state.LastSuccessfulRun) {
+					state.LastSuccessfulRun = timestamp
+				}
+				state.EventsBehind += pendingEvents
+				if oldestPending.Valid && (state.OldestPendingEvent.IsZero() || oldestPending.Time.Before(state.OldestPendingEvent)) {
+					state.OldestPendingEvent = oldestPending.Time
+				}
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Lk0Vv", "Errors.Query.CloseRows")
+			}
+
+			for _, state := range states {
+				if state.LatestSequence < state.CurrentSequence {
+					state.LatestSequence = state.CurrentSequence
+				}
+			}
+
+			return &ProjectionStates{
+				ProjectionStates: states,
+				SearchResponse: SearchResponse{
+					Count: uint64(len(states)),
+				},
+			}, nil
+		}
+}
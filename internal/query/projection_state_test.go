@@ -0,0 +1,188 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	projectionStatesStmt = regexp.QuoteMeta(`SELECT projections.current_sequences.projection_name,` +
+		` projections.current_sequences.current_sequence,` +
+		` projections.current_sequences.timestamp,` +
+		` projections.locks.locker_id,` +
+		` projections.locks.locked_until,` +
+		` pending.latest_sequence,` +
+		` pending.pending_events,` +
+		` pending.oldest_pending` +
+		` FROM projections.current_sequences` +
+		` LEFT JOIN projections.locks ON projections.current_sequences.projection_name = projections.locks.projection_name` +
+		` CROSS JOIN LATERAL (SELECT $1::JSONB -> projections.current_sequences.projection_name -> projections.current_sequences.aggregate_type AS event_types) AS reduced` +
+		` CROSS JOIN LATERAL (SELECT max(event_sequence) AS latest_sequence, count(*) AS pending_events, min(creation_date) AS oldest_pending` +
+		` FROM eventstore.events` +
+		` WHERE aggregate_type = projections.current_sequences.aggregate_type` +
+		` AND event_sequence > projections.current_sequences.current_sequence` +
+		` AND (reduced.event_types IS NULL OR event_type IN (SELECT jsonb_array_elements_text(reduced.event_types)))) AS pending` +
+		` ORDER BY projections.current_sequences.projection_name`)
+	projectionStatesCols = []string{
+		"projection_name",
+		"current_sequence",
+		"timestamp",
+		"locker_id",
+		"locked_until",
+		"latest_sequence",
+		"pending_events",
+		"oldest_pending",
+	}
+)
+
+func Test_ProjectionStatesPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareProjectionStatesQuery no result",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					projectionStatesStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &ProjectionStates{ProjectionStates: []*ProjectionState{}},
+		},
+		{
+			name:    "prepareProjectionStatesQuery up to date",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					projectionStatesStmt,
+					projectionStatesCols,
+					[][]driver.Value{
+						{
+							"projection-name",
+							uint64(20211108),
+							testNow,
+							"locker",
+							testNow,
+							nil,
+							uint64(0),
+							nil,
+						},
+					},
+				),
+			},
+			object: &ProjectionStates{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				ProjectionStates: []*ProjectionState{
+					{
+						ProjectionName:    "projection-name",
+						CurrentSequence:   20211108,
+						LatestSequence:    20211108,
+						LastSuccessfulRun: testNow,
+						LockerID:          "locker",
+						LockedUntil:       testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareProjectionStatesQuery multiple aggregate types",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					projectionStatesStmt,
+					projectionStatesCols,
+					[][]driver.Value{
+						{
+							"projection-name",
+							uint64(20211108),
+							testNow,
+							nil,
+							nil,
+							int64(20211120),
+							uint64(3),
+							testNow,
+						},
+						{
+							"projection-name",
+							uint64(20211109),
+							testNow,
+							nil,
+							nil,
+							int64(20211110),
+							uint64(1),
+							testNow,
+						},
+						{
+							"projection-name-2",
+							uint64(20211109),
+							testNow,
+							nil,
+							nil,
+							nil,
+							uint64(0),
+							nil,
+						},
+					},
+				),
+			},
+			object: &ProjectionStates{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				ProjectionStates: []*ProjectionState{
+					{
+						ProjectionName:     "projection-name",
+						CurrentSequence:    20211109,
+						LatestSequence:     20211120,
+						EventsBehind:       4,
+						OldestPendingEvent: testNow,
+						LastSuccessfulRun:  testNow,
+					},
+					{
+						ProjectionName:    "projection-name-2",
+						CurrentSequence:   20211109,
+						LatestSequence:    20211109,
+						LastSuccessfulRun: testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareProjectionStatesQuery sql err",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					projectionStatesStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/query/projection"
+)
+
+var (
+	translationBundlesTable = table{
+		name: projection.TranslationBundleProjectionTable,
+	}
+	TranslationBundleColumnComponent = Column{
+		name:  projection.TranslationBundleColumnComponent,
+		table: translationBundlesTable,
+	}
+	TranslationBundleColumnLanguage = Column{
+		name:  projection.TranslationBundleColumnLanguage,
+		table: translationBundlesTable,
+	}
+	TranslationBundleColumnCreationDate = Column{
+		name:  projection.TranslationBundleColumnCreationDate,
+		table: translationBundlesTable,
+	}
+	TranslationBundleColumnChangeDate = Column{
+		name:  projection.TranslationBundleColumnChangeDate,
+		table: translationBundlesTable,
+	}
+	TranslationBundleColumnSequence = Column{
+		name:  projection.TranslationBundleColumnSequence,
+		table: translationBundlesTable,
+	}
+	TranslationBundleColumnMessages = Column{
+		name:  projection.TranslationBundleColumnMessages,
+		table: translationBundlesTable,
+	}
+)
+
+type TranslationBundles struct {
+	SearchResponse
+	TranslationBundles []*TranslationBundle
+}
+
+type TranslationBundle struct {
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+
+	Component domain.TranslationComponent
+	Language  language.Tag
+	Messages  map[string]string
+}
+
+type TranslationBundleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+//SearchTranslationBundles returns the uploaded translation bundles
+// the translations shipped with zitadel aren't part of the result
+func (q *Queries) SearchTranslationBundles(ctx context.Context, queries *TranslationBundleSearchQueries) (bundles *TranslationBundles, err error) {
+	query, scan := prepareTranslationBundlesQuery()
+	stmt, args, err := queries.toQuery(query).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Tb2sI", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tb3sI", "Errors.Internal")
+	}
+	bundles, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	bundles.LatestSequence, err = q.latestSequence(ctx, translationBundlesTable)
+	return bundles, err
+}
+
+//TranslationBundlesByComponent returns all uploaded translation bundles of the component
+func (q *Queries) TranslationBundlesByComponent(ctx context.Context, component domain.TranslationComponent) (*TranslationBundles, error) {
+	componentQuery, err := NewTranslationBundleComponentSearchQuery(component)
+	if err != nil {
+		return nil, err
+	}
+	return q.SearchTranslationBundles(ctx, &TranslationBundleSearchQueries{Queries: []SearchQuery{componentQuery}})
+}
+
+//TranslationBundleByComponentAndLanguage returns the uploaded translation bundle of the component in the language
+func (q *Queries) TranslationBundleByComponentAndLanguage(ctx context.Context, component domain.TranslationComponent, lang language.Tag) (*TranslationBundle, error) {
+	componentQuery, err := NewTranslationBundleComponentSearchQuery(component)
+	if err != nil {
+		return nil, err
+	}
+	languageQuery, err := NewTranslationBundleLanguageSearchQuery(lang)
+	if err != nil {
+		return nil, err
+	}
+	bundles, err := q.SearchTranslationBundles(ctx, &TranslationBundleSearchQueries{Queries: []SearchQuery{componentQuery, languageQuery}})
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles.TranslationBundles) == 0 {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Tb2nF", "Errors.TranslationBundle.NotFound")
+	}
+	return bundles.TranslationBundles[0], nil
+}
+
+//DefaultTranslationMessages returns the messages of the component shipped with zitadel in the language
+func (q *Queries) DefaultTranslationMessages(component domain.TranslationComponent, lang language.Tag) (map[string]string, error) {
+	var dir http.FileSystem
+	switch component {
+	case domain.TranslationComponentLogin:
+		dir = q.LoginDir
+	case domain.TranslationComponentNotification:
+		dir = q.NotificationDir
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Tb2cI", "Errors.TranslationBundle.Invalid")
+	}
+	return i18n.ReadMessages(dir, lang)
+}
+
+func NewTranslationBundleComponentSearchQuery(value domain.TranslationComponent) (SearchQuery, error) {
+	return NewNumberQuery(TranslationBundleColumnComponent, value, NumberEquals)
+}
+
+func NewTranslationBundleLanguageSearchQuery(value language.Tag) (SearchQuery, error) {
+	return NewTextQuery(TranslationBundleColumnLanguage, value.String(), TextEquals)
+}
+
+func (q *TranslationBundleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareTranslationBundlesQuery() (sq.SelectBuilder, func(*sql.Rows) (*TranslationBundles, error)) {
+	return sq.Select(
+			TranslationBundleColumnCreationDate.identifier(),
+			TranslationBundleColumnChangeDate.identifier(),
+			TranslationBundleColumnSequence.identifier(),
+			TranslationBundleColumnComponent.identifier(),
+			TranslationBundleColumnLanguage.identifier(),
+			TranslationBundleColumnMessages.identifier(),
+			countColumn.identifier()).
+			From(translationBundlesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*TranslationBundles, error) {
+			bundles := make([]*TranslationBundle, 0)
+			var count uint64
+			for rows.Next() {
+				bundle := new(TranslationBundle)
+				var lang string
+				var messages []byte
+				err := rows.Scan(
+					&bundle.CreationDate,
+					&bundle.ChangeDate,
+					&bundle.Sequence,
+					&bundle.Component,
+					&lang,
+					&messages,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				bundle.Language = language.Make(lang)
+				if err := json.Unmarshal(messages, &bundle.Messages); err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Tb2mJ", "Errors.Internal")
+				}
+				bundles = append(bundles, bundle)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Tb2cR", "Errors.Query.CloseRows")
+			}
+
+			return &TranslationBundles{
+				TranslationBundles: bundles,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+)
+
+var (
+	translationBundlesQuery = regexp.QuoteMeta(`SELECT zitadel.projections.translation_bundles.creation_date,` +
+		` zitadel.projections.translation_bundles.change_date,` +
+		` zitadel.projections.translation_bundles.sequence,` +
+		` zitadel.projections.translation_bundles.component,` +
+		` zitadel.projections.translation_bundles.language,` +
+		` zitadel.projections.translation_bundles.messages,` +
+		` COUNT(*) OVER ()` +
+		` FROM zitadel.projections.translation_bundles`)
+	translationBundlesColumns = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"component",
+		"language",
+		"messages",
+		"count",
+	}
+)
+
+func Test_TranslationBundlePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTranslationBundlesQuery no result",
+			prepare: prepareTranslationBundlesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					translationBundlesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &TranslationBundles{TranslationBundles: []*TranslationBundle{}},
+		},
+		{
+			name:    "prepareTranslationBundlesQuery multiple result",
+			prepare: prepareTranslationBundlesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					translationBundlesQuery,
+					translationBundlesColumns,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211111),
+							domain.TranslationComponentLogin,
+							"fr-CH",
+							[]byte(`{"Login.Title":"Bienvenue"}`),
+						},
+						{
+							testNow,
+							testNow,
+							uint64(20211111),
+							domain.TranslationComponentNotification,
+							"pt",
+							[]byte(`{"InitCode.Title":"Bem-vindo"}`),
+						},
+					},
+				),
+			},
+			object: &TranslationBundles{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				TranslationBundles: []*TranslationBundle{
+					{
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211111,
+						Component:    domain.TranslationComponentLogin,
+						Language:     language.MustParse("fr-CH"),
+						Messages:     map[string]string{"Login.Title": "Bienvenue"},
+					},
+					{
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211111,
+						Component:    domain.TranslationComponentNotification,
+						Language:     language.Portuguese,
+						Messages:     map[string]string{"InitCode.Title": "Bem-vindo"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareTranslationBundlesQuery sql err",
+			prepare: prepareTranslationBundlesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					translationBundlesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -48,6 +48,9 @@ type Human struct {
 	IsEmailVerified   bool
 	Phone             string
 	IsPhoneVerified   bool
+	PasswordChanged   time.Time
+	LockReason        domain.UserLockReason
+	LockedUntil       time.Time
 }
 
 type Profile struct {
@@ -202,6 +205,20 @@ var (
 		name:  projection.HumanIsPhoneVerifiedCol,
 		table: humanTable,
 	}
+
+	// password
+	HumanPasswordChangedCol = Column{
+		name:  projection.HumanPasswordChangedCol,
+		table: humanTable,
+	}
+	HumanLockReasonCol = Column{
+		name:  projection.HumanLockReasonCol,
+		table: humanTable,
+	}
+	HumanLockedUntilCol = Column{
+		name:  projection.HumanLockedUntilCol,
+		table: humanTable,
+	}
 )
 
 var (
@@ -463,6 +480,9 @@ func prepareUserQuery() (sq.SelectBuilder, func(*sql.Row) (*User, error)) {
 			HumanIsEmailVerifiedCol.identifier(),
 			HumanPhoneCol.identifier(),
 			HumanIsPhoneVerifiedCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
+			HumanLockReasonCol.identifier(),
+			HumanLockedUntilCol.identifier(),
 			MachineUserIDCol.identifier(),
 			MachineNameCol.identifier(),
 			MachineDescriptionCol.identifier(),
@@ -490,6 +510,9 @@ func prepareUserQuery() (sq.SelectBuilder, func(*sql.Row) (*User, error)) {
 			isEmailVerified := sql.NullBool{}
 			phone := sql.NullString{}
 			isPhoneVerified := sql.NullBool{}
+			passwordChanged := sql.NullTime{}
+			lockReason := sql.NullInt32{}
+			lockedUntil := sql.NullTime{}
 
 			machineID := sql.NullString{}
 			name := sql.NullString{}
@@ -518,6 +541,9 @@ func prepareUserQuery() (sq.SelectBuilder, func(*sql.Row) (*User, error)) {
 				&isEmailVerified,
 				&phone,
 				&isPhoneVerified,
+				&passwordChanged,
+				&lockReason,
+				&lockedUntil,
 				&machineID,
 				&name,
 				&description,
@@ -547,6 +573,9 @@ func prepareUserQuery() (sq.SelectBuilder, func(*sql.Row) (*User, error)) {
 					IsEmailVerified:   isEmailVerified.Bool,
 					Phone:             phone.String,
 					IsPhoneVerified:   isPhoneVerified.Bool,
+					PasswordChanged:   passwordChanged.Time,
+					LockReason:        domain.UserLockReason(lockReason.Int32),
+					LockedUntil:       lockedUntil.Time,
 				}
 			} else if machineID.Valid {
 				u.Machine = &Machine{
@@ -799,6 +828,9 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 			HumanIsEmailVerifiedCol.identifier(),
 			HumanPhoneCol.identifier(),
 			HumanIsPhoneVerifiedCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
+			HumanLockReasonCol.identifier(),
+			HumanLockedUntilCol.identifier(),
 			MachineUserIDCol.identifier(),
 			MachineNameCol.identifier(),
 			MachineDescriptionCol.identifier(),
@@ -829,6 +861,9 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 				isEmailVerified := sql.NullBool{}
 				phone := sql.NullString{}
 				isPhoneVerified := sql.NullBool{}
+				passwordChanged := sql.NullTime{}
+				lockReason := sql.NullInt32{}
+				lockedUntil := sql.NullTime{}
 
 				machineID := sql.NullString{}
 				name := sql.NullString{}
@@ -857,6 +892,9 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 					&isEmailVerified,
 					&phone,
 					&isPhoneVerified,
+					&passwordChanged,
+					&lockReason,
+					&lockedUntil,
 					&machineID,
 					&name,
 					&description,
@@ -884,6 +922,9 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 						IsEmailVerified:   isEmailVerified.Bool,
 						Phone:             phone.String,
 						IsPhoneVerified:   isPhoneVerified.Bool,
+						PasswordChanged:   passwordChanged.Time,
+						LockReason:        domain.UserLockReason(lockReason.Int32),
+						LockedUntil:       lockedUntil.Time,
 					}
 				} else if machineID.Valid {
 					u.Machine = &Machine{
@@ -282,9 +282,9 @@ func prepareOrgMember() string {
 		OrgMemberSequence.identifier(),
 		OrgMemberResourceOwner.identifier(),
 		OrgMemberOrgID.identifier(),
-		"NULL::STRING AS "+membershipIAMID.name,
-		"NULL::STRING AS "+membershipProjectID.name,
-		"NULL::STRING AS "+membershipGrantID.name,
+		"NULL::TEXT AS "+membershipIAMID.name,
+		"NULL::TEXT AS "+membershipProjectID.name,
+		"NULL::TEXT AS "+membershipGrantID.name,
 	).From(orgMemberTable.identifier()).MustSql()
 	return stmt
 }
@@ -297,10 +297,10 @@ func prepareIAMMember() string {
 		IAMMemberChangeDate.identifier(),
 		IAMMemberSequence.identifier(),
 		IAMMemberResourceOwner.identifier(),
-		"NULL::STRING AS "+membershipOrgID.name,
+		"NULL::TEXT AS "+membershipOrgID.name,
 		IAMMemberIAMID.identifier(),
-		"NULL::STRING AS "+membershipProjectID.name,
-		"NULL::STRING AS "+membershipGrantID.name,
+		"NULL::TEXT AS "+membershipProjectID.name,
+		"NULL::TEXT AS "+membershipGrantID.name,
 	).From(iamMemberTable.identifier()).MustSql()
 	return stmt
 }
@@ -313,10 +313,10 @@ func prepareProjectMember() string {
 		ProjectMemberChangeDate.identifier(),
 		ProjectMemberSequence.identifier(),
 		ProjectMemberResourceOwner.identifier(),
-		"NULL::STRING AS "+membershipOrgID.name,
-		"NULL::STRING AS "+membershipIAMID.name,
+		"NULL::TEXT AS "+membershipOrgID.name,
+		"NULL::TEXT AS "+membershipIAMID.name,
 		ProjectMemberProjectID.identifier(),
-		"NULL::STRING AS "+membershipGrantID.name,
+		"NULL::TEXT AS "+membershipGrantID.name,
 	).From(projectMemberTable.identifier()).MustSql()
 
 	return stmt
@@ -330,8 +330,8 @@ func prepareProjectGrantMember() string {
 		ProjectGrantMemberChangeDate.identifier(),
 		ProjectGrantMemberSequence.identifier(),
 		ProjectGrantMemberResourceOwner.identifier(),
-		"NULL::STRING AS "+membershipOrgID.name,
-		"NULL::STRING AS "+membershipIAMID.name,
+		"NULL::TEXT AS "+membershipOrgID.name,
+		"NULL::TEXT AS "+membershipIAMID.name,
 		ProjectGrantMemberProjectID.identifier(),
 		ProjectGrantMemberGrantID.identifier(),
 	).From(projectGrantMemberTable.identifier()).MustSql()
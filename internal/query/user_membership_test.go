@@ -34,9 +34,9 @@ var (
 			", members.sequence" +
 			", members.resource_owner" +
 			", members.org_id" +
-			", NULL::STRING AS iam_id" +
-			", NULL::STRING AS project_id" +
-			", NULL::STRING AS grant_id" +
+			", NULL::TEXT AS iam_id" +
+			", NULL::TEXT AS project_id" +
+			", NULL::TEXT AS grant_id" +
 			" FROM zitadel.projections.org_members as members" +
 			" UNION ALL " +
 			"SELECT members.user_id" +
@@ -45,10 +45,10 @@ var (
 			", members.change_date" +
 			", members.sequence" +
 			", members.resource_owner" +
-			", NULL::STRING AS org_id" +
+			", NULL::TEXT AS org_id" +
 			", members.iam_id" +
-			", NULL::STRING AS project_id" +
-			", NULL::STRING AS grant_id" +
+			", NULL::TEXT AS project_id" +
+			", NULL::TEXT AS grant_id" +
 			" FROM zitadel.projections.iam_members as members" +
 			" UNION ALL " +
 			"SELECT members.user_id" +
@@ -57,10 +57,10 @@ var (
 			", members.change_date" +
 			", members.sequence" +
 			", members.resource_owner" +
-			", NULL::STRING AS org_id" +
-			", NULL::STRING AS iam_id" +
+			", NULL::TEXT AS org_id" +
+			", NULL::TEXT AS iam_id" +
 			", members.project_id" +
-			", NULL::STRING AS grant_id" +
+			", NULL::TEXT AS grant_id" +
 			" FROM zitadel.projections.project_members as members" +
 			" UNION ALL " +
 			"SELECT members.user_id" +
@@ -69,8 +69,8 @@ var (
 			", members.change_date" +
 			", members.sequence" +
 			", members.resource_owner" +
-			", NULL::STRING AS org_id" +
-			", NULL::STRING AS iam_id" +
+			", NULL::TEXT AS org_id" +
+			", NULL::TEXT AS iam_id" +
 			", members.project_id" +
 			", members.grant_id" +
 			" FROM zitadel.projections.project_grant_members as members" +
@@ -21,6 +21,7 @@ type UserMetadata struct {
 	CreationDate  time.Time
 	ChangeDate    time.Time
 	ResourceOwner string
+	UserID        string
 	Sequence      uint64
 	Key           string
 	Value         []byte
@@ -106,6 +107,30 @@ func (q *Queries) SearchUserMetadata(ctx context.Context, userID string, queries
 	return metadata, err
 }
 
+//SearchOrgUserMetadata returns the metadata of all users of the organisation
+func (q *Queries) SearchOrgUserMetadata(ctx context.Context, orgID string, queries *UserMetadataSearchQueries) (*UserMetadataList, error) {
+	query, scan := prepareUserMetadataListQuery()
+	stmt, args, err := queries.toQuery(query).Where(
+		sq.Eq{
+			UserMetadataResourceOwnerCol.identifier(): orgID,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ex2mD", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ex3mD", "Errors.Internal")
+	}
+	metadata, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	metadata.LatestSequence, err = q.latestSequence(ctx, userMetadataTable)
+	return metadata, err
+}
+
 func (q *UserMetadataSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
 	query = q.SearchRequest.toQuery(query)
 	for _, q := range q.Queries {
@@ -168,6 +193,7 @@ func prepareUserMetadataListQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserMet
 			UserMetadataCreationDateCol.identifier(),
 			UserMetadataChangeDateCol.identifier(),
 			UserMetadataResourceOwnerCol.identifier(),
+			UserMetadataUserIDCol.identifier(),
 			UserMetadataSequenceCol.identifier(),
 			UserMetadataKeyCol.identifier(),
 			UserMetadataValueCol.identifier(),
@@ -183,6 +209,7 @@ func prepareUserMetadataListQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserMet
 					&m.CreationDate,
 					&m.ChangeDate,
 					&m.ResourceOwner,
+					&m.UserID,
 					&m.Sequence,
 					&m.Key,
 					&m.Value,
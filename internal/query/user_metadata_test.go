@@ -30,6 +30,7 @@ var (
 	userMetadataListQuery = `SELECT zitadel.projections.user_metadata.creation_date,` +
 		` zitadel.projections.user_metadata.change_date,` +
 		` zitadel.projections.user_metadata.resource_owner,` +
+		` zitadel.projections.user_metadata.user_id,` +
 		` zitadel.projections.user_metadata.sequence,` +
 		` zitadel.projections.user_metadata.key,` +
 		` zitadel.projections.user_metadata.value,` +
@@ -39,6 +40,7 @@ var (
 		"creation_date",
 		"change_date",
 		"resource_owner",
+		"user_id",
 		"sequence",
 		"key",
 		"value",
@@ -148,6 +150,7 @@ func Test_UserMetadataPrepares(t *testing.T) {
 							testNow,
 							testNow,
 							"resource_owner",
+							"user_id",
 							uint64(20211108),
 							"key",
 							[]byte("value"),
@@ -164,6 +167,7 @@ func Test_UserMetadataPrepares(t *testing.T) {
 						CreationDate:  testNow,
 						ChangeDate:    testNow,
 						ResourceOwner: "resource_owner",
+						UserID:        "user_id",
 						Sequence:      20211108,
 						Key:           "key",
 						Value:         []byte("value"),
@@ -183,6 +187,7 @@ func Test_UserMetadataPrepares(t *testing.T) {
 							testNow,
 							testNow,
 							"resource_owner",
+							"user_id",
 							uint64(20211108),
 							"key",
 							[]byte("value"),
@@ -191,6 +196,7 @@ func Test_UserMetadataPrepares(t *testing.T) {
 							testNow,
 							testNow,
 							"resource_owner",
+							"user_id",
 							uint64(20211108),
 							"key2",
 							[]byte("value2"),
@@ -207,6 +213,7 @@ func Test_UserMetadataPrepares(t *testing.T) {
 						CreationDate:  testNow,
 						ChangeDate:    testNow,
 						ResourceOwner: "resource_owner",
+						UserID:        "user_id",
 						Sequence:      20211108,
 						Key:           "key",
 						Value:         []byte("value"),
@@ -215,6 +222,7 @@ func Test_UserMetadataPrepares(t *testing.T) {
 						CreationDate:  testNow,
 						ChangeDate:    testNow,
 						ResourceOwner: "resource_owner",
+						UserID:        "user_id",
 						Sequence:      20211108,
 						Key:           "key2",
 						Value:         []byte("value2"),
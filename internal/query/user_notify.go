@@ -0,0 +1,71 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+//NotifyUser contains the data of a user which is needed to send notifications
+type NotifyUser struct {
+	ID                 string
+	CreationDate       time.Time
+	ChangeDate         time.Time
+	ResourceOwner      string
+	Sequence           uint64
+	UserName           string
+	LoginNames         []string
+	PreferredLoginName string
+	FirstName          string
+	LastName           string
+	NickName           string
+	DisplayName        string
+	PreferredLanguage  string
+	LastEmail          string
+	VerifiedEmail      string
+	LastPhone          string
+	VerifiedPhone      string
+	PasswordSet        bool
+}
+
+func (q *Queries) GetNotifyUserByID(ctx context.Context, userID string, queries ...SearchQuery) (*NotifyUser, error) {
+	user, err := q.GetUserByID(ctx, userID, queries...)
+	if err != nil {
+		return nil, err
+	}
+	return notifyUserFromUser(user), nil
+}
+
+func notifyUserFromUser(user *User) *NotifyUser {
+	notifyUser := &NotifyUser{
+		ID:                 user.ID,
+		CreationDate:       user.CreationDate,
+		ChangeDate:         user.ChangeDate,
+		ResourceOwner:      user.ResourceOwner,
+		Sequence:           user.Sequence,
+		UserName:           user.Username,
+		LoginNames:         user.LoginNames,
+		PreferredLoginName: user.PreferredLoginName,
+	}
+	if user.Human == nil {
+		return notifyUser
+	}
+	notifyUser.FirstName = user.Human.FirstName
+	notifyUser.LastName = user.Human.LastName
+	notifyUser.NickName = user.Human.NickName
+	notifyUser.DisplayName = user.Human.DisplayName
+	if user.Human.PreferredLanguage != language.Und {
+		notifyUser.PreferredLanguage = user.Human.PreferredLanguage.String()
+	}
+	notifyUser.LastEmail = user.Human.Email
+	if user.Human.IsEmailVerified {
+		notifyUser.VerifiedEmail = user.Human.Email
+	}
+	notifyUser.LastPhone = user.Human.Phone
+	if user.Human.IsPhoneVerified {
+		notifyUser.VerifiedPhone = user.Human.Phone
+	}
+	notifyUser.PasswordSet = !user.Human.PasswordChanged.IsZero()
+	return notifyUser
+}
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func Test_notifyUserFromUser(t *testing.T) {
+	testNow := time.Now()
+	tests := []struct {
+		name string
+		user *User
+		want *NotifyUser
+	}{
+		{
+			name: "machine",
+			user: &User{
+				ID:                 "id",
+				CreationDate:       testNow,
+				ChangeDate:         testNow,
+				ResourceOwner:      "ro",
+				Sequence:           20211108,
+				Username:           "username",
+				LoginNames:         []string{"login_name1", "login_name2"},
+				PreferredLoginName: "login_name1",
+				Machine: &Machine{
+					Name: "name",
+				},
+			},
+			want: &NotifyUser{
+				ID:                 "id",
+				CreationDate:       testNow,
+				ChangeDate:         testNow,
+				ResourceOwner:      "ro",
+				Sequence:           20211108,
+				UserName:           "username",
+				LoginNames:         []string{"login_name1", "login_name2"},
+				PreferredLoginName: "login_name1",
+			},
+		},
+		{
+			name: "human verified",
+			user: &User{
+				ID:       "id",
+				Username: "username",
+				Human: &Human{
+					FirstName:         "first_name",
+					LastName:          "last_name",
+					NickName:          "nick_name",
+					DisplayName:       "display_name",
+					PreferredLanguage: language.German,
+					Email:             "email",
+					IsEmailVerified:   true,
+					Phone:             "phone",
+					IsPhoneVerified:   true,
+					PasswordChanged:   testNow,
+				},
+			},
+			want: &NotifyUser{
+				ID:                "id",
+				UserName:          "username",
+				FirstName:         "first_name",
+				LastName:          "last_name",
+				NickName:          "nick_name",
+				DisplayName:       "display_name",
+				PreferredLanguage: "de",
+				LastEmail:         "email",
+				VerifiedEmail:     "email",
+				LastPhone:         "phone",
+				VerifiedPhone:     "phone",
+				PasswordSet:       true,
+			},
+		},
+		{
+			name: "human not verified",
+			user: &User{
+				ID:       "id",
+				Username: "username",
+				Human: &Human{
+					FirstName:         "first_name",
+					LastName:          "last_name",
+					DisplayName:       "display_name",
+					PreferredLanguage: language.Und,
+					Email:             "email",
+					Phone:             "phone",
+				},
+			},
+			want: &NotifyUser{
+				ID:          "id",
+				UserName:    "username",
+				FirstName:   "first_name",
+				LastName:    "last_name",
+				DisplayName: "display_name",
+				LastEmail:   "email",
+				LastPhone:   "phone",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notifyUserFromUser(tt.user); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("notifyUserFromUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
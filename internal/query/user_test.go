@@ -38,6 +38,9 @@ var (
 		` zitadel.projections.users_humans.is_email_verified,` +
 		` zitadel.projections.users_humans.phone,` +
 		` zitadel.projections.users_humans.is_phone_verified,` +
+		` zitadel.projections.users_humans.password_changed,` +
+		` zitadel.projections.users_humans.lock_reason,` +
+		` zitadel.projections.users_humans.locked_until,` +
 		` zitadel.projections.users_machines.user_id,` +
 		` zitadel.projections.users_machines.name,` +
 		` zitadel.projections.users_machines.description` +
@@ -76,6 +79,9 @@ var (
 		"is_email_verified",
 		"phone",
 		"is_phone_verified",
+		"password_changed",
+		"lock_reason",
+		"locked_until",
 		//machine
 		"user_id",
 		"name",
@@ -209,6 +215,9 @@ var (
 		` zitadel.projections.users_humans.is_email_verified,` +
 		` zitadel.projections.users_humans.phone,` +
 		` zitadel.projections.users_humans.is_phone_verified,` +
+		` zitadel.projections.users_humans.password_changed,` +
+		` zitadel.projections.users_humans.lock_reason,` +
+		` zitadel.projections.users_humans.locked_until,` +
 		` zitadel.projections.users_machines.user_id,` +
 		` zitadel.projections.users_machines.name,` +
 		` zitadel.projections.users_machines.description,` +
@@ -248,6 +257,9 @@ var (
 		"is_email_verified",
 		"phone",
 		"is_phone_verified",
+		"password_changed",
+		"lock_reason",
+		"locked_until",
 		//machine
 		"user_id",
 		"name",
@@ -316,6 +328,9 @@ func Test_UserPrepares(t *testing.T) {
 						true,
 						"phone",
 						true,
+						testNow,
+						nil,
+						nil,
 						//machine
 						nil,
 						nil,
@@ -346,6 +361,7 @@ func Test_UserPrepares(t *testing.T) {
 					IsEmailVerified:   true,
 					Phone:             "phone",
 					IsPhoneVerified:   true,
+					PasswordChanged:   testNow,
 				},
 			},
 		},
@@ -380,6 +396,9 @@ func Test_UserPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						//machine
 						"id",
 						"name",
@@ -819,6 +838,9 @@ func Test_UserPrepares(t *testing.T) {
 							true,
 							"phone",
 							true,
+							testNow,
+							nil,
+							nil,
 							//machine
 							nil,
 							nil,
@@ -855,6 +877,7 @@ func Test_UserPrepares(t *testing.T) {
 							IsEmailVerified:   true,
 							Phone:             "phone",
 							IsPhoneVerified:   true,
+							PasswordChanged:   testNow,
 						},
 					},
 				},
@@ -892,6 +915,9 @@ func Test_UserPrepares(t *testing.T) {
 							true,
 							"phone",
 							true,
+							testNow,
+							nil,
+							nil,
 							//machine
 							nil,
 							nil,
@@ -921,6 +947,9 @@ func Test_UserPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							//machine
 							"id",
 							"name",
@@ -957,6 +986,7 @@ func Test_UserPrepares(t *testing.T) {
 							IsEmailVerified:   true,
 							Phone:             "phone",
 							IsPhoneVerified:   true,
+							PasswordChanged:   testNow,
 						},
 					},
 					{
@@ -0,0 +1,146 @@
+package renderer
+
+import (
+	"bytes"
+	"net/http"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/caos/logging"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/i18n"
+)
+
+//disallowedBuiltins are the builtin functions of text/template which can't be used in custom templates
+var disallowedBuiltins = map[string]bool{
+	"call": true,
+}
+
+//ValidateTemplate checks if the content can be parsed as template
+// only the provided functions, the translate function and the builtin functions (except call) are available
+func ValidateTemplate(name, content string, funcNames []string) error {
+	funcs := make(template.FuncMap, len(funcNames)+1)
+	for _, funcName := range funcNames {
+		funcs[funcName] = validationFunc
+	}
+	funcs[TranslateFn] = validationFunc
+	tmpl, err := template.New(name).Funcs(funcs).Parse(content)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "RENDE-Tp2iV", "Errors.Template.Invalid")
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if usesDisallowedBuiltin(t.Tree.Root) {
+			return errors.ThrowInvalidArgument(nil, "RENDE-Tp2fD", "Errors.Template.FunctionNotAllowed")
+		}
+	}
+	return nil
+}
+
+func validationFunc(...interface{}) string {
+	return ""
+}
+
+func usesDisallowedBuiltin(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if usesDisallowedBuiltin(child) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return usesDisallowedBuiltin(n.Pipe)
+	case *parse.IfNode:
+		return usesDisallowedBuiltin(&n.BranchNode)
+	case *parse.RangeNode:
+		return usesDisallowedBuiltin(&n.BranchNode)
+	case *parse.WithNode:
+		return usesDisallowedBuiltin(&n.BranchNode)
+	case *parse.BranchNode:
+		return usesDisallowedBuiltin(n.Pipe) || usesDisallowedBuiltin(n.List) || usesDisallowedBuiltin(n.ElseList)
+	case *parse.TemplateNode:
+		return usesDisallowedBuiltin(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if usesDisallowedBuiltin(cmd) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if usesDisallowedBuiltin(arg) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return usesDisallowedBuiltin(n.Node)
+	case *parse.IdentifierNode:
+		return disallowedBuiltins[n.Ident]
+	}
+	return false
+}
+
+//TemplatesWithOverrides returns a copy of the templates with the overrides (file name -> content) applied
+// the overrides are validated with the provided functions, the default templates are not modified
+func (r *Renderer) TemplatesWithOverrides(overrides map[string]string, funcNames []string) (*template.Template, error) {
+	tmpl, err := r.templates.Clone()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "RENDE-Tp3cL", "Errors.Internal")
+	}
+	for file, content := range overrides {
+		if tmpl.Lookup(file) == nil {
+			return nil, errors.ThrowNotFound(nil, "RENDE-Tp3nF", "Errors.Template.NotFound")
+		}
+		if err := ValidateTemplate(file, content, funcNames); err != nil {
+			return nil, err
+		}
+		if _, err := tmpl.New(file).Parse(content); err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "RENDE-Tp3iV", "Errors.Template.Invalid")
+		}
+	}
+	return tmpl, nil
+}
+
+//HasTemplate checks if the file is one of the templates
+func (r *Renderer) HasTemplate(file string) bool {
+	return r.templates.Lookup(file) != nil
+}
+
+//RenderTemplateWithFallback renders the template and renders the fallback template if the template can't be executed
+// the output of the template is buffered, so nothing of the failed template is written
+func (r *Renderer) RenderTemplateWithFallback(w http.ResponseWriter, req *http.Request, translator *i18n.Translator, tmpl, fallback *template.Template, data interface{}, reqFuncs map[string]interface{}) {
+	if tmpl == nil || tmpl == fallback {
+		r.RenderTemplate(w, req, translator, fallback, data, reqFuncs)
+		return
+	}
+	reqFuncs = r.registerTranslateFn(req, translator, reqFuncs)
+	var buf bytes.Buffer
+	if err := tmpl.Funcs(reqFuncs).Execute(&buf, data); err != nil {
+		logging.LogWithFields("RENDE-Tp4fB", "template", tmpl.Name()).WithError(err).Warn("error rendering custom template, default template is rendered")
+		r.RenderTemplate(w, req, translator, fallback, data, reqFuncs)
+		return
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logging.LogWithFields("RENDE-Tp4wR", "template", tmpl.Name()).WithError(err).Warn("error writing rendered template")
+	}
+}
+
+//ExecuteTemplate renders the template into a string, errors are returned
+func (r *Renderer) ExecuteTemplate(translator *i18n.Translator, tmpl *template.Template, data interface{}, reqFuncs map[string]interface{}) (string, error) {
+	reqFuncs = r.registerTranslateFn(nil, translator, reqFuncs)
+	var buf bytes.Buffer
+	if err := tmpl.Funcs(reqFuncs).Execute(&buf, data); err != nil {
+		return "", errors.ThrowInvalidArgument(err, "RENDE-Tp5eX", "Errors.Template.Invalid")
+	}
+	return buf.String(), nil
+}
@@ -22,6 +22,7 @@ const (
 
 type Renderer struct {
 	Templates        map[string]*template.Template
+	templates        *template.Template
 	dir              http.FileSystem
 	translatorConfig i18n.TranslatorConfig
 }
@@ -97,6 +98,7 @@ func (r *Renderer) loadTemplates(dir http.FileSystem, translator *i18n.Translato
 			return errors.ThrowNotFound(err, "RENDE-dfTe1", "cannot append file to templates")
 		}
 	}
+	r.templates = tmpl
 	r.Templates = make(map[string]*template.Template, len(tmplMapping))
 	for name, file := range tmplMapping {
 		r.Templates[name] = tmpl.Lookup(file)
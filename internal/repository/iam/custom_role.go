@@ -0,0 +1,168 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueCustomRoleType = "custom_role"
+	customRolePrefix     = iamEventTypePrefix + "role."
+
+	CustomRoleAddedEventType   = customRolePrefix + "added"
+	CustomRoleChangedEventType = customRolePrefix + "changed"
+	CustomRoleRemovedEventType = customRolePrefix + "removed"
+)
+
+func NewAddCustomRoleUniqueConstraint(role string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueCustomRoleType,
+		role,
+		"Errors.IAM.CustomRole.AlreadyExists")
+}
+
+func NewRemoveCustomRoleUniqueConstraint(role string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueCustomRoleType,
+		role)
+}
+
+type CustomRoleAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Role        string                 `json:"role,omitempty"`
+	Scope       domain.CustomRoleScope `json:"scope,omitempty"`
+	Permissions []string               `json:"permissions,omitempty"`
+}
+
+func (e *CustomRoleAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomRoleAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddCustomRoleUniqueConstraint(e.Role)}
+}
+
+func NewCustomRoleAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	role string,
+	scope domain.CustomRoleScope,
+	permissions []string,
+) *CustomRoleAddedEvent {
+	return &CustomRoleAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomRoleAddedEventType,
+		),
+		Role:        role,
+		Scope:       scope,
+		Permissions: permissions,
+	}
+}
+
+func CustomRoleAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomRoleAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Cr2mA", "unable to unmarshal custom role")
+	}
+
+	return e, nil
+}
+
+type CustomRoleChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+func (e *CustomRoleChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomRoleChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewCustomRoleChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	role string,
+	permissions []string,
+) *CustomRoleChangedEvent {
+	return &CustomRoleChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomRoleChangedEventType,
+		),
+		Role:        role,
+		Permissions: permissions,
+	}
+}
+
+func CustomRoleChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomRoleChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Cr2mC", "unable to unmarshal custom role")
+	}
+
+	return e, nil
+}
+
+type CustomRoleRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Role string `json:"role,omitempty"`
+}
+
+func (e *CustomRoleRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomRoleRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemoveCustomRoleUniqueConstraint(e.Role)}
+}
+
+func NewCustomRoleRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	role string,
+) *CustomRoleRemovedEvent {
+	return &CustomRoleRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomRoleRemovedEventType,
+		),
+		Role: role,
+	}
+}
+
+func CustomRoleRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomRoleRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Cr2mR", "unable to unmarshal custom role")
+	}
+
+	return e, nil
+}
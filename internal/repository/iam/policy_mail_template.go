@@ -9,8 +9,10 @@ import (
 )
 
 var (
-	MailTemplateAddedEventType   = iamEventTypePrefix + policy.MailTemplatePolicyAddedEventType
-	MailTemplateChangedEventType = iamEventTypePrefix + policy.MailTemplatePolicyChangedEventType
+	MailTemplateAddedEventType        = iamEventTypePrefix + policy.MailTemplatePolicyAddedEventType
+	MailTemplateChangedEventType      = iamEventTypePrefix + policy.MailTemplatePolicyChangedEventType
+	MailMessageTemplateSetEventType   = iamEventTypePrefix + policy.MailMessageTemplateSetEventType
+	MailMessageTemplateResetEventType = iamEventTypePrefix + policy.MailMessageTemplateResetEventType
 )
 
 type MailTemplateAddedEvent struct {
@@ -65,3 +67,55 @@ func MailTemplateChangedEventMapper(event *repository.Event) (eventstore.Event,
 
 	return &MailTemplateChangedEvent{MailTemplateChangedEvent: *e.(*policy.MailTemplateChangedEvent)}, nil
 }
+
+type MailMessageTemplateSetEvent struct {
+	policy.MailMessageTemplateSetEvent
+}
+
+func NewMailMessageTemplateSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	template []byte,
+) *MailMessageTemplateSetEvent {
+	return &MailMessageTemplateSetEvent{
+		MailMessageTemplateSetEvent: *policy.NewMailMessageTemplateSetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailMessageTemplateSetEventType),
+			messageType,
+			template),
+	}
+}
+
+func MailMessageTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailMessageTemplateSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailMessageTemplateSetEvent{MailMessageTemplateSetEvent: *e.(*policy.MailMessageTemplateSetEvent)}, nil
+}
+
+type MailMessageTemplateResetEvent struct {
+	policy.MailMessageTemplateResetEvent
+}
+
+func NewMailMessageTemplateResetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+) *MailMessageTemplateResetEvent {
+	return &MailMessageTemplateResetEvent{
+		MailMessageTemplateResetEvent: *policy.NewMailMessageTemplateResetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailMessageTemplateResetEventType),
+			messageType),
+	}
+}
+
+func MailMessageTemplateResetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailMessageTemplateResetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailMessageTemplateResetEvent{MailMessageTemplateResetEvent: *e.(*policy.MailMessageTemplateResetEvent)}, nil
+}
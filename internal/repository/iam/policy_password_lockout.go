@@ -2,6 +2,7 @@ package iam
 
 import (
 	"context"
+	"time"
 
 	"github.com/caos/zitadel/internal/eventstore"
 
@@ -21,7 +22,11 @@ type LockoutPolicyAddedEvent struct {
 func NewLockoutPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	maxAttempts uint64,
+	maxAttempts,
+	maxOTPAttempts uint64,
+	lockoutDuration time.Duration,
+	maxIPAttempts uint64,
+	ipThrottleDuration time.Duration,
 	showLockoutFailure bool,
 ) *LockoutPolicyAddedEvent {
 	return &LockoutPolicyAddedEvent{
@@ -31,6 +36,10 @@ func NewLockoutPolicyAddedEvent(
 				aggregate,
 				LockoutPolicyAddedEventType),
 			maxAttempts,
+			maxOTPAttempts,
+			lockoutDuration,
+			maxIPAttempts,
+			ipThrottleDuration,
 			showLockoutFailure),
 	}
 }
@@ -0,0 +1,64 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	ProjectionEventSkippedEventType eventstore.EventType = "iam.projection.event.skipped"
+)
+
+type ProjectionEventSkippedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ProjectionName string `json:"projectionName"`
+	FailedSequence uint64 `json:"failedSequence"`
+	FailureCount   uint   `json:"failureCount"`
+	Error          string `json:"error"`
+}
+
+func (e *ProjectionEventSkippedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ProjectionEventSkippedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewProjectionEventSkippedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	projectionName string,
+	failedSequence uint64,
+	failureCount uint,
+	err string,
+) *ProjectionEventSkippedEvent {
+	return &ProjectionEventSkippedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ProjectionEventSkippedEventType,
+		),
+		ProjectionName: projectionName,
+		FailedSequence: failedSequence,
+		FailureCount:   failureCount,
+		Error:          err,
+	}
+}
+
+func ProjectionEventSkippedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ProjectionEventSkippedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-4Xm9q", "unable to unmarshal projection event skipped")
+	}
+
+	return e, nil
+}
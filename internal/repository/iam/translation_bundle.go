@@ -0,0 +1,113 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	translationBundlePrefix = iamEventTypePrefix + "translation.bundle."
+
+	TranslationBundleSetEventType     = translationBundlePrefix + "set"
+	TranslationBundleRemovedEventType = translationBundlePrefix + "removed"
+)
+
+type TranslationBundleSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Component domain.TranslationComponent `json:"component,omitempty"`
+	Language  language.Tag                `json:"language,omitempty"`
+	Messages  map[string]string           `json:"messages,omitempty"`
+}
+
+func (e *TranslationBundleSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *TranslationBundleSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTranslationBundleSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	component domain.TranslationComponent,
+	language language.Tag,
+	messages map[string]string,
+) *TranslationBundleSetEvent {
+	return &TranslationBundleSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TranslationBundleSetEventType,
+		),
+		Component: component,
+		Language:  language,
+		Messages:  messages,
+	}
+}
+
+func TranslationBundleSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &TranslationBundleSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Tb2mS", "unable to unmarshal translation bundle")
+	}
+
+	return e, nil
+}
+
+type TranslationBundleRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Component domain.TranslationComponent `json:"component,omitempty"`
+	Language  language.Tag                `json:"language,omitempty"`
+}
+
+func (e *TranslationBundleRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *TranslationBundleRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTranslationBundleRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	component domain.TranslationComponent,
+	language language.Tag,
+) *TranslationBundleRemovedEvent {
+	return &TranslationBundleRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TranslationBundleRemovedEventType,
+		),
+		Component: component,
+		Language:  language,
+	}
+}
+
+func TranslationBundleRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &TranslationBundleRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Tb2mR", "unable to unmarshal translation bundle")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,11 @@
+package notification
+
+import "github.com/caos/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(QueuedEventType, QueuedEventMapper).
+		RegisterFilterEventMapper(SentEventType, SentEventMapper).
+		RegisterFilterEventMapper(RetryScheduledEventType, RetryScheduledEventMapper).
+		RegisterFilterEventMapper(FailedEventType, FailedEventMapper).
+		RegisterFilterEventMapper(ResendRequestedEventType, ResendRequestedEventMapper)
+}
@@ -0,0 +1,235 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/caos/zitadel/internal/crypto"
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueIdempotencyKey     = "notification_idempotency_key"
+	eventTypePrefix          = eventstore.EventType("notification.")
+	QueuedEventType          = eventTypePrefix + "queued"
+	SentEventType            = eventTypePrefix + "sent"
+	RetryScheduledEventType  = eventTypePrefix + "retry.scheduled"
+	FailedEventType          = eventTypePrefix + "failed"
+	ResendRequestedEventType = eventTypePrefix + "resend.requested"
+)
+
+//NewAddIdempotencyKeyUniqueConstraint prevents that the same notification is queued multiple times
+func NewAddIdempotencyKeyUniqueConstraint(idempotencyKey string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueIdempotencyKey,
+		idempotencyKey,
+		"Errors.Notification.AlreadyQueued")
+}
+
+type QueuedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID      string                  `json:"userId"`
+	MessageType string                  `json:"messageType,omitempty"`
+	Channel     domain.NotificationType `json:"channel"`
+	Recipient   string                  `json:"recipient"`
+	Message     *crypto.CryptoValue     `json:"message"`
+
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+}
+
+func (e *QueuedEvent) Data() interface{} {
+	return e
+}
+
+func (e *QueuedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	if e.IdempotencyKey == "" {
+		return nil
+	}
+	return []*eventstore.EventUniqueConstraint{NewAddIdempotencyKeyUniqueConstraint(e.IdempotencyKey)}
+}
+
+func NewQueuedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	messageType string,
+	channel domain.NotificationType,
+	recipient string,
+	message *crypto.CryptoValue,
+	idempotencyKey string,
+) *QueuedEvent {
+	return &QueuedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			QueuedEventType,
+		),
+		UserID:         userID,
+		MessageType:    messageType,
+		Channel:        channel,
+		Recipient:      recipient,
+		Message:        message,
+		IdempotencyKey: idempotencyKey,
+	}
+}
+
+func QueuedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &QueuedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Ob2qU", "unable to unmarshal notification queued")
+	}
+
+	return e, nil
+}
+
+type SentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *SentEvent) Data() interface{} {
+	return nil
+}
+
+func (e *SentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSentEvent(ctx context.Context, aggregate *eventstore.Aggregate) *SentEvent {
+	return &SentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SentEventType,
+		),
+	}
+}
+
+func SentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &SentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type RetryScheduledEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Error       string    `json:"error,omitempty"`
+	NextAttempt time.Time `json:"nextAttempt"`
+}
+
+func (e *RetryScheduledEvent) Data() interface{} {
+	return e
+}
+
+func (e *RetryScheduledEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRetryScheduledEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deliveryErr string,
+	nextAttempt time.Time,
+) *RetryScheduledEvent {
+	return &RetryScheduledEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RetryScheduledEventType,
+		),
+		Error:       deliveryErr,
+		NextAttempt: nextAttempt,
+	}
+}
+
+func RetryScheduledEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RetryScheduledEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Ob2rU", "unable to unmarshal notification retry scheduled")
+	}
+
+	return e, nil
+}
+
+type FailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Error string `json:"error,omitempty"`
+}
+
+func (e *FailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *FailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deliveryErr string,
+) *FailedEvent {
+	return &FailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			FailedEventType,
+		),
+		Error: deliveryErr,
+	}
+}
+
+func FailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &FailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "NOTIF-Ob2fU", "unable to unmarshal notification failed")
+	}
+
+	return e, nil
+}
+
+type ResendRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ResendRequestedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *ResendRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewResendRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *ResendRequestedEvent {
+	return &ResendRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ResendRequestedEventType,
+		),
+	}
+}
+
+func ResendRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &ResendRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
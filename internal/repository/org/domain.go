@@ -20,6 +20,8 @@ const (
 	OrgDomainVerificationFailedEventType = domainEventPrefix + "verification.failed"
 	OrgDomainVerifiedEventType           = domainEventPrefix + "verified"
 	OrgDomainPrimarySetEventType         = domainEventPrefix + "primary.set"
+	OrgDomainLoginSetEventType           = domainEventPrefix + "login.set"
+	OrgDomainLoginRemovedEventType       = domainEventPrefix + "login.removed"
 	OrgDomainRemovedEventType            = domainEventPrefix + "removed"
 )
 
@@ -230,6 +232,80 @@ func DomainPrimarySetEventMapper(event *repository.Event) (eventstore.Event, err
 	return orgDomainPrimarySet, nil
 }
 
+type DomainLoginSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Domain string `json:"domain,omitempty"`
+}
+
+func (e *DomainLoginSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *DomainLoginSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDomainLoginSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, domain string) *DomainLoginSetEvent {
+	return &DomainLoginSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgDomainLoginSetEventType,
+		),
+		Domain: domain,
+	}
+}
+
+func DomainLoginSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	orgDomainLoginSet := &DomainLoginSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, orgDomainLoginSet)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Lg2sd", "unable to unmarshal org domain login set")
+	}
+
+	return orgDomainLoginSet, nil
+}
+
+type DomainLoginRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Domain string `json:"domain,omitempty"`
+}
+
+func (e *DomainLoginRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DomainLoginRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDomainLoginRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, domain string) *DomainLoginRemovedEvent {
+	return &DomainLoginRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgDomainLoginRemovedEventType,
+		),
+		Domain: domain,
+	}
+}
+
+func DomainLoginRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	orgDomainLoginRemoved := &DomainLoginRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, orgDomainLoginRemoved)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Lg3rd", "unable to unmarshal org domain login removed")
+	}
+
+	return orgDomainLoginRemoved, nil
+}
+
 type DomainRemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
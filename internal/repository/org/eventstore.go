@@ -14,6 +14,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(OrgDomainVerificationFailedEventType, DomainVerificationFailedEventMapper).
 		RegisterFilterEventMapper(OrgDomainVerifiedEventType, DomainVerifiedEventMapper).
 		RegisterFilterEventMapper(OrgDomainPrimarySetEventType, DomainPrimarySetEventMapper).
+		RegisterFilterEventMapper(OrgDomainLoginSetEventType, DomainLoginSetEventMapper).
+		RegisterFilterEventMapper(OrgDomainLoginRemovedEventType, DomainLoginRemovedEventMapper).
 		RegisterFilterEventMapper(OrgDomainRemovedEventType, DomainRemovedEventMapper).
 		RegisterFilterEventMapper(MemberAddedEventType, MemberAddedEventMapper).
 		RegisterFilterEventMapper(MemberChangedEventType, MemberChangedEventMapper).
@@ -59,8 +61,15 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(PrivacyPolicyAddedEventType, PrivacyPolicyAddedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyChangedEventType, PrivacyPolicyChangedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyRemovedEventType, PrivacyPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper).
+		RegisterFilterEventMapper(NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper).
+		RegisterFilterEventMapper(NotificationPolicyRemovedEventType, NotificationPolicyRemovedEventMapper).
 		RegisterFilterEventMapper(MailTemplateAddedEventType, MailTemplateAddedEventMapper).
 		RegisterFilterEventMapper(MailTemplateChangedEventType, MailTemplateChangedEventMapper).
+		RegisterFilterEventMapper(MailMessageTemplateSetEventType, MailMessageTemplateSetEventMapper).
+		RegisterFilterEventMapper(MailMessageTemplateResetEventType, MailMessageTemplateResetEventMapper).
+		RegisterFilterEventMapper(LoginTemplateSetEventType, LoginTemplateSetEventMapper).
+		RegisterFilterEventMapper(LoginTemplateRemovedEventType, LoginTemplateRemovedEventMapper).
 		RegisterFilterEventMapper(MailTemplateRemovedEventType, MailTemplateRemovedEventMapper).
 		RegisterFilterEventMapper(MailTextAddedEventType, MailTextAddedEventMapper).
 		RegisterFilterEventMapper(MailTextChangedEventType, MailTextChangedEventMapper).
@@ -0,0 +1,92 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	loginTemplateEventPrefix      = orgEventTypePrefix + "login.template."
+	LoginTemplateSetEventType     = loginTemplateEventPrefix + "set"
+	LoginTemplateRemovedEventType = loginTemplateEventPrefix + "removed"
+)
+
+type LoginTemplateSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Template string `json:"template,omitempty"`
+	AssetKey string `json:"assetKey,omitempty"`
+}
+
+func (e *LoginTemplateSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *LoginTemplateSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLoginTemplateSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, template, assetKey string) *LoginTemplateSetEvent {
+	return &LoginTemplateSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LoginTemplateSetEventType,
+		),
+		Template: template,
+		AssetKey: assetKey,
+	}
+}
+
+func LoginTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	templateSet := &LoginTemplateSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, templateSet)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Tp6sd", "unable to unmarshal login template set")
+	}
+
+	return templateSet, nil
+}
+
+type LoginTemplateRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Template string `json:"template,omitempty"`
+}
+
+func (e *LoginTemplateRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *LoginTemplateRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLoginTemplateRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, template string) *LoginTemplateRemovedEvent {
+	return &LoginTemplateRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LoginTemplateRemovedEventType,
+		),
+		Template: template,
+	}
+}
+
+func LoginTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	templateRemoved := &LoginTemplateRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, templateRemoved)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Tp6rd", "unable to unmarshal login template removed")
+	}
+
+	return templateRemoved, nil
+}
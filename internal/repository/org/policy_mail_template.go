@@ -10,9 +10,11 @@ import (
 )
 
 var (
-	MailTemplateAddedEventType   = orgEventTypePrefix + policy.MailTemplatePolicyAddedEventType
-	MailTemplateChangedEventType = orgEventTypePrefix + policy.MailTemplatePolicyChangedEventType
-	MailTemplateRemovedEventType = orgEventTypePrefix + policy.MailTemplatePolicyRemovedEventType
+	MailTemplateAddedEventType        = orgEventTypePrefix + policy.MailTemplatePolicyAddedEventType
+	MailTemplateChangedEventType      = orgEventTypePrefix + policy.MailTemplatePolicyChangedEventType
+	MailTemplateRemovedEventType      = orgEventTypePrefix + policy.MailTemplatePolicyRemovedEventType
+	MailMessageTemplateSetEventType   = orgEventTypePrefix + policy.MailMessageTemplateSetEventType
+	MailMessageTemplateResetEventType = orgEventTypePrefix + policy.MailMessageTemplateResetEventType
 )
 
 type MailTemplateAddedEvent struct {
@@ -91,3 +93,55 @@ func MailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event,
 
 	return &MailTemplateRemovedEvent{MailTemplateRemovedEvent: *e.(*policy.MailTemplateRemovedEvent)}, nil
 }
+
+type MailMessageTemplateSetEvent struct {
+	policy.MailMessageTemplateSetEvent
+}
+
+func NewMailMessageTemplateSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	template []byte,
+) *MailMessageTemplateSetEvent {
+	return &MailMessageTemplateSetEvent{
+		MailMessageTemplateSetEvent: *policy.NewMailMessageTemplateSetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailMessageTemplateSetEventType),
+			messageType,
+			template),
+	}
+}
+
+func MailMessageTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailMessageTemplateSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailMessageTemplateSetEvent{MailMessageTemplateSetEvent: *e.(*policy.MailMessageTemplateSetEvent)}, nil
+}
+
+type MailMessageTemplateResetEvent struct {
+	policy.MailMessageTemplateResetEvent
+}
+
+func NewMailMessageTemplateResetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+) *MailMessageTemplateResetEvent {
+	return &MailMessageTemplateResetEvent{
+		MailMessageTemplateResetEvent: *policy.NewMailMessageTemplateResetEvent(
+			eventstore.NewBaseEventForPush(ctx, aggregate, MailMessageTemplateResetEventType),
+			messageType),
+	}
+}
+
+func MailMessageTemplateResetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.MailMessageTemplateResetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailMessageTemplateResetEvent{MailMessageTemplateResetEvent: *e.(*policy.MailMessageTemplateResetEvent)}, nil
+}
@@ -2,6 +2,7 @@ package org
 
 import (
 	"context"
+	"time"
 
 	"github.com/caos/zitadel/internal/eventstore"
 
@@ -22,7 +23,11 @@ type LockoutPolicyAddedEvent struct {
 func NewLockoutPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	maxAttempts uint64,
+	maxAttempts,
+	maxOTPAttempts uint64,
+	lockoutDuration time.Duration,
+	maxIPAttempts uint64,
+	ipThrottleDuration time.Duration,
 	showLockoutFailure bool,
 ) *LockoutPolicyAddedEvent {
 	return &LockoutPolicyAddedEvent{
@@ -32,6 +37,10 @@ func NewLockoutPolicyAddedEvent(
 				aggregate,
 				LockoutPolicyAddedEventType),
 			maxAttempts,
+			maxOTPAttempts,
+			lockoutDuration,
+			maxIPAttempts,
+			ipThrottleDuration,
 			showLockoutFailure),
 	}
 }
@@ -14,6 +14,10 @@ const (
 	MailTemplatePolicyAddedEventType   = mailTemplatePolicyPrefix + "added"
 	MailTemplatePolicyChangedEventType = mailTemplatePolicyPrefix + "changed"
 	MailTemplatePolicyRemovedEventType = mailTemplatePolicyPrefix + "removed"
+
+	mailMessageTemplatePrefix         = mailTemplatePolicyPrefix + "message."
+	MailMessageTemplateSetEventType   = mailMessageTemplatePrefix + "set"
+	MailMessageTemplateResetEventType = mailMessageTemplatePrefix + "reset"
 )
 
 type MailTemplateAddedEvent struct {
@@ -127,3 +131,80 @@ func MailTemplateRemovedEventMapper(event *repository.Event) (eventstore.Event,
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+type MailMessageTemplateSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+	Template    []byte `json:"template,omitempty"`
+}
+
+func (e *MailMessageTemplateSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *MailMessageTemplateSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMailMessageTemplateSetEvent(
+	base *eventstore.BaseEvent,
+	messageType string,
+	template []byte,
+) *MailMessageTemplateSetEvent {
+	return &MailMessageTemplateSetEvent{
+		BaseEvent:   *base,
+		MessageType: messageType,
+		Template:    template,
+	}
+}
+
+func MailMessageTemplateSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MailMessageTemplateSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Mt3sU", "unable to unmarshal mail message template")
+	}
+
+	return e, nil
+}
+
+type MailMessageTemplateResetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType,omitempty"`
+}
+
+func (e *MailMessageTemplateResetEvent) Data() interface{} {
+	return e
+}
+
+func (e *MailMessageTemplateResetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMailMessageTemplateResetEvent(
+	base *eventstore.BaseEvent,
+	messageType string,
+) *MailMessageTemplateResetEvent {
+	return &MailMessageTemplateResetEvent{
+		BaseEvent:   *base,
+		MessageType: messageType,
+	}
+}
+
+func MailMessageTemplateResetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MailMessageTemplateResetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Mt3rU", "unable to unmarshal mail message template")
+	}
+
+	return e, nil
+}
@@ -2,6 +2,7 @@ package policy
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/caos/zitadel/internal/eventstore"
 
@@ -18,8 +19,12 @@ const (
 type LockoutPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MaxPasswordAttempts uint64 `json:"maxPasswordAttempts,omitempty"`
-	ShowLockOutFailures bool   `json:"showLockOutFailures,omitempty"`
+	MaxPasswordAttempts uint64        `json:"maxPasswordAttempts,omitempty"`
+	MaxOTPAttempts      uint64        `json:"maxOTPAttempts,omitempty"`
+	LockoutDuration     time.Duration `json:"lockoutDuration,omitempty"`
+	MaxIPAttempts       uint64        `json:"maxIPAttempts,omitempty"`
+	IPThrottleDuration  time.Duration `json:"ipThrottleDuration,omitempty"`
+	ShowLockOutFailures bool          `json:"showLockOutFailures,omitempty"`
 }
 
 func (e *LockoutPolicyAddedEvent) Data() interface{} {
@@ -32,13 +37,21 @@ func (e *LockoutPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueC
 
 func NewLockoutPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	maxAttempts uint64,
+	maxAttempts,
+	maxOTPAttempts uint64,
+	lockoutDuration time.Duration,
+	maxIPAttempts uint64,
+	ipThrottleDuration time.Duration,
 	showLockOutFailures bool,
 ) *LockoutPolicyAddedEvent {
 
 	return &LockoutPolicyAddedEvent{
 		BaseEvent:           *base,
 		MaxPasswordAttempts: maxAttempts,
+		MaxOTPAttempts:      maxOTPAttempts,
+		LockoutDuration:     lockoutDuration,
+		MaxIPAttempts:       maxIPAttempts,
+		IPThrottleDuration:  ipThrottleDuration,
 		ShowLockOutFailures: showLockOutFailures,
 	}
 }
@@ -59,8 +72,12 @@ func LockoutPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, e
 type LockoutPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MaxPasswordAttempts *uint64 `json:"maxPasswordAttempts,omitempty"`
-	ShowLockOutFailures *bool   `json:"showLockOutFailures,omitempty"`
+	MaxPasswordAttempts *uint64        `json:"maxPasswordAttempts,omitempty"`
+	MaxOTPAttempts      *uint64        `json:"maxOTPAttempts,omitempty"`
+	LockoutDuration     *time.Duration `json:"lockoutDuration,omitempty"`
+	MaxIPAttempts       *uint64        `json:"maxIPAttempts,omitempty"`
+	IPThrottleDuration  *time.Duration `json:"ipThrottleDuration,omitempty"`
+	ShowLockOutFailures *bool          `json:"showLockOutFailures,omitempty"`
 }
 
 func (e *LockoutPolicyChangedEvent) Data() interface{} {
@@ -95,6 +112,30 @@ func ChangeMaxAttempts(maxAttempts uint64) func(*LockoutPolicyChangedEvent) {
 	}
 }
 
+func ChangeMaxOTPAttempts(maxAttempts uint64) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.MaxOTPAttempts = &maxAttempts
+	}
+}
+
+func ChangeLockoutDuration(duration time.Duration) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.LockoutDuration = &duration
+	}
+}
+
+func ChangeMaxIPAttempts(maxAttempts uint64) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.MaxIPAttempts = &maxAttempts
+	}
+}
+
+func ChangeIPThrottleDuration(duration time.Duration) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.IPThrottleDuration = &duration
+	}
+}
+
 func ChangeShowLockOutFailures(showLockOutFailures bool) func(*LockoutPolicyChangedEvent) {
 	return func(e *LockoutPolicyChangedEvent) {
 		e.ShowLockOutFailures = &showLockOutFailures
@@ -20,6 +20,7 @@ const (
 	HumanPasswordCodeSentType       = passwordEventPrefix + "code.sent"
 	HumanPasswordCheckSucceededType = passwordEventPrefix + "check.succeeded"
 	HumanPasswordCheckFailedType    = passwordEventPrefix + "check.failed"
+	HumanPasswordHashUpdatedType    = passwordEventPrefix + "hash.updated"
 )
 
 type HumanPasswordChangedEvent struct {
@@ -69,6 +70,49 @@ func HumanPasswordChangedEventMapper(event *repository.Event) (eventstore.Event,
 	return humanAdded, nil
 }
 
+//HumanPasswordHashUpdatedEvent replaces the hash of the unchanged password
+// e.g. after the password was verified against a hash of an outdated algorithm
+type HumanPasswordHashUpdatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Secret *crypto.CryptoValue `json:"secret,omitempty"`
+}
+
+func (e *HumanPasswordHashUpdatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPasswordHashUpdatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordHashUpdatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	secret *crypto.CryptoValue,
+) *HumanPasswordHashUpdatedEvent {
+	return &HumanPasswordHashUpdatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordHashUpdatedType,
+		),
+		Secret: secret,
+	}
+}
+
+func HumanPasswordHashUpdatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	hashUpdated := &HumanPasswordHashUpdatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, hashUpdated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Hs7Up", "unable to unmarshal human password hash updated")
+	}
+
+	return hashUpdated, nil
+}
+
 type HumanPasswordCodeAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
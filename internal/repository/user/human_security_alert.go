@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	humanSecurityAlertPrefix   = humanEventPrefix + "security.alert."
+	HumanSecurityAlertSentType = humanSecurityAlertPrefix + "sent"
+)
+
+//HumanSecurityAlertSentEvent marks the security alert
+// of the event with the sequence as sent
+type HumanSecurityAlertSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType   string `json:"messageType"`
+	EventSequence uint64 `json:"eventSequence"`
+}
+
+func (e *HumanSecurityAlertSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanSecurityAlertSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanSecurityAlertSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	eventSequence uint64,
+) *HumanSecurityAlertSentEvent {
+	return &HumanSecurityAlertSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSecurityAlertSentType,
+		),
+		MessageType:   messageType,
+		EventSequence: eventSequence,
+	}
+}
+
+func HumanSecurityAlertSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	alertSent := &HumanSecurityAlertSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, alertSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Sa3sU", "unable to unmarshal human security alert sent")
+	}
+	return alertSent, nil
+}
@@ -50,30 +50,45 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	Reason      domain.UserLockReason `json:"reason,omitempty"`
+	LockedUntil *time.Time            `json:"lockedUntil,omitempty"`
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
 	return nil
 }
 
-func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserLockedEvent {
+//NewUserLockedEvent creates the event which locks the user
+// the user is unlocked automatically after lockedUntil if it's not nil
+func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate, reason domain.UserLockReason, lockedUntil *time.Time) *UserLockedEvent {
 	return &UserLockedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
 			ctx,
 			aggregate,
 			UserLockedType,
 		),
+		Reason:      reason,
+		LockedUntil: lockedUntil,
 	}
 }
 
 func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
-	return &UserLockedEvent{
+	e := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return e, nil
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Lo3kR", "unable to unmarshal user locked")
+	}
+	return e, nil
 }
 
 type UserUnlockedEvent struct {
@@ -2,9 +2,10 @@ package setup
 
 import (
 	"github.com/caos/zitadel/internal/command"
-	"github.com/caos/zitadel/internal/domain"
 )
 
+//IAMSetUp contains the configuration of the setup steps
+// steps without configuration are registered without a field
 type IAMSetUp struct {
 	Step1  *command.Step1
 	Step2  *command.Step2
@@ -24,41 +25,5 @@ type IAMSetUp struct {
 	Step16 *command.Step16
 	Step17 *command.Step17
 	Step18 *command.Step18
-	Step19 *command.Step19
-	Step20 *command.Step20
-	Step21 *command.Step21
-}
-
-func (setup *IAMSetUp) Steps(currentDone domain.Step) ([]command.Step, error) {
-	steps := make([]command.Step, 0)
-
-	for _, step := range []command.Step{
-		setup.Step1,
-		setup.Step2,
-		setup.Step3,
-		setup.Step4,
-		setup.Step5,
-		setup.Step6,
-		setup.Step7,
-		setup.Step8,
-		setup.Step9,
-		setup.Step10,
-		setup.Step11,
-		setup.Step12,
-		setup.Step13,
-		setup.Step14,
-		setup.Step15,
-		setup.Step16,
-		setup.Step17,
-		setup.Step18,
-		setup.Step19,
-		setup.Step20,
-		setup.Step21,
-	} {
-		if step.Step() <= currentDone {
-			continue
-		}
-		steps = append(steps, step)
-	}
-	return steps, nil
+	Step22 *command.Step22
 }
@@ -2,41 +2,110 @@ package setup
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/caos/logging"
 
 	"github.com/caos/zitadel/internal/command"
 	"github.com/caos/zitadel/internal/domain"
 	caos_errs "github.com/caos/zitadel/internal/errors"
-	"github.com/caos/zitadel/internal/eventstore/v1/models"
 )
 
-func Execute(ctx context.Context, setUpConfig IAMSetUp, iamID string, commands *command.Commands) error {
+//Execute executes the registered steps which are not done yet
+// a step which was interrupted is executed again
+func Execute(ctx context.Context, setUpConfig IAMSetUp, commands *command.Commands) error {
 	logging.Log("SETUP-JAK2q").Info("starting setup")
 
-	iam, err := commands.GetIAM(ctx)
-	if err != nil && !caos_errs.IsNotFound(err) {
+	open, err := openSteps(ctx, &setUpConfig, commands)
+	if err != nil {
 		return err
 	}
-	if iam != nil && (iam.SetUpDone == domain.StepCount-1 || iam.SetUpStarted != iam.SetUpDone) {
+	if len(open) == 0 {
 		logging.Log("SETUP-VA2k1").Info("all steps done")
 		return nil
 	}
 
-	if iam == nil {
-		iam = &domain.IAM{ObjectRoot: models.ObjectRoot{AggregateID: iamID}}
+	for _, step := range open {
+		logging.LogWithFields("SETUP-Nq2Gs", "step", step.step, "name", step.name).Info("execute step")
+		err = commands.ExecuteSetupSteps(ctx, []command.Step{step.configured})
+		if err != nil {
+			return caos_errs.ThrowInternalf(err, "SETUP-Hs8sE", "setup step %d %s failed", step.step, step.name)
+		}
 	}
 
-	steps, err := setUpConfig.Steps(iam.SetUpDone)
-	if err != nil || len(steps) == 0 {
-		return err
+	logging.Log("SETUP-ds31h").Info("setup done")
+	return nil
+}
+
+//DryRun returns the registered steps which would be executed by Execute without executing them
+// it fails if a step which would be executed isn't configured
+func DryRun(ctx context.Context, setUpConfig IAMSetUp, commands *command.Commands) ([]*domain.StepStatus, error) {
+	open, err := openSteps(ctx, &setUpConfig, commands)
+	if err != nil {
+		return nil, err
 	}
+	result := make([]*domain.StepStatus, len(open))
+	for i, step := range open {
+		result[i] = step.current
+	}
+	return result, nil
+}
 
-	err = commands.ExecuteSetupSteps(ctx, steps)
+//Status returns the state of all registered steps
+func Status(ctx context.Context, commands *command.Commands) ([]*domain.StepStatus, error) {
+	states, err := commands.SetupStepStatus(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	result := make([]*domain.StepStatus, len(steps))
+	for i, step := range steps {
+		result[i] = step.status(states)
+	}
+	return result, nil
+}
 
-	logging.Log("SETUP-ds31h").Info("setup done")
-	return nil
+type openStep struct {
+	*registeredStep
+	current    *domain.StepStatus
+	configured command.Step
+}
+
+func openSteps(ctx context.Context, setUpConfig *IAMSetUp, commands *command.Commands) ([]*openStep, error) {
+	states, err := commands.SetupStepStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	open := make([]*openStep, 0, len(steps))
+	for _, step := range steps {
+		status := step.status(states)
+		if status.State == domain.StepStateDone {
+			continue
+		}
+		configured := step.config(setUpConfig)
+		if !isConfigured(configured) {
+			return nil, caos_errs.ThrowPreconditionFailedf(nil, "SETUP-Nc3tG", "setup step %d %s is not configured", step.step, step.name)
+		}
+		open = append(open, &openStep{registeredStep: step, current: status, configured: configured})
+	}
+	return open, nil
+}
+
+func (s *registeredStep) status(states map[domain.Step]*domain.StepStatus) *domain.StepStatus {
+	status := &domain.StepStatus{
+		Step: s.step,
+		Name: s.name,
+	}
+	if state, ok := states[s.step]; ok {
+		status.State = state.State
+		status.ChangeDate = state.ChangeDate
+	}
+	return status
+}
+
+func isConfigured(step command.Step) bool {
+	if step == nil {
+		return false
+	}
+	value := reflect.ValueOf(step)
+	return value.Kind() != reflect.Ptr || !value.IsNil()
 }
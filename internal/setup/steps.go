@@ -0,0 +1,69 @@
+package setup
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/caos/zitadel/internal/command"
+	"github.com/caos/zitadel/internal/domain"
+)
+
+//registeredStep is a named setup step
+// config returns the configured step or nil if the step isn't configured
+type registeredStep struct {
+	step   domain.Step
+	name   string
+	config func(*IAMSetUp) command.Step
+}
+
+var steps []*registeredStep
+
+//RegisterStep adds a step to the setup
+// the steps are executed in ascending order of their numbers,
+// the number and the name of a released step must never change
+// because the state of the steps is stored in the eventstore
+func RegisterStep(step domain.Step, name string, config func(*IAMSetUp) command.Step) {
+	for _, registered := range steps {
+		if registered.step == step || registered.name == name {
+			panic(fmt.Sprintf("setup step %d %q already registered as %d %q", step, name, registered.step, registered.name))
+		}
+	}
+	steps = append(steps, &registeredStep{step: step, name: name, config: config})
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].step < steps[j].step
+	})
+}
+
+//LastStep returns the number of the last registered step
+// the setup is done if the last step is done
+func LastStep() domain.Step {
+	if len(steps) == 0 {
+		return 0
+	}
+	return steps[len(steps)-1].step
+}
+
+func init() {
+	RegisterStep(domain.Step1, "initial_orgs_and_projects", func(s *IAMSetUp) command.Step { return s.Step1 })
+	RegisterStep(domain.Step2, "default_password_complexity_policy", func(s *IAMSetUp) command.Step { return s.Step2 })
+	RegisterStep(domain.Step3, "default_password_age_policy", func(s *IAMSetUp) command.Step { return s.Step3 })
+	RegisterStep(domain.Step4, "default_password_lockout_policy", func(s *IAMSetUp) command.Step { return s.Step4 })
+	RegisterStep(domain.Step5, "default_org_iam_policy", func(s *IAMSetUp) command.Step { return s.Step5 })
+	RegisterStep(domain.Step6, "default_label_policy", func(s *IAMSetUp) command.Step { return s.Step6 })
+	RegisterStep(domain.Step7, "login_policy_otp", func(s *IAMSetUp) command.Step { return s.Step7 })
+	RegisterStep(domain.Step8, "login_policy_u2f", func(s *IAMSetUp) command.Step { return s.Step8 })
+	RegisterStep(domain.Step9, "login_policy_passwordless", func(s *IAMSetUp) command.Step { return s.Step9 })
+	RegisterStep(domain.Step10, "default_mail_template", func(s *IAMSetUp) command.Step { return s.Step10 })
+	RegisterStep(domain.Step11, "migrate_unique_constraints", func(s *IAMSetUp) command.Step { return s.Step11 })
+	RegisterStep(domain.Step12, "default_features", func(s *IAMSetUp) command.Step { return s.Step12 })
+	RegisterStep(domain.Step13, "update_default_mail_template", func(s *IAMSetUp) command.Step { return s.Step13 })
+	RegisterStep(domain.Step14, "activate_label_policies", func(s *IAMSetUp) command.Step { return s.Step14 })
+	RegisterStep(domain.Step15, "update_default_mail_template_2", func(s *IAMSetUp) command.Step { return s.Step15 })
+	RegisterStep(domain.Step16, "default_message_texts", func(s *IAMSetUp) command.Step { return s.Step16 })
+	RegisterStep(domain.Step17, "default_privacy_policy", func(s *IAMSetUp) command.Step { return s.Step17 })
+	RegisterStep(domain.Step18, "default_lockout_policy", func(s *IAMSetUp) command.Step { return s.Step18 })
+	RegisterStep(domain.Step19, "claim_usernames_of_domain_orgs", func(*IAMSetUp) command.Step { return new(command.Step19) })
+	RegisterStep(domain.Step20, "previous_aggregate_sequences", func(*IAMSetUp) command.Step { return new(command.Step20) })
+	RegisterStep(domain.Step21, "global_org_self_management_role", func(*IAMSetUp) command.Step { return new(command.Step21) })
+	RegisterStep(domain.Step22, "default_notification_policy", func(s *IAMSetUp) command.Step { return s.Step22 })
+}
@@ -0,0 +1,87 @@
+package setup
+
+import (
+	"testing"
+
+	"github.com/caos/zitadel/internal/command"
+	"github.com/caos/zitadel/internal/domain"
+)
+
+func TestRegisteredSteps(t *testing.T) {
+	config := &IAMSetUp{}
+	for i, step := range steps {
+		if i > 0 && steps[i-1].step >= step.step {
+			t.Errorf("steps not ordered: %d after %d", step.step, steps[i-1].step)
+		}
+		if step.name == "" {
+			t.Errorf("step %d has no name", step.step)
+		}
+		if configured := step.config(config); configured != nil && isConfigured(configured) && configured.Step() != step.step {
+			t.Errorf("step %d registered as %d", configured.Step(), step.step)
+		}
+	}
+	if LastStep() != domain.Step22 {
+		t.Errorf("expected last step %d got %d", domain.Step22, LastStep())
+	}
+}
+
+func TestRegisterStep_duplicate(t *testing.T) {
+	tests := []struct {
+		name string
+		step domain.Step
+		desc string
+	}{
+		{
+			name: "number already registered",
+			step: domain.Step1,
+			desc: "other_name",
+		},
+		{
+			name: "name already registered",
+			step: domain.Step(1000),
+			desc: "initial_orgs_and_projects",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			RegisterStep(tt.step, tt.desc, func(*IAMSetUp) command.Step { return nil })
+		})
+	}
+}
+
+func Test_isConfigured(t *testing.T) {
+	var step1 *command.Step1
+	tests := []struct {
+		name string
+		step command.Step
+		want bool
+	}{
+		{
+			name: "nil",
+			step: nil,
+			want: false,
+		},
+		{
+			name: "nil pointer",
+			step: step1,
+			want: false,
+		},
+		{
+			name: "configured",
+			step: &command.Step1{},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConfigured(tt.step); got != tt.want {
+				t.Errorf("isConfigured() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/caos/zitadel/internal/domain"
 	caos_errors "github.com/caos/zitadel/internal/errors"
 )
 
@@ -19,6 +20,13 @@ func (m *MockStorage) ExpectAddObjectNoError() *MockStorage {
 	return m
 }
 
+func (m *MockStorage) ExpectAddObjectWithKey(key string) *MockStorage {
+	m.EXPECT().
+		PutObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&domain.AssetInfo{Key: key}, nil)
+	return m
+}
+
 func (m *MockStorage) ExpectAddObjectError() *MockStorage {
 	m.EXPECT().
 		PutObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
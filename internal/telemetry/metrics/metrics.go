@@ -15,6 +15,16 @@ const (
 	SpoolerDivCounterDescription    = "Spooler div from last successful run to now in milliseconds"
 	Database                        = "database"
 	ViewName                        = "view_name"
+
+	ProjectionEventsBehind                  = "zitadel.projection_events_behind"
+	ProjectionEventsBehindDescription       = "Count of events not yet processed by the projection"
+	ProjectionMillisecondsBehind            = "zitadel.projection_milliseconds_behind"
+	ProjectionMillisecondsBehindDescription = "Age of the oldest event not yet processed by the projection in milliseconds"
+	ProjectionLastRunDiv                    = "zitadel.projection_last_run_div_milliseconds"
+	ProjectionLastRunDivDescription         = "Projection div from last successful run to now in milliseconds"
+	ProjectionLocked                        = "zitadel.projection_locked"
+	ProjectionLockedDescription             = "1 if a worker holds the lock of the projection, otherwise 0"
+	ProjectionName                          = "projection_name"
 )
 
 type Metrics interface {
@@ -24,8 +34,13 @@ type Metrics interface {
 	AddCount(ctx context.Context, name string, value int64, labels map[string]attribute.Value) error
 	RegisterUpDownSumObserver(name, description string, callbackFunc metric.Int64ObserverFunc) error
 	RegisterValueObserver(name, description string, callbackFunc metric.Int64ObserverFunc) error
+	RegisterBatchValueObserver(descriptions map[string]string, callbackFunc BatchValueObserverFunc) error
 }
 
+//BatchValueObserverFunc reports the values of multiple gauges in one callback
+// observe must be called with the name of one of the registered gauges
+type BatchValueObserverFunc func(ctx context.Context, observe func(name string, value int64, labels map[string]attribute.Value))
+
 type Config interface {
 	NewMetrics() error
 }
@@ -73,3 +88,12 @@ func RegisterValueObserver(name, description string, callbackFunc metric.Int64Ob
 	}
 	return M.RegisterValueObserver(name, description, callbackFunc)
 }
+
+//RegisterBatchValueObserver registers a gauge for each name in descriptions
+// all gauges are observed by a single call of callbackFunc
+func RegisterBatchValueObserver(descriptions map[string]string, callbackFunc BatchValueObserverFunc) error {
+	if M == nil {
+		return nil
+	}
+	return M.RegisterBatchValueObserver(descriptions, callbackFunc)
+}
@@ -93,6 +93,33 @@ func (m *Metrics) RegisterValueObserver(name, description string, callbackFunc m
 	return nil
 }
 
+func (m *Metrics) RegisterBatchValueObserver(descriptions map[string]string, callbackFunc metrics.BatchValueObserverFunc) error {
+	var mu sync.RWMutex
+	gauges := make(map[string]metric.Int64GaugeObserver, len(descriptions))
+	batchObserver := metric.Must(m.Meter).NewBatchObserver(func(ctx context.Context, result metric.BatchObserverResult) {
+		mu.RLock()
+		defer mu.RUnlock()
+		callbackFunc(ctx, func(name string, value int64, labels map[string]attribute.Value) {
+			gauge, ok := gauges[name]
+			if !ok {
+				return
+			}
+			result.Observe(MapToKeyValue(labels), gauge.Observation(value))
+		})
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	for name, description := range descriptions {
+		if _, exists := m.ValueObservers.Load(name); exists {
+			continue
+		}
+		gauge := batchObserver.NewInt64GaugeObserver(name, metric.WithDescription(description))
+		gauges[name] = gauge
+		m.ValueObservers.Store(name, gauge)
+	}
+	return nil
+}
+
 func MapToKeyValue(labels map[string]attribute.Value) []attribute.KeyValue {
 	if labels == nil {
 		return nil
@@ -12,12 +12,21 @@ const (
 	tmplChangePasswordDone = "changepassworddone"
 )
 
+type changePasswordTemplateData struct {
+	passwordData
+	Expired  bool
+	Warning  bool
+	DaysLeft uint64
+}
+
 type changePasswordData struct {
 	OldPassword             string `schema:"change-old-password"`
 	NewPassword             string `schema:"change-new-password"`
 	NewPasswordConfirmation string `schema:"change-password-confirmation"`
 }
 
+type changePasswordSkipData struct{}
+
 func (l *Login) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	data := new(changePasswordData)
 	authReq, err := l.getAuthRequestAndParseData(r, data)
@@ -28,20 +37,42 @@ func (l *Login) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	_, err = l.command.ChangePassword(setContext(r.Context(), authReq.UserOrgID), authReq.UserOrgID, authReq.UserID, data.OldPassword, data.NewPassword, userAgentID)
 	if err != nil {
-		l.renderChangePassword(w, r, authReq, err)
+		l.renderChangePassword(w, r, authReq, possibleChangePasswordStep(authReq), err)
 		return
 	}
 	l.renderChangePasswordDone(w, r, authReq)
 }
 
-func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+func (l *Login) handleChangePasswordSkip(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequestAndParseData(r, new(changePasswordSkipData))
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.SkipPasswordChangeWarning(setContext(r.Context(), authReq.UserOrgID), authReq.ID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.ChangePasswordStep, err error) {
 	var errID, errMessage string
 	if err != nil {
 		errID, errMessage = l.getErrorMessage(r, err)
 	}
-	data := passwordData{
-		baseData:    l.getBaseData(r, authReq, "Change Password", errID, errMessage),
-		profileData: l.getProfileData(authReq),
+	data := changePasswordTemplateData{
+		passwordData: passwordData{
+			baseData:    l.getBaseData(r, authReq, "Change Password", errID, errMessage),
+			profileData: l.getProfileData(authReq),
+		},
+	}
+	if step != nil {
+		data.Expired = step.Expired
+		data.Warning = step.Warning
+		data.DaysLeft = step.DaysLeft
 	}
 	policy, description, _ := l.getPasswordComplexityPolicy(r, authReq, authReq.UserOrgID)
 	if policy != nil {
@@ -60,12 +91,21 @@ func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, aut
 			data.HasNumber = NumberRegex
 		}
 	}
-	translator := l.getTranslator(authReq)
+	translator := l.getTranslator(r.Context(), authReq)
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplChangePassword], data, nil)
 }
 
 func (l *Login) renderChangePasswordDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
 	var errType, errMessage string
 	data := l.getUserData(r, authReq, "Password Change Done", errType, errMessage)
-	l.renderer.RenderTemplate(w, r, l.getTranslator(authReq), l.renderer.Templates[tmplChangePasswordDone], data, nil)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplChangePasswordDone], data, nil)
+}
+
+func possibleChangePasswordStep(authReq *domain.AuthRequest) *domain.ChangePasswordStep {
+	for _, step := range authReq.PossibleSteps {
+		if changePasswordStep, ok := step.(*domain.ChangePasswordStep); ok {
+			return changePasswordStep
+		}
+	}
+	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"net/url"
+	"sync"
 
 	"github.com/caos/logging"
 	"github.com/gorilla/csrf"
@@ -43,6 +45,7 @@ type Login struct {
 	oidcAuthCallbackURL string
 	IDPConfigAesCrypto  crypto.EncryptionAlgorithm
 	iamDomain           string
+	customTemplates     sync.Map
 }
 
 type Config struct {
@@ -98,18 +101,25 @@ func CreateLogin(config Config, command *command.Commands, query *query.Queries,
 	logging.Log("CONFI-dHR2a").OnError(err).Panic("unable to create csrfInterceptor")
 	cache, err := middleware.DefaultCacheInterceptor(EndpointResources, config.Cache.MaxAge.Duration, config.Cache.SharedMaxAge.Duration)
 	logging.Log("CONFI-BHq2a").OnError(err).Panic("unable to create cacheInterceptor")
-	security := middleware.SecurityHeaders(csp(), login.cspErrorHandler)
+	security := middleware.SecurityHeaders(csp(config.OidcAuthCallbackURL), login.cspErrorHandler)
 	userAgentCookie, err := middleware.NewUserAgentHandler(config.UserAgentCookieConfig, id.SonyFlakeGenerator, localDevMode)
 	logging.Log("CONFI-Dvwf2").OnError(err).Panic("unable to create userAgentInterceptor")
-	login.router = CreateRouter(login, statikFS, csrf, cache, security, userAgentCookie, middleware.TelemetryHandler(EndpointResources))
+	login.router = CreateRouter(login, statikFS, csrf, cache, security, userAgentCookie, middleware.TelemetryHandler(EndpointResources), translatorCache)
 	login.renderer = CreateRenderer(prefix, statikFS, staticStorage, config.LanguageCookieName, config.DefaultLanguage)
+	login.renderer.customTemplates = login.getCustomTemplates
 	login.parser = form.NewParser()
 	return login, handlerPrefix
 }
 
-func csp() *middleware.CSP {
+//csp restricts the form submissions to the login itself and the oidc callback
+// redirects to other origins after a form submission are done by the redirect page (see redirectFromForm)
+func csp(oidcAuthCallbackURL string) *middleware.CSP {
 	csp := middleware.DefaultSCP
 	csp.ObjectSrc = middleware.CSPSourceOptsSelf()
+	csp.FormAction = middleware.CSPSourceOptsSelf()
+	if callback, err := url.Parse(oidcAuthCallbackURL); err == nil && callback.Host != "" {
+		csp.FormAction = csp.FormAction.AddHost(callback.Scheme + "://" + callback.Host)
+	}
 	csp.StyleSrc = csp.StyleSrc.AddNonce()
 	csp.ScriptSrc = csp.ScriptSrc.AddNonce()
 	return &csp
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/caos/logging"
+
+	http_utils "github.com/caos/zitadel/internal/api/http"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/query"
+)
+
+//getLoginDomainOrg returns the organisation which uses the host of the request as login domain
+// nil is returned if the login is called on the default host or the host is not a login domain
+func (l *Login) getLoginDomainOrg(r *http.Request) *query.Org {
+	host := http_utils.HostFromRequest(r)
+	if host == "" || host == http_utils.HostFromURL(l.baseURL) {
+		return nil
+	}
+	org, err := l.query.OrgByLoginDomain(r.Context(), host)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logging.LogWithFields("HANDL-Lg5oD", "host", host).WithError(err).Warn("unable to get org of login domain")
+		}
+		return nil
+	}
+	return org
+}
+
+//getBaseURL returns the base url of the login on the host of the request if it's a login domain
+// otherwise the configured base url is returned
+func (l *Login) getBaseURL(r *http.Request) string {
+	return l.urlOnLoginDomain(r, l.baseURL)
+}
+
+//getOIDCAuthCallbackURL returns the callback url of the oidc authorization on the host of the request if it's a login domain
+// otherwise the configured callback url is returned
+func (l *Login) getOIDCAuthCallbackURL(r *http.Request) string {
+	return l.urlOnLoginDomain(r, l.oidcAuthCallbackURL)
+}
+
+func (l *Login) urlOnLoginDomain(r *http.Request, rawURL string) string {
+	if l.getLoginDomainOrg(r) == nil {
+		return rawURL
+	}
+	hostURL, err := http_utils.ReplaceHost(rawURL, r.Host)
+	if err != nil {
+		logging.LogWithFields("HANDL-Lg5uR", "host", r.Host).WithError(err).Warn("unable to replace host of url")
+		return rawURL
+	}
+	return hostURL
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/caos/logging"
+	"github.com/rakyll/statik/fs"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/i18n"
+	"github.com/caos/zitadel/internal/query"
+)
+
+//loginTemplatePartials are the templates which are only used inside of other templates,
+// they are previewed on the login page
+var loginTemplatePartials = map[string]bool{
+	"main.html":          true,
+	"header.html":        true,
+	"footer.html":        true,
+	"error-message.html": true,
+	"user_profile.html":  true,
+}
+
+type customTemplatesFunc func(ctx context.Context, orgID string) *template.Template
+
+type customTemplating interface {
+	templatesOrgID() string
+}
+
+func (d baseData) templatesOrgID() string {
+	return d.customTemplatesOrgID
+}
+
+//RenderTemplate renders the template of the organisation which is used for the private labeling if it uploaded one
+// and the login is restricted to its users (see customTemplatesOrgID)
+// the default template is rendered if the organisation has none or it can't be rendered
+func (r *Renderer) RenderTemplate(w http.ResponseWriter, req *http.Request, translator *i18n.Translator, tmpl *template.Template, data interface{}, reqFuncs map[string]interface{}) {
+	customTemplates := r.customTemplatesOfData(req.Context(), data)
+	if customTemplates == nil {
+		r.Renderer.RenderTemplate(w, req, translator, tmpl, data, reqFuncs)
+		return
+	}
+	r.RenderTemplateWithFallback(w, req, translator, customTemplates.Lookup(tmpl.Name()), tmpl, data, reqFuncs)
+}
+
+func (r *Renderer) customTemplatesOfData(ctx context.Context, data interface{}) *template.Template {
+	if r.customTemplates == nil {
+		return nil
+	}
+	templating, ok := data.(customTemplating)
+	if !ok {
+		return nil
+	}
+	return r.customTemplates(ctx, templating.templatesOrgID())
+}
+
+type cachedLoginTemplates struct {
+	signature string
+	templates *template.Template
+}
+
+//getCustomTemplates returns the default templates with the templates of the organisation applied
+// the templates are cached until the organisation changes one of its templates
+func (l *Login) getCustomTemplates(ctx context.Context, orgID string) *template.Template {
+	if orgID == "" || orgID == domain.IAMID {
+		return nil
+	}
+	loginTemplates, err := l.query.LoginTemplatesByOrg(ctx, orgID)
+	if err != nil {
+		logging.LogWithFields("HANDL-Tp1qL", "orgID", orgID).WithError(err).Warn("unable to get login templates of org")
+		return nil
+	}
+	if len(loginTemplates.LoginTemplates) == 0 {
+		l.customTemplates.Delete(orgID)
+		return nil
+	}
+	signature := loginTemplatesSignature(loginTemplates.LoginTemplates)
+	if cached, ok := l.customTemplates.Load(orgID); ok && cached.(*cachedLoginTemplates).signature == signature {
+		return cached.(*cachedLoginTemplates).templates
+	}
+	overrides := make(map[string]string, len(loginTemplates.LoginTemplates))
+	for _, loginTemplate := range loginTemplates.LoginTemplates {
+		content, _, err := l.getStatic(ctx, orgID, loginTemplate.AssetKey)
+		if err != nil {
+			logging.LogWithFields("HANDL-Tp1aL", "orgID", orgID, "template", loginTemplate.Template).WithError(err).Warn("unable to load login template of org")
+			return nil
+		}
+		overrides[loginTemplate.Template] = string(content)
+	}
+	templates, err := l.renderer.TemplatesWithOverrides(overrides, domain.LoginTemplateFuncs)
+	logging.LogWithFields("HANDL-Tp1pL", "orgID", orgID).OnError(err).Warn("unable to parse login templates of org, default templates are used")
+	l.customTemplates.Store(orgID, &cachedLoginTemplates{signature: signature, templates: templates})
+	return templates
+}
+
+func loginTemplatesSignature(loginTemplates []*query.LoginTemplate) string {
+	keys := make([]string, len(loginTemplates))
+	for i, loginTemplate := range loginTemplates {
+		keys[i] = loginTemplate.Template + "=" + loginTemplate.AssetKey
+	}
+	return strings.Join(keys, ";")
+}
+
+var (
+	previewRenderer     *Renderer
+	previewRendererErr  error
+	previewRendererOnce sync.Once
+)
+
+func getPreviewRenderer() (*Renderer, error) {
+	previewRendererOnce.Do(func() {
+		statikFS, err := fs.NewWithNamespace("login")
+		if err != nil {
+			previewRendererErr = caos_errs.ThrowInternal(err, "HANDL-Tp2fS", "Errors.Internal")
+			return
+		}
+		previewRenderer = CreateRenderer("", statikFS, nil, "", language.English)
+	})
+	return previewRenderer, previewRendererErr
+}
+
+//RenderLoginTemplatePreview renders the login template with the content instead of the default template
+// sample data and the label policy of the organisation are used,
+// partial templates (e.g. header.html) are previewed on the login page
+func RenderLoginTemplatePreview(loginTemplate string, content []byte, orgID string, policy *query.LabelPolicy, lang language.Tag) (string, error) {
+	if !domain.IsLoginTemplate(loginTemplate) {
+		return "", caos_errs.ThrowInvalidArgument(nil, "HANDL-Tp2iV", "Errors.Org.LoginTemplate.Invalid")
+	}
+	r, err := getPreviewRenderer()
+	if err != nil {
+		return "", err
+	}
+	templates, err := r.TemplatesWithOverrides(map[string]string{loginTemplate: string(content)}, domain.LoginTemplateFuncs)
+	if err != nil {
+		return "", err
+	}
+	page := loginTemplate
+	if loginTemplatePartials[loginTemplate] {
+		page = r.Templates[tmplLogin].Name()
+	}
+	translator, err := r.NewTranslator()
+	if err != nil {
+		return "", err
+	}
+	translator.SetPreferredLanguages(lang.String())
+	return r.ExecuteTemplate(translator, templates.Lookup(page), previewData(page, orgID, policy, lang), nil)
+}
+
+//previewData returns sample data of the type the login uses to render the template
+func previewData(loginTemplate, orgID string, policy *query.LabelPolicy, lang language.Tag) interface{} {
+	base := baseData{
+		Lang:                   lang.String(),
+		Title:                  "Preview",
+		Theme:                  "zitadel",
+		ThemeMode:              "lgn-light-theme",
+		PrivateLabelingOrgID:   orgID,
+		OrgID:                  orgID,
+		OrgName:                "ACME",
+		PrimaryDomain:          "acme.ch",
+		DisplayLoginNameSuffix: true,
+		AuthReqID:              "preview",
+		LoginPolicy: &domain.LoginPolicy{
+			AllowUsernamePassword: true,
+			AllowRegister:         true,
+		},
+	}
+	if policy != nil {
+		base.LabelPolicy = labelPolicyToDomain(policy)
+	}
+	profile := profileData{
+		LoginName:   "john.doe@acme.ch",
+		UserName:    "john.doe",
+		DisplayName: "John Doe",
+	}
+	user := userData{
+		baseData:    base,
+		profileData: profile,
+	}
+	webAuthN := webAuthNData{
+		userData: user,
+	}
+	password := passwordData{
+		baseData:                  base,
+		profileData:               profile,
+		PasswordPolicyDescription: "Preview",
+		MinLength:                 8,
+	}
+	switch loginTemplate {
+	case "error.html":
+		return base
+	case "register_option.html":
+		return registerOptionData{baseData: base}
+	case "select_user.html":
+		return userSelectionData{
+			baseData: base,
+			Users: []domain.UserSelection{
+				{
+					UserID:            "preview",
+					UserName:          profile.UserName,
+					DisplayName:       profile.DisplayName,
+					LoginName:         profile.LoginName,
+					UserSessionState:  domain.UserSessionStateActive,
+					SelectionPossible: true,
+				},
+			},
+		}
+	case "change_password.html":
+		return changePasswordTemplateData{passwordData: password}
+	case "init_password.html":
+		return initPasswordData{baseData: base, profileData: profile, MinLength: password.MinLength}
+	case "init_user.html":
+		return initUserData{baseData: base, profileData: profile, MinLength: password.MinLength}
+	case "register.html":
+		return registerData{baseData: base, MinLength: password.MinLength}
+	case "register_org.html":
+		return registerOrgData{baseData: base, MinLength: password.MinLength, IamDomain: base.PrimaryDomain}
+	case "external_register_overview.html":
+		return externalRegisterData{baseData: base}
+	case "external_not_found_option.html":
+		return externalNotFoundOptionData{baseData: base}
+	case "mail_verification.html", "mail_verified.html":
+		return mailVerificationData{baseData: base, profileData: profile}
+	case "mfa_prompt.html":
+		return mfaData{baseData: base, profileData: profile, MFAProviders: []domain.MFAType{domain.MFATypeOTP, domain.MFATypeU2F}}
+	case "mfa_init_otp.html":
+		return mfaVerifyData{baseData: base, profileData: profile, MFAType: domain.MFATypeOTP}
+	case "mfa_init_done.html":
+		return mfaDoneData{baseData: base, profileData: profile, MFAType: domain.MFATypeOTP}
+	case "mfa_init_u2f.html":
+		return u2fInitData{webAuthNData: webAuthN, MFAType: domain.MFATypeU2F}
+	case "mfa_verification_u2f.html":
+		return mfaU2FData{webAuthNData: webAuthN, MFAProviders: []domain.MFAType{domain.MFATypeU2F}, SelectedProvider: domain.MFATypeU2F}
+	case "passwordless.html":
+		return passwordlessData{webAuthNData: webAuthN, PasswordLogin: true}
+	case "passwordless_prompt.html":
+		return passwordlessPromptData{userData: user}
+	case "passwordless_registration.html":
+		return passwordlessRegistrationData{webAuthNData: webAuthN, OrgID: orgID}
+	case "passwordless_registration_done.html":
+		return passwordlessRegistrationDoneDate{userData: user}
+	case "login_success.html":
+		return loginSuccessData{userData: user}
+	default:
+		return user
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/caos/zitadel/internal/domain"
+)
+
+const (
+	tmplRedirect = "redirect"
+)
+
+type redirectData struct {
+	userData
+	RedirectURI string
+}
+
+//redirectFromForm redirects to the url
+// if the request is a form submission, the redirect page is rendered instead,
+// because the form-action of the csp also applies to the redirects of a form submission
+// the redirect page can't be overridden by the templates of an organisation
+func (l *Login) redirectFromForm(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, url string) {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, url, http.StatusFound)
+		return
+	}
+	data := redirectData{
+		userData:    l.getUserData(r, authReq, "Redirect", "", ""),
+		RedirectURI: url,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplRedirect], data, nil)
+}
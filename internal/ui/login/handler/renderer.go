@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
@@ -27,8 +28,9 @@ const (
 
 type Renderer struct {
 	*renderer.Renderer
-	pathPrefix    string
-	staticStorage static.Storage
+	pathPrefix      string
+	staticStorage   static.Storage
+	customTemplates customTemplatesFunc
 }
 type LanguageData struct {
 	Lang string
@@ -73,6 +75,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
+		tmplRedirect:                     "redirect.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
@@ -176,6 +179,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"changePasswordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangePassword)
 		},
+		"changePasswordSkipUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointChangePasswordSkip)
+		},
 		"registerOptionUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointRegisterOption)
 		},
@@ -286,7 +292,7 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 	case *domain.LoginSucceededStep:
 		l.redirectToLoginSuccess(w, r, authReq.ID)
 	case *domain.ChangePasswordStep:
-		l.renderChangePassword(w, r, authReq, err)
+		l.renderChangePassword(w, r, authReq, step, err)
 	case *domain.VerifyEMailStep:
 		l.renderMailVerification(w, r, authReq, "", err)
 	case *domain.MFAPromptStep:
@@ -316,7 +322,7 @@ func (l *Login) renderInternalError(w http.ResponseWriter, r *http.Request, auth
 		_, msg = l.getErrorMessage(r, err)
 	}
 	data := l.getBaseData(r, authReq, "Error", "Internal", msg)
-	l.renderer.RenderTemplate(w, r, l.getTranslator(authReq), l.renderer.Templates[tmplError], data, nil)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplError], data, nil)
 }
 
 func (l *Login) getUserData(r *http.Request, authReq *domain.AuthRequest, title string, errType, errMessage string) userData {
@@ -336,7 +342,7 @@ func (l *Login) getBaseData(r *http.Request, authReq *domain.AuthRequest, title
 			ErrID:      errType,
 			ErrMessage: errMessage,
 		},
-		Lang:                   l.renderer.ReqLang(l.getTranslator(authReq), r).String(),
+		Lang:                   l.renderer.ReqLang(l.getTranslator(r.Context(), authReq), r).String(),
 		Title:                  title,
 		Theme:                  l.getTheme(r),
 		ThemeMode:              l.getThemeMode(r),
@@ -350,6 +356,7 @@ func (l *Login) getBaseData(r *http.Request, authReq *domain.AuthRequest, title
 		CSRF:                   csrf.TemplateField(r),
 		Nonce:                  http_mw.GetNonce(r),
 	}
+	baseData.customTemplatesOrgID = customTemplatesOrgID(authReq, baseData.PrivateLabelingOrgID)
 	var privacyPolicy *domain.PrivacyPolicy
 	if authReq != nil {
 		baseData.LoginPolicy = authReq.LoginPolicy
@@ -360,6 +367,7 @@ func (l *Login) getBaseData(r *http.Request, authReq *domain.AuthRequest, title
 		}
 		privacyPolicy = authReq.PrivacyPolicy
 	} else {
+		baseData = l.setLoginDomainOrgOnBaseData(r, baseData)
 		policy, err := l.query.DefaultPrivacyPolicy(r.Context())
 		if err != nil {
 			return baseData
@@ -370,8 +378,59 @@ func (l *Login) getBaseData(r *http.Request, authReq *domain.AuthRequest, title
 	return baseData
 }
 
-func (l *Login) getTranslator(authReq *domain.AuthRequest) *i18n.Translator {
+//setLoginDomainOrgOnBaseData sets the organisation and its branding
+// if the login is called on a login domain of an organisation without an auth request
+func (l *Login) setLoginDomainOrgOnBaseData(r *http.Request, baseData baseData) baseData {
+	org := l.getLoginDomainOrg(r)
+	if org == nil {
+		return baseData
+	}
+	baseData.OrgID = org.ID
+	baseData.OrgName = org.Name
+	baseData.PrimaryDomain = org.Domain
+	baseData.PrivateLabelingOrgID = org.ID
+	baseData.customTemplatesOrgID = org.ID
+	policy, err := l.query.ActiveLabelPolicyByOrg(r.Context(), org.ID)
+	if err != nil {
+		logging.LogWithFields("HANDL-Lg5pL", "orgID", org.ID).WithError(err).Warn("unable to get label policy of login domain")
+		return baseData
+	}
+	baseData.LabelPolicy = labelPolicyToDomain(policy)
+	return baseData
+}
+
+//requestTranslatorsKey is the context key of the translators built during a request
+type requestTranslatorsKey struct{}
+
+//requestTranslators are the translators of a request by the auth request they were built for
+type requestTranslators map[*domain.AuthRequest]*i18n.Translator
+
+//translatorCache keeps the translators of a request in its context,
+// so the translations, bundles and texts are only loaded once per request (e.g. for the base data and the page)
+func translatorCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), requestTranslatorsKey{}, make(requestTranslators))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+//getTranslator returns the translator of the request for the auth request
+// it's built on the first call of the request
+func (l *Login) getTranslator(ctx context.Context, authReq *domain.AuthRequest) *i18n.Translator {
+	translators, _ := ctx.Value(requestTranslatorsKey{}).(requestTranslators)
+	if translator, ok := translators[authReq]; ok {
+		return translator
+	}
+	translator := l.newTranslator(ctx, authReq)
+	if translators != nil {
+		translators[authReq] = translator
+	}
+	return translator
+}
+
+func (l *Login) newTranslator(ctx context.Context, authReq *domain.AuthRequest) *i18n.Translator {
 	translator, _ := l.renderer.NewTranslator()
+	l.addTranslationBundles(ctx, translator)
 	if authReq != nil {
 		l.addLoginTranslations(translator, authReq.DefaultTranslations)
 		l.addLoginTranslations(translator, authReq.OrgTranslations)
@@ -419,7 +478,7 @@ func (l *Login) setLinksOnBaseData(baseData baseData, privacyPolicy *domain.Priv
 func (l *Login) getErrorMessage(r *http.Request, err error) (errID, errMsg string) {
 	caosErr := new(caos_errs.CaosError)
 	if errors.As(err, &caosErr) {
-		localized := l.renderer.LocalizeFromRequest(l.getTranslator(nil), r, caosErr.Message, nil)
+		localized := l.renderer.LocalizeFromRequest(l.getTranslator(r.Context(), nil), r, caosErr.Message, nil)
 		return caosErr.ID, localized
 
 	}
@@ -474,6 +533,19 @@ func (l *Login) getPrivateLabelingID(authReq *domain.AuthRequest) string {
 	return privateLabelingOrgID
 }
 
+//customTemplatesOrgID returns the organisation of the private labeling if its templates can be used
+// the templates of an organisation are only used for its own users, so the login has to be restricted to the organisation
+// or the user of the auth request has to be one of its users
+func customTemplatesOrgID(authReq *domain.AuthRequest, privateLabelingOrgID string) string {
+	if authReq == nil {
+		return ""
+	}
+	if authReq.RequestedOrgID == privateLabelingOrgID || authReq.UserOrgID == privateLabelingOrgID {
+		return privateLabelingOrgID
+	}
+	return ""
+}
+
 func (l *Login) getOrgName(authReq *domain.AuthRequest) string {
 	if authReq == nil {
 		return ""
@@ -498,13 +570,35 @@ func (l *Login) isDisplayLoginNameSuffix(authReq *domain.AuthRequest) bool {
 	return authReq.LabelPolicy != nil && !authReq.LabelPolicy.HideLoginNameSuffix
 }
 
+//addTranslationBundles adds the uploaded translation bundles of the login
+// the custom texts are added afterwards and overwrite the texts of the bundles
+func (l *Login) addTranslationBundles(ctx context.Context, translator *i18n.Translator) {
+	if translator == nil {
+		return
+	}
+	bundles, err := l.query.TranslationBundlesByComponent(ctx, domain.TranslationComponentLogin)
+	if err != nil {
+		logging.Log("HANDLE-Tb2gL").WithError(err).Warn("unable to get translation bundles")
+		return
+	}
+	for _, bundle := range bundles.TranslationBundles {
+		err := translator.AddBundle(bundle.Language, bundle.Messages)
+		logging.Log("HANDLE-Tb3gL").OnError(err).Warn("could not add translation bundle to translator")
+	}
+}
+
+//addLoginTranslations adds the texts grouped by their language,
+// as every added language resets the fallback chains of the translator
 func (l *Login) addLoginTranslations(translator *i18n.Translator, customTexts []*domain.CustomText) {
+	messages := make(map[language.Tag][]i18n.Message)
 	for _, text := range customTexts {
-		msg := i18n.Message{
+		messages[text.Language] = append(messages[text.Language], i18n.Message{
 			ID:   text.Key,
 			Text: text.Text,
-		}
-		err := l.renderer.AddMessages(translator, text.Language, msg)
+		})
+	}
+	for lang, langMessages := range messages {
+		err := l.renderer.AddMessages(translator, lang, langMessages...)
 		logging.Log("HANDLE-GD3g2").OnError(err).Warn("could no add message to translator")
 	}
 }
@@ -551,6 +645,8 @@ type baseData struct {
 	IDPProviders           []*domain.IDPProvider
 	LabelPolicy            *domain.LabelPolicy
 	LoginTexts             []*domain.CustomLoginText
+
+	customTemplatesOrgID string
 }
 
 type errorData struct {
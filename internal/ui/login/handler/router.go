@@ -24,6 +24,7 @@ const (
 	EndpointPassword                 = "/password"
 	EndpointInitPassword             = "/password/init"
 	EndpointChangePassword           = "/password/change"
+	EndpointChangePasswordSkip       = "/password/change/skip"
 	EndpointPasswordReset            = "/password/reset"
 	EndpointInitUser                 = "/user/init"
 	EndpointMFAVerify                = "/mfa/verify"
@@ -80,6 +81,7 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerification).Methods(http.MethodGet)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerificationCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointChangePassword, login.handleChangePassword).Methods(http.MethodPost)
+	router.HandleFunc(EndpointChangePasswordSkip, login.handleChangePasswordSkip).Methods(http.MethodPost)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOption).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOptionCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointExternalNotFoundOption, login.handleExternalNotFoundOptionCheck).Methods(http.MethodPost)
@@ -12,6 +12,12 @@ function autoSubmit() {
                 event.preventDefault();
             });
         }
-        form.submit();
+        // navigate instead of submitting the form,
+        // because the form-action of the csp would block the redirect to the application
+        let url = new URL(form.action);
+        new FormData(form).forEach(function (value, key) {
+            url.searchParams.set(key, value);
+        });
+        window.location.assign(url.toString());
     }
 }
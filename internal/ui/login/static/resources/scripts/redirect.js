@@ -0,0 +1,6 @@
+document.addEventListener('DOMContentLoaded', function () {
+    let link = document.getElementById("redirect-link");
+    if (link) {
+        window.location.replace(link.href);
+    }
+});
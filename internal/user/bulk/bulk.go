@@ -0,0 +1,226 @@
+package bulk
+
+import (
+	"io"
+
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+//Format of the file containing the users of a bulk import or export
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+//User is a single entry of an import or export file
+// ID is only set on export and ignored on import
+type User struct {
+	ID                     string            `json:"id,omitempty"`
+	UserName               string            `json:"userName"`
+	FirstName              string            `json:"firstName"`
+	LastName               string            `json:"lastName"`
+	NickName               string            `json:"nickName,omitempty"`
+	DisplayName            string            `json:"displayName,omitempty"`
+	PreferredLanguage      string            `json:"preferredLanguage,omitempty"`
+	Gender                 string            `json:"gender,omitempty"`
+	Email                  string            `json:"email"`
+	IsEmailVerified        bool              `json:"isEmailVerified,omitempty"`
+	Phone                  string            `json:"phone,omitempty"`
+	IsPhoneVerified        bool              `json:"isPhoneVerified,omitempty"`
+	Password               string            `json:"password,omitempty"`
+	HashedPassword         string            `json:"hashedPassword,omitempty"`
+	PasswordChangeRequired bool              `json:"passwordChangeRequired,omitempty"`
+	IDPLinks               []*IDPLink        `json:"idpLinks,omitempty"`
+	Metadata               map[string]string `json:"metadata,omitempty"`
+	Grants                 []*Grant          `json:"grants,omitempty"`
+}
+
+type IDPLink struct {
+	IDPID    string `json:"idpId"`
+	UserID   string `json:"userId"`
+	UserName string `json:"userName,omitempty"`
+}
+
+type Grant struct {
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"projectGrantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys,omitempty"`
+}
+
+var genders = map[domain.Gender]string{
+	domain.GenderFemale:  "female",
+	domain.GenderMale:    "male",
+	domain.GenderDiverse: "diverse",
+}
+
+//Read parses the users of an import file
+func Read(format Format, r io.Reader) ([]*domain.ImportUser, error) {
+	var users []*User
+	var err error
+	switch format {
+	case FormatJSON:
+		users, err = readJSON(r)
+	case FormatCSV:
+		users, err = readCSV(r)
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "BULK-Fo2mT", "Errors.User.Import.FormatUnsupported")
+	}
+	if err != nil {
+		return nil, err
+	}
+	imports := make([]*domain.ImportUser, len(users))
+	for i, user := range users {
+		imports[i], err = user.toDomain()
+		if err != nil {
+			return nil, caos_errs.ThrowInvalidArgumentf(err, "BULK-Us2rI", "Errors.User.Import.Invalid: user %d", i)
+		}
+	}
+	return imports, nil
+}
+
+//Write writes the users as export file
+// the file can be used as import file, passwords are never exported
+func Write(format Format, w io.Writer, users []*domain.ImportUser) error {
+	exports := make([]*User, len(users))
+	for i, user := range users {
+		exports[i] = userFromDomain(user)
+	}
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, exports)
+	case FormatCSV:
+		return writeCSV(w, exports)
+	}
+	return caos_errs.ThrowInvalidArgument(nil, "BULK-Fo3mT", "Errors.User.Import.FormatUnsupported")
+}
+
+func (u *User) toDomain() (*domain.ImportUser, error) {
+	human := &domain.Human{
+		Username: u.UserName,
+		Profile: &domain.Profile{
+			FirstName:   u.FirstName,
+			LastName:    u.LastName,
+			NickName:    u.NickName,
+			DisplayName: u.DisplayName,
+		},
+		Email: &domain.Email{
+			EmailAddress:    u.Email,
+			IsEmailVerified: u.IsEmailVerified,
+		},
+	}
+	if u.PreferredLanguage != "" {
+		lang, err := language.Parse(u.PreferredLanguage)
+		if err != nil {
+			return nil, caos_errs.ThrowInvalidArgument(err, "BULK-La2gI", "Errors.User.Import.Invalid")
+		}
+		human.PreferredLanguage = lang
+	}
+	if u.Gender != "" {
+		gender, ok := genderFromString(u.Gender)
+		if !ok {
+			return nil, caos_errs.ThrowInvalidArgumentf(nil, "BULK-Ge2dI", "Errors.User.Import.Invalid: gender %s", u.Gender)
+		}
+		human.Gender = gender
+	}
+	if u.Phone != "" {
+		human.Phone = &domain.Phone{
+			PhoneNumber:     u.Phone,
+			IsPhoneVerified: u.IsPhoneVerified,
+		}
+	}
+	if u.Password != "" || u.HashedPassword != "" {
+		human.Password = &domain.Password{
+			SecretString:   u.Password,
+			EncodedHash:    u.HashedPassword,
+			ChangeRequired: u.PasswordChangeRequired,
+		}
+	}
+	user := &domain.ImportUser{
+		Human:    human,
+		IDPLinks: make([]*domain.UserIDPLink, len(u.IDPLinks)),
+		Metadata: make([]*domain.Metadata, 0, len(u.Metadata)),
+		Grants:   make([]*domain.UserGrant, len(u.Grants)),
+	}
+	for i, link := range u.IDPLinks {
+		user.IDPLinks[i] = &domain.UserIDPLink{
+			IDPConfigID:    link.IDPID,
+			ExternalUserID: link.UserID,
+			DisplayName:    link.UserName,
+		}
+	}
+	for _, key := range sortedKeys(u.Metadata) {
+		user.Metadata = append(user.Metadata, &domain.Metadata{
+			Key:   key,
+			Value: []byte(u.Metadata[key]),
+		})
+	}
+	for i, grant := range u.Grants {
+		user.Grants[i] = &domain.UserGrant{
+			ProjectID:      grant.ProjectID,
+			ProjectGrantID: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		}
+	}
+	return user, nil
+}
+
+func userFromDomain(user *domain.ImportUser) *User {
+	human := user.Human
+	u := &User{
+		ID:       human.AggregateID,
+		UserName: human.Username,
+	}
+	if human.Profile != nil {
+		u.FirstName = human.FirstName
+		u.LastName = human.LastName
+		u.NickName = human.NickName
+		u.DisplayName = human.DisplayName
+		u.Gender = genders[human.Gender]
+		if !human.PreferredLanguage.IsRoot() {
+			u.PreferredLanguage = human.PreferredLanguage.String()
+		}
+	}
+	if human.Email != nil {
+		u.Email = human.EmailAddress
+		u.IsEmailVerified = human.IsEmailVerified
+	}
+	if human.Phone != nil {
+		u.Phone = human.PhoneNumber
+		u.IsPhoneVerified = human.IsPhoneVerified
+	}
+	for _, link := range user.IDPLinks {
+		u.IDPLinks = append(u.IDPLinks, &IDPLink{
+			IDPID:    link.IDPConfigID,
+			UserID:   link.ExternalUserID,
+			UserName: link.DisplayName,
+		})
+	}
+	if len(user.Metadata) > 0 {
+		u.Metadata = make(map[string]string, len(user.Metadata))
+	}
+	for _, metadata := range user.Metadata {
+		u.Metadata[metadata.Key] = string(metadata.Value)
+	}
+	for _, grant := range user.Grants {
+		u.Grants = append(u.Grants, &Grant{
+			ProjectID:      grant.ProjectID,
+			ProjectGrantID: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		})
+	}
+	return u
+}
+
+func genderFromString(value string) (domain.Gender, bool) {
+	for gender, name := range genders {
+		if name == value {
+			return gender, true
+		}
+	}
+	return domain.GenderUnspecified, false
+}
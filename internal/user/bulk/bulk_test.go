@@ -0,0 +1,240 @@
+package bulk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/caos/zitadel/internal/domain"
+	caos_errs "github.com/caos/zitadel/internal/errors"
+	es_models "github.com/caos/zitadel/internal/eventstore/v1/models"
+)
+
+func TestRead(t *testing.T) {
+	type args struct {
+		format Format
+		data   string
+	}
+	type res struct {
+		want []*domain.ImportUser
+		err  func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "unsupported format, invalid argument error",
+			args: args{
+				format: "xml",
+				data:   "<users/>",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "json, ok",
+			args: args{
+				format: FormatJSON,
+				data: `[{
+					"userName": "username",
+					"firstName": "firstname",
+					"lastName": "lastname",
+					"preferredLanguage": "de",
+					"gender": "female",
+					"email": "email@test.ch",
+					"isEmailVerified": true,
+					"phone": "+41711234567",
+					"hashedPassword": "{SSHA}hash",
+					"idpLinks": [{"idpId": "idp1", "userId": "external1", "userName": "external"}],
+					"metadata": {"key": "value"},
+					"grants": [{"projectId": "project1", "roleKeys": ["role1"]}]
+				}]`,
+			},
+			res: res{
+				want: []*domain.ImportUser{
+					{
+						Human: &domain.Human{
+							Username: "username",
+							Profile: &domain.Profile{
+								FirstName:         "firstname",
+								LastName:          "lastname",
+								PreferredLanguage: language.German,
+								Gender:            domain.GenderFemale,
+							},
+							Email: &domain.Email{
+								EmailAddress:    "email@test.ch",
+								IsEmailVerified: true,
+							},
+							Phone: &domain.Phone{
+								PhoneNumber: "+41711234567",
+							},
+							Password: &domain.Password{
+								EncodedHash: "{SSHA}hash",
+							},
+						},
+						IDPLinks: []*domain.UserIDPLink{
+							{IDPConfigID: "idp1", ExternalUserID: "external1", DisplayName: "external"},
+						},
+						Metadata: []*domain.Metadata{
+							{Key: "key", Value: []byte("value")},
+						},
+						Grants: []*domain.UserGrant{
+							{ProjectID: "project1", RoleKeys: []string{"role1"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "json unknown field, invalid argument error",
+			args: args{
+				format: FormatJSON,
+				data:   `[{"userName": "username", "unknown": "value"}]`,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "json invalid gender, invalid argument error",
+			args: args{
+				format: FormatJSON,
+				data:   `[{"userName": "username", "gender": "unknown"}]`,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "csv, ok",
+			args: args{
+				format: FormatCSV,
+				data: "user_name,first_name,last_name,email,is_email_verified,password,password_change_required,grants,metadata.key\n" +
+					`username,firstname,lastname,email@test.ch,true,Password1!,true,"[{""projectId"":""project1""}]",value` + "\n",
+			},
+			res: res{
+				want: []*domain.ImportUser{
+					{
+						Human: &domain.Human{
+							Username: "username",
+							Profile: &domain.Profile{
+								FirstName: "firstname",
+								LastName:  "lastname",
+							},
+							Email: &domain.Email{
+								EmailAddress:    "email@test.ch",
+								IsEmailVerified: true,
+							},
+							Password: &domain.Password{
+								SecretString:   "Password1!",
+								ChangeRequired: true,
+							},
+						},
+						IDPLinks: []*domain.UserIDPLink{},
+						Metadata: []*domain.Metadata{
+							{Key: "key", Value: []byte("value")},
+						},
+						Grants: []*domain.UserGrant{
+							{ProjectID: "project1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "csv unknown column, invalid argument error",
+			args: args{
+				format: FormatCSV,
+				data:   "user_name,unknown\nusername,value\n",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "csv invalid bool, invalid argument error",
+			args: args{
+				format: FormatCSV,
+				data:   "user_name,is_email_verified\nusername,maybe\n",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(tt.args.format, strings.NewReader(tt.args.data))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	users := []*domain.ImportUser{
+		{
+			Human: &domain.Human{
+				ObjectRoot: es_models.ObjectRoot{AggregateID: "user1"},
+				Username:   "username",
+				Profile: &domain.Profile{
+					FirstName:         "firstname",
+					LastName:          "lastname",
+					DisplayName:       "firstname lastname",
+					PreferredLanguage: language.English,
+					Gender:            domain.GenderDiverse,
+				},
+				Email: &domain.Email{
+					EmailAddress:    "email@test.ch",
+					IsEmailVerified: true,
+				},
+			},
+			IDPLinks: []*domain.UserIDPLink{
+				{IDPConfigID: "idp1", ExternalUserID: "external1"},
+			},
+			Metadata: []*domain.Metadata{
+				{Key: "key", Value: []byte("value")},
+			},
+			Grants: []*domain.UserGrant{
+				{ProjectID: "project1", RoleKeys: []string{"role1", "role2"}},
+			},
+		},
+	}
+	for _, format := range []Format{FormatJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := Write(format, buf, users); err != nil {
+				t.Fatalf("write failed: %v", err)
+			}
+			got, err := Read(format, buf)
+			if err != nil {
+				t.Fatalf("read failed: %v", err)
+			}
+			if !assert.Len(t, got, 1) {
+				return
+			}
+			assert.Empty(t, got[0].Human.AggregateID)
+			assert.Equal(t, users[0].Human.Username, got[0].Human.Username)
+			assert.Equal(t, users[0].Human.Profile, got[0].Human.Profile)
+			assert.Equal(t, users[0].Human.Email, got[0].Human.Email)
+			assert.Nil(t, got[0].Human.Phone)
+			assert.Nil(t, got[0].Human.Password)
+			assert.Equal(t, users[0].IDPLinks, got[0].IDPLinks)
+			assert.Equal(t, users[0].Metadata, got[0].Metadata)
+			assert.Equal(t, users[0].Grants, got[0].Grants)
+		})
+	}
+}
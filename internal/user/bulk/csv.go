@@ -0,0 +1,212 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+//csv files start with a header row naming the columns
+// idp_links and grants contain json arrays in the format of the json file
+// metadata is stored in one column per key prefixed by metadataColumnPrefix
+const metadataColumnPrefix = "metadata."
+
+type csvColumn struct {
+	name  string
+	read  func(u *User, value string) error
+	write func(u *User) (string, error)
+}
+
+var csvColumns = []*csvColumn{
+	stringColumn("id", func(u *User) *string { return &u.ID }),
+	stringColumn("user_name", func(u *User) *string { return &u.UserName }),
+	stringColumn("first_name", func(u *User) *string { return &u.FirstName }),
+	stringColumn("last_name", func(u *User) *string { return &u.LastName }),
+	stringColumn("nick_name", func(u *User) *string { return &u.NickName }),
+	stringColumn("display_name", func(u *User) *string { return &u.DisplayName }),
+	stringColumn("preferred_language", func(u *User) *string { return &u.PreferredLanguage }),
+	stringColumn("gender", func(u *User) *string { return &u.Gender }),
+	stringColumn("email", func(u *User) *string { return &u.Email }),
+	boolColumn("is_email_verified", func(u *User) *bool { return &u.IsEmailVerified }),
+	stringColumn("phone", func(u *User) *string { return &u.Phone }),
+	boolColumn("is_phone_verified", func(u *User) *bool { return &u.IsPhoneVerified }),
+	stringColumn("password", func(u *User) *string { return &u.Password }),
+	stringColumn("hashed_password", func(u *User) *string { return &u.HashedPassword }),
+	boolColumn("password_change_required", func(u *User) *bool { return &u.PasswordChangeRequired }),
+	jsonColumn("idp_links", func(u *User) interface{} { return &u.IDPLinks }, func(u *User) bool { return len(u.IDPLinks) > 0 }),
+	jsonColumn("grants", func(u *User) interface{} { return &u.Grants }, func(u *User) bool { return len(u.Grants) > 0 }),
+}
+
+func readCSV(r io.Reader) ([]*User, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BULK-Cs2hR", "Errors.User.Import.Invalid")
+	}
+	columns := make([]*csvColumn, len(header))
+	for i, name := range header {
+		columns[i] = csvColumnByName(strings.TrimSpace(name))
+		if columns[i] == nil {
+			return nil, caos_errs.ThrowInvalidArgumentf(nil, "BULK-Cs2cN", "Errors.User.Import.Invalid: unknown column %s", name)
+		}
+	}
+	users := make([]*User, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return users, nil
+		}
+		if err != nil {
+			return nil, caos_errs.ThrowInvalidArgument(err, "BULK-Cs2rR", "Errors.User.Import.Invalid")
+		}
+		user := new(User)
+		for i, value := range record {
+			if err := columns[i].read(user, value); err != nil {
+				return nil, caos_errs.ThrowInvalidArgumentf(err, "BULK-Cs2vR", "Errors.User.Import.Invalid: line %d column %s", line, columns[i].name)
+			}
+		}
+		users = append(users, user)
+	}
+}
+
+func writeCSV(w io.Writer, users []*User) error {
+	columns := append(append([]*csvColumn{}, csvColumns...), metadataColumns(users)...)
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	if err := writer.Write(header); err != nil {
+		return caos_errs.ThrowInternal(err, "BULK-Cs2hW", "Errors.Internal")
+	}
+	for _, user := range users {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			value, err := column.write(user)
+			if err != nil {
+				return err
+			}
+			record[i] = value
+		}
+		if err := writer.Write(record); err != nil {
+			return caos_errs.ThrowInternal(err, "BULK-Cs2rW", "Errors.Internal")
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return caos_errs.ThrowInternal(err, "BULK-Cs2fW", "Errors.Internal")
+	}
+	return nil
+}
+
+func csvColumnByName(name string) *csvColumn {
+	if strings.HasPrefix(name, metadataColumnPrefix) && len(name) > len(metadataColumnPrefix) {
+		return metadataColumn(strings.TrimPrefix(name, metadataColumnPrefix))
+	}
+	for _, column := range csvColumns {
+		if column.name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+//metadataColumns returns a column for each metadata key of the users
+func metadataColumns(users []*User) []*csvColumn {
+	keys := make(map[string]string)
+	for _, user := range users {
+		for key := range user.Metadata {
+			keys[key] = ""
+		}
+	}
+	columns := make([]*csvColumn, 0, len(keys))
+	for _, key := range sortedKeys(keys) {
+		columns = append(columns, metadataColumn(key))
+	}
+	return columns
+}
+
+func stringColumn(name string, field func(*User) *string) *csvColumn {
+	return &csvColumn{
+		name: name,
+		read: func(u *User, value string) error {
+			*field(u) = value
+			return nil
+		},
+		write: func(u *User) (string, error) {
+			return *field(u), nil
+		},
+	}
+}
+
+func boolColumn(name string, field func(*User) *bool) *csvColumn {
+	return &csvColumn{
+		name: name,
+		read: func(u *User, value string) (err error) {
+			if value == "" {
+				return nil
+			}
+			*field(u), err = strconv.ParseBool(value)
+			return err
+		},
+		write: func(u *User) (string, error) {
+			return strconv.FormatBool(*field(u)), nil
+		},
+	}
+}
+
+func jsonColumn(name string, field func(*User) interface{}, isSet func(*User) bool) *csvColumn {
+	return &csvColumn{
+		name: name,
+		read: func(u *User, value string) error {
+			if value == "" {
+				return nil
+			}
+			return json.Unmarshal([]byte(value), field(u))
+		},
+		write: func(u *User) (string, error) {
+			if !isSet(u) {
+				return "", nil
+			}
+			value, err := json.Marshal(field(u))
+			if err != nil {
+				return "", caos_errs.ThrowInternal(err, "BULK-Cs2jW", "Errors.Internal")
+			}
+			return string(value), nil
+		},
+	}
+}
+
+func metadataColumn(key string) *csvColumn {
+	return &csvColumn{
+		name: metadataColumnPrefix + key,
+		read: func(u *User, value string) error {
+			if value == "" {
+				return nil
+			}
+			if u.Metadata == nil {
+				u.Metadata = make(map[string]string)
+			}
+			u.Metadata[key] = value
+			return nil
+		},
+		write: func(u *User) (string, error) {
+			return u.Metadata[key], nil
+		},
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,28 @@
+package bulk
+
+import (
+	"encoding/json"
+	"io"
+
+	caos_errs "github.com/caos/zitadel/internal/errors"
+)
+
+//readJSON parses a json array of users
+func readJSON(r io.Reader) ([]*User, error) {
+	users := make([]*User, 0)
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&users); err != nil {
+		return nil, caos_errs.ThrowInvalidArgument(err, "BULK-Js2nR", "Errors.User.Import.Invalid")
+	}
+	return users, nil
+}
+
+func writeJSON(w io.Writer, users []*User) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(users); err != nil {
+		return caos_errs.ThrowInternal(err, "BULK-Js2nW", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+ALTER TABLE zitadel.projections.failed_events ADD COLUMN last_failed TIMESTAMPTZ;
+ALTER TABLE zitadel.projections.failed_events ADD COLUMN skipped BOOLEAN DEFAULT false NOT NULL;
+ALTER TABLE zitadel.projections.failed_events ADD COLUMN skip_reason TEXT;
+ALTER TABLE zitadel.projections.failed_events ADD COLUMN retry_requested BOOLEAN DEFAULT false NOT NULL;
+
+CREATE TABLE zitadel.projections.failed_event_errors (
+    projection_name TEXT
+    , failed_sequence BIGINT
+    , failure_count SMALLINT
+    , error TEXT
+    , creation_date TIMESTAMPTZ NOT NULL
+
+    , PRIMARY KEY (projection_name, failed_sequence, creation_date)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE eventstore.snapshots (
+    name TEXT
+    , aggregate_id TEXT
+    , resource_owner TEXT
+    , version INT8 NOT NULL
+    , event_sequence INT8 NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , data JSONB
+
+    , PRIMARY KEY (name, aggregate_id, resource_owner)
+);
+
+GRANT UPDATE, DELETE ON TABLE eventstore.snapshots TO eventstore;
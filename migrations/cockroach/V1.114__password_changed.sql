@@ -0,0 +1,11 @@
+ALTER TABLE zitadel.projections.users_humans ADD COLUMN password_changed TIMESTAMPTZ;
+
+UPDATE zitadel.projections.users_humans h SET password_changed = (
+    SELECT max(e.creation_date) FROM eventstore.events e
+    WHERE e.aggregate_type = 'user'
+        AND e.aggregate_id = h.user_id
+        AND (
+            e.event_type IN ('user.human.password.changed', 'user.password.changed')
+            OR (e.event_type IN ('user.human.added', 'user.human.selfregistered', 'user.added', 'user.selfregistered') AND e.event_data ? 'secret')
+        )
+);
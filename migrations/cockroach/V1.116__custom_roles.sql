@@ -0,0 +1,12 @@
+CREATE TABLE zitadel.projections.custom_roles (
+    role STRING NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner STRING NOT NULL
+
+    , scope SMALLINT NOT NULL
+    , permissions STRING[]
+
+    , PRIMARY KEY (role)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE zitadel.projections.mail_message_templates (
+    aggregate_id STRING NOT NULL
+    , message_type STRING NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , is_default BOOLEAN NOT NULL
+
+    , template BYTES
+
+    , PRIMARY KEY (aggregate_id, message_type)
+);
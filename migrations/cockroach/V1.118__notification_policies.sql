@@ -0,0 +1,18 @@
+CREATE TABLE zitadel.projections.notification_policies (
+    id STRING NOT NULL
+    , creation_date TIMESTAMPTZ NULL
+    , change_date TIMESTAMPTZ NULL
+    , sequence INT8 NULL
+    , state INT2 NULL
+    , resource_owner STRING
+    , is_default BOOLEAN
+
+    , password_changed BOOLEAN
+    , mfa_added BOOLEAN
+    , mfa_removed BOOLEAN
+    , new_user_agent BOOLEAN
+    , user_locked BOOLEAN
+    , email_changed BOOLEAN
+
+    , PRIMARY KEY (id)
+);
@@ -0,0 +1,21 @@
+CREATE TABLE zitadel.projections.notifications (
+    id STRING NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner STRING NOT NULL
+
+    , user_id STRING NOT NULL
+    , message_type STRING
+    , channel INT2
+    , recipient STRING
+    , message JSONB
+    , state INT2
+    , attempts INT8
+    , last_error STRING
+    , next_attempt TIMESTAMPTZ
+
+    , PRIMARY KEY (id)
+    , INDEX idx_user (resource_owner, user_id)
+    , INDEX idx_due (state, next_attempt)
+);
@@ -0,0 +1,6 @@
+INSERT INTO zitadel.projections.current_sequences (projection_name, aggregate_type, current_sequence, timestamp)
+    SELECT 'zitadel.projections.user_notifier', 'user', MAX(current_sequence), MAX(event_timestamp)
+    FROM notification.current_sequences
+    WHERE view_name = 'notification.notifications'
+    HAVING COUNT(*) > 0
+ON CONFLICT (projection_name, aggregate_type) DO NOTHING;
@@ -0,0 +1,11 @@
+CREATE TABLE zitadel.projections.login_templates (
+    aggregate_id STRING NOT NULL
+    , template STRING NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , asset_key STRING NOT NULL
+
+    , PRIMARY KEY (aggregate_id, template)
+);
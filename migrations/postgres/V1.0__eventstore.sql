@@ -0,0 +1,56 @@
+-- postgres does not support queries across databases
+-- the eventstore and the projections are therefore stored in the schemas
+-- eventstore and projections of the database zitadel
+-- the Database of the EventstoreBase and of the Projections must be set to zitadel
+
+CREATE SCHEMA IF NOT EXISTS eventstore;
+
+CREATE SEQUENCE eventstore.event_seq;
+
+CREATE TABLE eventstore.events (
+    id UUID DEFAULT gen_random_uuid()
+    , event_type TEXT
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , aggregate_version TEXT NOT NULL
+    , event_sequence BIGINT NOT NULL DEFAULT nextval('eventstore.event_seq')
+    , previous_aggregate_sequence BIGINT
+    , previous_aggregate_type_sequence BIGINT
+    , creation_date TIMESTAMPTZ NOT NULL DEFAULT now()
+    , event_data JSONB
+    , editor_user TEXT NOT NULL
+    , editor_service TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , CONSTRAINT event_sequence_pk PRIMARY KEY (event_sequence)
+    , CONSTRAINT previous_sequence_unique UNIQUE (previous_aggregate_sequence)
+    , CONSTRAINT prev_agg_type_seq_unique UNIQUE (previous_aggregate_type_sequence)
+);
+ALTER SEQUENCE eventstore.event_seq OWNED BY eventstore.events.event_sequence;
+
+CREATE INDEX agg_type_agg_id ON eventstore.events (aggregate_type, aggregate_id);
+CREATE INDEX agg_type ON eventstore.events (aggregate_type);
+CREATE INDEX agg_type_seq ON eventstore.events (aggregate_type, event_sequence DESC);
+CREATE INDEX default_event_query ON eventstore.events (aggregate_type, aggregate_id, event_type, resource_owner);
+CREATE INDEX max_sequence ON eventstore.events (aggregate_type, aggregate_id, event_sequence DESC);
+CREATE INDEX changes_idx ON eventstore.events (aggregate_type, aggregate_id, creation_date);
+
+CREATE TABLE eventstore.unique_constraints (
+    unique_type TEXT
+    , unique_field TEXT
+
+    , PRIMARY KEY (unique_type, unique_field)
+);
+
+CREATE TABLE eventstore.snapshots (
+    name TEXT
+    , aggregate_id TEXT
+    , resource_owner TEXT
+    , version INT8 NOT NULL
+    , event_sequence INT8 NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , data JSONB
+
+    , PRIMARY KEY (name, aggregate_id, resource_owner)
+);
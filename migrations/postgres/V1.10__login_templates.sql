@@ -0,0 +1,11 @@
+CREATE TABLE projections.login_templates (
+    aggregate_id TEXT NOT NULL
+    , template TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , asset_key TEXT NOT NULL
+
+    , PRIMARY KEY (aggregate_id, template)
+);
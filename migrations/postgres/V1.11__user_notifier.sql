@@ -0,0 +1,9 @@
+-- the user notifier replaces the notification handler of the v1 view, which is stored in cockroach
+-- the current sequence is therefore seeded with the latest user event of the eventstore
+-- so existing installations don't resend the codes and notifications of historic events
+INSERT INTO projections.current_sequences (projection_name, aggregate_type, current_sequence, timestamp)
+    SELECT 'zitadel.projections.user_notifier', 'user', MAX(event_sequence), MAX(creation_date)
+    FROM eventstore.events
+    WHERE aggregate_type = 'user'
+    HAVING COUNT(*) > 0
+ON CONFLICT (projection_name, aggregate_type) DO NOTHING;
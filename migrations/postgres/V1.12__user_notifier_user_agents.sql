@@ -0,0 +1,23 @@
+CREATE TABLE projections.user_notifier_user_agents (
+    user_id TEXT NOT NULL
+    , user_agent_id TEXT NOT NULL
+    , sequence INT8 NOT NULL
+
+    , PRIMARY KEY (user_id, user_agent_id)
+);
+
+-- the user agents of the password checks already handled by the user notifier
+-- are seeded from the eventstore, so existing users aren't alerted about known user agents
+INSERT INTO projections.user_notifier_user_agents (user_id, user_agent_id, sequence)
+    SELECT aggregate_id, event_data->>'userAgentID', MIN(event_sequence)
+    FROM eventstore.events
+    WHERE aggregate_type = 'user'
+        AND event_type IN ('user.human.password.check.succeeded', 'user.password.check.succeeded')
+        AND event_data->>'userAgentID' <> ''
+        AND event_sequence <= COALESCE((
+            SELECT current_sequence
+            FROM projections.current_sequences
+            WHERE projection_name = 'zitadel.projections.user_notifier' AND aggregate_type = 'user'
+        ), 0)
+    GROUP BY aggregate_id, event_data->>'userAgentID'
+ON CONFLICT (user_id, user_agent_id) DO NOTHING;
@@ -0,0 +1,791 @@
+-- equals the projections of migrations/cockroach up to V1.113
+-- cockroach specific syntax is replaced:
+-- STRING by TEXT, BYTES by BYTEA, IF and IFNULL by CASE and COALESCE
+-- and inline indexes by CREATE INDEX
+
+CREATE SCHEMA IF NOT EXISTS projections;
+
+CREATE TABLE projections.locks (
+    locker_id TEXT
+    , locked_until TIMESTAMPTZ(3)
+    , projection_name TEXT
+
+    , PRIMARY KEY (projection_name)
+);
+
+CREATE TABLE projections.current_sequences (
+    projection_name TEXT
+    , aggregate_type TEXT
+    , current_sequence BIGINT
+    , timestamp TIMESTAMPTZ
+
+    , PRIMARY KEY (projection_name, aggregate_type)
+);
+
+CREATE TABLE projections.failed_events (
+    projection_name TEXT
+    , failed_sequence BIGINT
+    , failure_count SMALLINT
+    , error TEXT
+    , last_failed TIMESTAMPTZ
+    , skipped BOOLEAN DEFAULT false NOT NULL
+    , skip_reason TEXT
+    , retry_requested BOOLEAN DEFAULT false NOT NULL
+
+    , PRIMARY KEY (projection_name, failed_sequence)
+);
+
+CREATE TABLE projections.failed_event_errors (
+    projection_name TEXT
+    , failed_sequence BIGINT
+    , failure_count SMALLINT
+    , error TEXT
+    , creation_date TIMESTAMPTZ NOT NULL
+
+    , PRIMARY KEY (projection_name, failed_sequence, creation_date)
+);
+
+CREATE TABLE projections.orgs (
+    id TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT
+    , org_state SMALLINT
+    , sequence BIGINT
+
+    , primary_domain TEXT
+    , name TEXT
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.org_owners_orgs (
+    id TEXT
+    , name TEXT
+    , creation_date TIMESTAMPTZ
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.org_owners_users (
+    org_id TEXT
+    , owner_id TEXT
+    , language VARCHAR(10)
+    , email TEXT
+    , first_name TEXT
+    , last_name TEXT
+    , gender INT2
+
+    , PRIMARY KEY (owner_id, org_id)
+    , CONSTRAINT fk_org FOREIGN KEY (org_id) REFERENCES projections.org_owners_orgs (id) ON DELETE CASCADE
+);
+
+CREATE VIEW projections.org_owners AS (
+    SELECT o.id AS org_id
+        , o.name AS org_name
+        , o.creation_date
+        , u.owner_id
+        , u.language
+        , u.email
+        , u.first_name
+        , u.last_name
+        , u.gender
+    FROM projections.org_owners_orgs o
+    JOIN projections.org_owners_users u ON o.id = u.org_id
+);
+
+CREATE TABLE projections.actions (
+    id TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT
+    , action_state SMALLINT
+    , sequence BIGINT
+
+    , name TEXT
+    , script TEXT
+    , timeout BIGINT
+    , allowed_to_fail BOOLEAN
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.flows_triggers (
+    flow_type SMALLINT
+    , trigger_type SMALLINT
+    , resource_owner TEXT
+    , action_id TEXT
+    , trigger_sequence SMALLINT
+
+    , PRIMARY KEY (flow_type, trigger_type, resource_owner, action_id)
+);
+
+CREATE TABLE projections.projects (
+    id TEXT
+    , name TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT
+    , creator_id TEXT
+    , state INT2
+    , project_role_assertion BOOLEAN
+    , project_role_check BOOLEAN
+    , has_project_check BOOLEAN
+    , private_labeling_setting SMALLINT
+    , sequence BIGINT
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.project_grants (
+    project_id TEXT
+    , grant_id TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT
+    , state INT2
+    , sequence BIGINT
+
+    , granted_org_id TEXT
+    , granted_role_keys TEXT[]
+    , creator_id TEXT
+
+    , PRIMARY KEY (grant_id)
+);
+
+CREATE TABLE projections.project_roles (
+    project_id TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT
+    , sequence BIGINT
+
+    , role_key TEXT
+    , display_name TEXT
+    , group_name TEXT
+    , creator_id TEXT
+
+    , PRIMARY KEY (project_id, role_key)
+);
+
+CREATE TABLE projections.org_domains (
+    creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , sequence BIGINT
+
+    , domain TEXT
+    , org_id TEXT
+    , is_verified BOOLEAN
+    , is_primary BOOLEAN
+    , validation_type SMALLINT
+
+    , PRIMARY KEY (org_id, domain)
+);
+
+CREATE TABLE projections.login_policies (
+    aggregate_id TEXT
+
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , sequence BIGINT
+
+    , allow_register BOOLEAN
+    , allow_username_password BOOLEAN
+    , allow_external_idps BOOLEAN
+
+    , force_mfa BOOLEAN
+    , second_factors SMALLINT[]
+    , multi_factors SMALLINT[]
+    , passwordless_type SMALLINT
+    , is_default BOOLEAN
+    , hide_password_reset BOOLEAN
+
+    , PRIMARY KEY (aggregate_id)
+);
+
+CREATE TABLE projections.idps (
+    id TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , sequence BIGINT
+    , resource_owner TEXT
+
+    , state SMALLINT
+    , name TEXT
+    , styling_type SMALLINT
+    , owner_type SMALLINT
+    , auto_register BOOLEAN
+    , type INT2
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.idps_oidc_config (
+    idp_id TEXT REFERENCES projections.idps (id) ON DELETE CASCADE
+
+    , client_id TEXT
+    , client_secret JSONB
+    , issuer TEXT
+    , scopes TEXT[]
+    , display_name_mapping SMALLINT
+    , username_mapping SMALLINT
+    , authorization_endpoint TEXT
+    , token_endpoint TEXT
+
+    , PRIMARY KEY (idp_id)
+);
+
+CREATE TABLE projections.idps_jwt_config (
+    idp_id TEXT REFERENCES projections.idps (id) ON DELETE CASCADE
+
+    , issuer TEXT
+    , keys_endpoint TEXT
+    , header_name TEXT
+    , endpoint TEXT
+
+    , PRIMARY KEY (idp_id)
+);
+
+CREATE TABLE projections.password_complexity_policies (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NULL
+    , change_date TIMESTAMPTZ NULL
+    , sequence INT8 NULL
+    , state INT2 NULL
+    , resource_owner TEXT
+
+    , is_default BOOLEAN
+    , min_length INT8 NULL
+    , has_lowercase BOOLEAN NULL
+    , has_uppercase BOOLEAN NULL
+    , has_symbol BOOLEAN NULL
+    , has_number BOOLEAN NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.password_age_policies (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NULL
+    , change_date TIMESTAMPTZ NULL
+    , sequence INT8 NULL
+    , state INT2 NULL
+    , resource_owner TEXT
+
+    , is_default BOOLEAN
+    , max_age_days INT8 NULL
+    , expire_warn_days INT8 NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.lockout_policies (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NULL
+    , change_date TIMESTAMPTZ NULL
+    , sequence INT8 NULL
+    , state INT2 NULL
+    , resource_owner TEXT
+
+    , is_default BOOLEAN
+    , max_password_attempts INT8 NULL
+    , show_failure BOOLEAN NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.privacy_policies (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NULL
+    , change_date TIMESTAMPTZ NULL
+    , sequence INT8 NULL
+    , state INT2 NULL
+    , resource_owner TEXT
+
+    , is_default BOOLEAN
+    , privacy_link TEXT
+    , tos_link TEXT
+    , help_link TEXT DEFAULT '' NOT NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.org_iam_policies (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NULL
+    , change_date TIMESTAMPTZ NULL
+    , sequence INT8 NULL
+    , state INT2 NULL
+    , resource_owner TEXT
+
+    , is_default BOOLEAN
+    , user_login_must_be_domain BOOLEAN
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.mail_templates (
+    aggregate_id TEXT NOT NULL
+
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , state SMALLINT
+    , sequence BIGINT
+    , is_default BOOLEAN
+
+    , template BYTEA
+
+    , PRIMARY KEY (aggregate_id)
+);
+
+CREATE TABLE projections.message_texts (
+    aggregate_id TEXT
+
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , state SMALLINT
+    , sequence BIGINT
+
+    , type TEXT
+    , language TEXT
+    , title TEXT
+    , pre_header TEXT
+    , subject TEXT
+    , greeting TEXT
+    , text TEXT
+    , button_text TEXT
+    , footer_text TEXT
+
+    , PRIMARY KEY (aggregate_id, type, language)
+);
+
+CREATE TABLE projections.custom_texts (
+    aggregate_id TEXT
+
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , sequence BIGINT
+    , is_default BOOLEAN
+
+    , template TEXT
+    , language TEXT
+    , key TEXT
+    , text TEXT
+
+    , PRIMARY KEY (aggregate_id, template, key, language)
+);
+
+CREATE TABLE projections.features (
+    aggregate_id TEXT NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , is_default BOOLEAN NOT NULL DEFAULT false
+
+    , tier_name TEXT
+    , tier_description TEXT
+    , state INT2 NOT NULL DEFAULT 0
+    , state_description TEXT
+    , audit_log_retention BIGINT NOT NULL DEFAULT 0
+    , login_policy_factors BOOLEAN NOT NULL DEFAULT false
+    , login_policy_idp BOOLEAN NOT NULL DEFAULT false
+    , login_policy_passwordless BOOLEAN NOT NULL DEFAULT false
+    , login_policy_registration BOOLEAN NOT NULL DEFAULT false
+    , login_policy_username_login BOOLEAN NOT NULL DEFAULT false
+    , login_policy_password_reset BOOLEAN NOT NULL DEFAULT false
+    , password_complexity_policy BOOLEAN NOT NULL DEFAULT false
+    , label_policy_private_label BOOLEAN NOT NULL DEFAULT false
+    , label_policy_watermark BOOLEAN NOT NULL DEFAULT false
+    , custom_domain BOOLEAN NOT NULL DEFAULT false
+    , privacy_policy BOOLEAN NOT NULL DEFAULT false
+    , metadata_user BOOLEAN NOT NULL DEFAULT false
+    , custom_text_message BOOLEAN NOT NULL DEFAULT false
+    , custom_text_login BOOLEAN NOT NULL DEFAULT false
+    , lockout_policy BOOLEAN NOT NULL DEFAULT false
+    , actions BOOLEAN NOT NULL DEFAULT false
+    , actions_allowed INT2 DEFAULT 0
+    , max_actions INT8 DEFAULT 0
+
+    , PRIMARY KEY (aggregate_id)
+);
+
+CREATE TABLE projections.apps (
+    id TEXT
+    , project_id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT NOT NULL
+    , state INT2
+    , sequence INT8
+
+    , name TEXT
+
+    , PRIMARY KEY (id)
+);
+CREATE INDEX apps_idx_project_id ON projections.apps (project_id);
+
+CREATE TABLE projections.apps_api_configs (
+    app_id TEXT REFERENCES projections.apps (id) ON DELETE CASCADE
+
+    , client_id TEXT NOT NULL
+    , client_secret JSONB
+    , auth_method INT2
+
+    , PRIMARY KEY (app_id)
+);
+
+CREATE TABLE projections.apps_oidc_configs (
+    app_id TEXT REFERENCES projections.apps (id) ON DELETE CASCADE
+
+    , version INT2 NOT NULL
+    , client_id TEXT NOT NULL
+    , client_secret JSONB
+    , redirect_uris TEXT[]
+    , response_types INT2[]
+    , grant_types INT2[]
+    , application_type INT2
+    , auth_method_type INT2
+    , post_logout_redirect_uris TEXT[]
+    , is_dev_mode BOOLEAN
+    , access_token_type INT2
+    , access_token_role_assertion BOOLEAN
+    , id_token_role_assertion BOOLEAN
+    , id_token_userinfo_assertion BOOLEAN
+    , clock_skew INT8
+    , additional_origins TEXT[]
+
+    , PRIMARY KEY (app_id)
+);
+
+CREATE TABLE projections.idp_user_links (
+    idp_id TEXT
+    , user_id TEXT
+    , external_user_id TEXT
+    , display_name TEXT
+
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , sequence INT8
+    , resource_owner TEXT
+
+    , PRIMARY KEY (idp_id, external_user_id)
+);
+CREATE INDEX idp_user_links_idx_user ON projections.idp_user_links (user_id);
+
+CREATE TABLE projections.idp_login_policy_links (
+    idp_id TEXT
+    , aggregate_id TEXT
+    , provider_type INT2
+
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , sequence INT8
+    , resource_owner TEXT
+
+    , PRIMARY KEY (aggregate_id, idp_id)
+);
+
+CREATE TABLE projections.login_names_users (
+    id TEXT NOT NULL
+    , user_name TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (id)
+);
+CREATE INDEX login_names_users_idx_ro ON projections.login_names_users (resource_owner);
+
+CREATE TABLE projections.login_names_domains (
+    name TEXT NOT NULL
+    , is_primary BOOLEAN NOT NULL DEFAULT false
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (resource_owner, name)
+);
+
+CREATE TABLE projections.login_names_policies (
+    must_be_domain BOOLEAN NOT NULL
+    , is_default BOOLEAN NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (resource_owner)
+);
+CREATE INDEX login_names_policies_idx_is_default ON projections.login_names_policies (resource_owner, is_default);
+
+CREATE VIEW projections.login_names
+AS SELECT
+    user_id
+    , CASE WHEN must_be_domain
+        THEN CONCAT(user_name, '@', domain)
+        ELSE user_name
+    END AS login_name
+    , COALESCE(is_primary, true) AS is_primary -- is_default is null on additional verified domain and policy with must_be_domain=false
+FROM (
+SELECT
+    policy_users.user_id
+    , policy_users.user_name
+    , policy_users.resource_owner
+    , policy_users.must_be_domain
+    , domains.name AS domain
+    , domains.is_primary
+FROM (
+    SELECT
+        users.id as user_id
+        , users.user_name
+        , users.resource_owner
+        , COALESCE(policy_custom.must_be_domain, policy_default.must_be_domain) AS must_be_domain
+    FROM projections.login_names_users users
+    LEFT JOIN projections.login_names_policies policy_custom on policy_custom.resource_owner = users.resource_owner
+    LEFT JOIN projections.login_names_policies policy_default on policy_default.is_default = true) policy_users
+LEFT JOIN projections.login_names_domains domains ON policy_users.must_be_domain AND policy_users.resource_owner = domains.resource_owner
+) login_names;
+
+CREATE TABLE projections.label_policies (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , state INT2 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , is_default BOOLEAN NOT NULL DEFAULT false
+    , hide_login_name_suffix BOOLEAN NOT NULL DEFAULT false
+    , font_url TEXT
+    , watermark_disabled BOOLEAN NOT NULL DEFAULT false
+    , should_error_popup BOOLEAN NOT NULL DEFAULT false
+    , light_primary_color TEXT
+    , light_warn_color TEXT
+    , light_background_color TEXT
+    , light_font_color TEXT
+    , light_logo_url TEXT
+    , light_icon_url TEXT
+    , dark_primary_color TEXT
+    , dark_warn_color TEXT
+    , dark_background_color TEXT
+    , dark_font_color TEXT
+    , dark_logo_url TEXT
+    , dark_icon_url TEXT
+
+    , PRIMARY KEY (id, state)
+);
+
+CREATE TABLE projections.users (
+    id TEXT
+    , creation_date TIMESTAMPTZ
+    , change_date TIMESTAMPTZ
+    , resource_owner TEXT NOT NULL
+    , state INT2
+    , sequence INT8
+    , type INT2
+
+    , username TEXT
+
+    , PRIMARY KEY (id)
+);
+CREATE INDEX users_idx_username ON projections.users (username);
+
+CREATE TABLE projections.users_machines (
+    user_id TEXT REFERENCES projections.users (id) ON DELETE CASCADE
+
+    , name TEXT NOT NULL
+    , description TEXT
+
+    , PRIMARY KEY (user_id)
+);
+
+CREATE TABLE projections.users_humans (
+    user_id TEXT REFERENCES projections.users (id) ON DELETE CASCADE
+
+    --profile
+    , first_name TEXT NOT NULL
+    , last_name TEXT NOT NULL
+    , nick_name TEXT
+    , display_name TEXT
+    , preferred_language VARCHAR(10)
+    , gender INT2
+    , avatar_key TEXT
+
+    --email
+    , email TEXT NOT NULL
+    , is_email_verified BOOLEAN NOT NULL DEFAULT false
+
+    --phone
+    , phone TEXT
+    , is_phone_verified BOOLEAN
+
+    , PRIMARY KEY (user_id)
+);
+
+CREATE TABLE projections.org_members (
+    org_id TEXT NOT NULL
+    , user_id TEXT NOT NULL
+    , roles TEXT[]
+
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (org_id, user_id)
+);
+CREATE INDEX org_members_idx_user ON projections.org_members (user_id);
+
+CREATE TABLE projections.iam_members (
+    iam_id TEXT NOT NULL
+    , user_id TEXT NOT NULL
+    , roles TEXT[]
+
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (iam_id, user_id)
+);
+CREATE INDEX iam_members_idx_user ON projections.iam_members (user_id);
+
+CREATE TABLE projections.project_members (
+    project_id TEXT NOT NULL
+    , user_id TEXT NOT NULL
+    , roles TEXT[]
+
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (project_id, user_id)
+);
+CREATE INDEX project_members_idx_user ON projections.project_members (user_id);
+
+CREATE TABLE projections.project_grant_members (
+    project_id TEXT NOT NULL
+    , user_id TEXT NOT NULL
+    , grant_id TEXT
+    , roles TEXT[]
+
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , PRIMARY KEY (project_id, grant_id, user_id)
+);
+CREATE INDEX project_grant_members_idx_user ON projections.project_grant_members (user_id);
+
+CREATE TABLE projections.keys (
+    id TEXT
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , resource_owner TEXT NOT NULL
+    , sequence INT8 NOT NULL
+    , algorithm TEXT DEFAULT '' NOT NULL
+    , use TEXT DEFAULT '' NOT NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.keys_private (
+    id TEXT REFERENCES projections.keys ON DELETE NO ACTION
+    , expiry TIMESTAMPTZ NOT NULL
+    , key JSONB NOT NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.keys_public (
+    id TEXT REFERENCES projections.keys ON DELETE NO ACTION
+    , expiry TIMESTAMPTZ NOT NULL
+    , key BYTEA NOT NULL
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.authn_keys (
+    id TEXT
+    , creation_date TIMESTAMPTZ NOT NULL
+    , resource_owner TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , sequence INT8 NOT NULL
+
+    , object_id TEXT NOT NULL
+    , expiration TIMESTAMPTZ NOT NULL
+    , identifier TEXT NOT NULL
+    , public_key BYTEA NOT NULL
+    , enabled BOOLEAN NOT NULL DEFAULT true
+    , type INT2 NOT NULL DEFAULT 0
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.user_grants (
+    id TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , user_id TEXT NOT NULL
+    , project_id TEXT NOT NULL
+    , grant_id TEXT NOT NULL
+    , roles TEXT[]
+    , state INT2 NOT NULL
+
+    , PRIMARY KEY (id)
+);
+CREATE INDEX user_grants_idx_user ON projections.user_grants (user_id);
+CREATE INDEX user_grants_idx_ro ON projections.user_grants (resource_owner);
+
+CREATE TABLE projections.user_metadata (
+    user_id TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , key TEXT NOT NULL
+    , value BYTEA
+
+    , PRIMARY KEY (user_id, key)
+);
+CREATE INDEX user_metadata_idx_ro ON projections.user_metadata (resource_owner);
+
+CREATE TABLE projections.user_auth_methods (
+    token_id TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , user_id TEXT NOT NULL
+    , state INT2 NOT NULL
+    , method_type INT2 NOT NULL
+    , name TEXT NOT NULL
+
+    , PRIMARY KEY (user_id, method_type, token_id)
+);
+CREATE INDEX user_auth_methods_idx_ro ON projections.user_auth_methods (resource_owner);
+
+CREATE TABLE projections.iam (
+    id TEXT NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , global_org_id TEXT DEFAULT ''
+    , iam_project_id TEXT DEFAULT ''
+    , setup_started SMALLINT DEFAULT 0
+    , setup_done SMALLINT DEFAULT 0
+
+    , PRIMARY KEY (id)
+);
+
+CREATE TABLE projections.personal_access_tokens (
+    id TEXT
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , resource_owner TEXT NOT NULL
+    , sequence INT8 NOT NULL
+    , user_id TEXT NOT NULL
+    , expiration TIMESTAMPTZ NOT NULL
+    , scopes TEXT[]
+
+    , PRIMARY KEY (id)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE projections.lockout_policies ADD COLUMN max_otp_attempts INT8 NULL;
+ALTER TABLE projections.lockout_policies ADD COLUMN lockout_duration INT8 NULL;
+ALTER TABLE projections.lockout_policies ADD COLUMN max_ip_attempts INT8 NULL;
+ALTER TABLE projections.lockout_policies ADD COLUMN ip_throttle_duration INT8 NULL;
+
+ALTER TABLE projections.users_humans ADD COLUMN lock_reason INT2 NULL;
+ALTER TABLE projections.users_humans ADD COLUMN locked_until TIMESTAMPTZ NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE projections.custom_roles (
+    role TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , scope SMALLINT NOT NULL
+    , permissions TEXT[]
+
+    , PRIMARY KEY (role)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE projections.mail_message_templates (
+    aggregate_id TEXT NOT NULL
+    , message_type TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , is_default BOOLEAN NOT NULL
+
+    , template BYTEA
+
+    , PRIMARY KEY (aggregate_id, message_type)
+);
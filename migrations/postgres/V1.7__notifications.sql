@@ -0,0 +1,21 @@
+CREATE TABLE projections.notifications (
+    id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+    , resource_owner TEXT NOT NULL
+
+    , user_id TEXT NOT NULL
+    , message_type TEXT
+    , channel INT2
+    , recipient TEXT
+    , message JSONB
+    , state INT2
+    , attempts INT8
+    , last_error TEXT
+    , next_attempt TIMESTAMPTZ
+
+    , PRIMARY KEY (id)
+);
+CREATE INDEX notifications_idx_user ON projections.notifications (resource_owner, user_id);
+CREATE INDEX notifications_idx_due ON projections.notifications (state, next_attempt);
@@ -0,0 +1,11 @@
+CREATE TABLE projections.translation_bundles (
+    component INT2 NOT NULL
+    , language TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , sequence INT8 NOT NULL
+
+    , messages JSONB
+
+    , PRIMARY KEY (component, language)
+);
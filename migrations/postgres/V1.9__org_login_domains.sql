@@ -0,0 +1,3 @@
+ALTER TABLE projections.org_domains ADD COLUMN is_login_domain BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS org_domains_domain_idx ON projections.org_domains (domain);